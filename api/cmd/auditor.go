@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditorEmail  *string = new(string)
+	auditorRevoke *bool   = new(bool)
+)
+
+// auditorCmd représente la commande d'attribution du rôle d'auditeur du registre à un employé
+var auditorCmd = &cobra.Command{
+	Use:   "auditor",
+	Short: "grant or revoke the auditor role",
+	Long:  "grant an employee the read-only auditor role of the ticket ledger, or revoke it, from the next sign-in",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := services.User(
+			&security.UserAccess{Role: security.ROLE_ADMIN},
+			repositories.NewUserRepository(database.Get(config.GetString("services.employee.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			nil,
+		)
+
+		employee, err := service.SetAuditor(&transfert.Credential{Email: auditorEmail}, !*auditorRevoke)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Employee %s \n", employee.ID)
+		cmd.Printf("Auditor %t \n", employee.Auditor)
+
+		return nil
+	},
+}
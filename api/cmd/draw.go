@@ -0,0 +1,62 @@
+package main
+
+import (
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	drawCampaign *string = new(string)
+	drawVerify   *bool   = new(bool)
+)
+
+// drawCmd représente la commande du tirage au sort final
+var drawCmd = &cobra.Command{
+	Use:   "draw",
+	Short: "run the grand prize draw",
+	Long:  "run the grand prize draw of a campaign, or replay a recorded one with --verify",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := services.Game(
+			&security.UserAccess{Role: security.ROLE_ADMIN},
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			nil,
+			nil,
+		)
+
+		dto := &transfert.Draw{Campaign: drawCampaign}
+
+		var draw *entities.Draw
+		var err errors.ErrorInterface
+
+		if *drawVerify {
+			draw, err = service.VerifyDraw(dto)
+		} else {
+			draw, err = service.RunDraw(dto)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Campaign %s \n", *drawCampaign)
+		cmd.Printf("Seed %s \n", draw.Seed)
+		cmd.Printf("Participants %d \n", draw.Participants)
+		cmd.Printf("Checksum %s \n", draw.Checksum)
+		cmd.Printf("Winner %s \n", *draw.WinnerID)
+
+		return nil
+	},
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrawCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+	drawCampaign = aws.String("cli")
+
+	cmd := drawCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+
+	// Aucune campagne "cli", le tirage ne peut pas avoir lieu
+	err := cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "campaign.not_found", err.Error())
+
+	// Ni tirage à rejouer
+	drawVerify = aws.Bool(true)
+	defer func() { drawVerify = aws.Bool(false) }()
+
+	err = cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "campaign.not_found", err.Error())
+}
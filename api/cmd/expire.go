@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	expireCampaign *string = new(string)
+)
+
+// expireCmd représente la commande d'expiration des tickets d'une campagne échue
+var expireCmd = &cobra.Command{
+	Use:   "expire",
+	Short: "expire the tickets of a campaign",
+	Long: "expire the tickets never claimed after the end of a campaign and the prizes never collected after its claim deadline\n" +
+		"the server already runs the expiry at each deadline, this command runs it again, e.g. after a failure logged by the server",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := services.Game(
+			&security.UserAccess{Role: security.ROLE_ADMIN},
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			nil,
+			nil,
+		)
+
+		expiry, err := service.ExpireTickets(&transfert.Campaign{ID: expireCampaign})
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Campaign %s \n", *expireCampaign)
+		cmd.Printf("Unclaimed %d (%.2f €) \n", expiry.Unclaimed, expiry.UnclaimedValue)
+		cmd.Printf("Uncollected %d (%.2f €) \n", expiry.Uncollected, expiry.UncollectedValue)
+
+		return nil
+	},
+}
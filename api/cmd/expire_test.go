@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+	expireCampaign = aws.String("cli")
+
+	cmd := expireCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+
+	// Aucune campagne "cli", aucun ticket ne peut expirer
+	err := cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "campaign.not_found", err.Error())
+}
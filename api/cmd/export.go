@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/printer"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportCampaign *string = new(string)
+	exportPrize    *string = new(string)
+	exportFormat   *string = new(string)
+	exportOutput   *string = new(string)
+	exportOffset   *int    = new(int)
+	exportLimit    *int    = new(int)
+)
+
+// exportCmd représente la commande d'export des tickets pour l'imprimeur
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "export a batch of tickets",
+	Long: "export a batch of tickets as CSV or as a print-ready PDF, the batch is recorded once written\n" +
+		"a PDF is built in memory, so it is split into files of at most 100 sheets (2400 tickets) named after the output, " +
+		"tickets-1.pdf, tickets-2.pdf... each recorded as its own batch",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo := repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT)))
+		service := services.Game(&security.UserAccess{Role: security.ROLE_ADMIN}, repo, nil, nil)
+
+		dto := &transfert.Batch{Format: exportFormat, Offset: exportOffset, Limit: exportLimit}
+		if *exportCampaign != "" {
+			dto.Campaign = exportCampaign
+		}
+
+		if *exportPrize != "" {
+			dto.PrizeID = exportPrize
+		}
+
+		if *exportFormat != printer.PDF || (*exportLimit > 0 && *exportLimit <= services.BatchPDFMax) {
+			return exportBatch(cmd, service, dto, *exportOutput)
+		}
+
+		// Le PDF est découpé en parties de BatchPDFMax tickets au plus, la première résout la campagne
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: dto.Format, Campaign: dto.Campaign, PrizeID: dto.PrizeID, Offset: dto.Offset})
+		if err != nil {
+			return err
+		}
+
+		total, err := repo.CountTicket(&transfert.Ticket{CampaignID: batch.CampaignID, PrizeID: batch.PrizeID})
+		if err != nil {
+			return err
+		}
+
+		remaining := max(total-*exportOffset, 0)
+		if *exportLimit > 0 {
+			remaining = min(remaining, *exportLimit)
+		}
+
+		parts := max((remaining+services.BatchPDFMax-1)/services.BatchPDFMax, 1)
+		ext := filepath.Ext(*exportOutput)
+		for part := 0; part < parts; part++ {
+			offset := *exportOffset + part*services.BatchPDFMax
+			limit := min(remaining-part*services.BatchPDFMax, services.BatchPDFMax)
+			output := *exportOutput
+			if parts > 1 {
+				output = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(*exportOutput, ext), part+1, ext)
+			}
+
+			if err := exportBatch(cmd, service, &transfert.Batch{Format: dto.Format, Campaign: dto.Campaign, PrizeID: dto.PrizeID, Offset: &offset, Limit: &limit}, output); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+// exportBatch écrit un lot dans le fichier output et l'enregistre
+func exportBatch(cmd *cobra.Command, service services.GameServiceInterface, dto *transfert.Batch, output string) error {
+	batch, err := service.PrepareBatch(dto)
+	if err != nil {
+		return err
+	}
+
+	file, ferr := os.Create(output)
+	if ferr != nil {
+		return ferr
+	}
+
+	defer file.Close()
+
+	if err := service.WriteBatch(batch, file); err != nil {
+		return err
+	}
+
+	cmd.Printf("Batch %s \n", batch.ID)
+	cmd.Printf("Tickets %d \n", batch.Count)
+	cmd.Printf("Checksum %s \n", batch.Checksum)
+	cmd.Printf("Output %s \n", output)
+
+	return nil
+}
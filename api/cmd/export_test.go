@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+	output := filepath.Join(t.TempDir(), "tickets.csv")
+	exportOutput = aws.String(output)
+	exportFormat = aws.String("csv")
+
+	cmd := exportCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+
+	// Base vide, l'export ne contient que l'entête
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, b.String(), "Tickets 0")
+
+	content, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Equal(t, "number,code,caption\n", string(content))
+
+	// Base vide, le PDF tient en une seule partie nommée comme la sortie
+	output = filepath.Join(t.TempDir(), "tickets.pdf")
+	exportOutput = aws.String(output)
+	exportFormat = aws.String("pdf")
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.FileExists(t, output)
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(output), "tickets-1.pdf"))
+
+	// Format inconnu
+	exportFormat = aws.String("docx")
+	err = cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "batch.invalid_format", err.Error())
+
+	// Campagne inconnue
+	exportFormat = aws.String("pdf")
+	exportCampaign = aws.String("cli")
+	defer func() { exportCampaign = aws.String("") }()
+
+	err = cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "campaign.not_found", err.Error())
+}
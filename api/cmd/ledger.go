@@ -0,0 +1,77 @@
+package main
+
+import (
+	"os"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ledgerOutput *string = new(string)
+	ledgerVerify *bool   = new(bool)
+)
+
+// ledgerCmd représente la commande d'export et de vérification du registre des tickets
+var ledgerCmd = &cobra.Command{
+	Use:   "ledger",
+	Short: "export or verify the ticket ledger",
+	Long:  "export the hash-chained ledger of ticket creations and changes as CSV, or recompute its chain and compare it with the stored tickets",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		logger.Info("loading configuration")
+		return config.Load(env.CONFIG_URI)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		service := services.Game(
+			&security.UserAccess{Role: security.ROLE_ADMIN},
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			nil,
+			nil,
+		)
+
+		if *ledgerVerify {
+			report, err := service.VerifyLedger()
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Entries %d \n", report.Entries)
+			cmd.Printf("Tickets %d \n", report.Tickets)
+			cmd.Printf("Head %s \n", report.Head)
+
+			if report.BrokenAt != nil {
+				cmd.Printf("Broken at %d \n", *report.BrokenAt)
+			}
+
+			cmd.Printf("Altered %d \n", report.Altered)
+			for _, id := range report.Samples {
+				cmd.Printf("  %s \n", id)
+			}
+
+			cmd.Printf("Valid %t \n", report.Valid)
+
+			return nil
+		}
+
+		file, ferr := os.Create(*ledgerOutput)
+		if ferr != nil {
+			return ferr
+		}
+
+		defer file.Close()
+
+		if err := service.WriteLedger(file); err != nil {
+			return err
+		}
+
+		cmd.Printf("Output %s \n", *ledgerOutput)
+
+		return nil
+	},
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+	output := filepath.Join(t.TempDir(), "ledger.csv")
+	ledgerOutput = aws.String(output)
+
+	cmd := ledgerCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+
+	// Base vide, l'export ne contient que l'entête
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, b.String(), "Output "+output)
+
+	content, err := os.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Equal(t, "sequence,created_at,event,ticket_id,previous_status,status,prize_id,campaign_id,owner_id,credential_id,digest,previous_hash,hash\n", string(content))
+
+	// Un registre vide est intègre
+	ledgerVerify = aws.Bool(true)
+	defer func() { ledgerVerify = aws.Bool(false) }()
+
+	assert.Nil(t, cmd.RunE(cmd, nil))
+	assert.Contains(t, b.String(), "Entries 0")
+	assert.Contains(t, b.String(), "Valid true")
+}
+
+func TestAuditorCmd(t *testing.T) {
+	env.CONFIG_URI = aws.String("../config.test.yml")
+	auditorEmail = aws.String("cli@yopmail.com")
+
+	cmd := auditorCmd
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetErr(b)
+	assert.Nil(t, cmd.PreRunE(cmd, nil))
+
+	// Aucun compte "cli@yopmail.com"
+	err := cmd.RunE(cmd, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, "credential.not_found", err.Error())
+}
@@ -25,17 +25,37 @@ import (
 )
 
 var callBack hook.Handler = func(tags ...string) {
+	gameRepository := repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT)))
+
+	events.CreatePrizes(
+		gameRepository,
+		config.Get("project.tickets.types", map[string]int{}).(map[string]int),
+	)
+
 	events.HydrateDBWithTickets(
-		repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		gameRepository,
+		events.CreateCampaign(gameRepository),
 		config.Get("project.tickets.required", 10000).(int),
-		config.Get("project.tickets.types", map[string]int{}).(map[string]int),
 	)
 
+	events.RecordLedger(gameRepository)
+
 	eventStore.CreateStores(
 		repoStore.NewStoreRepository(database.Get(config.GetString("services.store.database", config.DEFAULT))),
 	)
 }
 
+// expiryStop arrête l'expiration planifiée des tickets à l'arrêt du serveur
+var expiryStop = make(chan struct{})
+
+// scheduleExpiry expire les tickets des campagnes à leurs dates limites tant que le serveur tourne
+var scheduleExpiry hook.OnceHandler = func(tags ...string) {
+	events.ScheduleExpiry(
+		repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		expiryStop,
+	)
+}
+
 // Helper use Cobra package to create a CLI and give Args gesture
 var Helper *cobra.Command = &cobra.Command{
 	Use:                   "thetiptop",
@@ -48,6 +68,8 @@ var Helper *cobra.Command = &cobra.Command{
 		generated.SwaggerInfo.Version = env.BUILD_VERSION
 		logger.SetLevel(levels.DEBUG)
 		hook.Register(hook.EventOnDBInit, callBack)
+		hook.Register(hook.EventOnStart, scheduleExpiry)
+		hook.Register(hook.EventOnStop, hook.OnceHandlerSync(func(tags ...string) { close(expiryStop) }))
 
 		return config.Load(env.CONFIG_URI)
 	},
@@ -88,11 +110,37 @@ var versionCmd = &cobra.Command{
 // @name 						Authorization
 // @description Type "Bearer" followed by a space and JWT token.
 func main() {
-	env.CONFIG_URI = Helper.Flags().String("config", env.DEFAULT_CONFIG_URI, "URI de la configuration")
-	env.AWS_PROFILE = Helper.Flags().String("profile", env.DEFAULT_AWS_PROFILE, "Profil AWS")
+	env.CONFIG_URI = Helper.PersistentFlags().String("config", env.DEFAULT_CONFIG_URI, "URI de la configuration")
+	env.AWS_PROFILE = Helper.PersistentFlags().String("profile", env.DEFAULT_AWS_PROFILE, "Profil AWS")
 	env.PORT_HTTP = Helper.Flags().Int("http-port", env.DEFAULT_PORT_HTTP, "Port HTTP")
 	env.PORT_HTTPS = Helper.Flags().Int("https-port", env.DEFAULT_PORT_HTTPS, "Port HTTPS")
 
+	drawCampaign = drawCmd.Flags().String("campaign", "", "Campagne du tirage")
+	drawVerify = drawCmd.Flags().Bool("verify", false, "Rejoue le tirage enregistré")
+	drawCmd.MarkFlagRequired("campaign")
+
+	exportCampaign = exportCmd.Flags().String("campaign", "", "Campagne des tickets, campagne en cours si vide")
+	exportPrize = exportCmd.Flags().String("prize", "", "Identifiant du lot des tickets")
+	exportFormat = exportCmd.Flags().String("format", "pdf", "Format de sortie, csv ou pdf")
+	exportOutput = exportCmd.Flags().String("output", "tickets.pdf", "Fichier de sortie")
+	exportOffset = exportCmd.Flags().Int("offset", 0, "Nombre de tickets à ignorer")
+	exportLimit = exportCmd.Flags().Int("limit", 0, "Nombre de tickets à exporter, tous si 0, découpés en fichiers de 2400 tickets au plus pour un PDF")
+
+	expireCampaign = expireCmd.Flags().String("campaign", "", "Campagne dont les tickets expirent")
+	expireCmd.MarkFlagRequired("campaign")
+
+	ledgerOutput = ledgerCmd.Flags().String("output", "ledger.csv", "Fichier de sortie")
+	ledgerVerify = ledgerCmd.Flags().Bool("verify", false, "Vérifie la chaîne du registre au lieu de l'exporter")
+
+	auditorEmail = auditorCmd.Flags().String("email", "", "Email de l'employé")
+	auditorRevoke = auditorCmd.Flags().Bool("revoke", false, "Retire le rôle d'auditeur")
+	auditorCmd.MarkFlagRequired("email")
+
 	Helper.AddCommand(versionCmd)
+	Helper.AddCommand(drawCmd)
+	Helper.AddCommand(exportCmd)
+	Helper.AddCommand(expireCmd)
+	Helper.AddCommand(ledgerCmd)
+	Helper.AddCommand(auditorCmd)
 	Helper.Execute()
 }
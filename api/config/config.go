@@ -13,6 +13,7 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/aws/s3"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 )
 
@@ -32,18 +33,52 @@ type Config struct {
 	Providers struct {
 		Mails     map[string]*mail.Config     `yaml:"mails"`
 		Databases map[string]*database.Config `yaml:"databases"`
+		OIDC      map[string]*oidc.Config     `yaml:"oidc"` // External sign-in providers of the clients, by name
 	} `yaml:"providers"`
 	Security struct {
 		Validation struct {
 			Expire string `yaml:"expire"`
 		} `yaml:"validation"`
-		JWT *jwt.JWT `yaml:"jwt"`
+		JWT     *jwt.JWT `yaml:"jwt"`
+		Lockout struct {
+			Attempts   int  `yaml:"attempts"`    // Failures of a credential before it gets locked
+			IPAttempts int  `yaml:"ip_attempts"` // Failures from an IP before it gets locked, slowed down past attempts
+			Delay      int  `yaml:"delay"`       // Seconds of back-off after the first penalized failure, doubled on each one
+			MaxDelay   int  `yaml:"max_delay"`   // Maximum back-off in seconds
+			Duration   int  `yaml:"duration"`    // Minutes of lock
+			Window     int  `yaml:"window"`      // Minutes after which failures are forgotten
+			Mail       bool `yaml:"mail"`        // Mail the client when its account gets locked
+		} `yaml:"lockout"`
+		TOTP struct {
+			Required bool   `yaml:"required"` // Employees without second factor only get tokens limited to its enrolment
+			Issuer   string `yaml:"issuer"`   // Service name shown by the authenticator apps
+		} `yaml:"totp"`
 	} `yaml:"security"`
 	Project struct {
 		Tickets struct {
-			Required int            `yaml:"required"`
-			Types    map[string]int `yaml:"types"`
+			Required  int            `yaml:"required"`
+			Types     map[string]int `yaml:"types"`     // Initial prize catalogue, only used while the catalogue is empty
+			Secret    string         `yaml:"secret"`    // HMAC key signing the ticket codes, codes are only Luhn checked when empty
+			Signature int            `yaml:"signature"` // Number of digits of the signature segment
+			Link      string         `yaml:"link"`      // Claim screen URL, QR codes asked with a link encode it with the token in its query
 		} `yaml:"tickets"`
+		Fraud struct {
+			Window           int `yaml:"window"`            // Minutes over which the recent claims are counted
+			CredentialClaims int `yaml:"credential_claims"` // Claims of a credential within the window
+			IPClaims         int `yaml:"ip_claims"`         // Claims from an IP within the window
+			DeviceClaims     int `yaml:"device_claims"`     // Claims from a device within the window
+			FailedAttempts   int `yaml:"failed_attempts"`   // Failed claims within the window
+			IPAccounts       int `yaml:"ip_accounts"`       // Distinct credentials claiming from an IP within the window
+			AccountAge       int `yaml:"account_age"`       // Minutes under which an account is considered new
+			Threshold        int `yaml:"threshold"`         // Score from which a claim is held for review
+		} `yaml:"fraud"`
+		Expiry struct {
+			Grace int `yaml:"grace"` // Minutes after a deadline before its tickets expire
+			Batch int `yaml:"batch"` // Tickets expired per transaction
+		} `yaml:"expiry"`
+		Receipts struct {
+			Bucket string `yaml:"bucket"` // S3 bucket of the receipt photos attached to the claims, photos are refused when empty
+		} `yaml:"receipts"`
 	} `yaml:"project"`
 }
 
@@ -72,9 +107,7 @@ func Get(key string, defaultValue any) any {
 
 		switch val.Kind() {
 		case reflect.Struct:
-			val = val.FieldByNameFunc(func(name string) bool {
-				return strings.EqualFold(elem, name)
-			})
+			val = structField(val, elem)
 		case reflect.Map:
 			val = val.MapIndex(reflect.ValueOf(elem))
 		default:
@@ -90,6 +123,21 @@ func Get(key string, defaultValue any) any {
 	return finalValue
 }
 
+// structField finds the field of a struct matching a path element
+// The yaml name is tried first so keys like "credential_claims" are found, then the Go name ignoring case
+func structField(val reflect.Value, elem string) reflect.Value {
+	t := val.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ","); name != "" && name == elem {
+			return val.Field(i)
+		}
+	}
+
+	return val.FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(elem, name)
+	})
+}
+
 func convertValue(val any, defaultValue any) any {
 	switch defaultValue.(type) {
 	case int:
@@ -129,6 +177,15 @@ func GetInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// GetPositiveInt reads a limit, a missing value or a value lower than 1 falls back to the default
+func GetPositiveInt(key string, defaultValue int) int {
+	if value := GetInt(key, defaultValue); value > 0 {
+		return value
+	}
+
+	return defaultValue
+}
+
 func GetString(key string, defaultValue string) string {
 	value := Get(key, defaultValue)
 
@@ -201,6 +258,10 @@ func (cfg *Config) Initialize() error {
 		return err
 	}
 
+	if err := oidc.New(cfg.Providers.OIDC); err != nil {
+		return err
+	}
+
 	return nil
 }
 
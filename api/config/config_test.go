@@ -1,6 +1,9 @@
 package config_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -35,6 +38,32 @@ func TestGetInt(t *testing.T) {
 	config.Load(aws.String("../config.test.yml"))
 	assert.Equal(t, 3, config.GetInt("providers.databases", 3))
 	assert.Equal(t, 1025, config.GetInt("providers.mails.default.port", 3))
+
+	// Les clés sont trouvées par leur nom yaml comme par leur nom Go
+	assert.Equal(t, 15, config.GetInt("security.jwt.expire", 0))
+	assert.Equal(t, 15, config.GetInt("security.JWT.Expire", 0))
+	assert.Equal(t, "Europe/Paris", config.GetString("security.jwt.TZ", ""))
+}
+
+func TestGetPositiveInt(t *testing.T) {
+	// Configuration propre au test, une limite à 0 n'a pas sa place dans la configuration partagée
+	shared, err := os.ReadFile("../config.test.yml")
+	assert.Nil(t, err)
+
+	content := strings.Replace(string(shared), "    credential_claims: 5\n", "    credential_claims: 5\n    ip_claims: 0\n", 1)
+	assert.NotEqual(t, string(shared), content)
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	assert.Nil(t, config.Load(aws.String(path)))
+	defer config.Reset()
+
+	assert.Equal(t, 5, config.GetPositiveInt("project.fraud.credential_claims", 3))
+	assert.Equal(t, 3, config.GetPositiveInt("project.fraud.unknown", 3))
+
+	// Une limite inférieure à 1 retombe sur sa valeur par défaut
+	assert.Equal(t, 0, config.GetInt("project.fraud.ip_claims", 10))
+	assert.Equal(t, 10, config.GetPositiveInt("project.fraud.ip_claims", 10))
 }
 
 func TestAll(t *testing.T) {
@@ -71,6 +100,11 @@ func TestAll(t *testing.T) {
 	assert.Equal(t, "secret", config.Get("security.jwt.secret", "default-value"))
 	assert.Equal(t, 15, config.GetInt("security.jwt.expire", 0))
 	assert.Equal(t, 30, config.GetInt("security.jwt.refresh", 0))
+
+	// Project - keys are matched by their yaml name
+	assert.Equal(t, "https://thetiptop.local/claim", config.GetString("project.tickets.link", "default-value"))
+	assert.Equal(t, 5, config.GetInt("project.fraud.credential_claims", 0))
+	assert.Equal(t, 0, config.GetInt("project.fraud.ip_claims", 0))
 }
 
 func TestGet(t *testing.T) {
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructField(t *testing.T) {
+	value := reflect.ValueOf(struct {
+		IPClaims    int `yaml:"ip_claims"`
+		Window      int `yaml:"window"`
+		Untagged    int
+		Ignored     int `yaml:",omitempty"`
+		Account_Age int `yaml:"age"`
+	}{IPClaims: 1, Window: 2, Untagged: 3, Ignored: 4, Account_Age: 5})
+
+	// Nom yaml
+	assert.Equal(t, 1, structField(value, "ip_claims").Interface())
+	assert.Equal(t, 2, structField(value, "window").Interface())
+	assert.Equal(t, 5, structField(value, "age").Interface())
+
+	// Nom Go sans tenir compte de la casse
+	assert.Equal(t, 1, structField(value, "ipclaims").Interface())
+	assert.Equal(t, 2, structField(value, "Window").Interface())
+	assert.Equal(t, 3, structField(value, "untagged").Interface())
+	assert.Equal(t, 4, structField(value, "ignored").Interface())
+	assert.Equal(t, 5, structField(value, "account_age").Interface())
+
+	// Champ inconnu
+	assert.False(t, structField(value, "unknown").IsValid())
+	assert.False(t, structField(value, "").IsValid())
+}
@@ -10,6 +10,7 @@ type PermissionInterface interface {
 	IsGrantedByRoles(roles ...Role) bool
 	IsGrantedByRules(rules ...Rule) bool
 	GetCredentialID() *string
+	GetSessionID() *string
 	CanRead(ressource database.Entity, rules ...Rule) bool
 	CanCreate(ressource database.Entity, rules ...Rule) bool
 	CanUpdate(ressource database.Entity, rules ...Rule) bool
@@ -18,6 +19,7 @@ type PermissionInterface interface {
 
 type UserAccess struct {
 	CredentialID string
+	SessionID    string // Sign-in the token was issued for, empty for tokens without server-side session
 	Role         Role
 }
 
@@ -42,6 +44,14 @@ func (p *UserAccess) GetCredentialID() *string {
 	return &p.CredentialID
 }
 
+func (p *UserAccess) GetSessionID() *string {
+	if p.SessionID == "" {
+		return nil
+	}
+
+	return &p.SessionID
+}
+
 func (p *UserAccess) IsGrantedByRules(rules ...Rule) bool {
 	for _, rule := range rules {
 		if rule(p) {
@@ -129,6 +139,7 @@ func NewUserAccess(token any) *UserAccess {
 	if token != nil {
 		if token, ok := token.(*jwt.Token); ok {
 			p.CredentialID = token.ID
+			p.SessionID = token.Session
 			if role, exists := token.Data["role"]; exists {
 				if roleStr, ok := role.(string); ok {
 					p.Role = Role(roleStr)
@@ -43,6 +43,14 @@ func TestGetCredentialID(t *testing.T) {
 	assert.Nil(t, p.GetCredentialID())
 }
 
+func TestGetSessionID(t *testing.T) {
+	p := &security.UserAccess{CredentialID: "test-id", SessionID: "session-id"}
+	assert.Equal(t, aws.String("session-id"), p.GetSessionID())
+
+	p = &security.UserAccess{CredentialID: "test-id"}
+	assert.Nil(t, p.GetSessionID())
+}
+
 func TestIsAuthenticated(t *testing.T) {
 	p := &security.UserAccess{CredentialID: "test-id"}
 	assert.True(t, p.IsAuthenticated())
@@ -164,11 +172,13 @@ func TestCanDelete(t *testing.T) {
 
 func TestNewUserAccess(t *testing.T) {
 	token := &jwt.Token{
-		ID:   "test-id",
-		Data: map[string]interface{}{"role": "admin"},
+		ID:      "test-id",
+		Session: "session-id",
+		Data:    map[string]interface{}{"role": "admin"},
 	}
 	p := security.NewUserAccess(token)
 	assert.Equal(t, "test-id", p.CredentialID)
+	assert.Equal(t, "session-id", p.SessionID)
 	assert.Equal(t, security.ROLE_ADMIN, p.Role)
 }
 
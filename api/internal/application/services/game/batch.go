@@ -0,0 +1,42 @@
+package game
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+func PrepareBatch(service services.GameServiceInterface, dtoBatch *transfert.Batch) (int, any) {
+	if err := dtoBatch.Check(data.Validator{
+		"format": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	batch, err := service.PrepareBatch(dtoBatch)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, batch
+}
+
+// WriteBatch streams a batch returned by PrepareBatch, once the response status has been sent
+func WriteBatch(service services.GameServiceInterface, batch *entities.Batch, w io.Writer) errors.ErrorInterface {
+	return service.WriteBatch(batch, w)
+}
+
+func GetBatches(service services.GameServiceInterface) (int, any) {
+	batches, err := service.GetBatches()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, batches
+}
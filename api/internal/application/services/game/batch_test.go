@@ -0,0 +1,83 @@
+package game_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareBatch(t *testing.T) {
+	t.Run("should prepare the batch successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoBatch := &transfert.Batch{Format: aws.String("pdf")}
+		expectedBatch := &entities.Batch{Format: "pdf"}
+		mockService.On("PrepareBatch", dtoBatch).Return(expectedBatch, nil)
+
+		statusCode, response := game.PrepareBatch(mockService, dtoBatch)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedBatch, response)
+	})
+
+	t.Run("should return error when format is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.PrepareBatch(mockService, &transfert.Batch{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "PrepareBatch")
+	})
+
+	t.Run("should return error when the format is unknown", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoBatch := &transfert.Batch{Format: aws.String("docx")}
+		mockService.On("PrepareBatch", dtoBatch).Return(nil, errors_domain_game.ErrBatchInvalidFormat)
+
+		statusCode, response := game.PrepareBatch(mockService, dtoBatch)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrBatchInvalidFormat, response)
+	})
+}
+
+func TestWriteBatch(t *testing.T) {
+	mockService := new(DomainGameService)
+	batch := &entities.Batch{Format: "csv"}
+	out := &bytes.Buffer{}
+	mockService.On("WriteBatch", batch, out).Return(nil)
+
+	assert.Nil(t, game.WriteBatch(mockService, batch, out))
+	mockService.AssertCalled(t, "WriteBatch", batch, out)
+}
+
+func TestGetBatches(t *testing.T) {
+	t.Run("should return the batches successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		expectedBatches := []*entities.Batch{{ID: "batch-1"}}
+		mockService.On("GetBatches").Return(expectedBatches, nil)
+
+		statusCode, response := game.GetBatches(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedBatches, response)
+	})
+
+	t.Run("should return error when unauthorized", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetBatches").Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.GetBatches(mockService)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func GetCampaigns(service services.GameServiceInterface) (int, any) {
+	campaigns, err := service.GetCampaigns()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaigns
+}
+
+func GetCampaign(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.GetCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaign
+}
+
+func CreateCampaign(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"label":          {validator.Required},
+		"start_at":       {validator.Required},
+		"end_at":         {validator.Required},
+		"claim_deadline": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.CreateCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, campaign
+}
+
+func UpdateCampaign(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	campaign, err := service.UpdateCampaign(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, campaign
+}
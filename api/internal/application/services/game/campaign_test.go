@@ -0,0 +1,155 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const campaignID = "6f1c2d3e-4b5a-4c6d-8e7f-9a0b1c2d3e4f"
+
+func TestGetCampaigns(t *testing.T) {
+	t.Run("should return campaigns successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		expectedCampaigns := []*entities.Campaign{{ID: campaignID}}
+		mockService.On("GetCampaigns").Return(expectedCampaigns, nil)
+
+		statusCode, response := game.GetCampaigns(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedCampaigns, response)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetCampaigns").Return(nil, errors.ErrInternalServer)
+
+		statusCode, response := game.GetCampaigns(mockService)
+
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+		assert.Error(t, response.(*errors.Error))
+	})
+}
+
+func TestGetCampaign(t *testing.T) {
+	t.Run("should return campaign successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		expectedCampaign := &entities.Campaign{ID: campaignID}
+		mockService.On("GetCampaign", dtoCampaign).Return(expectedCampaign, nil)
+
+		statusCode, response := game.GetCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedCampaign, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String("campaign")}
+
+		statusCode, _ := game.GetCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetCampaign", dtoCampaign)
+	})
+
+	t.Run("should return error when campaign not found", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("GetCampaign", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		statusCode, response := game.GetCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, response)
+	})
+}
+
+func TestCreateCampaign(t *testing.T) {
+	newDto := func() *transfert.Campaign {
+		return &transfert.Campaign{
+			Label:         aws.String("2024"),
+			StartAt:       aws.String("2024-10-01"),
+			EndAt:         aws.String("2024-10-31"),
+			ClaimDeadline: aws.String("2024-11-30"),
+		}
+	}
+
+	t.Run("should create campaign successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := newDto()
+		expectedCampaign := &entities.Campaign{ID: campaignID}
+		mockService.On("CreateCampaign", dtoCampaign).Return(expectedCampaign, nil)
+
+		statusCode, response := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expectedCampaign, response)
+	})
+
+	t.Run("should return error when claim deadline is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := newDto()
+		dtoCampaign.ClaimDeadline = nil
+
+		statusCode, _ := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreateCampaign", dtoCampaign)
+	})
+
+	t.Run("should return error when label already exists", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := newDto()
+		mockService.On("CreateCampaign", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignAlreadyExists)
+
+		statusCode, response := game.CreateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignAlreadyExists, response)
+	})
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	t.Run("should update campaign successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID), ClaimDeadline: aws.String("2024-12-31")}
+		expectedCampaign := &entities.Campaign{ID: campaignID}
+		mockService.On("UpdateCampaign", dtoCampaign).Return(expectedCampaign, nil)
+
+		statusCode, response := game.UpdateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedCampaign, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String("campaign")}
+
+		statusCode, _ := game.UpdateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "UpdateCampaign", dtoCampaign)
+	})
+
+	t.Run("should return error when dates are invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID), EndAt: aws.String("yesterday")}
+		mockService.On("UpdateCampaign", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignInvalidDates)
+
+		statusCode, response := game.UpdateCampaign(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidDates, response)
+	})
+}
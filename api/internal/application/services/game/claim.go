@@ -0,0 +1,34 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func GetClaimReviews(service services.GameServiceInterface, dtoReview *transfert.ClaimReview) (int, any) {
+	reviews, err := service.GetClaimReviews(dtoReview)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, reviews
+}
+
+func ReviewClaim(service services.GameServiceInterface, dtoReview *transfert.ClaimReview) (int, any) {
+	if err := dtoReview.Check(data.Validator{
+		"id":     {validator.Required, validator.ID},
+		"status": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	review, err := service.ReviewClaim(dtoReview)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, review
+}
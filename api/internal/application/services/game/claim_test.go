@@ -0,0 +1,83 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetClaimReviews(t *testing.T) {
+	t.Run("should list the reviews successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReview := &transfert.ClaimReview{}
+		expectedReviews := []*entities.ClaimReview{{ID: "review-123"}}
+		mockService.On("GetClaimReviews", dtoReview).Return(expectedReviews, nil)
+
+		statusCode, response := game.GetClaimReviews(mockService, dtoReview)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedReviews, response)
+	})
+
+	t.Run("should return error when the status is unknown", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReview := &transfert.ClaimReview{Status: aws.String("lost")}
+		mockService.On("GetClaimReviews", dtoReview).Return(nil, errors_domain_game.ErrReviewInvalidStatus)
+
+		statusCode, response := game.GetClaimReviews(mockService, dtoReview)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrReviewInvalidStatus, response)
+	})
+}
+
+func TestReviewClaim(t *testing.T) {
+	t.Run("should decide the review successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReview := &transfert.ClaimReview{
+			ID:     aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Status: aws.String("approved"),
+		}
+		expectedReview := &entities.ClaimReview{ID: "review-123", Status: entities.ReviewApproved}
+		mockService.On("ReviewClaim", dtoReview).Return(expectedReview, nil)
+
+		statusCode, response := game.ReviewClaim(mockService, dtoReview)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedReview, response)
+		mockService.AssertCalled(t, "ReviewClaim", dtoReview)
+	})
+
+	t.Run("should return error when the decision is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReview := &transfert.ClaimReview{ID: aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d")}
+
+		statusCode, response := game.ReviewClaim(mockService, dtoReview)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "ReviewClaim", dtoReview)
+	})
+
+	t.Run("should return error when the review was already decided", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReview := &transfert.ClaimReview{
+			ID:     aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Status: aws.String("rejected"),
+		}
+		mockService.On("ReviewClaim", dtoReview).Return(nil, errors_domain_game.ErrReviewClosed)
+
+		statusCode, response := game.ReviewClaim(mockService, dtoReview)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrReviewClosed, response)
+	})
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func PreviewDistribution(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	change, err := service.PreviewDistribution(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, change
+}
+
+func UpdateDistribution(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	change, err := service.UpdateDistribution(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, change
+}
+
+func GetDistributionChanges(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	changes, err := service.GetDistributionChanges(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, changes
+}
@@ -0,0 +1,111 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewDistribution(t *testing.T) {
+	t.Run("should preview the change successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID), Distribution: map[string]int{"prize-1": 50}}
+		expectedChange := &entities.DistributionChange{Tickets: 100}
+		mockService.On("PreviewDistribution", dtoCampaign).Return(expectedChange, nil)
+
+		statusCode, response := game.PreviewDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedChange, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String("campaign")}
+
+		statusCode, _ := game.PreviewDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "PreviewDistribution", dtoCampaign)
+	})
+
+	t.Run("should return error when the campaign has ended", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("PreviewDistribution", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignEnded)
+
+		statusCode, response := game.PreviewDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusForbidden, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignEnded, response)
+	})
+}
+
+func TestUpdateDistribution(t *testing.T) {
+	t.Run("should apply the change successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID), Tickets: aws.Int(200)}
+		expectedChange := &entities.DistributionChange{ID: "change-1", Tickets: 200, Generated: 100}
+		mockService.On("UpdateDistribution", dtoCampaign).Return(expectedChange, nil)
+
+		statusCode, response := game.UpdateDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedChange, response)
+	})
+
+	t.Run("should return error when id is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{}
+
+		statusCode, response := game.UpdateDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "UpdateDistribution", dtoCampaign)
+	})
+
+	t.Run("should return error when the user is not an employee", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("UpdateDistribution", dtoCampaign).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.UpdateDistribution(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestGetDistributionChanges(t *testing.T) {
+	t.Run("should list the changes successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		expectedChanges := []*entities.DistributionChange{{ID: "change-1"}}
+		mockService.On("GetDistributionChanges", dtoCampaign).Return(expectedChanges, nil)
+
+		statusCode, response := game.GetDistributionChanges(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedChanges, response)
+	})
+
+	t.Run("should return error when the campaign doesn't exist", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("GetDistributionChanges", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		statusCode, response := game.GetDistributionChanges(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, response)
+	})
+}
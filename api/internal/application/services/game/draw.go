@@ -0,0 +1,54 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func RunDraw(service services.GameServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"campaign": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	draw, err := service.RunDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, draw
+}
+
+func GetDraw(service services.GameServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"campaign": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	draw, err := service.GetDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, draw
+}
+
+func VerifyDraw(service services.GameServiceInterface, dtoDraw *transfert.Draw) (int, any) {
+	if err := dtoDraw.Check(data.Validator{
+		"campaign": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	draw, err := service.VerifyDraw(dtoDraw)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, draw
+}
@@ -0,0 +1,112 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunDraw(t *testing.T) {
+	t.Run("should run the draw successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		expectedDraw := &entities.Draw{ID: "draw-1"}
+		mockService.On("RunDraw", dtoDraw).Return(expectedDraw, nil)
+
+		statusCode, response := game.RunDraw(mockService, dtoDraw)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expectedDraw, response)
+	})
+
+	t.Run("should return error when campaign is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.RunDraw(mockService, &transfert.Draw{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RunDraw")
+	})
+
+	t.Run("should return error when the draw already ran", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		mockService.On("RunDraw", dtoDraw).Return(nil, errors_domain_game.ErrDrawAlreadyDone)
+
+		statusCode, response := game.RunDraw(mockService, dtoDraw)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, response)
+	})
+}
+
+func TestGetDraw(t *testing.T) {
+	t.Run("should return the draw successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		expectedDraw := &entities.Draw{ID: "draw-1"}
+		mockService.On("GetDraw", dtoDraw).Return(expectedDraw, nil)
+
+		statusCode, response := game.GetDraw(mockService, dtoDraw)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedDraw, response)
+	})
+
+	t.Run("should return error when the draw is not found", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		mockService.On("GetDraw", dtoDraw).Return(nil, errors_domain_game.ErrDrawNotFound)
+
+		statusCode, _ := game.GetDraw(mockService, dtoDraw)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+	})
+
+	t.Run("should return error when campaign is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.GetDraw(mockService, &transfert.Draw{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
+
+func TestVerifyDraw(t *testing.T) {
+	t.Run("should verify the draw successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		expectedDraw := &entities.Draw{ID: "draw-1"}
+		mockService.On("VerifyDraw", dtoDraw).Return(expectedDraw, nil)
+
+		statusCode, response := game.VerifyDraw(mockService, dtoDraw)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedDraw, response)
+	})
+
+	t.Run("should return error when the draw does not match", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoDraw := &transfert.Draw{Campaign: aws.String("2024")}
+		mockService.On("VerifyDraw", dtoDraw).Return(nil, errors_domain_game.ErrDrawMismatch)
+
+		statusCode, _ := game.VerifyDraw(mockService, dtoDraw)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+	})
+
+	t.Run("should return error when campaign is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.VerifyDraw(mockService, &transfert.Draw{})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+	})
+}
@@ -0,0 +1,39 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func ExpireTickets(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	expiry, err := service.ExpireTickets(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, expiry
+}
+
+func GetTicketExpiries(service services.GameServiceInterface, dtoCampaign *transfert.Campaign) (int, any) {
+	if err := dtoCampaign.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	expiries, err := service.GetTicketExpiries(dtoCampaign)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, expiries
+}
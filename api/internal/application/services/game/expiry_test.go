@@ -0,0 +1,86 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireTickets(t *testing.T) {
+	t.Run("should expire the tickets successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		expectedExpiry := &entities.TicketExpiry{ID: "expiry-1", Unclaimed: 10, Uncollected: 2}
+		mockService.On("ExpireTickets", dtoCampaign).Return(expectedExpiry, nil)
+
+		statusCode, response := game.ExpireTickets(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedExpiry, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String("campaign")}
+
+		statusCode, response := game.ExpireTickets(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "ExpireTickets", dtoCampaign)
+	})
+
+	t.Run("should return error when no deadline has passed", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("ExpireTickets", dtoCampaign).Return(nil, errors_domain_game.ErrCampaignDeadlineOpen)
+
+		statusCode, response := game.ExpireTickets(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrCampaignDeadlineOpen, response)
+	})
+}
+
+func TestGetTicketExpiries(t *testing.T) {
+	t.Run("should list the expiries successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		expectedExpiries := []*entities.TicketExpiry{{ID: "expiry-1"}}
+		mockService.On("GetTicketExpiries", dtoCampaign).Return(expectedExpiries, nil)
+
+		statusCode, response := game.GetTicketExpiries(mockService, dtoCampaign)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedExpiries, response)
+	})
+
+	t.Run("should return error when id is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{}
+
+		statusCode, _ := game.GetTicketExpiries(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetTicketExpiries", dtoCampaign)
+	})
+
+	t.Run("should return error when the user is not an employee", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoCampaign := &transfert.Campaign{ID: aws.String(campaignID)}
+		mockService.On("GetTicketExpiries", dtoCampaign).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.GetTicketExpiries(mockService, dtoCampaign)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
@@ -0,0 +1,31 @@
+package game
+
+import (
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+func PrepareLedger(service services.GameServiceInterface) (int, any) {
+	if err := service.PrepareLedger(); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, nil
+}
+
+// WriteLedger streams the ledger once PrepareLedger has succeeded and the response status has been sent
+func WriteLedger(service services.GameServiceInterface, w io.Writer) errors.ErrorInterface {
+	return service.WriteLedger(w)
+}
+
+func VerifyLedger(service services.GameServiceInterface) (int, any) {
+	report, err := service.VerifyLedger()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, report
+}
@@ -0,0 +1,67 @@
+package game_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrepareLedger(t *testing.T) {
+	t.Run("should accept the caller", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("PrepareLedger").Return(nil)
+
+		statusCode, response := game.PrepareLedger(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should return error when unauthorized", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("PrepareLedger").Return(errors.ErrUnauthorized)
+
+		statusCode, response := game.PrepareLedger(mockService)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestWriteLedger(t *testing.T) {
+	mockService := new(DomainGameService)
+	out := &bytes.Buffer{}
+	mockService.On("WriteLedger", out).Return(nil)
+
+	assert.Nil(t, game.WriteLedger(mockService, out))
+	mockService.AssertCalled(t, "WriteLedger", out)
+}
+
+func TestVerifyLedger(t *testing.T) {
+	t.Run("should return the report successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		expectedReport := &entities.LedgerReport{Entries: 3, Tickets: 2, Valid: true}
+		mockService.On("VerifyLedger").Return(expectedReport, nil)
+
+		statusCode, response := game.VerifyLedger(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedReport, response)
+	})
+
+	t.Run("should return error when unauthorized", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("VerifyLedger").Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.VerifyLedger(mockService)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
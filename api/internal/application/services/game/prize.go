@@ -0,0 +1,79 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func GetPrizes(service services.GameServiceInterface) (int, any) {
+	prizes, err := service.GetPrizes()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prizes
+}
+
+func GetPrize(service services.GameServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	prize, err := service.GetPrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prize
+}
+
+func CreatePrize(service services.GameServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"label":        {validator.Required},
+		"value":        {validator.Required},
+		"distribution": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	prize, err := service.CreatePrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, prize
+}
+
+func UpdatePrize(service services.GameServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	prize, err := service.UpdatePrize(dtoPrize)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, prize
+}
+
+func DeletePrize(service services.GameServiceInterface, dtoPrize *transfert.Prize) (int, any) {
+	if err := dtoPrize.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.DeletePrize(dtoPrize); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
@@ -0,0 +1,179 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const prizeID = "2bd8c1b3-5d4c-4a1f-9f6e-0d7a1c2b3e4f"
+
+func TestGetPrizes(t *testing.T) {
+	t.Run("should return prizes successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		expectedPrizes := []*entities.Prize{{ID: prizeID}}
+		mockService.On("GetPrizes").Return(expectedPrizes, nil)
+
+		statusCode, response := game.GetPrizes(mockService)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedPrizes, response)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		mockService.On("GetPrizes").Return(nil, errors.ErrInternalServer)
+
+		statusCode, response := game.GetPrizes(mockService)
+
+		assert.Equal(t, http.StatusInternalServerError, statusCode)
+		assert.Error(t, response.(*errors.Error))
+	})
+}
+
+func TestGetPrize(t *testing.T) {
+	t.Run("should return prize successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID)}
+		expectedPrize := &entities.Prize{ID: prizeID}
+		mockService.On("GetPrize", dtoPrize).Return(expectedPrize, nil)
+
+		statusCode, response := game.GetPrize(mockService, dtoPrize)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedPrize, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String("prize")}
+
+		statusCode, _ := game.GetPrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "GetPrize", dtoPrize)
+	})
+
+	t.Run("should return error when prize not found", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("GetPrize", dtoPrize).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		statusCode, response := game.GetPrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, response)
+	})
+}
+
+func TestCreatePrize(t *testing.T) {
+	t.Run("should create prize successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{Label: aws.String("Infuseur à thé"), Value: aws.Float64(8), Distribution: aws.Int(60)}
+		expectedPrize := &entities.Prize{ID: prizeID, Label: dtoPrize.Label}
+		mockService.On("CreatePrize", dtoPrize).Return(expectedPrize, nil)
+
+		statusCode, response := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expectedPrize, response)
+	})
+
+	t.Run("should return error when label is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{Value: aws.Float64(8), Distribution: aws.Int(60)}
+
+		statusCode, _ := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "CreatePrize", dtoPrize)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{Label: aws.String("Infuseur à thé"), Value: aws.Float64(8), Distribution: aws.Int(60)}
+		mockService.On("CreatePrize", dtoPrize).Return(nil, errors_domain_game.ErrPrizeAlreadyExists)
+
+		statusCode, response := game.CreatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, response)
+	})
+}
+
+func TestUpdatePrize(t *testing.T) {
+	t.Run("should update prize successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID), Active: aws.Bool(false)}
+		expectedPrize := &entities.Prize{ID: prizeID, Active: aws.Bool(false)}
+		mockService.On("UpdatePrize", dtoPrize).Return(expectedPrize, nil)
+
+		statusCode, response := game.UpdatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedPrize, response)
+	})
+
+	t.Run("should return error when id is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{Active: aws.Bool(false)}
+
+		statusCode, _ := game.UpdatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "UpdatePrize", dtoPrize)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID), Distribution: aws.Int(90)}
+		mockService.On("UpdatePrize", dtoPrize).Return(nil, errors_domain_game.ErrPrizeDistributionOverflow)
+
+		statusCode, response := game.UpdatePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeDistributionOverflow, response)
+	})
+}
+
+func TestDeletePrize(t *testing.T) {
+	t.Run("should delete prize successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("DeletePrize", dtoPrize).Return(nil)
+
+		statusCode, response := game.DeletePrize(mockService, dtoPrize)
+
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String("prize")}
+
+		statusCode, _ := game.DeletePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "DeletePrize", dtoPrize)
+	})
+
+	t.Run("should return error when prize is in use", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoPrize := &transfert.Prize{ID: aws.String(prizeID)}
+		mockService.On("DeletePrize", dtoPrize).Return(errors_domain_game.ErrPrizeInUse)
+
+		statusCode, response := game.DeletePrize(mockService, dtoPrize)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrPrizeInUse, response)
+	})
+}
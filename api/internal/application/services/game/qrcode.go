@@ -0,0 +1,24 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func RenderQRCode(service services.GameServiceInterface, dtoQRCode *transfert.QRCode) (int, any) {
+	if err := dtoQRCode.Check(data.Validator{
+		"token": {validator.Required, validator.Luhn},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	qrcode, err := service.RenderQRCode(dtoQRCode)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, qrcode
+}
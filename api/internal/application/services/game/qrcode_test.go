@@ -0,0 +1,48 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderQRCode(t *testing.T) {
+	t.Run("should render the QR code successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoQRCode := &transfert.QRCode{Token: aws.String("79927398713")}
+		expected := &entities.QRCode{Token: "79927398713", Format: "png"}
+		mockService.On("RenderQRCode", dtoQRCode).Return(expected, nil)
+
+		statusCode, response := game.RenderQRCode(mockService, dtoQRCode)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return error when the token is not a ticket code", func(t *testing.T) {
+		mockService := new(DomainGameService)
+
+		statusCode, _ := game.RenderQRCode(mockService, &transfert.QRCode{Token: aws.String("79927398710")})
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		mockService.AssertNotCalled(t, "RenderQRCode")
+	})
+
+	t.Run("should return error when an option is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoQRCode := &transfert.QRCode{Token: aws.String("79927398713"), Format: aws.String("gif")}
+		mockService.On("RenderQRCode", dtoQRCode).Return(nil, errors_domain_game.ErrQRCodeInvalidFormat)
+
+		statusCode, response := game.RenderQRCode(mockService, dtoQRCode)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrQRCodeInvalidFormat, response)
+	})
+}
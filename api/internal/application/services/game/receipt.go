@@ -0,0 +1,107 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// ReceiptPhotoMaxSize is the largest receipt photo accepted, in bytes, below the default body limit of the server
+const ReceiptPhotoMaxSize = 3 << 20
+
+// receiptPhotoTypes are the extensions of the accepted photos, by content type
+var receiptPhotoTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// PhotoStorage stores the receipt photos, implemented by the S3 provider
+type PhotoStorage interface {
+	PutObject(bucket *string, item *string, body io.Reader, contentType *string) error
+}
+
+func IssueReceipt(service services.GameServiceInterface, dtoReceipt *transfert.Receipt) (int, any) {
+	mandatory := data.Validator{
+		"store_id":  {validator.Required, validator.ID},
+		"caisse_id": {validator.Required, validator.ID},
+		"number":    {validator.Required},
+		"amount":    {validator.Required},
+	}
+
+	if dtoReceipt.Token != nil {
+		mandatory["token"] = []data.Control{validator.Luhn}
+	}
+
+	if err := dtoReceipt.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	receipt, err := service.IssueReceipt(dtoReceipt)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, receipt
+}
+
+// UploadReceiptPhoto stores the photo of a purchase receipt attached to a claim
+// The content type is read from the file itself, the one sent by the client is ignored
+//
+// Parameters:
+// - storage: PhotoStorage The storage the photo is uploaded to
+// - bucket: string The bucket of the receipt photos
+// - photo: *multipart.FileHeader The uploaded file
+//
+// Returns:
+// - *string: The key of the stored photo, kept on the ticket
+// - errors.ErrorInterface: ErrReceiptPhotoTooLarge, ErrReceiptInvalidPhoto if it is not a JPEG, PNG or PDF, or the error interface if it cannot be stored
+func UploadReceiptPhoto(storage PhotoStorage, bucket string, photo *multipart.FileHeader) (*string, errors.ErrorInterface) {
+	if photo.Size > ReceiptPhotoMaxSize {
+		return nil, errors_domain_game.ErrReceiptPhotoTooLarge
+	}
+
+	if storage == nil || bucket == "" {
+		return nil, errors.ErrInternalServer.Log(fmt.Errorf("no bucket configured for the receipt photos"))
+	}
+
+	file, err := photo.Open()
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, ReceiptPhotoMaxSize+1))
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	if len(content) > ReceiptPhotoMaxSize {
+		return nil, errors_domain_game.ErrReceiptPhotoTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	extension, ok := receiptPhotoTypes[contentType]
+	if !ok {
+		return nil, errors_domain_game.ErrReceiptInvalidPhoto
+	}
+
+	key := "receipts/" + uuid.NewString() + extension
+	if err := storage.PutObject(&bucket, &key, bytes.NewReader(content), &contentType); err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return &key, nil
+}
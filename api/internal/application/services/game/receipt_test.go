@@ -0,0 +1,157 @@
+package game_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type PhotoStorage struct {
+	mock.Mock
+}
+
+func (m *PhotoStorage) PutObject(bucket *string, item *string, body io.Reader, contentType *string) error {
+	args := m.Called(*bucket, *item, *contentType)
+	return args.Error(0)
+}
+
+// receiptPhoto simule le fichier envoyé dans le champ receipt_photo d'un formulaire
+func receiptPhoto(t *testing.T, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("receipt_photo", "receipt.jpg")
+	assert.Nil(t, err)
+	part.Write(content)
+	writer.Close()
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(game.ReceiptPhotoMaxSize)
+	assert.Nil(t, err)
+
+	return form.File["receipt_photo"][0]
+}
+
+func TestIssueReceipt(t *testing.T) {
+	newDTO := func() *transfert.Receipt {
+		return &transfert.Receipt{
+			StoreID:  aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			CaisseID: aws.String("9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"),
+			Number:   aws.String("0042"),
+			Amount:   aws.Float64(52.3),
+		}
+	}
+
+	t.Run("should issue receipt successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReceipt := newDTO()
+		expectedReceipt := &entities.Receipt{ID: "receipt-123", Number: "0042"}
+		mockService.On("IssueReceipt", dtoReceipt).Return(expectedReceipt, nil)
+
+		statusCode, response := game.IssueReceipt(mockService, dtoReceipt)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expectedReceipt, response)
+		mockService.AssertCalled(t, "IssueReceipt", dtoReceipt)
+	})
+
+	t.Run("should return error when number is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReceipt := newDTO()
+		dtoReceipt.Number = nil
+
+		statusCode, response := game.IssueReceipt(mockService, dtoReceipt)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "IssueReceipt", dtoReceipt)
+	})
+
+	t.Run("should return error when token is not a code", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReceipt := newDTO()
+		dtoReceipt.Token = aws.String("79927398710")
+
+		statusCode, response := game.IssueReceipt(mockService, dtoReceipt)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "IssueReceipt", dtoReceipt)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoReceipt := newDTO()
+		expectedError := errors_domain_game.ErrReceiptAlreadyExists
+		mockService.On("IssueReceipt", dtoReceipt).Return(nil, expectedError)
+
+		statusCode, response := game.IssueReceipt(mockService, dtoReceipt)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, expectedError, response)
+		mockService.AssertCalled(t, "IssueReceipt", dtoReceipt)
+	})
+}
+
+func TestUploadReceiptPhoto(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\nphoto")
+
+	t.Run("should store the photo and return its key", func(t *testing.T) {
+		storage := new(PhotoStorage)
+		storage.On("PutObject", "receipts", mock.Anything, "image/png").Return(nil)
+
+		key, err := game.UploadReceiptPhoto(storage, "receipts", receiptPhoto(t, png))
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(*key, "receipts/"))
+		assert.True(t, strings.HasSuffix(*key, ".png"))
+		storage.AssertCalled(t, "PutObject", "receipts", *key, "image/png")
+	})
+
+	t.Run("should refuse a file that is not a photo", func(t *testing.T) {
+		storage := new(PhotoStorage)
+
+		// Le type est lu dans le contenu, le nom du fichier est ignoré
+		key, err := game.UploadReceiptPhoto(storage, "receipts", receiptPhoto(t, []byte("<script></script>")))
+		assert.Nil(t, key)
+		assert.Equal(t, errors_domain_game.ErrReceiptInvalidPhoto, err)
+		storage.AssertNotCalled(t, "PutObject", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should refuse a photo too large", func(t *testing.T) {
+		storage := new(PhotoStorage)
+		photo := receiptPhoto(t, png)
+		photo.Size = game.ReceiptPhotoMaxSize + 1
+
+		key, err := game.UploadReceiptPhoto(storage, "receipts", photo)
+		assert.Nil(t, key)
+		assert.Equal(t, errors_domain_game.ErrReceiptPhotoTooLarge, err)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, err.Code())
+	})
+
+	t.Run("should return error without bucket", func(t *testing.T) {
+		key, err := game.UploadReceiptPhoto(new(PhotoStorage), "", receiptPhoto(t, png))
+		assert.Nil(t, key)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("should return error when the upload fails", func(t *testing.T) {
+		storage := new(PhotoStorage)
+		storage.On("PutObject", "receipts", mock.Anything, "image/png").Return(fmt.Errorf("access denied"))
+
+		key, err := game.UploadReceiptPhoto(storage, "receipts", receiptPhoto(t, png))
+		assert.Nil(t, key)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
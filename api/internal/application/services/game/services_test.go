@@ -1,6 +1,7 @@
 package game_test
 
 import (
+	"io"
 	"sync"
 
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
@@ -37,12 +38,13 @@ func (mgs *DomainGameService) GetRandomTicket() (*entities.Ticket, errors.ErrorI
 //
 // Parameters:
 // - dtoTicket: *game.Ticket - the ticket to be updated
+// - dtoClaim: *game.ClaimAttempt - the origin of the claim
 //
 // Returns:
 // - *entities.Ticket: the updated ticket, if successful
 // - errors.ErrorInterface: the error returned by the service, if any
-func (mgs *DomainGameService) UpdateTicket(dtoTicket *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
-	args := mgs.Called(dtoTicket)
+func (mgs *DomainGameService) UpdateTicket(dtoTicket *transfert.Ticket, dtoClaim *transfert.ClaimAttempt) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket, dtoClaim)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(errors.ErrorInterface)
 	}
@@ -54,17 +56,17 @@ func (mgs *DomainGameService) UpdateTicket(dtoTicket *transfert.Ticket) (*entiti
 // It uses testify's mock functionality to simulate return values and errors.
 //
 // Parameters:
-// - dtoTicket: *game.Ticket - the ticket to be updated
+// - dtoSearch: *game.TicketSearch - the filters, sort and page requested
 //
 // Returns:
-// - *entities.Ticket: the updated ticket, if successful
+// - *entities.TicketPage: the page of tickets, if successful
 // - errors.ErrorInterface: the error returned by the service, if any
-func (mgs *DomainGameService) GetTickets() ([]*entities.Ticket, errors.ErrorInterface) {
-	args := mgs.Called()
+func (mgs *DomainGameService) GetTickets(dtoSearch *transfert.TicketSearch) (*entities.TicketPage, errors.ErrorInterface) {
+	args := mgs.Called(dtoSearch)
 	if args.Get(0) == nil {
 		return nil, args.Get(1).(errors.ErrorInterface)
 	}
-	return args.Get(0).([]*entities.Ticket), nil
+	return args.Get(0).(*entities.TicketPage), nil
 }
 
 // GetTicketById simulates the GetTicketById method of the GameServiceInterface
@@ -84,3 +86,579 @@ func (mgs *DomainGameService) GetTicketById(dtoTicket *transfert.Ticket) (*entit
 	}
 	return args.Get(0).(*entities.Ticket), nil
 }
+
+// UpdateTicketStatus simulates the UpdateTicketStatus method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTicket: *game.Ticket - the ticket id and its requested status
+//
+// Returns:
+// - *entities.Ticket: the updated ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) UpdateTicketStatus(dtoTicket *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// RedeemTicket simulates the RedeemTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTicket: *game.Ticket - the printed token, the client, the store and the caisse
+//
+// Returns:
+// - *entities.Ticket: the redeemed ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) RedeemTicket(dtoTicket *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Ticket), nil
+}
+
+// IssueReceipt simulates the IssueReceipt method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoReceipt: *game.Receipt - the purchase recorded at the caisse
+//
+// Returns:
+// - *entities.Receipt: the recorded receipt with its ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) IssueReceipt(dtoReceipt *transfert.Receipt) (*entities.Receipt, errors.ErrorInterface) {
+	args := mgs.Called(dtoReceipt)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Receipt), nil
+}
+
+// GetClaimReviews simulates the GetClaimReviews method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoReview: *game.ClaimReview - the status of the listed reviews
+//
+// Returns:
+// - []*entities.ClaimReview: the held claims, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetClaimReviews(dtoReview *transfert.ClaimReview) ([]*entities.ClaimReview, errors.ErrorInterface) {
+	args := mgs.Called(dtoReview)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.ClaimReview), nil
+}
+
+// ReviewClaim simulates the ReviewClaim method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoReview: *game.ClaimReview - the review and the decision taken
+//
+// Returns:
+// - *entities.ClaimReview: the decided review, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) ReviewClaim(dtoReview *transfert.ClaimReview) (*entities.ClaimReview, errors.ErrorInterface) {
+	args := mgs.Called(dtoReview)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.ClaimReview), nil
+}
+
+// GetTicketHistory simulates the GetTicketHistory method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTicket: *game.Ticket - the ticket whose history is requested
+//
+// Returns:
+// - []*entities.TicketHistory: the status changes of the ticket, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetTicketHistory(dtoTicket *transfert.Ticket) ([]*entities.TicketHistory, errors.ErrorInterface) {
+	args := mgs.Called(dtoTicket)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.TicketHistory), nil
+}
+
+// GetPrizes simulates the GetPrizes method of the GameServiceInterface
+//
+// Returns:
+// - []*entities.Prize: the prize catalogue, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetPrizes() ([]*entities.Prize, errors.ErrorInterface) {
+	args := mgs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// GetPrize simulates the GetPrize method of the GameServiceInterface
+//
+// Parameters:
+// - dtoPrize: *game.Prize - the prize to read
+//
+// Returns:
+// - *entities.Prize: the prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetPrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mgs.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// CreatePrize simulates the CreatePrize method of the GameServiceInterface
+//
+// Parameters:
+// - dtoPrize: *game.Prize - the prize to create
+//
+// Returns:
+// - *entities.Prize: the created prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) CreatePrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mgs.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// UpdatePrize simulates the UpdatePrize method of the GameServiceInterface
+//
+// Parameters:
+// - dtoPrize: *game.Prize - the prize to update
+//
+// Returns:
+// - *entities.Prize: the updated prize, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) UpdatePrize(dtoPrize *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	args := mgs.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// DeletePrize simulates the DeletePrize method of the GameServiceInterface
+//
+// Parameters:
+// - dtoPrize: *game.Prize - the prize to delete
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) DeletePrize(dtoPrize *transfert.Prize) errors.ErrorInterface {
+	args := mgs.Called(dtoPrize)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// GetCampaigns simulates the GetCampaigns method of the GameServiceInterface
+//
+// Returns:
+// - []*entities.Campaign: the campaigns, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface) {
+	args := mgs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// GetCampaign simulates the GetCampaign method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign to retrieve
+//
+// Returns:
+// - *entities.Campaign: the campaign, if found
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// CreateCampaign simulates the CreateCampaign method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign to create
+//
+// Returns:
+// - *entities.Campaign: the created campaign, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) CreateCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// UpdateCampaign simulates the UpdateCampaign method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign to update
+//
+// Returns:
+// - *entities.Campaign: the updated campaign, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) UpdateCampaign(dtoCampaign *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// PreviewDistribution simulates the PreviewDistribution method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign and its new distribution
+//
+// Returns:
+// - *entities.DistributionChange: the change that would be applied, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) PreviewDistribution(dtoCampaign *transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.DistributionChange), nil
+}
+
+// UpdateDistribution simulates the UpdateDistribution method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign and its new distribution
+//
+// Returns:
+// - *entities.DistributionChange: the applied change, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) UpdateDistribution(dtoCampaign *transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.DistributionChange), nil
+}
+
+// GetDistributionChanges simulates the GetDistributionChanges method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign whose changes are listed
+//
+// Returns:
+// - []*entities.DistributionChange: the recorded changes, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetDistributionChanges(dtoCampaign *transfert.Campaign) ([]*entities.DistributionChange, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.DistributionChange), nil
+}
+
+// ExpireTickets simulates the ExpireTickets method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign whose tickets expire
+//
+// Returns:
+// - *entities.TicketExpiry: the summary of the expired tickets, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) ExpireTickets(dtoCampaign *transfert.Campaign) (*entities.TicketExpiry, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.TicketExpiry), nil
+}
+
+// GetTicketExpiries simulates the GetTicketExpiries method of the GameServiceInterface
+//
+// Parameters:
+// - dtoCampaign: *game.Campaign - the campaign whose expiries are listed
+//
+// Returns:
+// - []*entities.TicketExpiry: the recorded expiries, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetTicketExpiries(dtoCampaign *transfert.Campaign) ([]*entities.TicketExpiry, errors.ErrorInterface) {
+	args := mgs.Called(dtoCampaign)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.TicketExpiry), nil
+}
+
+// PrepareLedger simulates the PrepareLedger method of the GameServiceInterface
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) PrepareLedger() errors.ErrorInterface {
+	args := mgs.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// WriteLedger simulates the WriteLedger method of the GameServiceInterface
+//
+// Parameters:
+// - w: io.Writer - the destination of the export
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) WriteLedger(w io.Writer) errors.ErrorInterface {
+	args := mgs.Called(w)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// VerifyLedger simulates the VerifyLedger method of the GameServiceInterface
+//
+// Returns:
+// - *entities.LedgerReport: the verification report, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) VerifyLedger() (*entities.LedgerReport, errors.ErrorInterface) {
+	args := mgs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.LedgerReport), nil
+}
+
+// RunDraw simulates the RunDraw method of the GameServiceInterface
+//
+// Parameters:
+// - dtoDraw: *game.Draw - the draw to run
+//
+// Returns:
+// - *entities.Draw: the recorded draw, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) RunDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mgs.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// GetDraw simulates the GetDraw method of the GameServiceInterface
+//
+// Parameters:
+// - dtoDraw: *game.Draw - the draw to retrieve
+//
+// Returns:
+// - *entities.Draw: the draw, if found
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mgs.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// VerifyDraw simulates the VerifyDraw method of the GameServiceInterface
+//
+// Parameters:
+// - dtoDraw: *game.Draw - the draw to replay
+//
+// Returns:
+// - *entities.Draw: the verified draw, if it matches
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) VerifyDraw(dtoDraw *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	args := mgs.Called(dtoDraw)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// PrepareBatch simulates the PrepareBatch method of the GameServiceInterface
+//
+// Parameters:
+// - dtoBatch: *game.Batch - the export request
+//
+// Returns:
+// - *entities.Batch: the prepared batch, if the request is valid
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) PrepareBatch(dtoBatch *transfert.Batch) (*entities.Batch, errors.ErrorInterface) {
+	args := mgs.Called(dtoBatch)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Batch), nil
+}
+
+// WriteBatch simulates the WriteBatch method of the GameServiceInterface
+//
+// Parameters:
+// - batch: *entities.Batch - the prepared batch
+// - w: io.Writer - the destination of the export
+//
+// Returns:
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) WriteBatch(batch *entities.Batch, w io.Writer) errors.ErrorInterface {
+	args := mgs.Called(batch, w)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+// GetBatches simulates the GetBatches method of the GameServiceInterface
+//
+// Returns:
+// - []*entities.Batch: the recorded batches
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetBatches() ([]*entities.Batch, errors.ErrorInterface) {
+	args := mgs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.Batch), nil
+}
+
+// RenderQRCode simulates the RenderQRCode method of the GameServiceInterface
+//
+// Parameters:
+// - dto: *transfert.QRCode - the token and the rendering options
+//
+// Returns:
+// - *entities.QRCode: the rendered QR code
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) RenderQRCode(dto *transfert.QRCode) (*entities.QRCode, errors.ErrorInterface) {
+	args := mgs.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.QRCode), nil
+}
+
+// GiftTicket simulates the GiftTicket method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTransfer: *game.TicketTransfer - the ticket and the email of the recipient
+//
+// Returns:
+// - *entities.TicketTransfer: the pending transfer, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GiftTicket(dtoTransfer *transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface) {
+	args := mgs.Called(dtoTransfer)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.TicketTransfer), nil
+}
+
+// GetTicketTransfers simulates the GetTicketTransfers method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTransfer: *game.TicketTransfer - the status to filter on
+//
+// Returns:
+// - *entities.TransferPage: the page of transfers of the client, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetTicketTransfers(dtoTransfer *transfert.TicketTransfer) (*entities.TransferPage, errors.ErrorInterface) {
+	args := mgs.Called(dtoTransfer)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.TransferPage), nil
+}
+
+// AnswerTicketTransfer simulates the AnswerTicketTransfer method of the GameServiceInterface
+//
+// It uses testify's mock functionality to simulate return values and errors.
+//
+// Parameters:
+// - dtoTransfer: *game.TicketTransfer - the transfer and the answer given
+//
+// Returns:
+// - *entities.TicketTransfer: the closed transfer, if successful
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) AnswerTicketTransfer(dtoTransfer *transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface) {
+	args := mgs.Called(dtoTransfer)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.TicketTransfer), nil
+}
+
+// GetPrizeStatistics simulates the GetPrizeStatistics method of the GameServiceInterface
+//
+// Parameters:
+// - dto: *transfert.Statistics the requested period
+//
+// Returns:
+// - []*entities.PrizeStatistic: the tickets claimed and unclaimed per prize
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetPrizeStatistics(dto *transfert.Statistics) ([]*entities.PrizeStatistic, errors.ErrorInterface) {
+	args := mgs.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.PrizeStatistic), nil
+}
+
+// GetClaimStatistics simulates the GetClaimStatistics method of the GameServiceInterface
+//
+// Parameters:
+// - dto: *transfert.Statistics the requested period
+//
+// Returns:
+// - []*entities.PeriodStatistic: the claims per day or hour
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetClaimStatistics(dto *transfert.Statistics) ([]*entities.PeriodStatistic, errors.ErrorInterface) {
+	args := mgs.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.PeriodStatistic), nil
+}
+
+// GetStoreStatistics simulates the GetStoreStatistics method of the GameServiceInterface
+//
+// Parameters:
+// - dto: *transfert.Statistics the requested period
+//
+// Returns:
+// - []*entities.StoreStatistic: the claims and redemptions per caisse
+// - errors.ErrorInterface: the error returned by the service, if any
+func (mgs *DomainGameService) GetStoreStatistics(dto *transfert.Statistics) ([]*entities.StoreStatistic, errors.ErrorInterface) {
+	args := mgs.Called(dto)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.StoreStatistic), nil
+}
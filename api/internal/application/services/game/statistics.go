@@ -0,0 +1,34 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+)
+
+func GetPrizeStatistics(service services.GameServiceInterface, dtoStatistics *transfert.Statistics) (int, any) {
+	statistics, err := service.GetPrizeStatistics(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, statistics
+}
+
+func GetClaimStatistics(service services.GameServiceInterface, dtoStatistics *transfert.Statistics) (int, any) {
+	statistics, err := service.GetClaimStatistics(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, statistics
+}
+
+func GetStoreStatistics(service services.GameServiceInterface, dtoStatistics *transfert.Statistics) (int, any) {
+	statistics, err := service.GetStoreStatistics(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, statistics
+}
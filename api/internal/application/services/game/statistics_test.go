@@ -0,0 +1,90 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPrizeStatistics(t *testing.T) {
+	t.Run("should return the statistics per prize", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{From: aws.String("2024-10-01")}
+		expected := []*entities.PrizeStatistic{{Label: aws.String("Infuser"), Claimed: 2}}
+		mockService.On("GetPrizeStatistics", dto).Return(expected, nil)
+
+		statusCode, response := game.GetPrizeStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return error when unauthorized", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{}
+		mockService.On("GetPrizeStatistics", dto).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := game.GetPrizeStatistics(mockService, dto)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestGetClaimStatistics(t *testing.T) {
+	t.Run("should return the claims per period", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{Interval: aws.String("hour")}
+		expected := []*entities.PeriodStatistic{{Period: "2024-10-01 09:00", Count: 5}}
+		mockService.On("GetClaimStatistics", dto).Return(expected, nil)
+
+		statusCode, response := game.GetClaimStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return error when the interval is unknown", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{Interval: aws.String("week")}
+		mockService.On("GetClaimStatistics", dto).Return(nil, errors_domain_game.ErrStatisticsInvalidInterval)
+
+		statusCode, response := game.GetClaimStatistics(mockService, dto)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrStatisticsInvalidInterval, response)
+	})
+}
+
+func TestGetStoreStatistics(t *testing.T) {
+	t.Run("should return the statistics per caisse", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{}
+		expected := []*entities.StoreStatistic{{StoreID: aws.String("store-1"), Redeemed: 1}}
+		mockService.On("GetStoreStatistics", dto).Return(expected, nil)
+
+		statusCode, response := game.GetStoreStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+	})
+
+	t.Run("should return error when the period is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dto := &transfert.Statistics{From: aws.String("tomorrow")}
+		mockService.On("GetStoreStatistics", dto).Return(nil, errors.ErrValueIsNotDate)
+
+		statusCode, response := game.GetStoreStatistics(mockService, dto)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueIsNotDate, response)
+	})
+}
@@ -3,7 +3,10 @@ package game
 import (
 	"github.com/gofiber/fiber/v2"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
 )
 
 func GetRandomTicket(service services.GameServiceInterface) (int, any) {
@@ -15,22 +18,33 @@ func GetRandomTicket(service services.GameServiceInterface) (int, any) {
 	return fiber.StatusOK, ticket
 }
 
-func GetTickets(service services.GameServiceInterface) (int, any) {
-	tickets, err := service.GetTickets()
+func GetTickets(service services.GameServiceInterface, dtoSearch *transfert.TicketSearch) (int, any) {
+	page, err := service.GetTickets(dtoSearch)
 	if err != nil {
 		return err.Code(), err
 	}
 
-	return fiber.StatusOK, tickets
+	return fiber.StatusOK, page
 }
 
-func UpdateTicket(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
-	ticket, err := service.UpdateTicket(dtoTicket)
+func UpdateTicket(service services.GameServiceInterface, dtoTicket *transfert.Ticket, dtoClaim *transfert.ClaimAttempt) (int, any) {
+	if err := dtoTicket.Check(data.Validator{
+		"token": {validator.Required, validator.Luhn},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.UpdateTicket(dtoTicket, dtoClaim)
 
 	if err != nil {
 		return err.Code(), err
 	}
 
+	// A held claim is accepted for review, not yet claimed
+	if ticket.Status == entities.TicketReview {
+		return fiber.StatusAccepted, ticket
+	}
+
 	return fiber.StatusOK, ticket
 }
 
@@ -43,3 +57,52 @@ func GetTicketById(service services.GameServiceInterface, dtoTicket *transfert.T
 
 	return fiber.StatusOK, ticket
 }
+
+func UpdateTicketStatus(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
+	if err := dtoTicket.Check(data.Validator{
+		"id":     {validator.Required, validator.ID},
+		"status": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.UpdateTicketStatus(dtoTicket)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
+
+func RedeemTicket(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
+	if err := dtoTicket.Check(data.Validator{
+		"token":         {validator.Required, validator.Luhn},
+		"credential_id": {validator.Required, validator.ID},
+		"store_id":      {validator.Required, validator.ID},
+		"caisse_id":     {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	ticket, err := service.RedeemTicket(dtoTicket)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, ticket
+}
+
+func GetTicketHistory(service services.GameServiceInterface, dtoTicket *transfert.Ticket) (int, any) {
+	if err := dtoTicket.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	histories, err := service.GetTicketHistory(dtoTicket)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, histories
+}
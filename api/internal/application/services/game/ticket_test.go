@@ -9,6 +9,7 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/application/services/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,26 +17,27 @@ import (
 func TestGetTickets(t *testing.T) {
 	t.Run("should return tickets successfully", func(t *testing.T) {
 		mockService := new(DomainGameService)
-		expectedTickets := []*entities.Ticket{}
-		mockService.On("GetTickets").Return(expectedTickets, nil)
+		dtoSearch := &transfert.TicketSearch{Status: aws.String("claimed")}
+		expectedPage := entities.NewTicketPage(nil, 0, 1, 20)
+		mockService.On("GetTickets", dtoSearch).Return(expectedPage, nil)
 
-		statusCode, response := game.GetTickets(mockService)
+		statusCode, response := game.GetTickets(mockService, dtoSearch)
 
 		assert.Equal(t, fiber.StatusOK, statusCode)
-		assert.Equal(t, expectedTickets, response)
-		mockService.AssertCalled(t, "GetTickets")
+		assert.Equal(t, expectedPage, response)
+		mockService.AssertCalled(t, "GetTickets", dtoSearch)
 	})
 
 	t.Run("should return error when service fails", func(t *testing.T) {
 		mockService := new(DomainGameService)
 		expectedError := errors.ErrBadRequest
-		mockService.On("GetTickets").Return(nil, expectedError)
+		mockService.On("GetTickets", (*transfert.TicketSearch)(nil)).Return(nil, expectedError)
 
-		statusCode, response := game.GetTickets(mockService)
+		statusCode, response := game.GetTickets(mockService, nil)
 
 		assert.Equal(t, http.StatusBadRequest, statusCode)
 		assert.Error(t, response.(*errors.Error))
-		mockService.AssertCalled(t, "GetTickets")
+		mockService.AssertCalled(t, "GetTickets", (*transfert.TicketSearch)(nil))
 	})
 }
 
@@ -66,40 +68,87 @@ func TestGetRandomTicket(t *testing.T) {
 }
 
 func TestUpdateTicket(t *testing.T) {
+	dtoClaim := &transfert.ClaimAttempt{IP: aws.String("203.0.113.7")}
+
 	t.Run("should update ticket successfully", func(t *testing.T) {
 		// Create a mock service
 		mockService := new(DomainGameService)
-		dtoTicket := &transfert.Ticket{}
+		dtoTicket := &transfert.Ticket{Token: aws.String("79927398713")}
 		updatedTicket := &entities.Ticket{ID: "1", Token: "updated-token"}
 
 		// Configure the mock to return the updated ticket
-		mockService.On("UpdateTicket", dtoTicket).Return(updatedTicket, nil)
+		mockService.On("UpdateTicket", dtoTicket, dtoClaim).Return(updatedTicket, nil)
 
 		// Call the function under test
-		statusCode, response := game.UpdateTicket(mockService, dtoTicket)
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket, dtoClaim)
 
 		// Assert the results
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.Equal(t, updatedTicket, response)
-		mockService.AssertCalled(t, "UpdateTicket", dtoTicket)
+		mockService.AssertCalled(t, "UpdateTicket", dtoTicket, dtoClaim)
+	})
+
+	t.Run("should accept a held claim for review", func(t *testing.T) {
+		// Create a mock service
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{Token: aws.String("79927398713")}
+		heldTicket := &entities.Ticket{ID: "1", Status: entities.TicketReview}
+
+		// Configure the mock to return the held ticket
+		mockService.On("UpdateTicket", dtoTicket, dtoClaim).Return(heldTicket, nil)
+
+		// Call the function under test
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket, dtoClaim)
+
+		// Assert the results
+		assert.Equal(t, fiber.StatusAccepted, statusCode)
+		assert.Equal(t, heldTicket, response)
 	})
 
 	t.Run("should return error when update fails", func(t *testing.T) {
 		// Create a mock service
 		mockService := new(DomainGameService)
-		dtoTicket := &transfert.Ticket{}
+		dtoTicket := &transfert.Ticket{Token: aws.String("79927398713")}
 		expectedError := errors.ErrBadRequest
 
 		// Configure the mock to return an error
-		mockService.On("UpdateTicket", dtoTicket).Return(nil, expectedError)
+		mockService.On("UpdateTicket", dtoTicket, dtoClaim).Return(nil, expectedError)
 
 		// Call the function under test
-		statusCode, response := game.UpdateTicket(mockService, dtoTicket)
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket, dtoClaim)
 
 		// Assert the results
 		assert.Equal(t, http.StatusBadRequest, statusCode)
 		assert.Equal(t, expectedError, response)
-		mockService.AssertCalled(t, "UpdateTicket", dtoTicket)
+		mockService.AssertCalled(t, "UpdateTicket", dtoTicket, dtoClaim)
+	})
+
+	t.Run("should return error when the token is missing", func(t *testing.T) {
+		// Create a mock service
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String("1")}
+
+		// Call the function under test
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket, dtoClaim)
+
+		// Assert the results
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueRequired, response)
+		mockService.AssertNotCalled(t, "UpdateTicket", dtoTicket, dtoClaim)
+	})
+
+	t.Run("should return error when the token is not a code", func(t *testing.T) {
+		// Create a mock service
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{Token: aws.String("79927398710")}
+
+		// Call the function under test
+		statusCode, response := game.UpdateTicket(mockService, dtoTicket, dtoClaim)
+
+		// Assert the results
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueIsNotLuhn, response)
+		mockService.AssertNotCalled(t, "UpdateTicket", dtoTicket, dtoClaim)
 	})
 }
 
@@ -130,3 +179,146 @@ func TestGetTicketById(t *testing.T) {
 		mockService.AssertCalled(t, "GetTicketById", dtoTicket)
 	})
 }
+
+func TestUpdateTicketStatus(t *testing.T) {
+	id := "2bd8c1b3-5d4c-4a1f-9f6e-0d7a1c2b3e4f"
+
+	t.Run("should update ticket status successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String(id), Status: aws.String("redeemed")}
+		expectedTicket := &entities.Ticket{ID: id, Status: entities.TicketRedeemed}
+		mockService.On("UpdateTicketStatus", dtoTicket).Return(expectedTicket, nil)
+
+		statusCode, response := game.UpdateTicketStatus(mockService, dtoTicket)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedTicket, response)
+		mockService.AssertCalled(t, "UpdateTicketStatus", dtoTicket)
+	})
+
+	t.Run("should return error when status is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String(id)}
+
+		statusCode, response := game.UpdateTicketStatus(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "UpdateTicketStatus", dtoTicket)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String(id), Status: aws.String("redeemed")}
+		expectedError := errors.ErrUnauthorized
+		mockService.On("UpdateTicketStatus", dtoTicket).Return(nil, expectedError)
+
+		statusCode, response := game.UpdateTicketStatus(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusUnauthorized, statusCode)
+		assert.Equal(t, expectedError, response)
+		mockService.AssertCalled(t, "UpdateTicketStatus", dtoTicket)
+	})
+}
+
+func TestRedeemTicket(t *testing.T) {
+	newDTO := func() *transfert.Ticket {
+		return &transfert.Ticket{
+			Token:        aws.String("79927398713"),
+			CredentialID: aws.String("2bd8c1b3-5d4c-4a1f-9f6e-0d7a1c2b3e4f"),
+			StoreID:      aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			CaisseID:     aws.String("9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"),
+		}
+	}
+
+	t.Run("should redeem ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := newDTO()
+		expectedTicket := &entities.Ticket{ID: "ticket-123", Status: entities.TicketRedeemed}
+		mockService.On("RedeemTicket", dtoTicket).Return(expectedTicket, nil)
+
+		statusCode, response := game.RedeemTicket(mockService, dtoTicket)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedTicket, response)
+		mockService.AssertCalled(t, "RedeemTicket", dtoTicket)
+	})
+
+	t.Run("should return error when caisse is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := newDTO()
+		dtoTicket.CaisseID = nil
+
+		statusCode, response := game.RedeemTicket(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "RedeemTicket", dtoTicket)
+	})
+
+	t.Run("should return error when token is not a code", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := newDTO()
+		dtoTicket.Token = aws.String("79927398710")
+
+		statusCode, response := game.RedeemTicket(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "RedeemTicket", dtoTicket)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := newDTO()
+		expectedError := errors_domain_game.ErrTicketAlreadyRedeemed
+		mockService.On("RedeemTicket", dtoTicket).Return(nil, expectedError)
+
+		statusCode, response := game.RedeemTicket(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, expectedError, response)
+		mockService.AssertCalled(t, "RedeemTicket", dtoTicket)
+	})
+}
+
+func TestGetTicketHistory(t *testing.T) {
+	id := "2bd8c1b3-5d4c-4a1f-9f6e-0d7a1c2b3e4f"
+
+	t.Run("should return ticket history successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String(id)}
+		expectedHistory := []*entities.TicketHistory{{TicketID: aws.String(id), Status: entities.TicketClaimed}}
+		mockService.On("GetTicketHistory", dtoTicket).Return(expectedHistory, nil)
+
+		statusCode, response := game.GetTicketHistory(mockService, dtoTicket)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedHistory, response)
+		mockService.AssertCalled(t, "GetTicketHistory", dtoTicket)
+	})
+
+	t.Run("should return error when id is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String("not-an-id")}
+
+		statusCode, response := game.GetTicketHistory(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertNotCalled(t, "GetTicketHistory", dtoTicket)
+	})
+
+	t.Run("should return error when service fails", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTicket := &transfert.Ticket{ID: aws.String(id)}
+		expectedError := errors.ErrBadRequest
+		mockService.On("GetTicketHistory", dtoTicket).Return(nil, expectedError)
+
+		statusCode, response := game.GetTicketHistory(mockService, dtoTicket)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(*errors.Error))
+		mockService.AssertCalled(t, "GetTicketHistory", dtoTicket)
+	})
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+func GiftTicket(service services.GameServiceInterface, dtoTransfer *transfert.TicketTransfer) (int, any) {
+	if err := dtoTransfer.Check(data.Validator{
+		"ticket_id": {validator.Required, validator.ID},
+		"email":     {validator.Required, validator.Email},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	transfer, err := service.GiftTicket(dtoTransfer)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, transfer
+}
+
+func GetTicketTransfers(service services.GameServiceInterface, dtoTransfer *transfert.TicketTransfer) (int, any) {
+	transfers, err := service.GetTicketTransfers(dtoTransfer)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, transfers
+}
+
+func AnswerTicketTransfer(service services.GameServiceInterface, dtoTransfer *transfert.TicketTransfer) (int, any) {
+	if err := dtoTransfer.Check(data.Validator{
+		"id":     {validator.Required, validator.ID},
+		"status": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	transfer, err := service.AnswerTicketTransfer(dtoTransfer)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, transfer
+}
@@ -0,0 +1,128 @@
+package game_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiftTicket(t *testing.T) {
+	t.Run("should offer the ticket successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{
+			TicketID: aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Email:    aws.String("friend@thetiptop.fr"),
+		}
+		expectedTransfer := &entities.TicketTransfer{ID: "transfer-123", Status: entities.TransferPending}
+		mockService.On("GiftTicket", dtoTransfer).Return(expectedTransfer, nil)
+
+		statusCode, response := game.GiftTicket(mockService, dtoTransfer)
+
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, expectedTransfer, response)
+		mockService.AssertCalled(t, "GiftTicket", dtoTransfer)
+	})
+
+	t.Run("should return error when the email is invalid", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{
+			TicketID: aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Email:    aws.String("friend"),
+		}
+
+		statusCode, response := game.GiftTicket(mockService, dtoTransfer)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "GiftTicket", dtoTransfer)
+	})
+
+	t.Run("should return error when the ticket is already redeemed", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{
+			TicketID: aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Email:    aws.String("friend@thetiptop.fr"),
+		}
+		mockService.On("GiftTicket", dtoTransfer).Return(nil, errors_domain_game.ErrTicketAlreadyRedeemed)
+
+		statusCode, response := game.GiftTicket(mockService, dtoTransfer)
+
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed.Code(), statusCode)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, response)
+	})
+}
+
+func TestGetTicketTransfers(t *testing.T) {
+	t.Run("should list the transfers successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{}
+		expectedTransfers := entities.NewTransferPage([]*entities.TicketTransfer{{ID: "transfer-123"}}, 1, 1, 20)
+		mockService.On("GetTicketTransfers", dtoTransfer).Return(expectedTransfers, nil)
+
+		statusCode, response := game.GetTicketTransfers(mockService, dtoTransfer)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedTransfers, response)
+	})
+
+	t.Run("should return error when the status is unknown", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{Status: aws.String("lost")}
+		mockService.On("GetTicketTransfers", dtoTransfer).Return(nil, errors_domain_game.ErrTransferInvalidStatus)
+
+		statusCode, response := game.GetTicketTransfers(mockService, dtoTransfer)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTransferInvalidStatus, response)
+	})
+}
+
+func TestAnswerTicketTransfer(t *testing.T) {
+	t.Run("should answer the transfer successfully", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{
+			ID:     aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Status: aws.String("accepted"),
+		}
+		expectedTransfer := &entities.TicketTransfer{ID: "transfer-123", Status: entities.TransferAccepted}
+		mockService.On("AnswerTicketTransfer", dtoTransfer).Return(expectedTransfer, nil)
+
+		statusCode, response := game.AnswerTicketTransfer(mockService, dtoTransfer)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expectedTransfer, response)
+	})
+
+	t.Run("should return error when the answer is missing", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{ID: aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d")}
+
+		statusCode, response := game.AnswerTicketTransfer(mockService, dtoTransfer)
+
+		assert.Equal(t, http.StatusBadRequest, statusCode)
+		assert.Error(t, response.(errors.ErrorInterface))
+		mockService.AssertNotCalled(t, "AnswerTicketTransfer", dtoTransfer)
+	})
+
+	t.Run("should return error when the transfer was already answered", func(t *testing.T) {
+		mockService := new(DomainGameService)
+		dtoTransfer := &transfert.TicketTransfer{
+			ID:     aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			Status: aws.String("declined"),
+		}
+		mockService.On("AnswerTicketTransfer", dtoTransfer).Return(nil, errors_domain_game.ErrTransferClosed)
+
+		statusCode, response := game.AnswerTicketTransfer(mockService, dtoTransfer)
+
+		assert.Equal(t, http.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_game.ErrTransferClosed, response)
+	})
+}
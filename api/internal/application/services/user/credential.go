@@ -2,6 +2,7 @@ package services
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/application/validator"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
@@ -10,7 +11,7 @@ import (
 	serializer "github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 )
 
-func UserAuth(service services.UserServiceInterface, credentialDTO *transfert.Credential) (int, any) {
+func UserAuth(service services.UserServiceInterface, credentialDTO *transfert.Credential, sessionDTO *transfert.Session) (int, any) {
 	if err := credentialDTO.Check(data.Validator{
 		"email":    {validator.Required, validator.Email},
 		"password": {validator.Required, validator.Password},
@@ -18,26 +19,80 @@ func UserAuth(service services.UserServiceInterface, credentialDTO *transfert.Cr
 		return err.Code(), err
 	}
 
-	credentialID, role, err := service.UserAuth(credentialDTO)
+	credentialID, role, pending, err := service.UserAuth(credentialDTO)
 	if err != nil {
 		return err.Code(), err
 	}
 
-	accessToken, refreshToken, err := serializer.FromID(*credentialID, map[string]any{
+	return issueTokens(service, sessionDTO, *credentialID, role, pending)
+}
+
+// issueTokens opens the session of a sign-in and signs its tokens, pending ones are limited to the routes completing the second factor
+// The session records the device, the user agent and the IP of the sign-in
+func issueTokens(service services.UserServiceInterface, sessionDTO *transfert.Session, credentialID string, role security.Role, pending bool) (int, any) {
+	claims := map[string]any{
 		"role": role,
-	})
+	}
 
+	if pending {
+		claims[serializer.PENDING] = true
+	}
+
+	session, err := service.OpenSession(credentialID, sessionDTO)
 	if err != nil {
 		return err.Code(), err
 	}
 
-	return fiber.StatusOK, fiber.Map{
+	accessToken, refreshToken, err := serializer.FromSession(credentialID, session.Family, session.ID, claims)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	response := fiber.Map{
 		"access_token":  accessToken,
 		"refresh_token": refreshToken,
 	}
+
+	if pending {
+		response["second_factor"] = true
+	}
+
+	return fiber.StatusOK, response
 }
 
-func UserAuthRenew(refresh *serializer.Token) (int, any) {
+// Unlock lifts the back-off or the lock of an account, found by its email, and/or of an IP address
+//
+// Parameters:
+// - service: services.UserServiceInterface The service tracking the failed sign-ins
+// - lockoutDTO: *transfert.Lockout The email and/or the IP to unlock, at least one of them
+//
+// Returns:
+// - int: 204 once unlocked, the error code otherwise
+// - any: The error, nil on success
+func Unlock(service services.UserServiceInterface, lockoutDTO *transfert.Lockout) (int, any) {
+	mandatory := data.Validator{}
+	if lockoutDTO.Email != nil || lockoutDTO.IP == nil {
+		mandatory["email"] = []data.Control{validator.Required, validator.Email}
+	}
+
+	if lockoutDTO.IP != nil {
+		mandatory["ip"] = []data.Control{validator.Required, validator.IP}
+	}
+
+	if err := lockoutDTO.Check(mandatory); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.Unlock(lockoutDTO); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
+// UserAuthRenew exchanges a refresh token for new tokens of its session, the refresh token is spent
+// The session records the user agent and the IP of the renewal as its last use
+func UserAuthRenew(service services.UserServiceInterface, refresh *serializer.Token, sessionDTO *transfert.Session) (int, any) {
 	var err errors.ErrorInterface = errors.ErrAuthInvalidToken
 	if refresh == nil {
 		return err.Code(), err
@@ -52,7 +107,12 @@ func UserAuthRenew(refresh *serializer.Token) (int, any) {
 		return err.Code(), err
 	}
 
-	accessToken, refreshToken, err := serializer.FromID(refresh.ID, refresh.Data)
+	session, err := service.RenewSession(refresh.ID, refresh.JTI, sessionDTO)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	accessToken, refreshToken, err := serializer.FromSession(refresh.ID, session.Family, session.ID, refresh.Data)
 	if err != nil {
 		return err.Code(), err
 	}
@@ -63,6 +123,85 @@ func UserAuthRenew(refresh *serializer.Token) (int, any) {
 	}
 }
 
+// Logout revokes the session of the signed in user, its access token stays valid until it expires
+//
+// Parameters:
+// - service: services.UserServiceInterface The service tracking the sessions
+//
+// Returns:
+// - int: 204 once signed out, the error code otherwise
+// - any: The error, nil on success
+func Logout(service services.UserServiceInterface) (int, any) {
+	if err := service.Logout(); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
+// ListSessions lists the open sessions of the signed in user with their device, the current one flagged
+//
+// Parameters:
+// - service: services.UserServiceInterface The service tracking the sessions
+//
+// Returns:
+// - int: 200 with the sessions, the error code otherwise
+// - any: The sessions or the error
+func ListSessions(service services.UserServiceInterface) (int, any) {
+	sessions, err := service.ListSessions()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, sessions
+}
+
+// RevokeSession signs the signed in user out of one of its other sessions
+//
+// Parameters:
+// - service: services.UserServiceInterface The service tracking the sessions
+// - sessionDTO: *transfert.Session The ID of the session
+//
+// Returns:
+// - int: 204 once revoked, the error code otherwise
+// - any: The error, nil on success
+func RevokeSession(service services.UserServiceInterface, sessionDTO *transfert.Session) (int, any) {
+	if err := sessionDTO.Check(data.Validator{
+		"id": {validator.Required, validator.ID},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.RevokeSession(sessionDTO); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
+// RevokeSessions signs a user, found by its email, out of all its sessions
+//
+// Parameters:
+// - service: services.UserServiceInterface The service tracking the sessions
+// - credentialDTO: *transfert.Credential The email of the user
+//
+// Returns:
+// - int: 204 once revoked, the error code otherwise
+// - any: The error, nil on success
+func RevokeSessions(service services.UserServiceInterface, credentialDTO *transfert.Credential) (int, any) {
+	if err := credentialDTO.Check(data.Validator{
+		"email": {validator.Required, validator.Email},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	if err := service.RevokeSessions(credentialDTO); err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusNoContent, nil
+}
+
 func CredentialUpdate(service services.UserServiceInterface, validationDTO *transfert.Validation, credentialDTO *transfert.Credential) (int, any) {
 	if err := validationDTO.Check(data.Validator{
 		"token": {validator.Required, validator.Luhn},
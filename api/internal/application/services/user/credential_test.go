@@ -32,7 +32,7 @@ func TestUserAuth(t *testing.T) {
 		statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
 			Email:    &email,
 			Password: &passwordSyntaxFail,
-		})
+		}, nil)
 		assert.Equal(t, fiber.StatusBadRequest, statusCode)
 		assert.NotNil(t, response)
 	})
@@ -45,7 +45,7 @@ func TestUserAuth(t *testing.T) {
 		statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
 			Email:    &emailSyntaxFail,
 			Password: &password,
-		})
+		}, nil)
 		assert.Equal(t, fiber.StatusBadRequest, statusCode)
 		assert.NotNil(t, response)
 	})
@@ -53,12 +53,12 @@ func TestUserAuth(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		mockClient := new(DomainUserService)
 		// Simuler le cas où le client n'est pas trouvé
-		mockClient.On("UserAuth", mock.Anything).Return(nil, "", errors_domain_user.ErrClientNotFound)
+		mockClient.On("UserAuth", mock.Anything).Return(nil, "", false, errors_domain_user.ErrClientNotFound)
 
 		statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
 			Email:    &email,
 			Password: &password,
-		})
+		}, nil)
 		assert.Equal(t, fiber.StatusNotFound, statusCode)
 		assert.Error(t, response.(*errors.Error))
 		assert.NotNil(t, response)
@@ -70,14 +70,84 @@ func TestUserAuth(t *testing.T) {
 		assert.NoError(t, err)
 		mockClient := new(DomainUserService)
 		// Simuler un cas réussi avec une Credential valide et un ClientID valide
-		mockClient.On("UserAuth", mock.Anything).Return(&ids, security.ROLE_CONNECTED, nil)
+		// La session ouverte décrit l'appareil de la connexion
+		session := &transfert.Session{Device: aws.String("Pixel 8"), IP: aws.String("192.0.2.1")}
+		mockClient.On("UserAuth", mock.Anything).Return(&ids, security.ROLE_CONNECTED, false, nil)
+		mockClient.On("OpenSession", ids, session).Return(&entities.RefreshToken{ID: "token-id", Family: "token-id"}, nil)
 
 		statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
 			Email:    &email,
 			Password: &password,
-		})
+		}, session)
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.NotNil(t, response)
+
+		// Le jeton de rafraîchissement porte la session ouverte
+		refresh, err := jwt.TokenToClaims(response.(fiber.Map)["refresh_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, "token-id", refresh.JTI)
+		assert.Equal(t, "token-id", refresh.Session)
+	})
+
+	t.Run("session failure", func(t *testing.T) {
+		ids := "credential-id"
+		mockClient := new(DomainUserService)
+		mockClient.On("UserAuth", mock.Anything).Return(&ids, security.ROLE_CONNECTED, false, nil)
+		mockClient.On("OpenSession", ids, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
+			Email:    &email,
+			Password: &password,
+		}, nil)
+		assert.Equal(t, fiber.StatusInternalServerError, statusCode)
+		assert.Equal(t, errors.ErrInternalServer, response)
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	ip := "192.0.2.1"
+	ipSyntaxFail := "192.0.2"
+
+	t.Run("missing email and ip", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, response := services.Unlock(mockClient, &transfert.Lockout{})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueRequired, response)
+		mockClient.AssertNotCalled(t, "Unlock", mock.Anything)
+	})
+
+	t.Run("invalid syntax email", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.Unlock(mockClient, &transfert.Lockout{Email: &emailSyntaxFail})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+	})
+
+	t.Run("invalid syntax ip", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.Unlock(mockClient, &transfert.Lockout{IP: &ipSyntaxFail})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("Unlock", mock.Anything).Return(errors_domain_user.ErrLockoutNotFound)
+
+		statusCode, response := services.Unlock(mockClient, &transfert.Lockout{IP: &ip})
+		assert.Equal(t, fiber.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_user.ErrLockoutNotFound, response)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("Unlock", mock.Anything).Return(nil)
+
+		statusCode, response := services.Unlock(mockClient, &transfert.Lockout{Email: &email, IP: &ip})
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+		mockClient.AssertExpectations(t)
 	})
 }
 
@@ -87,7 +157,7 @@ func TestUserAuthRenew(t *testing.T) {
 
 	t.Run("invalid token - nil", func(t *testing.T) {
 		// Cas où le jeton est nil
-		statusCode, response := services.UserAuthRenew(nil)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), nil, nil)
 		assert.Equal(t, fiber.StatusBadRequest, statusCode)
 		assert.Error(t, response.(*errors.Error))
 	})
@@ -98,7 +168,7 @@ func TestUserAuthRenew(t *testing.T) {
 			Type: jwt.ACCESS, // Mauvais type de jeton
 		}
 
-		statusCode, response := services.UserAuthRenew(invalidToken)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), invalidToken, nil)
 		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
 		assert.Error(t, response.(*errors.Error))
 	})
@@ -110,7 +180,7 @@ func TestUserAuthRenew(t *testing.T) {
 			Exp:  time.Now().Add(-1 * time.Hour).Unix(), // Jeton expiré
 		}
 
-		statusCode, response := services.UserAuthRenew(expiredToken)
+		statusCode, response := services.UserAuthRenew(new(DomainUserService), expiredToken, nil)
 		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
 		assert.Error(t, response.(*errors.Error))
 	})
@@ -121,9 +191,13 @@ func TestUserAuthRenew(t *testing.T) {
 			Type: jwt.REFRESH,
 			ID:   "valid-client-id",
 			Exp:  time.Now().Add(1 * time.Hour).Unix(), // Jeton valide
+			JTI:  "token-id",
 		}
 
-		statusCode, response := services.UserAuthRenew(validToken)
+		mockClient := new(DomainUserService)
+		mockClient.On("RenewSession", "valid-client-id", "token-id", mock.Anything).Return(&entities.RefreshToken{ID: "next-id", Family: "family-id"}, nil)
+
+		statusCode, response := services.UserAuthRenew(mockClient, validToken, nil)
 		assert.Equal(t, fiber.StatusOK, statusCode)
 		assert.NotNil(t, response)
 
@@ -132,6 +206,130 @@ func TestUserAuthRenew(t *testing.T) {
 		assert.True(t, ok)
 		assert.NotNil(t, authResponse["access_token"])
 		assert.NotNil(t, authResponse["refresh_token"])
+
+		// Le nouveau jeton de rafraîchissement succède à l'ancien dans la même session
+		refresh, err := jwt.TokenToClaims(authResponse["refresh_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, "next-id", refresh.JTI)
+		assert.Equal(t, "family-id", refresh.Session)
+	})
+
+	t.Run("reused token", func(t *testing.T) {
+		// Un jeton déjà échangé est refusé
+		reusedToken := &jwt.Token{
+			Type: jwt.REFRESH,
+			ID:   "valid-client-id",
+			Exp:  time.Now().Add(1 * time.Hour).Unix(),
+			JTI:  "token-id",
+		}
+
+		mockClient := new(DomainUserService)
+		mockClient.On("RenewSession", "valid-client-id", "token-id", mock.Anything).Return(nil, errors_domain_user.ErrRefreshTokenReused)
+
+		statusCode, response := services.UserAuthRenew(mockClient, reusedToken, nil)
+		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenReused, response)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("Logout").Return(errors.ErrUnauthorized)
+
+		statusCode, response := services.Logout(mockClient)
+		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("Logout").Return(nil)
+
+		statusCode, response := services.Logout(mockClient)
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	t.Run("unauthorized", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("ListSessions").Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := services.ListSessions(mockClient)
+		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		sessions := []*entities.RefreshToken{{Family: "family-id", Device: aws.String("Pixel 8"), Current: true}}
+		mockClient := new(DomainUserService)
+		mockClient.On("ListSessions").Return(sessions, nil)
+
+		statusCode, response := services.ListSessions(mockClient)
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, sessions, response)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	id := "6a2f41a3-c54c-fce8-32d2-0324e1c32e22"
+
+	t.Run("invalid id", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.RevokeSession(mockClient, &transfert.Session{ID: aws.String("not-an-id")})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		mockClient.AssertNotCalled(t, "RevokeSession", mock.Anything)
+	})
+
+	t.Run("current session", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("RevokeSession", mock.Anything).Return(errors_domain_user.ErrSessionCurrent)
+
+		statusCode, response := services.RevokeSession(mockClient, &transfert.Session{ID: &id})
+		assert.Equal(t, fiber.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_user.ErrSessionCurrent, response)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("RevokeSession", mock.Anything).Return(nil)
+
+		statusCode, response := services.RevokeSession(mockClient, &transfert.Session{ID: &id})
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestRevokeSessions(t *testing.T) {
+	t.Run("invalid syntax email", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.RevokeSessions(mockClient, &transfert.Credential{Email: &emailSyntaxFail})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		mockClient.AssertNotCalled(t, "RevokeSessions", mock.Anything)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("RevokeSessions", mock.Anything).Return(errors_domain_user.ErrCredentialNotFound)
+
+		statusCode, response := services.RevokeSessions(mockClient, &transfert.Credential{Email: &email})
+		assert.Equal(t, fiber.StatusNotFound, statusCode)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, response)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("RevokeSessions", mock.Anything).Return(nil)
+
+		statusCode, response := services.RevokeSessions(mockClient, &transfert.Credential{Email: &email})
+		assert.Equal(t, fiber.StatusNoContent, statusCode)
+		assert.Nil(t, response)
+		mockClient.AssertExpectations(t)
 	})
 }
 
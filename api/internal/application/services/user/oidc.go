@@ -0,0 +1,58 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+)
+
+// StartOIDC starts the sign-in of a client with an external OpenID Connect provider
+//
+// Parameters:
+// - service: services.UserServiceInterface The service managing the clients
+// - provider: oidc.ServiceInterface The provider, nil when missing from the configuration
+// - oidcDTO: *transfert.OIDC The consents given in case the sign-in registers a new client
+//
+// Returns:
+// - int: 200 with the URL of the provider, the error code otherwise
+// - any: The URL or the error
+func StartOIDC(service services.UserServiceInterface, provider oidc.ServiceInterface, oidcDTO *transfert.OIDC) (int, any) {
+	url, err := service.StartOIDC(provider, oidcDTO)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, fiber.Map{
+		"url": url,
+	}
+}
+
+// OIDCAuth completes the sign-in of a client with an external provider and issues its tokens
+//
+// Parameters:
+// - service: services.UserServiceInterface The service managing the clients
+// - provider: oidc.ServiceInterface The provider, nil when missing from the configuration
+// - oidcDTO: *transfert.OIDC The code and the state sent back by the provider
+// - sessionDTO: *transfert.Session The device, user agent and IP of the sign-in
+//
+// Returns:
+// - int: 200 with the tokens, the error code otherwise
+// - any: The tokens or the error
+func OIDCAuth(service services.UserServiceInterface, provider oidc.ServiceInterface, oidcDTO *transfert.OIDC, sessionDTO *transfert.Session) (int, any) {
+	if err := oidcDTO.Check(data.Validator{
+		"code":  {validator.Required},
+		"state": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	credentialID, role, err := service.OIDCAuth(provider, oidcDTO)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return issueTokens(service, sessionDTO, *credentialID, role, false)
+}
@@ -0,0 +1,79 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartOIDC(t *testing.T) {
+	t.Run("unknown provider", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("StartOIDC", nil, mock.Anything).Return(nil, errors.ErrOIDCProviderUnknown)
+
+		statusCode, response := services.StartOIDC(mockClient, nil, &transfert.OIDC{})
+		assert.Equal(t, fiber.StatusNotFound, statusCode)
+		assert.Equal(t, errors.ErrOIDCProviderUnknown, response)
+	})
+
+	t.Run("started", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("StartOIDC", nil, mock.Anything).Return(aws.String("https://accounts.example.com/authorize"), nil)
+
+		statusCode, response := services.StartOIDC(mockClient, nil, &transfert.OIDC{CGU: trueValue})
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, "https://accounts.example.com/authorize", *response.(fiber.Map)["url"].(*string))
+	})
+}
+
+func TestOIDCAuth(t *testing.T) {
+	err := config.Load(aws.String("../../../../config.test.yml"))
+	assert.NoError(t, err)
+
+	t.Run("missing state", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.OIDCAuth(mockClient, nil, &transfert.OIDC{Code: aws.String("code")}, nil)
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		mockClient.AssertNotCalled(t, "OIDCAuth", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("OIDCAuth", nil, mock.Anything).Return(nil, "", errors_domain_user.ErrOIDCStateInvalid)
+
+		statusCode, response := services.OIDCAuth(mockClient, nil, &transfert.OIDC{Code: aws.String("code"), State: aws.String("state")}, nil)
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, response)
+	})
+
+	t.Run("signed in", func(t *testing.T) {
+		id := "credential-id"
+		mockClient := new(DomainUserService)
+		// Les jetons habituels sont délivrés après la connexion externe
+		mockClient.On("OIDCAuth", nil, mock.Anything).Return(&id, entities.ROLE_CLIENT, nil)
+		mockClient.On("OpenSession", id, mock.Anything).Return(&entities.RefreshToken{ID: "token-id", Family: "token-id"}, nil)
+
+		statusCode, response := services.OIDCAuth(mockClient, nil, &transfert.OIDC{Code: aws.String("code"), State: aws.String("state")}, nil)
+		require.Equal(t, fiber.StatusOK, statusCode)
+
+		tokens := response.(fiber.Map)
+		assert.NotEmpty(t, tokens["refresh_token"])
+
+		token, err := jwt.TokenToClaims(tokens["access_token"].(string))
+		require.Nil(t, err)
+		assert.Equal(t, id, token.ID)
+		assert.Equal(t, string(entities.ROLE_CLIENT), token.Data["role"])
+	})
+}
@@ -5,9 +5,12 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -52,13 +55,13 @@ func (dcs *DomainUserService) PasswordRecover(obj *transfert.Credential) errors.
 	return args.Get(0).(errors.ErrorInterface)
 }
 
-func (dcs *DomainUserService) UserAuth(obj *transfert.Credential) (*string, security.Role, errors.ErrorInterface) {
+func (dcs *DomainUserService) UserAuth(obj *transfert.Credential) (*string, security.Role, bool, errors.ErrorInterface) {
 	args := dcs.Called(obj)
 	if args.Get(0) == nil {
-		return nil, "", args.Get(2).(errors.ErrorInterface) // Retourne nil pour *string et l'erreur s'il y en a une
+		return nil, "", false, args.Get(3).(errors.ErrorInterface) // Retourne nil pour *string et l'erreur s'il y en a une
 	}
 
-	return args.Get(0).(*string), args.Get(1).(security.Role), nil
+	return args.Get(0).(*string), args.Get(1).(security.Role), args.Bool(2), nil
 }
 
 func (dcs *DomainUserService) MailValidation(validation *transfert.Validation, credential *transfert.Credential) (*entities.Validation, errors.ErrorInterface) {
@@ -152,3 +155,123 @@ func (dcs *DomainUserService) UpdateEmployee(dtoEmployee *transfert.Employee) (*
 	}
 	return args.Get(0).(*entities.Employee), nil
 }
+
+func (dcs *DomainUserService) SetAuditor(dtoCredential *transfert.Credential, auditor bool) (*entities.Employee, errors.ErrorInterface) {
+	args := dcs.Called(dtoCredential, auditor)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Employee), nil
+}
+
+func (dcs *DomainUserService) GetRegistrationStatistics(dtoStatistics *gameTransfert.Statistics) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface) {
+	args := dcs.Called(dtoStatistics)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*gameEntity.PeriodStatistic), nil
+}
+
+func (dcs *DomainUserService) GetNewsletterStatistics(dtoStatistics *gameTransfert.Statistics) (*entities.NewsletterStatistic, errors.ErrorInterface) {
+	args := dcs.Called(dtoStatistics)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.NewsletterStatistic), nil
+}
+
+func (dcs *DomainUserService) Unlock(dtoLockout *transfert.Lockout) errors.ErrorInterface {
+	args := dcs.Called(dtoLockout)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (dcs *DomainUserService) EnrollTOTP() (*entities.TOTPEnrolment, errors.ErrorInterface) {
+	args := dcs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.TOTPEnrolment), nil
+}
+
+func (dcs *DomainUserService) ConfirmTOTP(dtoTOTP *transfert.TOTP) ([]string, errors.ErrorInterface) {
+	args := dcs.Called(dtoTOTP)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]string), nil
+}
+
+func (dcs *DomainUserService) VerifyTOTP(dtoTOTP *transfert.TOTP) (*string, security.Role, errors.ErrorInterface) {
+	args := dcs.Called(dtoTOTP)
+	if args.Get(0) == nil {
+		return nil, "", args.Get(2).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*string), args.Get(1).(security.Role), nil
+}
+
+func (dcs *DomainUserService) StartOIDC(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, errors.ErrorInterface) {
+	args := dcs.Called(provider, dtoOIDC)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*string), nil
+}
+
+func (dcs *DomainUserService) OIDCAuth(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, security.Role, errors.ErrorInterface) {
+	args := dcs.Called(provider, dtoOIDC)
+	if args.Get(0) == nil {
+		return nil, "", args.Get(2).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*string), args.Get(1).(security.Role), nil
+}
+
+func (dcs *DomainUserService) OpenSession(credentialID string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface) {
+	args := dcs.Called(credentialID, dtoSession)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.RefreshToken), nil
+}
+
+func (dcs *DomainUserService) RenewSession(credentialID, jti string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface) {
+	args := dcs.Called(credentialID, jti, dtoSession)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.RefreshToken), nil
+}
+
+func (dcs *DomainUserService) Logout() errors.ErrorInterface {
+	args := dcs.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (dcs *DomainUserService) ListSessions() ([]*entities.RefreshToken, errors.ErrorInterface) {
+	args := dcs.Called()
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.RefreshToken), nil
+}
+
+func (dcs *DomainUserService) RevokeSession(dtoSession *transfert.Session) errors.ErrorInterface {
+	args := dcs.Called(dtoSession)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (dcs *DomainUserService) RevokeSessions(dtoCredential *transfert.Credential) errors.ErrorInterface {
+	args := dcs.Called(dtoCredential)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
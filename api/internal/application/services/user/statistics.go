@@ -0,0 +1,25 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
+)
+
+func GetRegistrationStatistics(service services.UserServiceInterface, dtoStatistics *gameTransfert.Statistics) (int, any) {
+	statistics, err := service.GetRegistrationStatistics(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, statistics
+}
+
+func GetNewsletterStatistics(service services.UserServiceInterface, dtoStatistics *gameTransfert.Statistics) (int, any) {
+	statistic, err := service.GetNewsletterStatistics(dtoStatistics)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, statistic
+}
@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRegistrationStatistics(t *testing.T) {
+	t.Run("registrations per day", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		dto := &gameTransfert.Statistics{From: aws.String("2024-10-01")}
+		expected := []*gameEntity.PeriodStatistic{{Period: "2024-10-01", Count: 12}}
+		mockService.On("GetRegistrationStatistics", dto).Return(expected, nil)
+
+		statusCode, response := services.GetRegistrationStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		dto := &gameTransfert.Statistics{}
+		mockService.On("GetRegistrationStatistics", dto).Return(nil, errors.ErrUnauthorized)
+
+		statusCode, response := services.GetRegistrationStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors.ErrUnauthorized, response)
+	})
+}
+
+func TestGetNewsletterStatistics(t *testing.T) {
+	t.Run("newsletter opt-in rate", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		dto := &gameTransfert.Statistics{}
+		expected := &entities.NewsletterStatistic{Clients: 10, Subscribers: 4, Rate: 0.4}
+		mockService.On("GetNewsletterStatistics", dto).Return(expected, nil)
+
+		statusCode, response := services.GetNewsletterStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, expected, response)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		mockService := new(DomainUserService)
+		dto := &gameTransfert.Statistics{To: aws.String("never")}
+		mockService.On("GetNewsletterStatistics", dto).Return(nil, errors.ErrValueIsNotDate)
+
+		statusCode, response := services.GetNewsletterStatistics(mockService, dto)
+
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		assert.Equal(t, errors.ErrValueIsNotDate, response)
+	})
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"github.com/gofiber/fiber/v2"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+)
+
+// EnrollTOTP starts the enrolment of the second factor of the signed in employee
+//
+// Parameters:
+// - service: services.UserServiceInterface The service managing the employees
+//
+// Returns:
+// - int: 201 with the secret and its otpauth URI, the error code otherwise
+// - any: The enrolment or the error
+func EnrollTOTP(service services.UserServiceInterface) (int, any) {
+	enrolment, err := service.EnrollTOTP()
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusCreated, enrolment
+}
+
+// ConfirmTOTP enables the second factor of the signed in employee with a first code of its app
+//
+// Parameters:
+// - service: services.UserServiceInterface The service managing the employees
+// - totpDTO: *transfert.TOTP The code shown by the app
+//
+// Returns:
+// - int: 200 with the recovery codes, the error code otherwise
+// - any: The recovery codes or the error
+func ConfirmTOTP(service services.UserServiceInterface, totpDTO *transfert.TOTP) (int, any) {
+	if err := totpDTO.Check(data.Validator{
+		"code": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	codes, err := service.ConfirmTOTP(totpDTO)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return fiber.StatusOK, fiber.Map{
+		"recovery_codes": codes,
+	}
+}
+
+// VerifyTOTP completes a pending sign-in with the second factor and issues unrestricted tokens
+//
+// Parameters:
+// - service: services.UserServiceInterface The service managing the employees
+// - totpDTO: *transfert.TOTP The TOTP code or a recovery code
+// - sessionDTO: *transfert.Session The device, user agent and IP of the sign-in
+//
+// Returns:
+// - int: 200 with the tokens, the error code otherwise
+// - any: The tokens or the error
+func VerifyTOTP(service services.UserServiceInterface, totpDTO *transfert.TOTP, sessionDTO *transfert.Session) (int, any) {
+	if err := totpDTO.Check(data.Validator{
+		"code": {validator.Required},
+	}); err != nil {
+		return err.Code(), err
+	}
+
+	credentialID, role, err := service.VerifyTOTP(totpDTO)
+	if err != nil {
+		return err.Code(), err
+	}
+
+	return issueTokens(service, sessionDTO, *credentialID, role, false)
+}
@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAuthPending(t *testing.T) {
+	err := config.Load(aws.String("../../../../config.test.yml"))
+	assert.NoError(t, err)
+
+	id := "credential-id"
+	mockClient := new(DomainUserService)
+	// La connexion d'un employé attend son second facteur
+	mockClient.On("UserAuth", mock.Anything).Return(&id, entities.ROLE_EMPLOYEE, true, nil)
+	mockClient.On("OpenSession", id, mock.Anything).Return(&entities.RefreshToken{ID: "token-id", Family: "token-id"}, nil)
+
+	statusCode, response := services.UserAuth(mockClient, &transfert.Credential{
+		Email:    &email,
+		Password: &password,
+	}, nil)
+	require.Equal(t, fiber.StatusOK, statusCode)
+
+	tokens := response.(fiber.Map)
+	assert.Equal(t, true, tokens["second_factor"])
+
+	token, err := jwt.TokenToClaims(tokens["access_token"].(string))
+	require.Nil(t, err)
+	assert.True(t, token.IsPending())
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	t.Run("enrolled", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("EnrollTOTP").Return(&entities.TOTPEnrolment{Secret: "SECRET", URI: "otpauth://totp/TheTipTop:employee"}, nil)
+
+		statusCode, response := services.EnrollTOTP(mockClient)
+		assert.Equal(t, fiber.StatusCreated, statusCode)
+		assert.Equal(t, "SECRET", response.(*entities.TOTPEnrolment).Secret)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("EnrollTOTP").Return(nil, errors_domain_user.ErrTOTPAlreadyEnabled)
+
+		statusCode, response := services.EnrollTOTP(mockClient)
+		assert.Equal(t, fiber.StatusConflict, statusCode)
+		assert.Equal(t, errors_domain_user.ErrTOTPAlreadyEnabled, response)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	t.Run("missing code", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.ConfirmTOTP(mockClient, &transfert.TOTP{})
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+		mockClient.AssertNotCalled(t, "ConfirmTOTP", mock.Anything)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("ConfirmTOTP", mock.Anything).Return(nil, errors_domain_user.ErrTOTPInvalid)
+
+		statusCode, response := services.ConfirmTOTP(mockClient, &transfert.TOTP{Code: aws.String("123456")})
+		assert.Equal(t, fiber.StatusUnauthorized, statusCode)
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, response)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("ConfirmTOTP", mock.Anything).Return([]string{"abcde-12345"}, nil)
+
+		statusCode, response := services.ConfirmTOTP(mockClient, &transfert.TOTP{Code: aws.String("123456")})
+		assert.Equal(t, fiber.StatusOK, statusCode)
+		assert.Equal(t, []string{"abcde-12345"}, response.(fiber.Map)["recovery_codes"])
+	})
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	err := config.Load(aws.String("../../../../config.test.yml"))
+	assert.NoError(t, err)
+
+	t.Run("missing code", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+
+		statusCode, _ := services.VerifyTOTP(mockClient, &transfert.TOTP{}, nil)
+		assert.Equal(t, fiber.StatusBadRequest, statusCode)
+	})
+
+	t.Run("not enrolled", func(t *testing.T) {
+		mockClient := new(DomainUserService)
+		mockClient.On("VerifyTOTP", mock.Anything).Return(nil, "", errors_domain_user.ErrTOTPNotEnrolled)
+
+		statusCode, response := services.VerifyTOTP(mockClient, &transfert.TOTP{Code: aws.String("123456")}, nil)
+		assert.Equal(t, fiber.StatusConflict, statusCode)
+		assert.Error(t, response.(*errors.Error))
+	})
+
+	t.Run("verified", func(t *testing.T) {
+		id := "credential-id"
+		mockClient := new(DomainUserService)
+		// Le second facteur vérifié donne des jetons complets
+		mockClient.On("VerifyTOTP", mock.Anything).Return(&id, entities.ROLE_EMPLOYEE, nil)
+		mockClient.On("OpenSession", id, mock.Anything).Return(&entities.RefreshToken{ID: "token-id", Family: "token-id"}, nil)
+
+		statusCode, response := services.VerifyTOTP(mockClient, &transfert.TOTP{Code: aws.String("123456")}, nil)
+		require.Equal(t, fiber.StatusOK, statusCode)
+
+		tokens := response.(fiber.Map)
+		assert.NotContains(t, tokens, "second_factor")
+
+		token, err := jwt.TokenToClaims(tokens["access_token"].(string))
+		require.Nil(t, err)
+		assert.False(t, token.IsPending())
+		assert.Equal(t, id, token.ID)
+	})
+}
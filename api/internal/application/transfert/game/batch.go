@@ -0,0 +1,52 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Batch struct {
+	ID       *string `json:"id" xml:"id" form:"id"`
+	Campaign *string `json:"campaign" xml:"campaign" form:"campaign"` // Campaign label, current campaign if empty
+	PrizeID  *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
+	Format   *string `json:"format" xml:"format" form:"format"`
+	Offset   *int    `json:"offset" xml:"offset" form:"offset"`
+	Limit    *int    `json:"limit" xml:"limit" form:"limit"`
+}
+
+func (b *Batch) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":       b.ID,
+		"campaign": b.Campaign,
+		"prize_id": b.PrizeID,
+		"format":   b.Format,
+		"offset":   b.Offset,
+		"limit":    b.Limit,
+	})
+}
+
+func NewBatch(obj data.Object, mandatory data.Validator) (*Batch, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	b := &Batch{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(b); err != nil {
+			return nil, err
+		}
+
+		return b, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
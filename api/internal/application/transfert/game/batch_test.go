@@ -0,0 +1,44 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBatch(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		batch, err := transfert.NewBatch(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, batch)
+	})
+
+	t.Run("Valid batch", func(t *testing.T) {
+		batch, err := transfert.NewBatch(data.Object{
+			"format":   aws.String("pdf"),
+			"campaign": aws.String("2024"),
+		}, data.Validator{
+			"format": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "pdf", *batch.Format)
+		assert.Equal(t, "2024", *batch.Campaign)
+		assert.NoError(t, batch.Check(data.Validator{
+			"format": {validator.Required},
+		}))
+	})
+
+	t.Run("Invalid batch - missing format", func(t *testing.T) {
+		batch, err := transfert.NewBatch(data.Object{
+			"campaign": aws.String("2024"),
+		}, data.Validator{
+			"format": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, batch)
+	})
+}
@@ -0,0 +1,66 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Campaign struct {
+	ID            *string        `json:"id" xml:"id" form:"id"`
+	Label         *string        `json:"label" xml:"label" form:"label"`
+	StartAt       *string        `json:"start_at" xml:"start_at" form:"start_at"`
+	EndAt         *string        `json:"end_at" xml:"end_at" form:"end_at"`
+	ClaimDeadline *string        `json:"claim_deadline" xml:"claim_deadline" form:"claim_deadline"`
+	Timezone      *string        `json:"timezone" xml:"timezone" form:"timezone"`
+	Tickets       *int           `json:"tickets" xml:"tickets" form:"tickets"`
+	Distribution  map[string]int `json:"distribution" xml:"-" form:"-"`
+	Eligibility   *Eligibility   `json:"eligibility" xml:"-" form:"-"`
+}
+
+// Eligibility carries the participation rules of a campaign, nil fields are left unchanged
+type Eligibility struct {
+	MinimumAge      *int     `json:"minimum_age"`
+	Countries       []string `json:"countries"`
+	HouseholdLimit  *int     `json:"household_limit"`
+	HouseholdPrizes []string `json:"household_prizes"`
+}
+
+func (c *Campaign) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":             c.ID,
+		"label":          c.Label,
+		"start_at":       c.StartAt,
+		"end_at":         c.EndAt,
+		"claim_deadline": c.ClaimDeadline,
+		"timezone":       c.Timezone,
+		"tickets":        c.Tickets,
+		"distribution":   c.Distribution,
+		"eligibility":    c.Eligibility,
+	})
+}
+
+func NewCampaign(obj data.Object, mandatory data.Validator) (*Campaign, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	c := &Campaign{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(c); err != nil {
+			return nil, err
+		}
+
+		return c, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,70 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCampaign(t *testing.T) {
+	mandatory := data.Validator{
+		"label":    {validator.Required},
+		"start_at": {validator.Required},
+	}
+
+	tests := []struct {
+		name      string
+		inputData data.Object
+		wantErr   bool
+	}{
+		{
+			name: "Valid campaign",
+			inputData: data.Object{
+				"label":          aws.String("2024"),
+				"start_at":       aws.String("2024-10-01"),
+				"end_at":         aws.String("2024-10-31"),
+				"claim_deadline": aws.String("2024-11-30"),
+				"timezone":       aws.String("Europe/Paris"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid campaign - missing start",
+			inputData: data.Object{
+				"label": aws.String("2024"),
+			},
+			wantErr: true,
+		},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, campaign)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		campaign, err := transfert.NewCampaign(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, campaign)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaign, err := transfert.NewCampaign(tt.inputData, mandatory)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, campaign)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, campaign)
+				assert.NoError(t, campaign.Check(mandatory))
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package transfert
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// ClaimAttempt describes where a claim comes from, filled by the server and never by the client
+type ClaimAttempt struct {
+	CredentialID *string    `json:"credential_id" xml:"credential_id" form:"credential_id"`
+	TicketID     *string    `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	IP           *string    `json:"ip" xml:"ip" form:"ip"`
+	Device       *string    `json:"device" xml:"device" form:"device"`
+	RegisteredAt *time.Time `json:"registered_at" xml:"registered_at" form:"registered_at" gorm:"-"` // Creation date of the claiming account
+}
+
+func (c *ClaimAttempt) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"credential_id": c.CredentialID,
+		"ticket_id":     c.TicketID,
+		"ip":            c.IP,
+		"device":        c.Device,
+		"registered_at": c.RegisteredAt,
+	})
+}
+
+type ClaimReview struct {
+	ID     *string `json:"id" xml:"id" form:"id"`
+	Status *string `json:"status" xml:"status" form:"status"`
+}
+
+func (r *ClaimReview) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":     r.ID,
+		"status": r.Status,
+	})
+}
+
+func NewClaimReview(obj data.Object, mandatory data.Validator) (*ClaimReview, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	r := &ClaimReview{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(r); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,44 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClaimReview(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		review, err := transfert.NewClaimReview(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, review)
+	})
+
+	t.Run("Valid review", func(t *testing.T) {
+		review, err := transfert.NewClaimReview(data.Object{
+			"id":     aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			"status": aws.String("approved"),
+		}, data.Validator{
+			"status": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "approved", *review.Status)
+		assert.NoError(t, review.Check(data.Validator{
+			"id":     {validator.Required, validator.ID},
+			"status": {validator.Required},
+		}))
+	})
+
+	t.Run("Invalid review - missing status", func(t *testing.T) {
+		review, err := transfert.NewClaimReview(data.Object{
+			"id": aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+		}, data.Validator{
+			"status": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, review)
+	})
+}
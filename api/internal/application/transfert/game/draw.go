@@ -0,0 +1,46 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Draw struct {
+	ID         *string `json:"id" xml:"id" form:"id"`
+	Campaign   *string `json:"campaign" xml:"campaign" form:"campaign"` // Campaign label
+	CampaignID *string `json:"campaign_id" xml:"campaign_id" form:"campaign_id"`
+}
+
+func (d *Draw) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":          d.ID,
+		"campaign":    d.Campaign,
+		"campaign_id": d.CampaignID,
+	})
+}
+
+func NewDraw(obj data.Object, mandatory data.Validator) (*Draw, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	d := &Draw{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(d); err != nil {
+			return nil, err
+		}
+
+		return d, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
@@ -0,0 +1,42 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDraw(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		draw, err := transfert.NewDraw(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, draw)
+	})
+
+	t.Run("Valid draw", func(t *testing.T) {
+		draw, err := transfert.NewDraw(data.Object{
+			"campaign": aws.String("2024"),
+		}, data.Validator{
+			"campaign": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "2024", *draw.Campaign)
+		assert.NoError(t, draw.Check(data.Validator{
+			"campaign": {validator.Required},
+		}))
+	})
+
+	t.Run("Invalid draw - missing campaign", func(t *testing.T) {
+		draw, err := transfert.NewDraw(data.Object{
+			"campaign_id": aws.String("campaign-id"),
+		}, data.Validator{
+			"campaign": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, draw)
+	})
+}
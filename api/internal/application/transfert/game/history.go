@@ -0,0 +1,30 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketHistory struct {
+	ID             *string `json:"id" xml:"id" form:"id"`
+	TicketID       *string `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	CredentialID   *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+	PreviousStatus *string `json:"previous_status" xml:"previous_status" form:"previous_status"`
+	Status         *string `json:"status" xml:"status" form:"status"`
+	StoreID        *string `json:"store_id" xml:"store_id" form:"store_id"`
+	CaisseID       *string `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	OwnerID        *string `json:"owner_id" xml:"owner_id" form:"owner_id"`
+}
+
+func (h *TicketHistory) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":              h.ID,
+		"ticket_id":       h.TicketID,
+		"credential_id":   h.CredentialID,
+		"previous_status": h.PreviousStatus,
+		"status":          h.Status,
+		"store_id":        h.StoreID,
+		"caisse_id":       h.CaisseID,
+		"owner_id":        h.OwnerID,
+	})
+}
@@ -0,0 +1,56 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Prize struct {
+	ID           *string  `json:"id" xml:"id" form:"id"`
+	Label        *string  `json:"label" xml:"label" form:"label"`
+	Description  *string  `json:"description" xml:"description" form:"description"`
+	Value        *float64 `json:"value" xml:"value" form:"value"`
+	Image        *string  `json:"image" xml:"image" form:"image"`
+	Position     *int     `json:"position" xml:"position" form:"position"`
+	Active       *bool    `json:"active" xml:"active" form:"active"`
+	Distribution *int     `json:"distribution" xml:"distribution" form:"distribution"`
+}
+
+func (p *Prize) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":           p.ID,
+		"label":        p.Label,
+		"description":  p.Description,
+		"value":        p.Value,
+		"image":        p.Image,
+		"position":     p.Position,
+		"active":       p.Active,
+		"distribution": p.Distribution,
+	})
+}
+
+func NewPrize(obj data.Object, mandatory data.Validator) (*Prize, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	p := &Prize{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(p); err != nil {
+			return nil, err
+		}
+
+		return p, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,68 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPrize(t *testing.T) {
+	tests := []struct {
+		name      string
+		inputData data.Object
+		wantErr   bool
+	}{
+		{
+			name: "Valid prize",
+			inputData: data.Object{
+				"label":        aws.String("Infuseur à thé"),
+				"value":        aws.Float64(8.9),
+				"distribution": aws.Int(60),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid prize - missing label",
+			inputData: data.Object{
+				"value":        aws.Float64(8.9),
+				"distribution": aws.Int(60),
+			},
+			wantErr: true,
+		},
+	}
+
+	t.Run("Nil object and validator", func(t *testing.T) {
+		prize, err := transfert.NewPrize(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, prize)
+	})
+
+	t.Run("Empty object and nil validator", func(t *testing.T) {
+		prize, err := transfert.NewPrize(data.Object{}, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, prize)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prize, err := transfert.NewPrize(tt.inputData, data.Validator{
+				"label": {validator.Required},
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, prize)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, prize)
+				assert.NoError(t, prize.Check(data.Validator{
+					"label": {validator.Required},
+				}))
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type QRCode struct {
+	Token  *string `json:"token" xml:"token" form:"token" query:"token"`
+	Format *string `json:"format" xml:"format" form:"format" query:"format"`
+	Size   *int    `json:"size" xml:"size" form:"size" query:"size"`
+	Level  *string `json:"level" xml:"level" form:"level" query:"level"`
+	Link   *bool   `json:"link" xml:"link" form:"link" query:"link"` // Encode the deep link to the claim screen instead of the bare token
+}
+
+func (q *QRCode) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"token":  q.Token,
+		"format": q.Format,
+		"size":   q.Size,
+		"level":  q.Level,
+		"link":   q.Link,
+	})
+}
+
+func NewQRCode(obj data.Object, mandatory data.Validator) (*QRCode, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	q := &QRCode{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(q); err != nil {
+			return nil, err
+		}
+
+		return q, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(q); err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
@@ -0,0 +1,48 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewQRCode(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		code, err := transfert.NewQRCode(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, code)
+	})
+
+	t.Run("Valid QR code", func(t *testing.T) {
+		code, err := transfert.NewQRCode(data.Object{
+			"token":  aws.String("79927398713"),
+			"format": aws.String("svg"),
+			"size":   aws.Int(512),
+			"level":  aws.String("H"),
+			"link":   aws.Bool(true),
+		}, data.Validator{
+			"token": {validator.Required, validator.Luhn},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "svg", *code.Format)
+		assert.Equal(t, 512, *code.Size)
+		assert.True(t, *code.Link)
+		assert.NoError(t, code.Check(data.Validator{
+			"token": {validator.Required, validator.Luhn},
+		}))
+	})
+
+	t.Run("Invalid QR code - token is not Luhn", func(t *testing.T) {
+		code, err := transfert.NewQRCode(data.Object{
+			"token": aws.String("79927398710"),
+		}, data.Validator{
+			"token": {validator.Required, validator.Luhn},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, code)
+	})
+}
@@ -0,0 +1,54 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Receipt struct {
+	ID          *string  `json:"id" xml:"id" form:"id"`
+	StoreID     *string  `json:"store_id" xml:"store_id" form:"store_id"`
+	CaisseID    *string  `json:"caisse_id" xml:"caisse_id" form:"caisse_id"`
+	Number      *string  `json:"number" xml:"number" form:"number"`
+	Amount      *float64 `json:"amount" xml:"amount" form:"amount"`
+	PurchasedAt *string  `json:"purchased_at" xml:"purchased_at" form:"purchased_at" gorm:"-"`
+	Token       *string  `json:"token" xml:"token" form:"token" gorm:"-"` // Printed ticket to hand over, a ticket is issued otherwise
+}
+
+func (r *Receipt) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":           r.ID,
+		"store_id":     r.StoreID,
+		"caisse_id":    r.CaisseID,
+		"number":       r.Number,
+		"amount":       r.Amount,
+		"purchased_at": r.PurchasedAt,
+		"token":        r.Token,
+	})
+}
+
+func NewReceipt(obj data.Object, mandatory data.Validator) (*Receipt, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	r := &Receipt{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(r); err != nil {
+			return nil, err
+		}
+
+		return r, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,45 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReceipt(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		receipt, err := transfert.NewReceipt(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, receipt)
+	})
+
+	t.Run("Valid receipt", func(t *testing.T) {
+		receipt, err := transfert.NewReceipt(data.Object{
+			"number": aws.String("0042"),
+			"token":  aws.String("79927398713"),
+		}, data.Validator{
+			"number": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "0042", *receipt.Number)
+		assert.Equal(t, "79927398713", *receipt.Token)
+		assert.NoError(t, receipt.Check(data.Validator{
+			"number": {validator.Required},
+			"token":  {validator.Luhn},
+		}))
+	})
+
+	t.Run("Invalid receipt - missing number", func(t *testing.T) {
+		receipt, err := transfert.NewReceipt(data.Object{
+			"token": aws.String("79927398713"),
+		}, data.Validator{
+			"number": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, receipt)
+	})
+}
@@ -0,0 +1,56 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketSearch struct {
+	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id" query:"credential_id"` // Employees only
+	Token        *string `json:"token" xml:"token" form:"token" query:"token"`                                 // Employees only
+	PrizeID      *string `json:"prize_id" xml:"prize_id" form:"prize_id" query:"prize_id"`
+	CampaignID   *string `json:"campaign_id" xml:"campaign_id" form:"campaign_id" query:"campaign_id"`
+	Status       *string `json:"status" xml:"status" form:"status" query:"status"`
+	Sort         *string `json:"sort" xml:"sort" form:"sort" query:"sort"` // claimed_at or -claimed_at
+	Page         *int    `json:"page" xml:"page" form:"page" query:"page"` // Starts at 1
+	Limit        *int    `json:"limit" xml:"limit" form:"limit" query:"limit"`
+}
+
+func (t *TicketSearch) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"credential_id": t.CredentialID,
+		"token":         t.Token,
+		"prize_id":      t.PrizeID,
+		"campaign_id":   t.CampaignID,
+		"status":        t.Status,
+		"sort":          t.Sort,
+		"page":          t.Page,
+		"limit":         t.Limit,
+	})
+}
+
+func NewTicketSearch(obj data.Object, mandatory data.Validator) (*TicketSearch, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	t := &TicketSearch{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(t); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
@@ -0,0 +1,48 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketSearch(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		search, err := transfert.NewTicketSearch(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, search)
+	})
+
+	t.Run("Valid search", func(t *testing.T) {
+		search, err := transfert.NewTicketSearch(data.Object{
+			"status": aws.String("claimed"),
+			"sort":   aws.String("-claimed_at"),
+			"page":   aws.Int(2),
+			"limit":  aws.Int(50),
+		}, data.Validator{
+			"status": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "claimed", *search.Status)
+		assert.Equal(t, "-claimed_at", *search.Sort)
+		assert.Equal(t, 2, *search.Page)
+		assert.Equal(t, 50, *search.Limit)
+		assert.NoError(t, search.Check(data.Validator{
+			"status": {validator.Required},
+		}))
+	})
+
+	t.Run("Invalid search - missing status", func(t *testing.T) {
+		search, err := transfert.NewTicketSearch(data.Object{
+			"page": aws.Int(1),
+		}, data.Validator{
+			"status": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, search)
+	})
+}
@@ -0,0 +1,46 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Statistics struct {
+	From     *string `json:"from" xml:"from" form:"from" query:"from"`
+	To       *string `json:"to" xml:"to" form:"to" query:"to"`
+	Interval *string `json:"interval" xml:"interval" form:"interval" query:"interval"` // day or hour
+}
+
+func (s *Statistics) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"from":     s.From,
+		"to":       s.To,
+		"interval": s.Interval,
+	})
+}
+
+func NewStatistics(obj data.Object, mandatory data.Validator) (*Statistics, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	s := &Statistics{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(s); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,45 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatistics(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, statistics)
+	})
+
+	t.Run("Valid statistics", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(data.Object{
+			"from":     aws.String("2024-10-01"),
+			"to":       aws.String("2024-10-31"),
+			"interval": aws.String("day"),
+		}, data.Validator{
+			"interval": {validator.Required},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "2024-10-01", *statistics.From)
+		assert.Equal(t, "2024-10-31", *statistics.To)
+		assert.NoError(t, statistics.Check(data.Validator{
+			"interval": {validator.Required},
+		}))
+	})
+
+	t.Run("Invalid statistics - missing interval", func(t *testing.T) {
+		statistics, err := transfert.NewStatistics(data.Object{
+			"from": aws.String("2024-10-01"),
+		}, data.Validator{
+			"interval": {validator.Required},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, statistics)
+	})
+}
@@ -7,17 +7,27 @@ import (
 
 type Ticket struct {
 	ID           *string `json:"id" xml:"id" form:"id"`
-	Prize        *string `json:"prize" xml:"prize" form:"prize"`
+	PrizeID      *string `json:"prize_id" xml:"prize_id" form:"prize_id"`
+	CampaignID   *string `json:"campaign_id" xml:"campaign_id" form:"campaign_id"`
 	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
 	Token        *string `json:"token" xml:"token" form:"token"`
+	Status       *string `json:"status" xml:"status" form:"status"`
+	StoreID      *string `json:"store_id" xml:"store_id" form:"store_id" gorm:"-"`    // Recorded in the history, never a ticket filter
+	CaisseID     *string `json:"caisse_id" xml:"caisse_id" form:"caisse_id" gorm:"-"` // Recorded in the history, never a ticket filter
+	ReceiptPhoto *string `json:"-" xml:"-" form:"-" gorm:"-"`                         // Key of the photo uploaded when claiming, never read from the request nor a ticket filter
 }
 
-func (c *Ticket) Check(validator data.Validator) error {
+func (c *Ticket) Check(validator data.Validator) errors.ErrorInterface {
 	return validator.Check(data.Object{
 		"id":            c.ID,
-		"prize":         c.Prize,
+		"prize_id":      c.PrizeID,
+		"campaign_id":   c.CampaignID,
 		"credential_id": c.CredentialID,
 		"token":         c.Token,
+		"status":        c.Status,
+		"store_id":      c.StoreID,
+		"caisse_id":     c.CaisseID,
+		"receipt_photo": c.ReceiptPhoto,
 	})
 }
 
@@ -20,7 +20,7 @@ func TestNewTicket(t *testing.T) {
 			name: "Valid ticket",
 			inputData: data.Object{
 				"id":            aws.String("123"),
-				"prize":         aws.String("Gold"),
+				"prize_id":      aws.String("Gold"),
 				"credential_id": aws.String("456"),
 				"token":         aws.String("abc123"),
 			},
@@ -29,7 +29,7 @@ func TestNewTicket(t *testing.T) {
 		{
 			name: "Invalid ticket - missing ID",
 			inputData: data.Object{
-				"prize":         aws.String("Gold"),
+				"prize_id":      aws.String("Gold"),
 				"credential_id": aws.String("456"),
 				"token":         aws.String("abc123"),
 			},
@@ -39,7 +39,7 @@ func TestNewTicket(t *testing.T) {
 			name: "Invalid ticket - missing Token",
 			inputData: data.Object{
 				"id":            aws.String("123"),
-				"prize":         aws.String("Gold"),
+				"prize_id":      aws.String("Gold"),
 				"credential_id": aws.String("456"),
 			},
 			wantErr: true,
@@ -65,7 +65,7 @@ func TestNewTicket(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ticket, err := transfert.NewTicket(tt.inputData, data.Validator{
 				"id":            {validator.Required},
-				"prize":         {validator.Required},
+				"prize_id":      {validator.Required},
 				"credential_id": {validator.Required},
 				"token":         {validator.Required},
 			})
@@ -80,7 +80,7 @@ func TestNewTicket(t *testing.T) {
 				// Validate the ticket object with the same validators
 				err := ticket.Check(data.Validator{
 					"id":            {validator.Required},
-					"prize":         {validator.Required},
+					"prize_id":      {validator.Required},
 					"credential_id": {validator.Required},
 					"token":         {validator.Required},
 				})
@@ -0,0 +1,52 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TicketTransfer struct {
+	ID       *string `json:"id" xml:"id" form:"id"`
+	TicketID *string `json:"ticket_id" xml:"ticket_id" form:"ticket_id"`
+	Email    *string `json:"email" xml:"email" form:"email"` // Recipient of the ticket
+	Status   *string `json:"status" xml:"status" form:"status" query:"status"`
+	Page     *int    `json:"page" xml:"page" form:"page" query:"page"` // Starts at 1
+	Limit    *int    `json:"limit" xml:"limit" form:"limit" query:"limit"`
+}
+
+func (t *TicketTransfer) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id":        t.ID,
+		"ticket_id": t.TicketID,
+		"email":     t.Email,
+		"status":    t.Status,
+		"page":      t.Page,
+		"limit":     t.Limit,
+	})
+}
+
+func NewTicketTransfer(obj data.Object, mandatory data.Validator) (*TicketTransfer, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	t := &TicketTransfer{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(t); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
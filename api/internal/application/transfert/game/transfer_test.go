@@ -0,0 +1,45 @@
+package transfert_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/application/validator"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketTransfer(t *testing.T) {
+	t.Run("Nil object and validator", func(t *testing.T) {
+		transfer, err := transfert.NewTicketTransfer(nil, nil)
+		assert.Error(t, err)
+		assert.Nil(t, transfer)
+	})
+
+	t.Run("Valid transfer", func(t *testing.T) {
+		transfer, err := transfert.NewTicketTransfer(data.Object{
+			"ticket_id": aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			"email":     aws.String("famille@thetiptop.fr"),
+		}, data.Validator{
+			"email": {validator.Required, validator.Email},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "famille@thetiptop.fr", *transfer.Email)
+		assert.NoError(t, transfer.Check(data.Validator{
+			"ticket_id": {validator.Required, validator.ID},
+			"email":     {validator.Required, validator.Email},
+		}))
+	})
+
+	t.Run("Invalid transfer - malformed email", func(t *testing.T) {
+		transfer, err := transfert.NewTicketTransfer(data.Object{
+			"ticket_id": aws.String("5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"),
+			"email":     aws.String("famille"),
+		}, data.Validator{
+			"email": {validator.Required, validator.Email},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, transfer)
+	})
+}
@@ -10,6 +10,11 @@ type Client struct {
 	Newsletter   *bool   `json:"newsletter" xml:"newsletter" form:"newsletter"`
 	CGU          *bool   `json:"cgu" xml:"cgu" form:"cgu"`
 	CredentialID *string `json:"credential_id" xml:"credential_id" form:"credential_id"`
+	BirthDate    *string `json:"birth_date" xml:"birth_date" form:"birth_date"`
+	Address      *string `json:"address" xml:"address" form:"address"`
+	PostalCode   *string `json:"postal_code" xml:"postal_code" form:"postal_code"`
+	City         *string `json:"city" xml:"city" form:"city"`
+	Country      *string `json:"country" xml:"country" form:"country"`
 }
 
 func (c *Client) Check(validator data.Validator) errors.ErrorInterface {
@@ -18,6 +23,11 @@ func (c *Client) Check(validator data.Validator) errors.ErrorInterface {
 		"newsletter":    c.Newsletter,
 		"cgu":           c.CGU,
 		"credential_id": c.CredentialID,
+		"birth_date":    c.BirthDate,
+		"address":       c.Address,
+		"postal_code":   c.PostalCode,
+		"city":          c.City,
+		"country":       c.Country,
 	})
 }
 
@@ -7,14 +7,17 @@ import (
 
 type Credential struct {
 	ID       *string `json:"id" xml:"id" form:"id"`
-	Email    *string `json:"email" xml:"email" form:"email"`
+	Email    *string `json:"email" xml:"email" form:"email" query:"email"`
 	Password *string `json:"password" xml:"password" form:"password"`
+	TOTP     *string `json:"totp" xml:"totp" form:"totp" gorm:"-"` // Second factor of employees, TOTP or recovery code, never a column
+	IP       *string `json:"-" xml:"-" form:"-" gorm:"-"`          // Origin of a sign-in, set by the handler and never bound from the request
 }
 
 func (c *Credential) Check(validator data.Validator) errors.ErrorInterface {
 	return validator.Check(data.Object{
 		"email":    c.Email,
 		"password": c.Password,
+		"totp":     c.TOTP,
 	})
 }
 
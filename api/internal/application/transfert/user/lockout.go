@@ -0,0 +1,44 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Lockout struct {
+	Email *string `json:"email" xml:"email" form:"email" query:"email"` // Account to unlock
+	IP    *string `json:"ip" xml:"ip" form:"ip" query:"ip"`             // Address to unlock
+}
+
+func (l *Lockout) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"email": l.Email,
+		"ip":    l.IP,
+	})
+}
+
+func NewLockout(obj data.Object, mandatory data.Validator) (*Lockout, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	l := &Lockout{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(l); err != nil {
+			return nil, err
+		}
+
+		return l, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(l); err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
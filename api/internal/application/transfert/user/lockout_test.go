@@ -0,0 +1,49 @@
+package transfert_test
+
+import (
+	"testing"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLockout(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Valid lockout",
+			wantErr: false,
+		},
+	}
+
+	// Test with nil object and nil validator
+	lockout, err := transfert.NewLockout(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, lockout)
+
+	// Test with empty object and nil validator
+	lockout, err = transfert.NewLockout(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, lockout)
+
+	// Iterate through test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := data.Object{}
+			lockout, err := transfert.NewLockout(obj, data.Validator{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, lockout)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, lockout)
+				err := lockout.Check(data.Validator{})
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type OIDC struct {
+	Code       *string `json:"code" xml:"code" form:"code"`                                      // Authorization code sent back by the provider
+	State      *string `json:"state" xml:"state" form:"state"`                                   // State sent back by the provider
+	CGU        *bool   `json:"cgu" xml:"cgu" form:"cgu" query:"cgu"`                             // Consent to the terms, required to register a new client
+	Newsletter *bool   `json:"newsletter" xml:"newsletter" form:"newsletter" query:"newsletter"` // Subscription of a new client to the newsletter
+}
+
+func (o *OIDC) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"code":       o.Code,
+		"state":      o.State,
+		"cgu":        o.CGU,
+		"newsletter": o.Newsletter,
+	})
+}
+
+func NewOIDC(obj data.Object, mandatory data.Validator) (*OIDC, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	o := &OIDC{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(o); err != nil {
+			return nil, err
+		}
+
+		return o, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
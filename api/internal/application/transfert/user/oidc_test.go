@@ -0,0 +1,49 @@
+package transfert_test
+
+import (
+	"testing"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOIDC(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Valid oidc request",
+			wantErr: false,
+		},
+	}
+
+	// Test with nil object and nil validator
+	oidc, err := transfert.NewOIDC(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, oidc)
+
+	// Test with empty object and nil validator
+	oidc, err = transfert.NewOIDC(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, oidc)
+
+	// Iterate through test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := data.Object{}
+			oidc, err := transfert.NewOIDC(obj, data.Validator{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, oidc)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, oidc)
+				err := oidc.Check(data.Validator{})
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type Session struct {
+	ID        *string `json:"id" xml:"id" form:"id"` // Session to revoke
+	Device    *string `json:"-" xml:"-" form:"-"`    // Device name header of the client app, set by the handler
+	UserAgent *string `json:"-" xml:"-" form:"-"`    // Set by the handler and never bound from the request
+	IP        *string `json:"-" xml:"-" form:"-"`    // Set by the handler and never bound from the request
+}
+
+func (s *Session) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"id": s.ID,
+	})
+}
+
+func NewSession(obj data.Object, mandatory data.Validator) (*Session, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	s := &Session{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(s); err != nil {
+			return nil, err
+		}
+
+		return s, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
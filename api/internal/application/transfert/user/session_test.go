@@ -0,0 +1,49 @@
+package transfert_test
+
+import (
+	"testing"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Valid session",
+			wantErr: false,
+		},
+	}
+
+	// Test with nil object and nil validator
+	session, err := transfert.NewSession(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, session)
+
+	// Test with empty object and nil validator
+	session, err = transfert.NewSession(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, session)
+
+	// Iterate through test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := data.Object{}
+			session, err := transfert.NewSession(obj, data.Validator{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, session)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, session)
+				err := session.Check(data.Validator{})
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
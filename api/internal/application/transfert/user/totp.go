@@ -0,0 +1,42 @@
+package transfert
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+type TOTP struct {
+	Code *string `json:"code" xml:"code" form:"code"` // TOTP code, or recovery code when completing a sign-in
+}
+
+func (t *TOTP) Check(validator data.Validator) errors.ErrorInterface {
+	return validator.Check(data.Object{
+		"code": t.Code,
+	})
+}
+
+func NewTOTP(obj data.Object, mandatory data.Validator) (*TOTP, error) {
+	if obj == nil {
+		return nil, errors.ErrNoData
+	}
+
+	t := &TOTP{}
+
+	if mandatory == nil {
+		if err := obj.Hydrate(t); err != nil {
+			return nil, err
+		}
+
+		return t, nil
+	}
+
+	if err := mandatory.Check(obj); err != nil {
+		return nil, err
+	}
+
+	if err := obj.Hydrate(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
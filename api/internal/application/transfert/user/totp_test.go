@@ -0,0 +1,49 @@
+package transfert_test
+
+import (
+	"testing"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTOTP(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "Valid totp",
+			wantErr: false,
+		},
+	}
+
+	// Test with nil object and nil validator
+	totp, err := transfert.NewTOTP(nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, totp)
+
+	// Test with empty object and nil validator
+	totp, err = transfert.NewTOTP(data.Object{}, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, totp)
+
+	// Iterate through test cases
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := data.Object{}
+			totp, err := transfert.NewTOTP(obj, data.Validator{})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, totp)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, totp)
+				err := totp.Check(data.Validator{})
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package validator
 
 import (
+	"net"
 	"net/mail"
+	"net/url"
 	"reflect"
 	"unicode"
 
@@ -55,6 +57,41 @@ func Luhn(value any, name string) errors.ErrorInterface {
 	return luhn.Validate()
 }
 
+func URL(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	u, err := url.ParseRequestURI(*str)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.ErrValueIsNotURL
+	}
+
+	return nil
+}
+
+func IP(value any, name string) errors.ErrorInterface {
+	if err := Required(value, name); err != nil {
+		return err
+	}
+
+	str := anyToPtrString(value)
+	if str == nil {
+		return errors.ErrValueIsNotString
+	}
+
+	if net.ParseIP(*str) == nil {
+		return errors.ErrValueIsNotIP
+	}
+
+	return nil
+}
+
 func ID(value any, name string) errors.ErrorInterface {
 	if err := Required(value, name); err != nil {
 		return err
@@ -104,6 +104,86 @@ func TestEmail(t *testing.T) {
 	}
 }
 
+func TestURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid URL",
+			url:     aws.String("https://cdn.kodmain.com/receipts/0042.jpg"),
+			wantErr: false,
+		},
+		{
+			name:    "Relative path",
+			url:     aws.String("/receipts/0042.jpg"),
+			wantErr: true,
+		},
+		{
+			name:    "Script scheme",
+			url:     aws.String("javascript:alert(1)"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty URL",
+			url:     nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.URL(tt.url, "url")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      *string
+		wantErr bool
+	}{
+		{
+			name:    "Valid IPv4",
+			ip:      aws.String("203.0.113.7"),
+			wantErr: false,
+		},
+		{
+			name:    "Valid IPv6",
+			ip:      aws.String("2001:db8::1"),
+			wantErr: false,
+		},
+		{
+			name:    "Hostname",
+			ip:      aws.String("localhost"),
+			wantErr: true,
+		},
+		{
+			name:    "Empty IP",
+			ip:      nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.IP(tt.ip, "ip")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestLuhn(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -186,6 +186,38 @@ const docTemplate = `{
                         "name": "newsletter",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1990-05-17",
+                        "description": "Birth date",
+                        "name": "birth_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal code",
+                        "name": "postal_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "FR",
+                        "description": "ISO 3166-1 alpha-2 country of residence",
+                        "name": "country",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -193,7 +225,7 @@ const docTemplate = `{
                         "description": "Password updated"
                     },
                     "400": {
-                        "description": "Invalid email, password or token"
+                        "description": "Invalid email, password, token, birth date or country"
                     },
                     "404": {
                         "description": "Client not found"
@@ -256,6 +288,38 @@ const docTemplate = `{
                         "name": "newsletter",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1990-05-17",
+                        "description": "Birth date",
+                        "name": "birth_date",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal address",
+                        "name": "address",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Postal code",
+                        "name": "postal_code",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "City",
+                        "name": "city",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "default": "FR",
+                        "description": "ISO 3166-1 alpha-2 country of residence",
+                        "name": "country",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
@@ -263,7 +327,7 @@ const docTemplate = `{
                         "description": "Client created"
                     },
                     "400": {
-                        "description": "Invalid email or password"
+                        "description": "Invalid email, password, birth date or country"
                     },
                     "409": {
                         "description": "Client already exists"
@@ -274,6 +338,105 @@ const docTemplate = `{
                 }
             }
         },
+        "/client/statistics/newsletter": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Newsletter opt-in rate of the clients registered during a period.",
+                "operationId": "jwt.Auth =\u003e user.GetNewsletterStatistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-10-01",
+                        "description": "First day or instant included, UTC without offset",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-10-31",
+                        "description": "Last day or instant included, UTC without offset",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Clients, subscribers and rate"
+                    },
+                    "400": {
+                        "description": "Invalid period"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/client/statistics/registrations": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the clients registered during a period, per day or hour.",
+                "operationId": "jwt.Auth =\u003e user.GetRegistrationStatistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-10-01",
+                        "description": "First day or instant included, UTC without offset",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-10-31",
+                        "description": "Last day or instant included, UTC without offset",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "hour"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Grouping interval",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Registrations per period"
+                    },
+                    "400": {
+                        "description": "Invalid period or interval"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
         "/client/{id}": {
             "get": {
                 "security": [
@@ -593,44 +756,116 @@ const docTemplate = `{
                 }
             }
         },
-        "/game/random": {
-            "get": {
+        "/game/batch": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "Streams the tickets ordered by ID as CSV or as an A4 PDF of 24 cells, each with the code and its QR code. The batch is recorded once fully written.\nA PDF is built in memory before being sent, so it holds at most 100 sheets (2400 tickets): limit defaults to 2400 and a larger limit is rejected. Export a larger range part by part, moving offset by the limit of the previous part. A CSV streams every ticket.",
                 "consumes": [
                     "multipart/form-data"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Game"
+                    "Batch"
+                ],
+                "summary": "Export a batch of tickets for the printer.",
+                "operationId": "jwt.Auth =\u003e game.ExportBatch",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "pdf"
+                        ],
+                        "type": "string",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Campaign label, current campaign when empty",
+                        "name": "campaign",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets to skip",
+                        "name": "offset",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets to export, all when empty for a CSV, 2400 at most and by default for a PDF",
+                        "name": "limit",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "Get a random ticket.",
-                "operationId": "jwt.Auth =\u003e game.GetTicket",
                 "responses": {
                     "200": {
-                        "description": "Ticket details"
+                        "description": "Tickets file",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "400": {
                         "description": "Bad request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Campaign or prize not found"
+                    }
+                }
+            }
+        },
+        "/game/batches": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Batch"
+                ],
+                "summary": "List the ticket exports.",
+                "operationId": "jwt.Auth =\u003e game.GetBatches",
+                "responses": {
+                    "200": {
+                        "description": "Batches details"
+                    },
                     "401": {
                         "description": "Unauthorized"
                     }
                 }
             }
         },
-        "/game/ticket": {
-            "put": {
+        "/game/campaign": {
+            "post": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "Dates without offset are read in the campaign timezone. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the active prize catalogue. The eligibility rules (minimum_age, countries, household_limit, household_prizes) can only be sent as JSON too.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -638,23 +873,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
                 ],
-                "summary": "Update a ticket.",
-                "operationId": "jwt.Auth =\u003e game.UpdateTicket",
+                "summary": "Open a new campaign, the tickets of the previous ones are kept.",
+                "operationId": "jwt.Auth =\u003e game.CreateCampaign",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Ticket ID",
-                        "name": "id",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2024-09-01 00:00",
+                        "description": "Tickets can be played from",
+                        "name": "start_at",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2024-09-30 23:59",
+                        "description": "Tickets can be played until",
+                        "name": "end_at",
                         "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "2024-10-30 23:59",
+                        "description": "Prizes can be handed over until",
+                        "name": "claim_deadline",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Europe/Paris",
+                        "description": "IANA timezone",
+                        "name": "timezone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets to generate",
+                        "name": "tickets",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Ticket details"
+                    "201": {
+                        "description": "Campaign created"
                     },
                     "400": {
                         "description": "Bad request"
@@ -662,33 +933,35 @@ const docTemplate = `{
                     "401": {
                         "description": "Unauthorized"
                     },
-                    "404": {
-                        "description": "Not found"
+                    "409": {
+                        "description": "Campaign already exists"
                     }
                 }
             }
         },
-        "/game/ticket/{id}": {
+        "/game/campaign/{id}": {
             "get": {
-                "security": [
-                    {
-                        "Bearer": []
-                    }
-                ],
-                "consumes": [
-                    "multipart/form-data"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
+                ],
+                "summary": "Get a campaign by id.",
+                "operationId": "game.GetCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get ticket by id.",
-                "operationId": "jwt.Auth =\u003e game.GetTicketById",
                 "responses": {
                     "200": {
-                        "description": "Tickets details"
+                        "description": "Campaign details"
                     },
                     "400": {
                         "description": "Bad request"
@@ -697,15 +970,14 @@ const docTemplate = `{
                         "description": "Not found"
                     }
                 }
-            }
-        },
-        "/game/tickets": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "Bearer": []
                     }
                 ],
+                "description": "The distribution and the eligibility rules can only be sent as JSON, the rules left out are kept.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -713,28 +985,1785 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Game"
+                    "Campaign"
+                ],
+                "summary": "Update a campaign.",
+                "operationId": "jwt.Auth =\u003e game.UpdateCampaign",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tickets can be played from",
+                        "name": "start_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tickets can be played until",
+                        "name": "end_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Prizes can be handed over until",
+                        "name": "claim_deadline",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone",
+                        "name": "timezone",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets to generate",
+                        "name": "tickets",
+                        "in": "formData"
+                    }
                 ],
-                "summary": "List all tickets likend to the authenticated user.",
-                "operationId": "jwt.Auth =\u003e game.GetTickets",
                 "responses": {
                     "200": {
-                        "description": "Tickets details"
+                        "description": "Campaign updated"
                     },
                     "400": {
                         "description": "Bad request"
                     },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
                     "404": {
                         "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Campaign already exists"
                     }
                 }
             }
         },
-        "/status/healthcheck": {
-            "get": {
-                "description": "get the status of server.",
-                "consumes": [
-                    "*/*"
+        "/game/campaign/{id}/distribution": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Missing tickets are generated and spare ones cancelled, tickets already handed over or claimed are kept. Every change is recorded. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the current one.\nAt most 10000 tickets are generated or cancelled per call. When the recorded change has tickets remaining, call again, with or without body, to resume from the tickets in database.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Change the distribution of a running campaign and rebalance its tickets.",
+                "operationId": "jwt.Auth =\u003e game.UpdateDistribution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets the campaign should own",
+                        "name": "tickets",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recorded change, with the tickets remaining for the next call"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Campaign ended"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Tickets changed during the rebalance"
+                    }
+                }
+            }
+        },
+        "/game/campaign/{id}/distribution/preview": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Nothing is written. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the current one.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Preview the tickets to generate or cancel for a campaign to match a new distribution.",
+                "operationId": "jwt.Auth =\u003e game.PreviewDistribution",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of tickets the campaign should own",
+                        "name": "tickets",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tickets to generate and cancel per prize"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Campaign ended"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/campaign/{id}/distributions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "List the distribution changes of a campaign, the most recent first.",
+                "operationId": "jwt.Auth =\u003e game.GetDistributionChanges",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Distribution changes"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/campaign/{id}/expire": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Tickets never claimed expire after the end of the campaign, prizes never collected after the claim deadline, each deadline delayed by project.expiry.grace minutes. Claims held for review are kept. Expired tickets stay stored but are left out of the ticket listings, and the forfeited prizes are recorded with their value.\nThe server runs the expiry by itself once each deadline and its grace period have passed, and at start for the deadlines passed while it was down. This endpoint runs it again on demand, e.g. after a failure logged by the server.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "Expire the tickets of a campaign whose deadline has passed.",
+                "operationId": "jwt.Auth =\u003e game.ExpireTickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Expired tickets and forfeited prizes"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "No deadline of the campaign has passed, or tickets changed during the expiry"
+                    }
+                }
+            }
+        },
+        "/game/campaign/{id}/expiries": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "List the ticket expiries of a campaign, the most recent first.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketExpiries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket expiries"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/campaigns": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Campaign"
+                ],
+                "summary": "List the campaigns, the most recent first.",
+                "operationId": "game.GetCampaigns",
+                "responses": {
+                    "200": {
+                        "description": "Campaigns details"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/game/draw": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Run the grand prize draw of a campaign.",
+                "operationId": "jwt.Auth =\u003e game.RunDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign label",
+                        "name": "campaign",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Draw recorded"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Campaign not found"
+                    },
+                    "409": {
+                        "description": "Draw already done, campaign running or no participant"
+                    }
+                }
+            }
+        },
+        "/game/draw/{campaign}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Get the grand prize draw of a campaign.",
+                "operationId": "jwt.Auth =\u003e game.GetDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign label",
+                        "name": "campaign",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draw details"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/draw/{campaign}/verify": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Draw"
+                ],
+                "summary": "Replay the grand prize draw of a campaign against the participants recorded with it.",
+                "operationId": "jwt.Auth =\u003e game.VerifyDraw",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Campaign label",
+                        "name": "campaign",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Draw verified"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Draw does not match"
+                    }
+                }
+            }
+        },
+        "/game/ledger": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Streams as CSV every entry of the append-only ledger of ticket creations, claims, redemptions and cancellations, in chain order. Each entry carries the SHA-256 of the ticket code rather than the code, and the hash chaining it to the previous entry, so the chain can be recomputed offline. Reserved to administrators and auditors.",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Export the ticket ledger for the bailiff.",
+                "operationId": "jwt.Auth =\u003e game.ExportLedger",
+                "responses": {
+                    "200": {
+                        "description": "Ledger file",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/ledger/verify": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Recomputes the hash chain and compares the state it vouches for with the stored tickets. The report gives the sequence of the first broken entry, the number of tickets altered, added or removed behind the ledger with the first of them, and the hash of the last entry. Reserved to administrators and auditors.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Ledger"
+                ],
+                "summary": "Verify the ticket ledger.",
+                "operationId": "jwt.Auth =\u003e game.VerifyLedger",
+                "responses": {
+                    "200": {
+                        "description": "Verification report"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/prize": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Add a prize to the catalogue.",
+                "operationId": "jwt.Auth =\u003e game.CreatePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Retail value in euros",
+                        "name": "value",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Display order",
+                        "name": "position",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Can be won",
+                        "name": "active",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Share of the tickets, in percent",
+                        "name": "distribution",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Prize created"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Prize already exists"
+                    }
+                }
+            }
+        },
+        "/game/prize/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Get a prize by id.",
+                "operationId": "game.GetPrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prize details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Update a prize of the catalogue.",
+                "operationId": "jwt.Auth =\u003e game.UpdatePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Label",
+                        "name": "label",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Retail value in euros",
+                        "name": "value",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Image URL",
+                        "name": "image",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Display order",
+                        "name": "position",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Can be won",
+                        "name": "active",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Share of the tickets, in percent",
+                        "name": "distribution",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Prize updated"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Prize already exists"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "Remove a prize that was never attributed to a ticket.",
+                "operationId": "jwt.Auth =\u003e game.DeletePrize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Prize deleted"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Prize in use"
+                    }
+                }
+            }
+        },
+        "/game/prizes": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prize"
+                ],
+                "summary": "List the prizes of the game, in display order.",
+                "operationId": "game.GetPrizes",
+                "responses": {
+                    "200": {
+                        "description": "Prizes details"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/game/qrcode/{token}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Renders the code of a ticket for printed tickets and prize vouchers, locally without any external service. Employees may render any ticket, clients only the tickets they hold.",
+                "produces": [
+                    "image/png",
+                    "image/svg+xml"
+                ],
+                "tags": [
+                    "Ticket"
+                ],
+                "summary": "Render a ticket token as a QR code.",
+                "operationId": "jwt.Auth =\u003e game.RenderQRCode",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ticket code",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "png",
+                            "svg"
+                        ],
+                        "type": "string",
+                        "default": "png",
+                        "description": "Image format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 2048,
+                        "minimum": 64,
+                        "type": "integer",
+                        "default": 256,
+                        "description": "Side of the image in pixels",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "L",
+                            "M",
+                            "Q",
+                            "H"
+                        ],
+                        "type": "string",
+                        "default": "M",
+                        "description": "Error-correction level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Encode the link to the claim screen instead of the bare code",
+                        "name": "link",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "QR code image",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Ticket not found"
+                    },
+                    "500": {
+                        "description": "No claim screen configured"
+                    }
+                }
+            }
+        },
+        "/game/random": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Get a random ticket.",
+                "operationId": "jwt.Auth =\u003e game.GetTicket",
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/receipt": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Record a purchase over 49€ at a caisse and hand a ticket over for it.",
+                "operationId": "jwt.Auth =\u003e game.IssueReceipt",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receipt number, unique within the store",
+                        "name": "number",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Purchase amount in euros",
+                        "name": "amount",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Purchase date, now by default",
+                        "name": "purchased_at",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Printed ticket code to hand over, a ticket is issued otherwise",
+                        "name": "token",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Receipt with its ticket"
+                    },
+                    "400": {
+                        "description": "Bad request or purchase not qualifying"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Ticket not found"
+                    },
+                    "409": {
+                        "description": "Receipt already recorded or no ticket to hand over"
+                    }
+                }
+            }
+        },
+        "/game/review/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Approve or reject a claim held for review.",
+                "operationId": "jwt.Auth =\u003e game.ReviewClaim",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Decision",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Review with its ticket"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Review already decided"
+                    }
+                }
+            }
+        },
+        "/game/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List the suspicious claims held for review, oldest first.",
+                "operationId": "jwt.Auth =\u003e game.GetClaimReviews",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Review status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reviews with their ticket"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/statistics/claims": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the tickets claimed during a period, per day or hour.",
+                "operationId": "jwt.Auth =\u003e game.GetClaimStatistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-10-01",
+                        "description": "First day or instant included, UTC without offset",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-10-31",
+                        "description": "Last day or instant included, UTC without offset",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "hour"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Grouping interval",
+                        "name": "interval",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Claims per period"
+                    },
+                    "400": {
+                        "description": "Invalid period or interval"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/statistics/prizes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the tickets claimed during a period and those still unclaimed at its end, per prize.",
+                "operationId": "jwt.Auth =\u003e game.GetPrizeStatistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-10-01",
+                        "description": "First day or instant included, UTC without offset",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-10-31",
+                        "description": "Last day or instant included, UTC without offset",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics per prize"
+                    },
+                    "400": {
+                        "description": "Invalid period"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/statistics/stores": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Count the tickets claimed and redeemed during a period, per store and caisse.",
+                "operationId": "jwt.Auth =\u003e game.GetStoreStatistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "2024-10-01",
+                        "description": "First day or instant included, UTC without offset",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-10-31",
+                        "description": "Last day or instant included, UTC without offset",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics per store and caisse"
+                    },
+                    "400": {
+                        "description": "Invalid period"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/ticket": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Update a ticket.",
+                "operationId": "jwt.Auth =\u003e game.UpdateTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed code of the ticket",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo of the purchase receipt, JPEG, PNG or PDF up to 3 MB, kept for disputes",
+                        "name": "receipt_photo",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Identifier of the client device",
+                        "name": "X-Device-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "202": {
+                        "description": "Claim held for review"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Client excluded by the eligibility rules of the campaign"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "413": {
+                        "description": "Receipt photo too large"
+                    }
+                }
+            }
+        },
+        "/game/ticket/redeem": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Hand over the prize of a claimed ticket at a caisse, found by its printed code.",
+                "operationId": "jwt.Auth =\u003e game.RedeemTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Printed ticket code",
+                        "name": "token",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Credential ID of the presenting client",
+                        "name": "credential_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse ID",
+                        "name": "caisse_id",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Ticket claimed by another client"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Ticket not claimed or already redeemed"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Get ticket by id.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketById",
+                "responses": {
+                    "200": {
+                        "description": "Tickets details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List the status changes of a ticket.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketHistory",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket history"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Distribute or cancel a ticket.",
+                "operationId": "jwt.Auth =\u003e game.UpdateTicketStatus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "distributed",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "New status, claims, reviews and redemptions have their own endpoints",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store where the change happens",
+                        "name": "store_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Caisse where the change happens",
+                        "name": "caisse_id",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Ticket details"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Transition not allowed"
+                    }
+                }
+            }
+        },
+        "/game/ticket/{id}/transfer": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Offer a claimed ticket to another client, who must accept it.",
+                "operationId": "jwt.Auth =\u003e game.GiftTicket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "email",
+                        "description": "Email of the recipient",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Pending transfer"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Ticket held by another client or claim deadline passed"
+                    },
+                    "404": {
+                        "description": "Ticket or recipient not found"
+                    },
+                    "409": {
+                        "description": "Ticket not claimed, already redeemed or already offered"
+                    }
+                }
+            }
+        },
+        "/game/tickets": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List a page of tickets, those of the authenticated client or all of them for employees.",
+                "operationId": "jwt.Auth =\u003e game.GetTickets",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Prize ID",
+                        "name": "prize_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Campaign ID",
+                        "name": "campaign_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "generated",
+                            "distributed",
+                            "review",
+                            "claimed",
+                            "redeemed",
+                            "cancelled",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Ticket status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Owner credential ID, employees only",
+                        "name": "credential_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket code, employees only",
+                        "name": "token",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "claimed_at",
+                            "-claimed_at"
+                        ],
+                        "type": "string",
+                        "default": "-claimed_at",
+                        "description": "Sort by claim date",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Tickets per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of tickets with the total count"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/game/transfer/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "Accept or decline a ticket offered to the client, or cancel an offer it made.",
+                "operationId": "jwt.Auth =\u003e game.AnswerTicketTransfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Transfer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "accepted",
+                            "declined",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Answer",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transfer with its ticket"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "403": {
+                        "description": "Ticket no longer held by the sender, claim deadline passed or recipient not eligible"
+                    },
+                    "404": {
+                        "description": "Not found"
+                    },
+                    "409": {
+                        "description": "Transfer already answered or ticket no longer transferable"
+                    }
+                }
+            }
+        },
+        "/game/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Game"
+                ],
+                "summary": "List a page of the ticket transfers sent or received by the client, most recent first.",
+                "operationId": "jwt.Auth =\u003e game.GetTicketTransfers",
+                "parameters": [
+                    {
+                        "enum": [
+                            "pending",
+                            "accepted",
+                            "declined",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Transfer status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Transfers per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of transfers with their ticket and the total count"
+                    },
+                    "400": {
+                        "description": "Bad request"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    }
+                }
+            }
+        },
+        "/status/healthcheck": {
+            "get": {
+                "description": "get the status of server.",
+                "consumes": [
+                    "*/*"
                 ],
                 "produces": [
                     "application/json"
@@ -769,63 +2798,349 @@ const docTemplate = `{
                     "200": {
                         "description": "OK"
                     }
-                }
-            }
-        },
-        "/store": {
-            "get": {
-                "consumes": [
-                    "multipart/form-data"
+                }
+            }
+        },
+        "/store": {
+            "get": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "List all store.",
+                "operationId": "store.List",
+                "responses": {
+                    "200": {
+                        "description": "list of store"
+                    }
+                }
+            }
+        },
+        "/store/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Store"
+                ],
+                "summary": "Get caisse by store",
+                "operationId": "store.GetStoreByID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Store ID",
+                        "name": "store_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of caisse"
+                    },
+                    "400": {
+                        "description": "Invalid store"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/auth": {
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Authenticate a client/employees.",
+                "operationId": "user.UserAuth",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "email",
+                        "default": "user-thetiptop@yopmail.com",
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "Aa1@azetyuiop",
+                        "description": "Password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "TOTP or recovery code of employees with a second factor",
+                        "name": "totp",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the device, shown in the list of the sessions",
+                        "name": "X-Device-Name",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Signed in, tokens limited to the second factor routes when second_factor is set"
+                    },
+                    "400": {
+                        "description": "Invalid email or password"
+                    },
+                    "401": {
+                        "description": "Invalid second factor"
+                    },
+                    "423": {
+                        "description": "Account or address locked after too many failures"
+                    },
+                    "429": {
+                        "description": "Retry later after a failure"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/auth/renew": {
+            "get": {
+                "consumes": [
+                    "*/*",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Renew JWT for a client/employees.",
+                "operationId": "user.UserAuthRenew",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "With the bearer started",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "JWT token renewed, the refresh token is spent"
+                    },
+                    "400": {
+                        "description": "Invalid token"
+                    },
+                    "401": {
+                        "description": "Token expired, revoked or reused"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/auth/totp": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Complete a sign-in pending for the second factor.",
+                "operationId": "jwt.Pending =\u003e user.VerifyTOTP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "TOTP code or recovery code",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the device, shown in the list of the sessions",
+                        "name": "X-Device-Name",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Employee signed in"
+                    },
+                    "400": {
+                        "description": "Missing code"
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid code"
+                    },
+                    "409": {
+                        "description": "Second factor not enabled"
+                    },
+                    "423": {
+                        "description": "Account locked after too many failures"
+                    },
+                    "429": {
+                        "description": "Retry later after a failure"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/lockout": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Unlock an account and/or an address after failed sign-ins.",
+                "operationId": "jwt.Auth =\u003e user.Unlock",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "email",
+                        "description": "Email address of the account",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IP address",
+                        "name": "ip",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Unlocked"
+                    },
+                    "400": {
+                        "description": "Invalid email or IP"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Account not found or not locked"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Store"
+                    "User"
                 ],
-                "summary": "List all store.",
-                "operationId": "store.List",
+                "summary": "Sign out, the refresh tokens of the session are revoked.",
+                "operationId": "jwt.Pending =\u003e user.Logout",
                 "responses": {
-                    "200": {
-                        "description": "list of store"
+                    "204": {
+                        "description": "Signed out"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal server error"
                     }
                 }
             }
         },
-        "/store/{id}": {
+        "/user/oidc/{provider}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Store"
+                    "User"
                 ],
-                "summary": "Get caisse by store",
-                "operationId": "store.GetStoreByID",
+                "summary": "Start the sign-in of a client with an external OpenID Connect provider.",
+                "operationId": "user.StartOIDC",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Store ID",
-                        "name": "store_id",
+                        "default": "google",
+                        "description": "Provider name in the configuration",
+                        "name": "provider",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Consent to the terms, required when the sign-in registers a new client",
+                        "name": "cgu",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Subscription of a new client to the newsletter",
+                        "name": "newsletter",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of caisse"
+                        "description": "URL of the provider to send the client to"
                     },
                     "400": {
-                        "description": "Invalid store"
+                        "description": "Invalid consents"
+                    },
+                    "404": {
+                        "description": "Unknown provider"
                     },
                     "500": {
                         "description": "Internal server error"
                     }
                 }
-            }
-        },
-        "/user/auth": {
+            },
             "post": {
                 "consumes": [
                     "multipart/form-data"
@@ -836,75 +3151,59 @@ const docTemplate = `{
                 "tags": [
                     "User"
                 ],
-                "summary": "Authenticate a client/employees.",
-                "operationId": "user.UserAuth",
+                "summary": "Complete the sign-in of a client with an external OpenID Connect provider.",
+                "operationId": "user.OIDCAuth",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "email",
-                        "default": "user-thetiptop@yopmail.com",
-                        "description": "Email address",
-                        "name": "email",
-                        "in": "formData",
+                        "default": "google",
+                        "description": "Provider name in the configuration",
+                        "name": "provider",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "default": "Aa1@azetyuiop",
-                        "description": "Password",
-                        "name": "password",
+                        "description": "Authorization code sent back by the provider",
+                        "name": "code",
                         "in": "formData",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Client signed in"
-                    },
-                    "400": {
-                        "description": "Invalid email or password"
                     },
-                    "500": {
-                        "description": "Internal server error"
-                    }
-                }
-            }
-        },
-        "/user/auth/renew": {
-            "get": {
-                "consumes": [
-                    "*/*",
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "User"
-                ],
-                "summary": "Renew JWT for a client/employees.",
-                "operationId": "user.UserAuthRenew",
-                "parameters": [
                     {
                         "type": "string",
-                        "description": "With the bearer started",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "State sent back by the provider",
+                        "name": "state",
+                        "in": "formData",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Name of the device, shown in the list of the sessions",
+                        "name": "X-Device-Name",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "JWT token renewed"
+                        "description": "Signed in, the account is linked or registered on the first sign-in"
                     },
                     "400": {
-                        "description": "Invalid token"
+                        "description": "Missing code, invalid or expired state, or terms not accepted to register"
                     },
                     "401": {
-                        "description": "Token expired"
+                        "description": "Identity token refused"
+                    },
+                    "403": {
+                        "description": "Email not verified by the provider or account of an employee"
+                    },
+                    "404": {
+                        "description": "Unknown provider"
                     },
                     "500": {
                         "description": "Internal server error"
+                    },
+                    "502": {
+                        "description": "Code refused by the provider"
                     }
                 }
             }
@@ -1028,6 +3327,197 @@ const docTemplate = `{
                 }
             }
         },
+        "/user/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List the sessions of the signed in user.",
+                "operationId": "jwt.Auth =\u003e user.ListSessions",
+                "responses": {
+                    "200": {
+                        "description": "Sessions with their device, the current one flagged"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign a user out of all its sessions.",
+                "operationId": "jwt.Auth =\u003e user.RevokeSessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "email",
+                        "description": "Email address of the user",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Sessions revoked"
+                    },
+                    "400": {
+                        "description": "Invalid email"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "User not found"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign out of another session of the signed in user.",
+                "operationId": "jwt.Auth =\u003e user.RevokeSession",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session revoked, its refresh token is refused"
+                    },
+                    "400": {
+                        "description": "Invalid ID"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "404": {
+                        "description": "Session not found"
+                    },
+                    "409": {
+                        "description": "Current session, to close by logout"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
+        "/user/totp": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Enable the TOTP second factor of the signed in employee with a first code.",
+                "operationId": "jwt.Pending =\u003e user.ConfirmTOTP",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Code shown by the authenticator app",
+                        "name": "code",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recovery codes, only shown once"
+                    },
+                    "400": {
+                        "description": "Missing code"
+                    },
+                    "401": {
+                        "description": "Unauthorized or invalid code"
+                    },
+                    "409": {
+                        "description": "Not enrolled or already enabled"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Start the enrolment of the TOTP second factor of the signed in employee.",
+                "operationId": "jwt.Pending =\u003e user.EnrollTOTP",
+                "responses": {
+                    "201": {
+                        "description": "Secret and otpauth URI to scan"
+                    },
+                    "401": {
+                        "description": "Unauthorized"
+                    },
+                    "409": {
+                        "description": "Second factor already enabled"
+                    },
+                    "500": {
+                        "description": "Internal server error"
+                    }
+                }
+            }
+        },
         "/user/validation/renew": {
             "post": {
                 "consumes": [
@@ -62,6 +62,15 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetSessionID() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
 func (m *PermissionMock) IsGrantedByRoles(roles ...security.Role) bool {
 	args := m.Called(roles)
 	return args.Bool(0)
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// Batch records an export of tickets sent to the printer
+// The filters are kept so the same export can be produced again and compared with its checksum
+type Batch struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	CampaignID   *string `gorm:"type:varchar(36);index" json:"campaign_id"`
+	PrizeID      *string `gorm:"type:varchar(36)" json:"prize_id"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"` // Credential who exported, empty from the CLI
+
+	// Additional fields
+	Format   string `gorm:"type:varchar(8)" json:"format"`
+	Offset   int    `json:"offset"`
+	Limit    int    `json:"limit"` // 0 exports every remaining ticket, at most BatchPDFMax for a PDF
+	Count    int    `json:"count"`
+	Checksum string `gorm:"type:varchar(64)" json:"checksum"` // SHA-256 of the exported codes
+}
+
+func CreateBatch(obj *transfert.Batch) *Batch {
+	b := &Batch{
+		PrizeID: obj.PrizeID,
+	}
+
+	if obj.ID != nil {
+		b.ID = *obj.ID
+	}
+
+	if obj.Format != nil {
+		b.Format = *obj.Format
+	}
+
+	if obj.Offset != nil {
+		b.Offset = *obj.Offset
+	}
+
+	if obj.Limit != nil {
+		b.Limit = *obj.Limit
+	}
+
+	return b
+}
+
+func (batch *Batch) IsPublic() bool {
+	return false
+}
+
+func (batch *Batch) GetOwnerID() string {
+	return ""
+}
+
+func (batch *Batch) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	batch.ID = id.String()
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBatch(t *testing.T) {
+	input := &transfert.Batch{
+		ID:      aws.String("batch-id"),
+		PrizeID: aws.String("prize-id"),
+		Format:  aws.String("pdf"),
+		Offset:  aws.Int(100),
+		Limit:   aws.Int(500),
+	}
+
+	batch := entities.CreateBatch(input)
+
+	assert.Equal(t, "batch-id", batch.ID)
+	assert.Equal(t, input.PrizeID, batch.PrizeID)
+	assert.Equal(t, "pdf", batch.Format)
+	assert.Equal(t, 100, batch.Offset)
+	assert.Equal(t, 500, batch.Limit)
+	assert.False(t, batch.IsPublic())
+	assert.Equal(t, "", batch.GetOwnerID())
+
+	// Sans bornes, tout le lot est exporté
+	batch = entities.CreateBatch(&transfert.Batch{})
+	assert.Equal(t, 0, batch.Offset)
+	assert.Equal(t, 0, batch.Limit)
+}
+
+func TestBatch_BeforeCreate(t *testing.T) {
+	batch := &entities.Batch{}
+	err := batch.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, batch.ID)
+}
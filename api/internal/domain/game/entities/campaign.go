@@ -0,0 +1,200 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"gorm.io/gorm"
+)
+
+// campaignLayouts lists the accepted date formats, dates without offset are read in the campaign timezone
+var campaignLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// Campaign is an edition of the contest, it owns its tickets
+// A nil boundary leaves the matching side of the window open
+type Campaign struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	Label         *string        `gorm:"type:varchar(64);uniqueIndex" json:"label"`
+	StartAt       *time.Time     `gorm:"index" json:"start_at"`               // Tickets can be played from
+	EndAt         *time.Time     `json:"end_at"`                              // Tickets can be played until
+	ClaimDeadline *time.Time     `json:"claim_deadline"`                      // Prizes can be handed over until
+	Timezone      *string        `gorm:"type:varchar(64)" json:"timezone"`    // IANA name, e.g. Europe/Paris
+	Tickets       *int           `json:"tickets"`                             // Number of tickets to generate
+	Distribution  map[string]int `gorm:"serializer:json" json:"distribution"` // Share of the tickets per prize ID, in percent
+	Eligibility   Eligibility    `gorm:"serializer:json" json:"eligibility"`  // Participation rules checked before a claim
+}
+
+// Apply copies the provided fields of the DTO into the campaign
+// Dates are parsed in the campaign timezone and the resulting window is validated
+//
+// Parameters:
+// - obj: *transfert.Campaign The fields to apply
+//
+// Returns:
+// - errors.ErrorInterface: ErrCampaignInvalidTimezone, ErrCampaignInvalidDates or ErrEligibilityInvalid
+func (campaign *Campaign) Apply(obj *transfert.Campaign) errors.ErrorInterface {
+	if obj.Timezone != nil {
+		if _, err := time.LoadLocation(*obj.Timezone); err != nil {
+			return errors_domain_game.ErrCampaignInvalidTimezone
+		}
+		campaign.Timezone = obj.Timezone
+	}
+
+	location := campaign.Location()
+	dates := []struct {
+		value  *string
+		target **time.Time
+	}{
+		{obj.StartAt, &campaign.StartAt},
+		{obj.EndAt, &campaign.EndAt},
+		{obj.ClaimDeadline, &campaign.ClaimDeadline},
+	}
+
+	for _, date := range dates {
+		if date.value == nil {
+			continue
+		}
+
+		parsed, ok := parseCampaignTime(*date.value, location)
+		if !ok {
+			return errors_domain_game.ErrCampaignInvalidDates
+		}
+
+		*date.target = parsed
+	}
+
+	if obj.Label != nil {
+		campaign.Label = obj.Label
+	}
+
+	if obj.Tickets != nil {
+		campaign.Tickets = obj.Tickets
+	}
+
+	if obj.Distribution != nil {
+		campaign.Distribution = obj.Distribution
+	}
+
+	if obj.Eligibility != nil {
+		if err := campaign.Eligibility.Apply(obj.Eligibility); err != nil {
+			return err
+		}
+	}
+
+	if campaign.StartAt != nil && campaign.EndAt != nil && !campaign.StartAt.Before(*campaign.EndAt) {
+		return errors_domain_game.ErrCampaignInvalidDates
+	}
+
+	if campaign.EndAt != nil && campaign.ClaimDeadline != nil && campaign.ClaimDeadline.Before(*campaign.EndAt) {
+		return errors_domain_game.ErrCampaignInvalidDates
+	}
+
+	return nil
+}
+
+// Location returns the campaign timezone, UTC when unset
+func (campaign *Campaign) Location() *time.Location {
+	if campaign.Timezone == nil {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(*campaign.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// IsStarted reports whether tickets can already be played at the given instant
+func (campaign *Campaign) IsStarted(at time.Time) bool {
+	return campaign.StartAt == nil || !at.Before(*campaign.StartAt)
+}
+
+// IsEnded reports whether tickets can no longer be played at the given instant
+func (campaign *Campaign) IsEnded(at time.Time) bool {
+	return campaign.EndAt != nil && at.After(*campaign.EndAt)
+}
+
+// IsClaimOpen reports whether prizes can still be handed over at the given instant
+func (campaign *Campaign) IsClaimOpen(at time.Time) bool {
+	return campaign.ClaimDeadline == nil || !at.After(*campaign.ClaimDeadline)
+}
+
+// ExpiresAt returns the instant from which a ticket in the given status is forfeited, nil when it never expires
+// Unplayed tickets expire at the end of the campaign, prizes left uncollected at the claim deadline,
+// both once the grace period has passed
+func (campaign *Campaign) ExpiresAt(status TicketStatus, grace time.Duration) *time.Time {
+	var deadline *time.Time
+
+	switch status {
+	case "", TicketGenerated, TicketDistributed:
+		deadline = campaign.EndAt
+	case TicketClaimed:
+		deadline = campaign.ClaimDeadline
+	}
+
+	if deadline == nil {
+		return nil
+	}
+
+	at := deadline.Add(grace)
+
+	return &at
+}
+
+// IsExpired reports whether a ticket in the given status is forfeited at the given instant
+func (campaign *Campaign) IsExpired(status TicketStatus, grace time.Duration, at time.Time) bool {
+	expiresAt := campaign.ExpiresAt(status, grace)
+	return expiresAt != nil && at.After(*expiresAt)
+}
+
+func (campaign *Campaign) IsPublic() bool {
+	return true
+}
+
+func (campaign *Campaign) GetOwnerID() string {
+	return ""
+}
+
+func (campaign *Campaign) BeforeUpdate(tx *gorm.DB) error {
+	campaign.UpdatedAt = time.Now()
+	return nil
+}
+
+func (campaign *Campaign) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	campaign.ID = id.String()
+
+	return nil
+}
+
+func parseCampaignTime(value string, location *time.Location) (*time.Time, bool) {
+	for _, layout := range campaignLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return &t, true
+		}
+	}
+
+	return nil, false
+}
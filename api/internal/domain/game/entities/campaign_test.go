@@ -0,0 +1,132 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaign_Apply(t *testing.T) {
+	t.Run("Should apply fields in the campaign timezone", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{
+			Label:         aws.String("2024"),
+			StartAt:       aws.String("2024-10-01"),
+			EndAt:         aws.String("2024-10-31 23:59"),
+			ClaimDeadline: aws.String("2024-11-30T23:59:59+01:00"),
+			Timezone:      aws.String("Europe/Paris"),
+			Tickets:       aws.Int(500000),
+			Distribution:  map[string]int{"prize-1": 60},
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2024", *campaign.Label)
+		assert.Equal(t, 500000, *campaign.Tickets)
+		assert.Equal(t, map[string]int{"prize-1": 60}, campaign.Distribution)
+
+		paris, _ := time.LoadLocation("Europe/Paris")
+		assert.True(t, campaign.StartAt.Equal(time.Date(2024, 10, 1, 0, 0, 0, 0, paris)))
+		assert.True(t, campaign.EndAt.Equal(time.Date(2024, 10, 31, 22, 59, 0, 0, time.UTC)))
+		assert.True(t, campaign.ClaimDeadline.Equal(time.Date(2024, 11, 30, 22, 59, 59, 0, time.UTC)))
+	})
+
+	t.Run("Should keep the fields that are not provided", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		assert.Nil(t, campaign.Apply(&transfert.Campaign{Label: aws.String("2024"), StartAt: aws.String("2024-10-01")}))
+
+		assert.Nil(t, campaign.Apply(&transfert.Campaign{EndAt: aws.String("2024-10-31")}))
+		assert.Equal(t, "2024", *campaign.Label)
+		assert.NotNil(t, campaign.StartAt)
+		assert.NotNil(t, campaign.EndAt)
+	})
+
+	t.Run("Should reject an unknown timezone", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{Timezone: aws.String("Mars/Olympus")})
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidTimezone, err)
+	})
+
+	t.Run("Should reject an invalid date", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{StartAt: aws.String("01/10/2024")})
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidDates, err)
+	})
+
+	t.Run("Should reject an end before the start", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{StartAt: aws.String("2024-10-31"), EndAt: aws.String("2024-10-01")})
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidDates, err)
+	})
+
+	t.Run("Should reject a claim deadline before the end", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{EndAt: aws.String("2024-10-31"), ClaimDeadline: aws.String("2024-10-30")})
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidDates, err)
+	})
+}
+
+func TestCampaign_Windows(t *testing.T) {
+	start := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 10, 31, 0, 0, 0, 0, time.UTC)
+	claim := time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)
+
+	campaign := &entities.Campaign{StartAt: &start, EndAt: &end, ClaimDeadline: &claim}
+
+	assert.False(t, campaign.IsStarted(start.Add(-time.Second)))
+	assert.True(t, campaign.IsStarted(start))
+	assert.False(t, campaign.IsEnded(end))
+	assert.True(t, campaign.IsEnded(end.Add(time.Second)))
+	assert.True(t, campaign.IsClaimOpen(claim))
+	assert.False(t, campaign.IsClaimOpen(claim.Add(time.Second)))
+
+	// Sans bornes, la campagne reste ouverte
+	open := &entities.Campaign{}
+	assert.True(t, open.IsStarted(start))
+	assert.False(t, open.IsEnded(claim))
+	assert.True(t, open.IsClaimOpen(claim))
+}
+
+func TestCampaign_Expiry(t *testing.T) {
+	end := time.Date(2024, 10, 31, 23, 59, 59, 0, time.UTC)
+	claim := time.Date(2024, 11, 30, 23, 59, 59, 0, time.UTC)
+	campaign := &entities.Campaign{EndAt: &end, ClaimDeadline: &claim}
+
+	// Les tickets jamais joués expirent à la fin de la campagne, les lots non retirés à la date limite
+	assert.Equal(t, end, *campaign.ExpiresAt(entities.TicketGenerated, 0))
+	assert.Equal(t, end.Add(time.Hour), *campaign.ExpiresAt(entities.TicketDistributed, time.Hour))
+	assert.Equal(t, claim, *campaign.ExpiresAt(entities.TicketClaimed, 0))
+	assert.Nil(t, campaign.ExpiresAt(entities.TicketReview, 0))
+	assert.Nil(t, campaign.ExpiresAt(entities.TicketRedeemed, 0))
+
+	assert.False(t, campaign.IsExpired(entities.TicketGenerated, 0, end))
+	assert.True(t, campaign.IsExpired(entities.TicketGenerated, 0, end.Add(time.Second)))
+	assert.False(t, campaign.IsExpired(entities.TicketGenerated, time.Hour, end.Add(time.Second)))
+	assert.False(t, campaign.IsExpired(entities.TicketClaimed, 0, end.Add(time.Second)))
+	assert.True(t, campaign.IsExpired(entities.TicketClaimed, 0, claim.Add(time.Second)))
+
+	// Sans bornes, rien n'expire
+	open := &entities.Campaign{}
+	assert.False(t, open.IsExpired(entities.TicketClaimed, 0, claim))
+}
+
+func TestCampaign_Location(t *testing.T) {
+	assert.Equal(t, time.UTC, (&entities.Campaign{}).Location())
+	assert.Equal(t, time.UTC, (&entities.Campaign{Timezone: aws.String("Mars/Olympus")}).Location())
+	assert.Equal(t, "Europe/Paris", (&entities.Campaign{Timezone: aws.String("Europe/Paris")}).Location().String())
+}
+
+func TestCampaign_BeforeCreate(t *testing.T) {
+	campaign := &entities.Campaign{}
+	assert.Nil(t, campaign.BeforeCreate(nil))
+	assert.NotEmpty(t, campaign.ID)
+	assert.True(t, campaign.IsPublic())
+	assert.Equal(t, "", campaign.GetOwnerID())
+
+	assert.Nil(t, campaign.BeforeUpdate(nil))
+	assert.False(t, campaign.UpdatedAt.IsZero())
+}
@@ -0,0 +1,162 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/config"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// Fraud signals raised on a claim
+const (
+	SignalCredentialVelocity = "credential_velocity" // The account claims too many tickets
+	SignalIPVelocity         = "ip_velocity"         // Too many tickets are claimed from the IP
+	SignalDeviceVelocity     = "device_velocity"     // Too many tickets are claimed from the device
+	SignalFailedAttempts     = "failed_attempts"     // Too many codes entered by the account, the IP or the device matched no ticket
+	SignalSharedIP           = "shared_ip"           // Too many other accounts claim from the IP
+	SignalNewAccount         = "new_account"         // The account claims right after its registration
+)
+
+// signalWeights gives the score each raised signal adds to a claim
+var signalWeights = map[string]int{
+	SignalCredentialVelocity: 2,
+	SignalIPVelocity:         2,
+	SignalDeviceVelocity:     2,
+	SignalFailedAttempts:     2,
+	SignalSharedIP:           3,
+	SignalNewAccount:         1,
+}
+
+// ClaimAttempt is an append-only record of a code entered by a client to claim a ticket
+type ClaimAttempt struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+
+	// Relations
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`
+	TicketID     *string `gorm:"type:varchar(36);index" json:"ticket_id"` // Ticket matched by the code, nil when it matched none
+
+	// Additional fields
+	IP     string `gorm:"type:varchar(45);index" json:"ip"`
+	Device string `gorm:"type:varchar(255);index" json:"device"` // Device header sent by the client, empty when absent
+	Failed bool   `json:"failed"`                                // The code was forged or matched no claimable ticket
+}
+
+func CreateClaimAttempt(obj *transfert.ClaimAttempt) *ClaimAttempt {
+	a := &ClaimAttempt{
+		CredentialID: obj.CredentialID,
+		TicketID:     obj.TicketID,
+	}
+
+	if obj.IP != nil {
+		a.IP = *obj.IP
+	}
+
+	if obj.Device != nil {
+		a.Device = *obj.Device
+	}
+
+	return a
+}
+
+func (attempt *ClaimAttempt) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	attempt.ID = id.String()
+
+	return nil
+}
+
+// ClaimSignals counts the recent claim attempts sharing the credential, the IP or the device of a claim
+type ClaimSignals struct {
+	CredentialClaims int `json:"credential_claims"`
+	IPClaims         int `json:"ip_claims"`
+	DeviceClaims     int `json:"device_claims"`
+	FailedAttempts   int `json:"failed_attempts"`
+	IPAccounts       int `json:"ip_accounts"` // Other accounts which claimed from the IP
+
+	AccountAge *time.Duration `gorm:"-" json:"-"` // Time since the account registration, nil when unknown
+}
+
+// FraudPolicy holds the limits above which a claim signal is raised
+type FraudPolicy struct {
+	Window           time.Duration // Period over which the attempts are counted
+	CredentialClaims int
+	IPClaims         int
+	DeviceClaims     int
+	FailedAttempts   int
+	IPAccounts       int
+	AccountAge       time.Duration // Accounts younger than this raise SignalNewAccount
+	Threshold        int           // Score from which a claim is held for review
+}
+
+// NewFraudPolicy builds the fraud policy from the project.fraud configuration
+// Durations are read in minutes, a limit lower than 1 falls back to its default
+//
+// Returns:
+// - *FraudPolicy: The policy
+func NewFraudPolicy() *FraudPolicy {
+	return &FraudPolicy{
+		Window:           time.Duration(config.GetPositiveInt("project.fraud.window", 24*60)) * time.Minute,
+		CredentialClaims: config.GetPositiveInt("project.fraud.credential_claims", 5),
+		IPClaims:         config.GetPositiveInt("project.fraud.ip_claims", 10),
+		DeviceClaims:     config.GetPositiveInt("project.fraud.device_claims", 10),
+		FailedAttempts:   config.GetPositiveInt("project.fraud.failed_attempts", 5),
+		IPAccounts:       config.GetPositiveInt("project.fraud.ip_accounts", 3),
+		AccountAge:       time.Duration(config.GetPositiveInt("project.fraud.account_age", 5)) * time.Minute,
+		Threshold:        config.GetPositiveInt("project.fraud.threshold", 3),
+	}
+}
+
+// Score weighs the signals raised by a claim
+//
+// Parameters:
+// - signals: *ClaimSignals The recent attempts sharing the claim credential, IP or device
+//
+// Returns:
+// - int: The fraud score of the claim
+// - []string: The raised signals
+func (policy *FraudPolicy) Score(signals *ClaimSignals) (int, []string) {
+	raised := []string{}
+
+	if signals.CredentialClaims >= policy.CredentialClaims {
+		raised = append(raised, SignalCredentialVelocity)
+	}
+
+	if signals.IPClaims >= policy.IPClaims {
+		raised = append(raised, SignalIPVelocity)
+	}
+
+	if signals.DeviceClaims >= policy.DeviceClaims {
+		raised = append(raised, SignalDeviceVelocity)
+	}
+
+	if signals.FailedAttempts >= policy.FailedAttempts {
+		raised = append(raised, SignalFailedAttempts)
+	}
+
+	if signals.IPAccounts >= policy.IPAccounts {
+		raised = append(raised, SignalSharedIP)
+	}
+
+	if signals.AccountAge != nil && *signals.AccountAge < policy.AccountAge {
+		raised = append(raised, SignalNewAccount)
+	}
+
+	score := 0
+	for _, signal := range raised {
+		score += signalWeights[signal]
+	}
+
+	return score, raised
+}
+
+// IsSuspicious reports whether a claim with the given score is held for review
+func (policy *FraudPolicy) IsSuspicious(score int) bool {
+	return score >= policy.Threshold
+}
@@ -0,0 +1,83 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateClaimAttempt(t *testing.T) {
+	attempt := entities.CreateClaimAttempt(&transfert.ClaimAttempt{
+		CredentialID: aws.String("client-id"),
+		TicketID:     aws.String("ticket-id"),
+		IP:           aws.String("203.0.113.7"),
+		Device:       aws.String("device-42"),
+	})
+
+	assert.Equal(t, "client-id", *attempt.CredentialID)
+	assert.Equal(t, "ticket-id", *attempt.TicketID)
+	assert.Equal(t, "203.0.113.7", attempt.IP)
+	assert.Equal(t, "device-42", attempt.Device)
+	assert.False(t, attempt.Failed)
+
+	assert.Nil(t, attempt.BeforeCreate(nil))
+	assert.NotEmpty(t, attempt.ID)
+}
+
+func TestNewFraudPolicy(t *testing.T) {
+	policy := entities.NewFraudPolicy()
+
+	assert.Equal(t, 24*time.Hour, policy.Window)
+	assert.Equal(t, 5*time.Minute, policy.AccountAge)
+	assert.Equal(t, 3, policy.Threshold)
+}
+
+func TestFraudPolicy_Score(t *testing.T) {
+	policy := entities.NewFraudPolicy()
+
+	t.Run("clean claim", func(t *testing.T) {
+		age := time.Hour
+		score, signals := policy.Score(&entities.ClaimSignals{CredentialClaims: 1, IPClaims: 1, AccountAge: &age})
+		assert.Equal(t, 0, score)
+		assert.Empty(t, signals)
+		assert.False(t, policy.IsSuspicious(score))
+	})
+
+	t.Run("new account alone is not enough", func(t *testing.T) {
+		age := time.Minute
+		score, signals := policy.Score(&entities.ClaimSignals{AccountAge: &age})
+		assert.Equal(t, []string{entities.SignalNewAccount}, signals)
+		assert.False(t, policy.IsSuspicious(score))
+	})
+
+	t.Run("new account claiming fast", func(t *testing.T) {
+		age := time.Minute
+		score, signals := policy.Score(&entities.ClaimSignals{CredentialClaims: 5, AccountAge: &age})
+		assert.Equal(t, []string{entities.SignalCredentialVelocity, entities.SignalNewAccount}, signals)
+		assert.True(t, policy.IsSuspicious(score))
+	})
+
+	t.Run("shared IP", func(t *testing.T) {
+		score, signals := policy.Score(&entities.ClaimSignals{IPAccounts: 3})
+		assert.Equal(t, []string{entities.SignalSharedIP}, signals)
+		assert.True(t, policy.IsSuspicious(score))
+	})
+
+	t.Run("every signal", func(t *testing.T) {
+		age := time.Duration(0)
+		score, signals := policy.Score(&entities.ClaimSignals{
+			CredentialClaims: 9,
+			IPClaims:         10,
+			DeviceClaims:     10,
+			FailedAttempts:   5,
+			IPAccounts:       4,
+			AccountAge:       &age,
+		})
+		assert.Len(t, signals, 6)
+		assert.Equal(t, 12, score)
+	})
+}
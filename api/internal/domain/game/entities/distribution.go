@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TicketPool counts the tickets of a prize inside a campaign
+type TicketPool struct {
+	PrizeID string `json:"prize_id"`
+	Live    int    `json:"live"`  // Tickets not cancelled
+	Spare   int    `json:"spare"` // Tickets still generated, never handed over nor claimed
+}
+
+// DistributionLine is the rebalance of one prize of a campaign
+type DistributionLine struct {
+	PrizeID  string `json:"prize_id"`
+	Share    int    `json:"share"`    // Target share of the tickets, in percent
+	Target   int    `json:"target"`   // Tickets the prize should own
+	Current  int    `json:"current"`  // Tickets the prize owns, cancelled ones excluded
+	Generate int    `json:"generate"` // Tickets to generate
+	Cancel   int    `json:"cancel"`   // Spare tickets to cancel
+	Kept     int    `json:"kept"`     // Surplus already handed over or claimed, it can't be cancelled
+}
+
+// DistributionChange is an append-only record of a reconfiguration of the prize distribution of a campaign
+// A preview is the same record, computed but never persisted
+type DistributionChange struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	CampaignID   *string `gorm:"type:varchar(36);index" json:"campaign_id"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"` // Credential who changed the distribution
+
+	// Additional fields
+	PreviousTickets      *int                `json:"previous_tickets"`
+	Tickets              int                 `json:"tickets"`
+	PreviousDistribution map[string]int      `gorm:"serializer:json" json:"previous_distribution"`
+	Distribution         map[string]int      `gorm:"serializer:json" json:"distribution"`
+	Lines                []*DistributionLine `gorm:"serializer:json" json:"lines"`
+	Generated            int                 `json:"generated"` // Tickets actually generated
+	Cancelled            int                 `json:"cancelled"` // Tickets actually cancelled
+	Remaining            int                 `json:"remaining"` // Tickets left to generate or cancel, handled by the next call
+}
+
+// NewDistributionChange computes the tickets to generate or cancel so that a campaign matches a distribution
+// The targets are rounded like at startup, prizes left out of the distribution target no ticket
+//
+// Parameters:
+// - campaign: *Campaign The campaign before the change
+// - tickets: int The number of tickets the campaign should own
+// - distribution: map[string]int The share of the tickets per prize ID, in percent
+// - pools: []*TicketPool The tickets the campaign owns per prize
+//
+// Returns:
+// - *DistributionChange: The change, one line per prize ordered by prize ID
+func NewDistributionChange(campaign *Campaign, tickets int, distribution map[string]int, pools []*TicketPool) *DistributionChange {
+	change := &DistributionChange{
+		CampaignID:           &campaign.ID,
+		PreviousTickets:      campaign.Tickets,
+		Tickets:              tickets,
+		PreviousDistribution: campaign.Distribution,
+		Distribution:         distribution,
+		Lines:                []*DistributionLine{},
+	}
+
+	lines := map[string]*DistributionLine{}
+	for prizeID, share := range distribution {
+		lines[prizeID] = &DistributionLine{
+			PrizeID: prizeID,
+			Share:   share,
+			Target:  int(math.Round(float64(tickets) * float64(share) / 100.0)),
+		}
+	}
+
+	spares := map[string]int{}
+	for _, pool := range pools {
+		line, ok := lines[pool.PrizeID]
+		if !ok {
+			line = &DistributionLine{PrizeID: pool.PrizeID}
+			lines[pool.PrizeID] = line
+		}
+
+		line.Current = pool.Live
+		spares[pool.PrizeID] = pool.Spare
+	}
+
+	for _, line := range lines {
+		switch {
+		case line.Target > line.Current:
+			line.Generate = line.Target - line.Current
+		case line.Target < line.Current:
+			line.Cancel = min(line.Current-line.Target, spares[line.PrizeID])
+			line.Kept = line.Current - line.Target - line.Cancel
+		}
+
+		change.Lines = append(change.Lines, line)
+	}
+
+	sort.Slice(change.Lines, func(i, j int) bool {
+		return change.Lines[i].PrizeID < change.Lines[j].PrizeID
+	})
+
+	return change
+}
+
+func (change *DistributionChange) IsPublic() bool {
+	return false
+}
+
+func (change *DistributionChange) GetOwnerID() string {
+	return ""
+}
+
+func (change *DistributionChange) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	change.ID = id.String()
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDistributionChange(t *testing.T) {
+	campaign := &entities.Campaign{
+		ID:           "campaign-id",
+		Tickets:      aws.Int(100),
+		Distribution: map[string]int{"prize-a": 60, "prize-b": 30, "prize-c": 10},
+	}
+
+	pools := []*entities.TicketPool{
+		{PrizeID: "prize-a", Live: 60, Spare: 50},
+		{PrizeID: "prize-b", Live: 30, Spare: 2},
+		{PrizeID: "prize-c", Live: 10, Spare: 10},
+	}
+
+	change := entities.NewDistributionChange(campaign, 200, map[string]int{"prize-a": 20, "prize-b": 5, "prize-d": 15}, pools)
+
+	assert.Equal(t, "campaign-id", *change.CampaignID)
+	assert.Equal(t, 100, *change.PreviousTickets)
+	assert.Equal(t, 200, change.Tickets)
+	assert.Equal(t, campaign.Distribution, change.PreviousDistribution)
+	assert.Len(t, change.Lines, 4)
+
+	// Le lot A passe de 60 à 40 tickets, assez de tickets sont encore en réserve
+	assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-a", Share: 20, Target: 40, Current: 60, Cancel: 20}, change.Lines[0])
+
+	// Le lot B passe de 30 à 10 tickets, seuls 2 tickets n'ont pas encore été remis
+	assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-b", Share: 5, Target: 10, Current: 30, Cancel: 2, Kept: 18}, change.Lines[1])
+
+	// Le lot C sort de la répartition, tous ses tickets en réserve sont annulés
+	assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-c", Current: 10, Cancel: 10}, change.Lines[2])
+
+	// Le lot D entre dans la répartition
+	assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-d", Share: 15, Target: 30, Generate: 30}, change.Lines[3])
+}
+
+func TestNewDistributionChange_Unchanged(t *testing.T) {
+	campaign := &entities.Campaign{ID: "campaign-id"}
+
+	change := entities.NewDistributionChange(campaign, 10, map[string]int{"prize-a": 50}, []*entities.TicketPool{
+		{PrizeID: "prize-a", Live: 5, Spare: 5},
+	})
+
+	assert.Nil(t, change.PreviousTickets)
+	assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-a", Share: 50, Target: 5, Current: 5}, change.Lines[0])
+}
+
+func TestDistributionChange(t *testing.T) {
+	change := &entities.DistributionChange{}
+
+	assert.False(t, change.IsPublic())
+	assert.Equal(t, "", change.GetOwnerID())
+
+	err := change.BeforeCreate(nil)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, change.ID)
+}
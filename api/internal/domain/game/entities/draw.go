@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// Draw is the recorded result of the grand prize draw of a campaign
+// The seed and the participants frozen with the draw allow anyone to replay it,
+// whatever happens to the tickets afterwards
+type Draw struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	CampaignID   *string `gorm:"type:varchar(36);uniqueIndex" json:"campaign_id"` // One draw per campaign
+	WinnerID     *string `gorm:"type:varchar(36);index" json:"winner_id"`         // Credential of the winner
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`     // Credential who ran the draw
+
+	// Additional fields
+	Seed           string   `gorm:"type:varchar(128)" json:"seed"`
+	Participants   int      `json:"participants"`
+	ParticipantIDs []string `gorm:"serializer:json" json:"participant_ids"` // Ordered credential IDs of the participants when the draw ran
+	Checksum       string   `gorm:"type:varchar(64)" json:"checksum"`       // SHA-256 of the ordered participants list
+}
+
+func CreateDraw(obj *transfert.Draw) *Draw {
+	d := &Draw{
+		CampaignID: obj.CampaignID,
+	}
+
+	if obj.ID != nil {
+		d.ID = *obj.ID
+	}
+
+	return d
+}
+
+func (draw *Draw) IsPublic() bool {
+	return false
+}
+
+func (draw *Draw) GetOwnerID() string {
+	return ""
+}
+
+func (draw *Draw) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	draw.ID = id.String()
+
+	return nil
+}
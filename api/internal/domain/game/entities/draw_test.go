@@ -0,0 +1,33 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDraw(t *testing.T) {
+	input := &transfert.Draw{
+		ID:         aws.String("draw-id"),
+		CampaignID: aws.String("campaign-id"),
+	}
+
+	draw := entities.CreateDraw(input)
+
+	assert.Equal(t, "draw-id", draw.ID)
+	assert.Equal(t, input.CampaignID, draw.CampaignID)
+	assert.Empty(t, draw.Seed)
+	assert.False(t, draw.IsPublic())
+	assert.Equal(t, "", draw.GetOwnerID())
+}
+
+func TestDraw_BeforeCreate(t *testing.T) {
+	draw := &entities.Draw{}
+	err := draw.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, draw.ID)
+}
@@ -0,0 +1,185 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// BirthDateLayout is the format of the birth date of the clients
+const BirthDateLayout = "2006-01-02"
+
+// Eligibility gathers the participation rules of a campaign, a rule left to its zero value is not enforced
+type Eligibility struct {
+	MinimumAge      int      `json:"minimum_age"`      // Age, in years, the client must have reached on the day of the claim
+	Countries       []string `json:"countries"`        // ISO 3166-1 alpha-2 codes of the countries of residence allowed
+	HouseholdLimit  int      `json:"household_limit"`  // Prizes a postal address may win during the campaign
+	HouseholdPrizes []string `json:"household_prizes"` // Prize IDs the household limit applies to, every prize when empty
+}
+
+// Claimant is the profile of the client checked against the eligibility rules
+type Claimant struct {
+	BirthDate *string // BirthDateLayout
+	Country   *string // ISO 3166-1 alpha-2 code
+	Household *string // Key of the postal address
+}
+
+// HouseholdQuota is the household limit a claim must fit in
+// Each prize counted takes one of the Limit slots of the household, the slots are unique per campaign and household
+type HouseholdQuota struct {
+	Household  string // Key of the postal address
+	CampaignID string // Campaign the limit belongs to
+	Limit      int    // Prizes the household may win
+}
+
+// FreeSlot returns the lowest slot of the quota not taken yet, 0 when the household reached its limit
+//
+// Parameters:
+// - taken: []int The slots held by the tickets of the household
+//
+// Returns:
+// - int: The free slot, from 1 to Limit
+func (quota *HouseholdQuota) FreeSlot(taken []int) int {
+	used := make(map[int]bool, len(taken))
+	for _, slot := range taken {
+		used[slot] = true
+	}
+
+	for slot := 1; slot <= quota.Limit; slot++ {
+		if !used[slot] {
+			return slot
+		}
+	}
+
+	return 0
+}
+
+// Apply copies the provided rules of the DTO, country codes are upper-cased
+//
+// Parameters:
+// - obj: *transfert.Eligibility The rules to apply
+//
+// Returns:
+// - errors.ErrorInterface: ErrEligibilityInvalid when a rule is out of range
+func (eligibility *Eligibility) Apply(obj *transfert.Eligibility) errors.ErrorInterface {
+	if obj.MinimumAge != nil {
+		eligibility.MinimumAge = *obj.MinimumAge
+	}
+
+	if obj.HouseholdLimit != nil {
+		eligibility.HouseholdLimit = *obj.HouseholdLimit
+	}
+
+	if obj.Countries != nil {
+		eligibility.Countries = make([]string, 0, len(obj.Countries))
+		for _, country := range obj.Countries {
+			eligibility.Countries = append(eligibility.Countries, strings.ToUpper(strings.TrimSpace(country)))
+		}
+	}
+
+	if obj.HouseholdPrizes != nil {
+		eligibility.HouseholdPrizes = obj.HouseholdPrizes
+	}
+
+	return eligibility.Validate()
+}
+
+// Validate checks the ranges of the rules and the format of the country codes
+func (eligibility *Eligibility) Validate() errors.ErrorInterface {
+	if eligibility.MinimumAge < 0 || eligibility.MinimumAge > 120 || eligibility.HouseholdLimit < 0 {
+		return errors_domain_game.ErrEligibilityInvalid
+	}
+
+	for _, country := range eligibility.Countries {
+		if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+			return errors_domain_game.ErrEligibilityInvalid
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty reports whether no rule is enforced
+func (eligibility *Eligibility) IsEmpty() bool {
+	return eligibility.MinimumAge == 0 && len(eligibility.Countries) == 0 && eligibility.HouseholdLimit == 0
+}
+
+// Covers reports whether the household limit applies to a prize
+func (eligibility *Eligibility) Covers(prizeID *string) bool {
+	if eligibility.HouseholdLimit == 0 || prizeID == nil {
+		return false
+	}
+
+	if len(eligibility.HouseholdPrizes) == 0 {
+		return true
+	}
+
+	for _, id := range eligibility.HouseholdPrizes {
+		if id == *prizeID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check applies the age and residence rules to a claimant, the household limit needs the claims and is checked when the ticket is saved
+//
+// Parameters:
+// - claimant: *Claimant The profile of the client
+// - at: time.Time The instant of the claim
+//
+// Returns:
+// - errors.ErrorInterface: ErrEligibilityProfileIncomplete when a rule lacks the data it needs,
+// ErrEligibilityUnderage or ErrEligibilityResidence when the client is excluded
+func (eligibility *Eligibility) Check(claimant *Claimant, at time.Time) errors.ErrorInterface {
+	if eligibility.MinimumAge > 0 {
+		age, ok := claimant.Age(at)
+		if !ok {
+			return errors_domain_game.ErrEligibilityProfileIncomplete
+		}
+
+		if age < eligibility.MinimumAge {
+			return errors_domain_game.ErrEligibilityUnderage
+		}
+	}
+
+	if len(eligibility.Countries) > 0 {
+		if claimant.Country == nil {
+			return errors_domain_game.ErrEligibilityProfileIncomplete
+		}
+
+		allowed := false
+		for _, country := range eligibility.Countries {
+			allowed = allowed || strings.EqualFold(country, *claimant.Country)
+		}
+
+		if !allowed {
+			return errors_domain_game.ErrEligibilityResidence
+		}
+	}
+
+	return nil
+}
+
+// Age returns the age in completed years of the claimant at the given instant, false when the birth date is unknown
+func (claimant *Claimant) Age(at time.Time) (int, bool) {
+	if claimant.BirthDate == nil {
+		return 0, false
+	}
+
+	birth, err := time.Parse(BirthDateLayout, *claimant.BirthDate)
+	if err != nil {
+		return 0, false
+	}
+
+	age := at.Year() - birth.Year()
+	if at.Month() < birth.Month() || (at.Month() == birth.Month() && at.Day() < birth.Day()) {
+		age--
+	}
+
+	return age, true
+}
@@ -0,0 +1,103 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEligibility_Apply(t *testing.T) {
+	t.Run("Should apply the provided rules", func(t *testing.T) {
+		campaign := &entities.Campaign{}
+		err := campaign.Apply(&transfert.Campaign{Eligibility: &transfert.Eligibility{
+			MinimumAge:     aws.Int(18),
+			Countries:      []string{"fr", " be "},
+			HouseholdLimit: aws.Int(1),
+		}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 18, campaign.Eligibility.MinimumAge)
+		assert.Equal(t, []string{"FR", "BE"}, campaign.Eligibility.Countries)
+		assert.Equal(t, 1, campaign.Eligibility.HouseholdLimit)
+		assert.False(t, campaign.Eligibility.IsEmpty())
+
+		// Les règles absentes du DTO sont conservées
+		assert.Nil(t, campaign.Apply(&transfert.Campaign{Eligibility: &transfert.Eligibility{MinimumAge: aws.Int(0)}}))
+		assert.Equal(t, 0, campaign.Eligibility.MinimumAge)
+		assert.Equal(t, []string{"FR", "BE"}, campaign.Eligibility.Countries)
+	})
+
+	t.Run("Should reject invalid rules", func(t *testing.T) {
+		rules := []*transfert.Eligibility{
+			{MinimumAge: aws.Int(-1)},
+			{MinimumAge: aws.Int(200)},
+			{HouseholdLimit: aws.Int(-2)},
+			{Countries: []string{"France"}},
+		}
+
+		for _, rule := range rules {
+			campaign := &entities.Campaign{}
+			assert.Equal(t, errors_domain_game.ErrEligibilityInvalid, campaign.Apply(&transfert.Campaign{Eligibility: rule}))
+		}
+	})
+}
+
+func TestEligibility_Covers(t *testing.T) {
+	rules := &entities.Eligibility{}
+	assert.True(t, rules.IsEmpty())
+	assert.False(t, rules.Covers(aws.String("prize-1")))
+
+	rules.HouseholdLimit = 1
+	assert.True(t, rules.Covers(aws.String("prize-1")))
+	assert.False(t, rules.Covers(nil))
+
+	rules.HouseholdPrizes = []string{"prize-1"}
+	assert.True(t, rules.Covers(aws.String("prize-1")))
+	assert.False(t, rules.Covers(aws.String("prize-2")))
+}
+
+func TestEligibility_Check(t *testing.T) {
+	now := time.Date(2024, 10, 15, 12, 0, 0, 0, time.UTC)
+	rules := &entities.Eligibility{MinimumAge: 18, Countries: []string{"FR"}}
+
+	// Le client a 18 ans le jour même du retrait
+	assert.Nil(t, rules.Check(&entities.Claimant{BirthDate: aws.String("2006-10-15"), Country: aws.String("fr")}, now))
+	assert.Equal(t, errors_domain_game.ErrEligibilityUnderage, rules.Check(&entities.Claimant{BirthDate: aws.String("2006-10-16"), Country: aws.String("FR")}, now))
+	assert.Equal(t, errors_domain_game.ErrEligibilityResidence, rules.Check(&entities.Claimant{BirthDate: aws.String("1990-01-01"), Country: aws.String("BE")}, now))
+	assert.Equal(t, errors_domain_game.ErrEligibilityProfileIncomplete, rules.Check(&entities.Claimant{Country: aws.String("FR")}, now))
+	assert.Equal(t, errors_domain_game.ErrEligibilityProfileIncomplete, rules.Check(&entities.Claimant{BirthDate: aws.String("1990-01-01")}, now))
+
+	// Sans règle, un profil vide est accepté
+	assert.Nil(t, (&entities.Eligibility{}).Check(&entities.Claimant{}, now))
+}
+
+func TestClaimant_Age(t *testing.T) {
+	now := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	age, ok := (&entities.Claimant{BirthDate: aws.String("2000-02-29")}).Age(now)
+	assert.True(t, ok)
+	assert.Equal(t, 24, age)
+
+	age, ok = (&entities.Claimant{BirthDate: aws.String("2000-03-02")}).Age(now)
+	assert.True(t, ok)
+	assert.Equal(t, 23, age)
+
+	_, ok = (&entities.Claimant{BirthDate: aws.String("29/02/2000")}).Age(now)
+	assert.False(t, ok)
+}
+
+func TestHouseholdQuota_FreeSlot(t *testing.T) {
+	quota := &entities.HouseholdQuota{Household: "household-1", CampaignID: "campaign-1", Limit: 3}
+
+	assert.Equal(t, 1, quota.FreeSlot(nil))
+	assert.Equal(t, 3, quota.FreeSlot([]int{1, 2}))
+
+	// Un lot libéré rend sa place, même au milieu
+	assert.Equal(t, 2, quota.FreeSlot([]int{3, 1}))
+	assert.Equal(t, 0, quota.FreeSlot([]int{1, 2, 3}))
+}
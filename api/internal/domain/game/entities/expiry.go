@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/config"
+	"gorm.io/gorm"
+)
+
+// ExpiryBatch is the default number of tickets expired per transaction
+const ExpiryBatch = 500
+
+// ExpiryLine is the forfeit of one prize of a campaign
+type ExpiryLine struct {
+	PrizeID     string  `json:"prize_id"`
+	Value       float64 `json:"value"`       // Retail value of the prize, in euros
+	Unclaimed   int     `json:"unclaimed"`   // Tickets never claimed before the end of the campaign
+	Uncollected int     `json:"uncollected"` // Prizes claimed but never collected before the claim deadline
+}
+
+// TicketExpiry is an append-only record of the tickets of a campaign forfeited at its deadlines,
+// the legal report of the prizes never awarded or never collected
+type TicketExpiry struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	CampaignID   *string `gorm:"type:varchar(36);index" json:"campaign_id"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"` // Credential who expired the tickets, nil from the command line
+
+	// Additional fields
+	Unclaimed        int           `json:"unclaimed"`
+	Uncollected      int           `json:"uncollected"`
+	UnclaimedValue   float64       `json:"unclaimed_value"`   // Value of the prizes never awarded, in euros
+	UncollectedValue float64       `json:"uncollected_value"` // Value of the prizes won but forfeited, in euros
+	Lines            []*ExpiryLine `gorm:"serializer:json" json:"lines"`
+}
+
+// ExpiryGrace reads project.expiry.grace, the delay after a deadline before its tickets expire
+func ExpiryGrace() time.Duration {
+	return time.Duration(max(config.GetInt("project.expiry.grace", 0), 0)) * time.Minute
+}
+
+// ExpiryBatchSize reads project.expiry.batch, the number of tickets expired per transaction
+func ExpiryBatchSize() int {
+	if batch := config.GetInt("project.expiry.batch", ExpiryBatch); batch > 0 {
+		return batch
+	}
+
+	return ExpiryBatch
+}
+
+// NewTicketExpiry sums up the tickets of a campaign expired per prize, and the value of the forfeited prizes
+//
+// Parameters:
+// - campaign: *Campaign The campaign of the tickets
+// - unclaimed: map[string]int The tickets never claimed, per prize ID
+// - uncollected: map[string]int The prizes never collected, per prize ID
+// - prizes: []*Prize The catalogue, giving the value of each prize
+//
+// Returns:
+// - *TicketExpiry: The summary, one line per prize ordered by prize ID
+func NewTicketExpiry(campaign *Campaign, unclaimed, uncollected map[string]int, prizes []*Prize) *TicketExpiry {
+	values := map[string]float64{}
+	for _, prize := range prizes {
+		if prize.Value != nil {
+			values[prize.ID] = *prize.Value
+		}
+	}
+
+	lines := map[string]*ExpiryLine{}
+	line := func(prizeID string) *ExpiryLine {
+		if _, ok := lines[prizeID]; !ok {
+			lines[prizeID] = &ExpiryLine{PrizeID: prizeID, Value: values[prizeID]}
+		}
+
+		return lines[prizeID]
+	}
+
+	for prizeID, count := range unclaimed {
+		line(prizeID).Unclaimed += count
+	}
+
+	for prizeID, count := range uncollected {
+		line(prizeID).Uncollected += count
+	}
+
+	expiry := &TicketExpiry{
+		CampaignID: &campaign.ID,
+		Lines:      []*ExpiryLine{},
+	}
+
+	for _, line := range lines {
+		expiry.Unclaimed += line.Unclaimed
+		expiry.Uncollected += line.Uncollected
+		expiry.UnclaimedValue += float64(line.Unclaimed) * line.Value
+		expiry.UncollectedValue += float64(line.Uncollected) * line.Value
+		expiry.Lines = append(expiry.Lines, line)
+	}
+
+	expiry.UnclaimedValue = math.Round(expiry.UnclaimedValue*100) / 100
+	expiry.UncollectedValue = math.Round(expiry.UncollectedValue*100) / 100
+
+	sort.Slice(expiry.Lines, func(i, j int) bool {
+		return expiry.Lines[i].PrizeID < expiry.Lines[j].PrizeID
+	})
+
+	return expiry
+}
+
+// IsEmpty reports whether no ticket expired
+func (expiry *TicketExpiry) IsEmpty() bool {
+	return expiry.Unclaimed == 0 && expiry.Uncollected == 0
+}
+
+func (expiry *TicketExpiry) IsPublic() bool {
+	return false
+}
+
+func (expiry *TicketExpiry) GetOwnerID() string {
+	return ""
+}
+
+func (expiry *TicketExpiry) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	expiry.ID = id.String()
+
+	return nil
+}
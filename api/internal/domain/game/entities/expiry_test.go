@@ -0,0 +1,57 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketExpiry(t *testing.T) {
+	campaign := &entities.Campaign{ID: "campaign-1"}
+	prizes := []*entities.Prize{
+		{ID: "prize-a", Value: aws.Float64(8.9)},
+		{ID: "prize-b", Value: aws.Float64(39)},
+		{ID: "prize-c"},
+	}
+
+	expiry := entities.NewTicketExpiry(campaign,
+		map[string]int{"prize-a": 3, "prize-c": 1},
+		map[string]int{"prize-a": 1, "prize-b": 2},
+		prizes,
+	)
+
+	assert.Equal(t, "campaign-1", *expiry.CampaignID)
+	assert.Equal(t, 4, expiry.Unclaimed)
+	assert.Equal(t, 3, expiry.Uncollected)
+	assert.Equal(t, 26.7, expiry.UnclaimedValue)
+	assert.Equal(t, 86.9, expiry.UncollectedValue)
+	assert.False(t, expiry.IsEmpty())
+
+	// Une ligne par lot, triée par identifiant
+	if assert.Len(t, expiry.Lines, 3) {
+		assert.Equal(t, &entities.ExpiryLine{PrizeID: "prize-a", Value: 8.9, Unclaimed: 3, Uncollected: 1}, expiry.Lines[0])
+		assert.Equal(t, &entities.ExpiryLine{PrizeID: "prize-b", Value: 39, Uncollected: 2}, expiry.Lines[1])
+		assert.Equal(t, &entities.ExpiryLine{PrizeID: "prize-c", Unclaimed: 1}, expiry.Lines[2])
+	}
+
+	empty := entities.NewTicketExpiry(campaign, map[string]int{}, nil, prizes)
+	assert.True(t, empty.IsEmpty())
+	assert.Empty(t, empty.Lines)
+}
+
+func TestExpiryPolicy(t *testing.T) {
+	// Sans configuration, les tickets expirent dès la date limite, par lots de la taille par défaut
+	assert.Equal(t, time.Duration(0), entities.ExpiryGrace())
+	assert.Equal(t, entities.ExpiryBatch, entities.ExpiryBatchSize())
+}
+
+func TestTicketExpiry_BeforeCreate(t *testing.T) {
+	expiry := &entities.TicketExpiry{}
+	assert.Nil(t, expiry.BeforeCreate(nil))
+	assert.NotEmpty(t, expiry.ID)
+	assert.False(t, expiry.IsPublic())
+	assert.Equal(t, "", expiry.GetOwnerID())
+}
@@ -0,0 +1,62 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+// TicketHistory is an append-only record of a ticket status change
+// Following the owners across the entries gives the ownership history of the ticket
+type TicketHistory struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	TicketID     *string `gorm:"type:varchar(36);index" json:"ticket_id"`
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"` // Credential who triggered the change
+	StoreID      *string `gorm:"type:varchar(36);index" json:"store_id"`      // Store where the change happened, nil online
+	CaisseID     *string `gorm:"type:varchar(36);index" json:"caisse_id"`     // Caisse where the change happened, nil online
+	OwnerID      *string `gorm:"type:varchar(36);index" json:"owner_id"`      // Credential holding the ticket after the change, nil while unclaimed
+
+	// Additional fields
+	PreviousStatus TicketStatus `gorm:"type:varchar(16)" json:"previous_status"`
+	Status         TicketStatus `gorm:"type:varchar(16);index" json:"status"`
+}
+
+func CreateTicketHistory(obj *transfert.TicketHistory) *TicketHistory {
+	h := &TicketHistory{
+		TicketID:     obj.TicketID,
+		CredentialID: obj.CredentialID,
+		StoreID:      obj.StoreID,
+		CaisseID:     obj.CaisseID,
+		OwnerID:      obj.OwnerID,
+	}
+
+	if obj.ID != nil {
+		h.ID = *obj.ID
+	}
+
+	if obj.PreviousStatus != nil {
+		h.PreviousStatus = TicketStatus(*obj.PreviousStatus)
+	}
+
+	if obj.Status != nil {
+		h.Status = TicketStatus(*obj.Status)
+	}
+
+	return h
+}
+
+func (history *TicketHistory) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	history.ID = id.String()
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateTicketHistory(t *testing.T) {
+	input := &transfert.TicketHistory{
+		ID:             aws.String("history-id"),
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("credential-id"),
+		PreviousStatus: aws.String("claimed"),
+		Status:         aws.String("redeemed"),
+		StoreID:        aws.String("store-id"),
+		CaisseID:       aws.String("caisse-id"),
+		OwnerID:        aws.String("owner-id"),
+	}
+
+	history := entities.CreateTicketHistory(input)
+
+	assert.Equal(t, "history-id", history.ID)
+	assert.Equal(t, input.TicketID, history.TicketID)
+	assert.Equal(t, input.CredentialID, history.CredentialID)
+	assert.Equal(t, entities.TicketClaimed, history.PreviousStatus)
+	assert.Equal(t, entities.TicketRedeemed, history.Status)
+	assert.Equal(t, input.StoreID, history.StoreID)
+	assert.Equal(t, input.CaisseID, history.CaisseID)
+	assert.Equal(t, input.OwnerID, history.OwnerID)
+}
+
+func TestTicketHistory_BeforeCreate(t *testing.T) {
+	history := &entities.TicketHistory{}
+	err := history.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, history.ID)
+}
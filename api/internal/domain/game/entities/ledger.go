@@ -0,0 +1,248 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// LedgerGenesis is the previous hash of the first entry of the ledger
+const LedgerGenesis = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// LedgerReportMax is the number of altered tickets listed by a verification report
+const LedgerReportMax = 100
+
+type LedgerEvent string
+
+const (
+	LedgerCreated  LedgerEvent = "created"  // Ticket generated
+	LedgerChanged  LedgerEvent = "changed"  // Status or owner of the ticket changed, mirrors a history entry
+	LedgerRecorded LedgerEvent = "recorded" // Ticket found without entry, recorded as it was stored
+)
+
+// LedgerHeader names the columns of the ledger export, in the order of LedgerEntry.Record
+var LedgerHeader = []string{
+	"sequence", "created_at", "event", "ticket_id", "previous_status", "status",
+	"prize_id", "campaign_id", "owner_id", "credential_id", "digest", "previous_hash", "hash",
+}
+
+// LedgerEntry is an append-only link of the hash chain recording the creation and every change of the tickets
+// Each entry hashes its content with the hash of the previous one, altering or removing an entry breaks every following link
+type LedgerEntry struct {
+	Sequence  int64     `gorm:"primaryKey;autoIncrement:false" json:"sequence"` // Position in the chain, from 1, unique so concurrent appends cannot fork it
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	TicketID     string  `gorm:"type:varchar(36);index" json:"ticket_id"`
+	PrizeID      *string `gorm:"type:varchar(36)" json:"prize_id"`      // Set on creation only
+	CampaignID   *string `gorm:"type:varchar(36)" json:"campaign_id"`   // Set on creation only
+	OwnerID      *string `gorm:"type:varchar(36)" json:"owner_id"`      // Credential holding the ticket after the event
+	CredentialID *string `gorm:"type:varchar(36)" json:"credential_id"` // Credential who triggered the event
+
+	// Additional fields
+	Event          LedgerEvent  `gorm:"type:varchar(16)" json:"event"`
+	PreviousStatus TicketStatus `gorm:"type:varchar(16)" json:"previous_status"`
+	Status         TicketStatus `gorm:"type:varchar(16)" json:"status"`
+	Digest         string       `gorm:"type:varchar(64)" json:"digest"` // SHA-256 of the ticket code, set on creation only, the code itself stays secret
+	PreviousHash   string       `gorm:"type:varchar(64)" json:"previous_hash"`
+	Hash           string       `gorm:"type:varchar(64);uniqueIndex" json:"hash"`
+}
+
+// LedgerState is the state of a ticket the ledger vouches for
+type LedgerState struct {
+	Status  TicketStatus
+	PrizeID string
+	OwnerID string
+	Digest  string
+}
+
+// LedgerReport is the result of the verification of the ledger
+type LedgerReport struct {
+	Entries  int      `json:"entries"`
+	Tickets  int      `json:"tickets"`   // Tickets compared with their ledger
+	Head     string   `json:"head"`      // Hash of the last entry, noted by the bailiff to detect a later rewrite of the whole chain
+	BrokenAt *int64   `json:"broken_at"` // Sequence of the first entry whose link or hash does not match, nil when the chain holds
+	Altered  int      `json:"altered"`   // Tickets whose stored state differs from their ledger, missing from the ledger or from the tickets
+	Samples  []string `json:"samples"`   // IDs of the first altered tickets, up to LedgerReportMax
+	Valid    bool     `json:"valid"`
+}
+
+// TicketDigest fingerprints a ticket code for the ledger
+func TicketDigest(ticket *Ticket) string {
+	sum := sha256.Sum256([]byte(ticket.Token.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewTicketLedgerEntry records a ticket as it is stored
+//
+// Parameters:
+// - ticket: *Ticket The ticket
+// - event: LedgerEvent LedgerCreated for a new ticket, LedgerRecorded for a ticket stored before the ledger
+//
+// Returns:
+// - *LedgerEntry: The entry, still to seal
+func NewTicketLedgerEntry(ticket *Ticket, event LedgerEvent) *LedgerEntry {
+	status := ticket.Status
+	if status == "" {
+		status = TicketGenerated
+	}
+
+	return &LedgerEntry{
+		Event:      event,
+		TicketID:   ticket.ID,
+		PrizeID:    ticket.PrizeID,
+		CampaignID: ticket.CampaignID,
+		OwnerID:    ticket.CredentialID,
+		Status:     status,
+		Digest:     TicketDigest(ticket),
+	}
+}
+
+// NewHistoryLedgerEntry records a status or owner change of a ticket
+//
+// Parameters:
+// - history: *TicketHistory The history entry of the change
+//
+// Returns:
+// - *LedgerEntry: The entry, still to seal
+func NewHistoryLedgerEntry(history *TicketHistory) *LedgerEntry {
+	entry := &LedgerEntry{
+		Event:          LedgerChanged,
+		OwnerID:        history.OwnerID,
+		CredentialID:   history.CredentialID,
+		PreviousStatus: history.PreviousStatus,
+		Status:         history.Status,
+	}
+
+	if history.TicketID != nil {
+		entry.TicketID = *history.TicketID
+	}
+
+	return entry
+}
+
+// Seal chains the entry after the previous one and computes its hash
+//
+// Parameters:
+// - previous: *LedgerEntry The last entry of the chain, nil for the first one
+// - at: time.Time The time of the entry
+func (entry *LedgerEntry) Seal(previous *LedgerEntry, at time.Time) {
+	entry.Sequence, entry.PreviousHash = 1, LedgerGenesis
+	if previous != nil {
+		entry.Sequence, entry.PreviousHash = previous.Sequence+1, previous.Hash
+	}
+
+	// Databases keep microseconds at best, the hash must survive the round trip
+	entry.CreatedAt = at.UTC().Truncate(time.Microsecond)
+	entry.Hash = entry.ComputeHash()
+}
+
+// ComputeHash hashes the content of the entry with the hash of the previous one
+//
+// Returns:
+// - string: The hex encoded SHA-256 of the fields, one per line in the order of LedgerHeader
+func (entry *LedgerEntry) ComputeHash() string {
+	record := entry.Record()
+
+	h := sha256.New()
+	for _, field := range record[:len(record)-1] {
+		h.Write([]byte(field))
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Follows reports whether the entry is correctly chained after the previous one and its hash matches its content
+func (entry *LedgerEntry) Follows(previous *LedgerEntry) bool {
+	sequence, hash := int64(1), LedgerGenesis
+	if previous != nil {
+		sequence, hash = previous.Sequence+1, previous.Hash
+	}
+
+	return entry.Sequence == sequence && entry.PreviousHash == hash && entry.Hash == entry.ComputeHash()
+}
+
+// Record formats the entry as a row of the ledger export, in the order of LedgerHeader
+func (entry *LedgerEntry) Record() []string {
+	return []string{
+		strconv.FormatInt(entry.Sequence, 10),
+		entry.CreatedAt.UTC().Format(time.RFC3339Nano),
+		string(entry.Event),
+		entry.TicketID,
+		entry.PreviousStatus.String(),
+		entry.Status.String(),
+		ledgerValue(entry.PrizeID),
+		ledgerValue(entry.CampaignID),
+		ledgerValue(entry.OwnerID),
+		ledgerValue(entry.CredentialID),
+		entry.Digest,
+		entry.PreviousHash,
+		entry.Hash,
+	}
+}
+
+// Apply replays the entry on the state of its ticket
+//
+// Parameters:
+// - state: *LedgerState The state before the entry, nil before the creation of the ticket
+//
+// Returns:
+// - *LedgerState: The state after the entry
+func (entry *LedgerEntry) Apply(state *LedgerState) *LedgerState {
+	if entry.Event != LedgerChanged || state == nil {
+		return &LedgerState{
+			Status:  entry.Status,
+			PrizeID: ledgerValue(entry.PrizeID),
+			OwnerID: ledgerValue(entry.OwnerID),
+			Digest:  entry.Digest,
+		}
+	}
+
+	return &LedgerState{
+		Status:  entry.Status,
+		PrizeID: state.PrizeID,
+		OwnerID: ledgerValue(entry.OwnerID),
+		Digest:  state.Digest,
+	}
+}
+
+// NewLedgerState reads the state of a stored ticket, to compare with its ledger
+func NewLedgerState(ticket *Ticket) *LedgerState {
+	status := ticket.Status
+	if status == "" {
+		status = TicketGenerated
+	}
+
+	return &LedgerState{
+		Status:  status,
+		PrizeID: ledgerValue(ticket.PrizeID),
+		OwnerID: ledgerValue(ticket.CredentialID),
+		Digest:  TicketDigest(ticket),
+	}
+}
+
+// Alter counts a ticket whose stored state differs from its ledger, keeping its ID among the first ones
+func (report *LedgerReport) Alter(ticketID string) {
+	report.Altered++
+	if len(report.Samples) < LedgerReportMax {
+		report.Samples = append(report.Samples, ticketID)
+	}
+}
+
+func (entry *LedgerEntry) IsPublic() bool {
+	return false
+}
+
+func (entry *LedgerEntry) GetOwnerID() string {
+	return ""
+}
+
+func ledgerValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
@@ -0,0 +1,80 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerEntry_Chain(t *testing.T) {
+	ticket := &entities.Ticket{
+		ID:         "ticket-1",
+		Token:      token.NewLuhn("123456789015"),
+		PrizeID:    aws.String("prize-1"),
+		CampaignID: aws.String("campaign-1"),
+	}
+
+	created := entities.NewTicketLedgerEntry(ticket, entities.LedgerCreated)
+	created.Seal(nil, time.Now())
+
+	assert.Equal(t, int64(1), created.Sequence)
+	assert.Equal(t, entities.LedgerGenesis, created.PreviousHash)
+	assert.Equal(t, entities.TicketGenerated, created.Status)
+	assert.Len(t, created.Hash, 64)
+	assert.NotContains(t, created.Record(), "123456789015")
+	assert.True(t, created.Follows(nil))
+
+	claimed := entities.NewHistoryLedgerEntry(&entities.TicketHistory{
+		TicketID:       aws.String("ticket-1"),
+		CredentialID:   aws.String("client-1"),
+		OwnerID:        aws.String("client-1"),
+		PreviousStatus: entities.TicketGenerated,
+		Status:         entities.TicketClaimed,
+	})
+	claimed.Seal(created, time.Now())
+
+	assert.Equal(t, int64(2), claimed.Sequence)
+	assert.Equal(t, created.Hash, claimed.PreviousHash)
+	assert.True(t, claimed.Follows(created))
+
+	t.Run("Should detect an altered entry", func(t *testing.T) {
+		altered := *claimed
+		altered.OwnerID = aws.String("client-2")
+		assert.False(t, altered.Follows(created))
+	})
+
+	t.Run("Should detect a removed entry", func(t *testing.T) {
+		assert.False(t, claimed.Follows(nil))
+	})
+
+	t.Run("Should survive the round trip of the database", func(t *testing.T) {
+		stored := *claimed
+		stored.CreatedAt = stored.CreatedAt.In(time.FixedZone("Paris", 7200))
+		assert.True(t, stored.Follows(created))
+	})
+
+	t.Run("Should replay the state of the ticket", func(t *testing.T) {
+		state := claimed.Apply(created.Apply(nil))
+
+		ticket.Status = entities.TicketClaimed
+		ticket.CredentialID = aws.String("client-1")
+		assert.Equal(t, entities.NewLedgerState(ticket), state)
+
+		ticket.PrizeID = aws.String("prize-2")
+		assert.NotEqual(t, entities.NewLedgerState(ticket), state)
+	})
+}
+
+func TestLedgerReport_Alter(t *testing.T) {
+	report := &entities.LedgerReport{}
+	for i := 0; i < entities.LedgerReportMax+1; i++ {
+		report.Alter("ticket")
+	}
+
+	assert.Equal(t, entities.LedgerReportMax+1, report.Altered)
+	assert.Len(t, report.Samples, entities.LedgerReportMax)
+}
@@ -0,0 +1,67 @@
+package entities
+
+// TicketPage is one page of a ticket listing, with the counts needed to browse the others
+type TicketPage struct {
+	Tickets []*Ticket `json:"tickets"`
+	Total   int       `json:"total"` // Tickets matching the filters, all pages included
+	Page    int       `json:"page"`
+	Limit   int       `json:"limit"`
+	Pages   int       `json:"pages"`
+}
+
+// NewTicketPage wraps a page of tickets and computes the number of pages
+//
+// Parameters:
+// - tickets: []*Ticket The tickets of the page
+// - total: int The number of tickets matching the filters
+// - page: int The page number, starting at 1
+// - limit: int The maximum number of tickets per page
+//
+// Returns:
+// - *TicketPage: The page
+func NewTicketPage(tickets []*Ticket, total, page, limit int) *TicketPage {
+	if tickets == nil {
+		tickets = []*Ticket{}
+	}
+
+	return &TicketPage{
+		Tickets: tickets,
+		Total:   total,
+		Page:    page,
+		Limit:   limit,
+		Pages:   (total + limit - 1) / limit,
+	}
+}
+
+// TransferPage is one page of a transfer listing, with the counts needed to browse the others
+type TransferPage struct {
+	Transfers []*TicketTransfer `json:"transfers"`
+	Total     int               `json:"total"` // Transfers matching the filters, all pages included
+	Page      int               `json:"page"`
+	Limit     int               `json:"limit"`
+	Pages     int               `json:"pages"`
+}
+
+// NewTransferPage wraps a page of transfers and computes the number of pages
+//
+// Parameters:
+// - transfers: []*TicketTransfer The transfers of the page
+// - total: int The number of transfers matching the filters
+// - page: int The page number, starting at 1
+// - limit: int The maximum number of transfers per page
+//
+// Returns:
+// - *TransferPage: The page
+func NewTransferPage(transfers []*TicketTransfer, total, page, limit int) *TransferPage {
+	if transfers == nil {
+		transfers = []*TicketTransfer{}
+	}
+
+	return &TransferPage{
+		Transfers: transfers,
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+		Pages:     (total + limit - 1) / limit,
+	}
+}
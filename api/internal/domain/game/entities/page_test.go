@@ -0,0 +1,36 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketPage(t *testing.T) {
+	page := entities.NewTicketPage([]*entities.Ticket{{ID: "ticket-1"}}, 41, 3, 20)
+	assert.Len(t, page.Tickets, 1)
+	assert.Equal(t, 41, page.Total)
+	assert.Equal(t, 3, page.Page)
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, 3, page.Pages)
+
+	page = entities.NewTicketPage(nil, 0, 1, 20)
+	assert.NotNil(t, page.Tickets)
+	assert.Empty(t, page.Tickets)
+	assert.Equal(t, 0, page.Pages)
+}
+
+func TestNewTransferPage(t *testing.T) {
+	page := entities.NewTransferPage([]*entities.TicketTransfer{{ID: "transfer-1"}}, 21, 2, 20)
+	assert.Len(t, page.Transfers, 1)
+	assert.Equal(t, 21, page.Total)
+	assert.Equal(t, 2, page.Page)
+	assert.Equal(t, 20, page.Limit)
+	assert.Equal(t, 2, page.Pages)
+
+	page = entities.NewTransferPage(nil, 0, 1, 20)
+	assert.NotNil(t, page.Transfers)
+	assert.Empty(t, page.Transfers)
+	assert.Equal(t, 0, page.Pages)
+}
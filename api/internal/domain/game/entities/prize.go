@@ -0,0 +1,80 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"gorm.io/gorm"
+)
+
+type Prize struct {
+	// Gorm model
+	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time       `json:"-"`
+	UpdatedAt time.Time       `json:"-"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"-"`
+
+	// Additional fields
+	Label        *string  `gorm:"type:varchar(255);uniqueIndex" json:"label"`
+	Description  *string  `gorm:"type:text" json:"description"`
+	Value        *float64 `gorm:"type:decimal(10,2)" json:"value"` // Retail value in euros
+	Image        *string  `gorm:"type:varchar(255)" json:"image"`
+	Position     *int     `gorm:"index" json:"position"` // Display order, ascending
+	Active       *bool    `gorm:"type:boolean;index" json:"active"`
+	Distribution *int     `json:"distribution"` // Share of the tickets, in percent
+}
+
+type Prizes []*Prize
+
+func CreatePrize(obj *transfert.Prize) *Prize {
+	p := &Prize{
+		Label:        obj.Label,
+		Description:  obj.Description,
+		Value:        obj.Value,
+		Image:        obj.Image,
+		Position:     obj.Position,
+		Active:       obj.Active,
+		Distribution: obj.Distribution,
+	}
+
+	if obj.ID != nil {
+		p.ID = *obj.ID
+	}
+
+	return p
+}
+
+// IsActive reports whether the prize can still be won
+func (prize *Prize) IsActive() bool {
+	return prize.Active != nil && *prize.Active
+}
+
+func (prize *Prize) IsPublic() bool {
+	return prize.IsActive()
+}
+
+func (prize *Prize) GetOwnerID() string {
+	return ""
+}
+
+func (prize *Prize) BeforeUpdate(tx *gorm.DB) error {
+	prize.UpdatedAt = time.Now()
+	return nil
+}
+
+func (prize *Prize) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	prize.ID = id.String()
+
+	if prize.Active == nil {
+		active := true
+		prize.Active = &active
+	}
+
+	return nil
+}
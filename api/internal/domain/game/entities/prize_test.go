@@ -0,0 +1,58 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreatePrize(t *testing.T) {
+	input := &transfert.Prize{
+		ID:           aws.String("prize-id"),
+		Label:        aws.String("Infuseur à thé"),
+		Value:        aws.Float64(8.9),
+		Position:     aws.Int(1),
+		Distribution: aws.Int(60),
+	}
+
+	prize := entities.CreatePrize(input)
+
+	assert.Equal(t, "prize-id", prize.ID)
+	assert.Equal(t, input.Label, prize.Label)
+	assert.Equal(t, input.Value, prize.Value)
+	assert.Equal(t, input.Position, prize.Position)
+	assert.Equal(t, input.Distribution, prize.Distribution)
+	assert.Equal(t, "", prize.GetOwnerID())
+}
+
+func TestPrize_IsActive(t *testing.T) {
+	prize := &entities.Prize{}
+	assert.False(t, prize.IsActive())
+	assert.False(t, prize.IsPublic())
+
+	prize.Active = aws.Bool(true)
+	assert.True(t, prize.IsActive())
+	assert.True(t, prize.IsPublic())
+}
+
+func TestPrize_BeforeCreate(t *testing.T) {
+	prize := &entities.Prize{}
+	err := prize.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, prize.ID)
+	assert.True(t, prize.IsActive())
+
+	inactive := &entities.Prize{Active: aws.Bool(false)}
+	assert.Nil(t, inactive.BeforeCreate(nil))
+	assert.False(t, inactive.IsActive())
+}
+
+func TestPrize_BeforeUpdate(t *testing.T) {
+	prize := &entities.Prize{}
+	assert.Nil(t, prize.BeforeUpdate(nil))
+	assert.False(t, prize.UpdatedAt.IsZero())
+}
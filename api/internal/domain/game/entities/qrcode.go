@@ -0,0 +1,44 @@
+package entities
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+)
+
+// QRCode is a ticket token rendered as an image, it is computed on demand and never stored
+type QRCode struct {
+	Token   string `json:"token"`
+	Content string `json:"content"` // Encoded text, the bare token or the claim link
+	Format  string `json:"format"`
+	Size    int    `json:"size"`
+	Level   string `json:"level"`
+	Image   []byte `json:"-"`
+}
+
+// CreateQRCode reads a render request, missing options fall back on a medium PNG of qr.DefaultSize
+func CreateQRCode(obj *transfert.QRCode) *QRCode {
+	q := &QRCode{
+		Format: qr.PNG,
+		Size:   qr.DefaultSize,
+		Level:  qr.LevelMedium,
+	}
+
+	if obj.Token != nil {
+		q.Token = *obj.Token
+		q.Content = *obj.Token
+	}
+
+	if obj.Format != nil {
+		q.Format = *obj.Format
+	}
+
+	if obj.Size != nil {
+		q.Size = *obj.Size
+	}
+
+	if obj.Level != nil {
+		q.Level = *obj.Level
+	}
+
+	return q
+}
@@ -0,0 +1,33 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateQRCode(t *testing.T) {
+	code := entities.CreateQRCode(&transfert.QRCode{
+		Token:  aws.String("79927398713"),
+		Format: aws.String(qr.SVG),
+		Size:   aws.Int(512),
+		Level:  aws.String(qr.LevelHigh),
+	})
+
+	assert.Equal(t, "79927398713", code.Token)
+	assert.Equal(t, "79927398713", code.Content)
+	assert.Equal(t, qr.SVG, code.Format)
+	assert.Equal(t, 512, code.Size)
+	assert.Equal(t, qr.LevelHigh, code.Level)
+
+	// Sans options, un PNG de taille par défaut au niveau moyen
+	code = entities.CreateQRCode(&transfert.QRCode{Token: aws.String("79927398713")})
+	assert.Equal(t, qr.PNG, code.Format)
+	assert.Equal(t, qr.DefaultSize, code.Size)
+	assert.Equal(t, qr.LevelMedium, code.Level)
+	assert.Nil(t, code.Image)
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"gorm.io/gorm"
+)
+
+// ReceiptMinimumAmount is the amount, in euros, a purchase must exceed to earn a ticket
+const ReceiptMinimumAmount = 49.0
+
+// Receipt is a qualifying purchase recorded at a caisse, with the ticket handed over for it
+type Receipt struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relations
+	StoreID      *string `gorm:"type:varchar(36);uniqueIndex:idx_receipt_number" json:"store_id"` // Numbers are unique within a store
+	CaisseID     *string `gorm:"type:varchar(36);index" json:"caisse_id"`
+	TicketID     *string `gorm:"type:varchar(36);uniqueIndex" json:"ticket_id"` // One ticket per receipt
+	CredentialID *string `gorm:"type:varchar(36);index" json:"credential_id"`   // Employee who recorded the purchase
+	Ticket       *Ticket `gorm:"foreignKey:TicketID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"ticket,omitempty"`
+
+	// Additional fields
+	Number      string    `gorm:"type:varchar(64);uniqueIndex:idx_receipt_number" json:"number"`
+	Amount      float64   `json:"amount"`
+	PurchasedAt time.Time `json:"purchased_at"`
+}
+
+func CreateReceipt(obj *transfert.Receipt) *Receipt {
+	r := &Receipt{
+		StoreID:  obj.StoreID,
+		CaisseID: obj.CaisseID,
+	}
+
+	if obj.ID != nil {
+		r.ID = *obj.ID
+	}
+
+	if obj.Number != nil {
+		r.Number = *obj.Number
+	}
+
+	if obj.Amount != nil {
+		r.Amount = *obj.Amount
+	}
+
+	return r
+}
+
+// NewPurchaseDate reads the purchase date of a receipt, now when unset
+// Dates without offset are read in UTC, purchases cannot happen in the future
+//
+// Parameters:
+// - value: *string The purchase date, in one of the campaign date formats
+// - now: time.Time The current instant
+//
+// Returns:
+// - time.Time: The purchase date
+// - errors.ErrorInterface: ErrValueIsNotDate if the date cannot be read or is in the future
+func NewPurchaseDate(value *string, now time.Time) (time.Time, errors.ErrorInterface) {
+	if value == nil {
+		return now, nil
+	}
+
+	date, ok := parseCampaignTime(*value, time.UTC)
+	if !ok || date.After(now) {
+		return time.Time{}, errors.ErrValueIsNotDate
+	}
+
+	return *date, nil
+}
+
+// IsQualifying reports whether the purchase earns a ticket
+func (receipt *Receipt) IsQualifying() bool {
+	return receipt.Amount > ReceiptMinimumAmount
+}
+
+func (receipt *Receipt) IsPublic() bool {
+	return false
+}
+
+func (receipt *Receipt) GetOwnerID() string {
+	return ""
+}
+
+func (receipt *Receipt) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	receipt.ID = id.String()
+
+	return nil
+}
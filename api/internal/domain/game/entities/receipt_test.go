@@ -0,0 +1,65 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateReceipt(t *testing.T) {
+	input := &transfert.Receipt{
+		ID:       aws.String("receipt-id"),
+		StoreID:  aws.String("store-id"),
+		CaisseID: aws.String("caisse-id"),
+		Number:   aws.String("0042"),
+		Amount:   aws.Float64(52.3),
+	}
+
+	receipt := entities.CreateReceipt(input)
+
+	assert.Equal(t, "receipt-id", receipt.ID)
+	assert.Equal(t, input.StoreID, receipt.StoreID)
+	assert.Equal(t, input.CaisseID, receipt.CaisseID)
+	assert.Equal(t, "0042", receipt.Number)
+	assert.Equal(t, 52.3, receipt.Amount)
+	assert.True(t, receipt.IsQualifying())
+	assert.False(t, receipt.IsPublic())
+	assert.Equal(t, "", receipt.GetOwnerID())
+}
+
+func TestReceipt_IsQualifying(t *testing.T) {
+	assert.False(t, (&entities.Receipt{Amount: 12}).IsQualifying())
+	assert.False(t, (&entities.Receipt{Amount: entities.ReceiptMinimumAmount}).IsQualifying())
+	assert.True(t, (&entities.Receipt{Amount: 49.01}).IsQualifying())
+}
+
+func TestNewPurchaseDate(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	date, err := entities.NewPurchaseDate(nil, now)
+	assert.Nil(t, err)
+	assert.Equal(t, now, date)
+
+	date, err = entities.NewPurchaseDate(aws.String("2024-10-01 09:30"), now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 10, 1, 9, 30, 0, 0, time.UTC), date)
+
+	_, err = entities.NewPurchaseDate(aws.String("2024-10-02"), now)
+	assert.Equal(t, errors.ErrValueIsNotDate, err)
+
+	_, err = entities.NewPurchaseDate(aws.String("yesterday"), now)
+	assert.Equal(t, errors.ErrValueIsNotDate, err)
+}
+
+func TestReceipt_BeforeCreate(t *testing.T) {
+	receipt := &entities.Receipt{}
+	err := receipt.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, receipt.ID)
+}
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewStatus defines the decision taken on a held claim
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"  // Claim waiting in the review queue
+	ReviewApproved ReviewStatus = "approved" // Claim accepted, the ticket is claimed
+	ReviewRejected ReviewStatus = "rejected" // Claim refused, the ticket is released
+)
+
+var reviewDecisions = map[ReviewStatus]bool{
+	ReviewApproved: true,
+	ReviewRejected: true,
+}
+
+// NewReviewDecision converts a string into a decision an employee may take
+//
+// Parameters:
+// - v: *string The decision label
+//
+// Returns:
+// - ReviewStatus: The matching status
+// - bool: false if the label is nil or not a decision
+func NewReviewDecision(v *string) (ReviewStatus, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	status := ReviewStatus(*v)
+
+	return status, reviewDecisions[status]
+}
+
+func (s ReviewStatus) String() string {
+	return string(s)
+}
+
+// ClaimReview is a suspicious claim held in the review queue
+type ClaimReview struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Relations
+	TicketID     string  `gorm:"type:varchar(36);index" json:"ticket_id"`
+	CredentialID string  `gorm:"type:varchar(36);index" json:"credential_id"` // Client who claimed the ticket
+	ReviewerID   *string `gorm:"type:varchar(36)" json:"reviewer_id"`         // Employee who took the decision
+	Ticket       *Ticket `gorm:"foreignKey:TicketID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"ticket,omitempty"`
+
+	// Additional fields
+	PreviousStatus TicketStatus `gorm:"type:varchar(16)" json:"previous_status"` // Status the ticket returns to when the claim is rejected
+	Score          int          `json:"score"`
+	Signals        []string     `gorm:"serializer:json" json:"signals"`
+	Status         ReviewStatus `gorm:"type:varchar(16);index;default:pending" json:"status"`
+	ReviewedAt     *time.Time   `json:"reviewed_at"`
+}
+
+func (review *ClaimReview) IsPublic() bool {
+	return false
+}
+
+func (review *ClaimReview) GetOwnerID() string {
+	return review.CredentialID
+}
+
+func (review *ClaimReview) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	review.ID = id.String()
+
+	if review.Status == "" {
+		review.Status = ReviewPending
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReviewDecision(t *testing.T) {
+	status, ok := entities.NewReviewDecision(aws.String("approved"))
+	assert.True(t, ok)
+	assert.Equal(t, entities.ReviewApproved, status)
+
+	status, ok = entities.NewReviewDecision(aws.String("rejected"))
+	assert.True(t, ok)
+	assert.Equal(t, entities.ReviewRejected, status)
+
+	_, ok = entities.NewReviewDecision(aws.String("pending"))
+	assert.False(t, ok)
+
+	_, ok = entities.NewReviewDecision(nil)
+	assert.False(t, ok)
+}
+
+func TestClaimReview(t *testing.T) {
+	review := &entities.ClaimReview{CredentialID: "client-id"}
+
+	assert.False(t, review.IsPublic())
+	assert.Equal(t, "client-id", review.GetOwnerID())
+
+	assert.Nil(t, review.BeforeCreate(nil))
+	assert.NotEmpty(t, review.ID)
+	assert.Equal(t, entities.ReviewPending, review.Status)
+}
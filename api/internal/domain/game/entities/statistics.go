@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"time"
+
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// Period bounds a statistic, the end is excluded and a nil bound leaves the range open
+type Period struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// NewPeriod reads the bounds of a statistic
+// Dates without offset are read in UTC, a day alone as upper bound includes the whole day
+//
+// Parameters:
+// - from: *string The first instant included, optional
+// - to: *string The last instant or day included, optional
+//
+// Returns:
+// - *Period: The parsed period
+// - errors.ErrorInterface: ErrValueIsNotDate if a bound can't be read, ErrStatisticsInvalidPeriod if it ends before it starts
+func NewPeriod(from, to *string) (*Period, errors.ErrorInterface) {
+	period := &Period{}
+
+	if from != nil {
+		t, ok := parseCampaignTime(*from, time.UTC)
+		if !ok {
+			return nil, errors.ErrValueIsNotDate
+		}
+
+		period.From = t
+	}
+
+	if to != nil {
+		t, ok := parseCampaignTime(*to, time.UTC)
+		if !ok {
+			return nil, errors.ErrValueIsNotDate
+		}
+
+		end := t.Add(time.Nanosecond)
+		if len(*to) == len(time.DateOnly) {
+			end = t.AddDate(0, 0, 1)
+		}
+
+		period.To = &end
+	}
+
+	if period.From != nil && period.To != nil && !period.From.Before(*period.To) {
+		return nil, errors_domain_game.ErrStatisticsInvalidPeriod
+	}
+
+	return period, nil
+}
+
+// NewInterval reads the grouping interval of a statistic, a day by default
+//
+// Parameters:
+// - value: *string database.Day or database.Hour, optional
+//
+// Returns:
+// - string: The interval
+// - errors.ErrorInterface: ErrStatisticsInvalidInterval for any other value
+func NewInterval(value *string) (string, errors.ErrorInterface) {
+	if value == nil || *value == "" {
+		return database.Day, nil
+	}
+
+	switch *value {
+	case database.Day, database.Hour:
+		return *value, nil
+	}
+
+	return "", errors_domain_game.ErrStatisticsInvalidInterval
+}
+
+// PrizeStatistic counts the tickets of a prize claimed during a period and those still unclaimed at its end
+type PrizeStatistic struct {
+	PrizeID   *string `json:"prize_id"`
+	Label     *string `json:"label"`
+	Claimed   int     `json:"claimed"`
+	Unclaimed int     `json:"unclaimed"`
+}
+
+// PeriodStatistic counts the events of a day or an hour, formatted "2006-01-02" or "2006-01-02 15:00" in UTC
+type PeriodStatistic struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+// StoreStatistic counts the tickets claimed and redeemed at a caisse, both IDs are empty for online claims
+type StoreStatistic struct {
+	StoreID  *string `json:"store_id"`
+	CaisseID *string `json:"caisse_id"`
+	Claimed  int     `json:"claimed"`
+	Redeemed int     `json:"redeemed"`
+}
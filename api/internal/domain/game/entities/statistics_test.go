@@ -0,0 +1,67 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPeriod(t *testing.T) {
+	t.Run("Should leave both bounds open", func(t *testing.T) {
+		period, err := entities.NewPeriod(nil, nil)
+		assert.Nil(t, err)
+		assert.Nil(t, period.From)
+		assert.Nil(t, period.To)
+	})
+
+	t.Run("Should include the whole last day", func(t *testing.T) {
+		period, err := entities.NewPeriod(aws.String("2024-10-01"), aws.String("2024-10-31"))
+		assert.Nil(t, err)
+		assert.True(t, period.From.Equal(time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)))
+		assert.True(t, period.To.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("Should include the last instant", func(t *testing.T) {
+		period, err := entities.NewPeriod(nil, aws.String("2024-10-31T12:00:00+02:00"))
+		assert.Nil(t, err)
+		assert.Nil(t, period.From)
+		assert.True(t, period.To.After(time.Date(2024, 10, 31, 10, 0, 0, 0, time.UTC)))
+		assert.True(t, period.To.Before(time.Date(2024, 10, 31, 10, 0, 1, 0, time.UTC)))
+	})
+
+	t.Run("Should reject an unreadable bound", func(t *testing.T) {
+		period, err := entities.NewPeriod(aws.String("yesterday"), nil)
+		assert.Nil(t, period)
+		assert.Equal(t, errors.ErrValueIsNotDate, err)
+
+		period, err = entities.NewPeriod(nil, aws.String("31/10/2024"))
+		assert.Nil(t, period)
+		assert.Equal(t, errors.ErrValueIsNotDate, err)
+	})
+
+	t.Run("Should reject a period ending before it starts", func(t *testing.T) {
+		period, err := entities.NewPeriod(aws.String("2024-10-31"), aws.String("2024-10-01"))
+		assert.Nil(t, period)
+		assert.Equal(t, errors_domain_game.ErrStatisticsInvalidPeriod, err)
+	})
+}
+
+func TestNewInterval(t *testing.T) {
+	interval, err := entities.NewInterval(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, database.Day, interval)
+
+	interval, err = entities.NewInterval(aws.String("hour"))
+	assert.Nil(t, err)
+	assert.Equal(t, database.Hour, interval)
+
+	interval, err = entities.NewInterval(aws.String("week"))
+	assert.Empty(t, interval)
+	assert.Equal(t, errors_domain_game.ErrStatisticsInvalidInterval, err)
+}
@@ -4,11 +4,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/config"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"gorm.io/gorm"
 )
 
+const (
+	TicketCodeLength   = 12 // Length of the unsigned codes, as generated before signing existed
+	TicketSignedLength = 16 // Length of the signed codes, the size of the token column
+)
+
 type Ticket struct {
 	// Gorm model
 	ID        string          `gorm:"type:varchar(36);primaryKey;" json:"id"`
@@ -19,13 +25,52 @@ type Ticket struct {
 	// Additional fields
 	CredentialID *string    `gorm:"type:varchar(36);index" json:"credential_id"`
 	Token        token.Luhn `gorm:"type:varchar(16);uniqueIndex" json:"token"`
-	Prize        *string    `gorm:"type:varchar(36);index" json:"prize"`
+	PrizeID      *string    `gorm:"type:varchar(36);index" json:"prize_id"`
+	CampaignID   *string    `gorm:"type:varchar(36);index;uniqueIndex:idx_ticket_household_slot" json:"campaign_id"`
+
+	// Lifecycle fields
+	Status     TicketStatus `gorm:"type:varchar(16);index;default:generated" json:"status"`
+	ClaimedAt  *time.Time   `gorm:"index" json:"claimed_at"`
+	RedeemedAt *time.Time   `json:"redeemed_at"`
+
+	// Disputes
+	ReceiptPhoto *string `gorm:"type:varchar(255)" json:"receipt_photo"` // Key of the photo of the purchase receipt in the receipts bucket, uploaded by the client when claiming
+
+	// Relations
+	Prize *Prize `gorm:"foreignKey:PrizeID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"prize,omitempty"`
+
+	// Eligibility
+	Household     *string `gorm:"type:varchar(64);uniqueIndex:idx_ticket_household_slot" json:"-"` // Key of the postal address of the owner, set when a household limit applies
+	HouseholdSlot *int    `gorm:"uniqueIndex:idx_ticket_household_slot" json:"-"`                  // Rank of the prize within the household limit, unique per campaign and household
+
+	// Concurrency
+	StoredCredentialID *string `gorm:"-" json:"-"` // Owner as read from the database, a status change only applies while it still holds the ticket
+}
+
+// NewTicketSigner builds the ticket code signer from the configuration
+// With project.tickets.secret set, codes carry project.tickets.signature HMAC digits (4 by default)
+//
+// Returns:
+// - *token.Signer: The signer, producing plain Luhn codes when no secret is configured
+func NewTicketSigner() *token.Signer {
+	secret := config.GetString("project.tickets.secret", "")
+	if secret == "" {
+		return token.NewSigner("", TicketCodeLength, 0)
+	}
+
+	digits := config.GetInt("project.tickets.signature", 4)
+	if digits < 1 {
+		digits = 4
+	}
+
+	return token.NewSigner(secret, TicketSignedLength, digits)
 }
 
 func CreateTicket(obj *transfert.Ticket) *Ticket {
 	t := &Ticket{
 		CredentialID: obj.CredentialID,
-		Prize:        obj.Prize,
+		PrizeID:      obj.PrizeID,
+		CampaignID:   obj.CampaignID,
 		Token:        token.NewLuhnP(obj.Token),
 	}
 
@@ -33,6 +78,10 @@ func CreateTicket(obj *transfert.Ticket) *Ticket {
 		t.ID = *obj.ID
 	}
 
+	if obj.Status != nil {
+		t.Status = TicketStatus(*obj.Status)
+	}
+
 	return t
 }
 
@@ -48,6 +97,11 @@ func (ticket *Ticket) GetOwnerID() string {
 	return *ticket.CredentialID
 }
 
+func (ticket *Ticket) AfterFind(tx *gorm.DB) error {
+	ticket.StoredCredentialID = ticket.CredentialID
+	return nil
+}
+
 func (ticket *Ticket) BeforeUpdate(tx *gorm.DB) error {
 	ticket.UpdatedAt = time.Now()
 	return nil
@@ -61,5 +115,9 @@ func (ticket *Ticket) BeforeCreate(tx *gorm.DB) error {
 
 	ticket.ID = id.String()
 
+	if ticket.Status == "" {
+		ticket.Status = TicketGenerated
+	}
+
 	return nil
 }
@@ -0,0 +1,46 @@
+package entities
+
+// TicketStatus defines the lifecycle state of a ticket
+type TicketStatus string
+
+const (
+	TicketGenerated   TicketStatus = "generated"   // Ticket created by the generator, not yet handed out
+	TicketDistributed TicketStatus = "distributed" // Ticket given to a store to be handed out with a purchase
+	TicketReview      TicketStatus = "review"      // Suspicious claim held until an employee approves or rejects it
+	TicketClaimed     TicketStatus = "claimed"     // Ticket linked to a client account online
+	TicketRedeemed    TicketStatus = "redeemed"    // Prize handed over to the client
+	TicketCancelled   TicketStatus = "cancelled"   // Ticket withdrawn from the game
+	TicketExpired     TicketStatus = "expired"     // Ticket not claimed, or prize not collected, before the deadline of its campaign
+)
+
+var ticketStatuses = map[TicketStatus]bool{
+	TicketGenerated:   true,
+	TicketDistributed: true,
+	TicketReview:      true,
+	TicketClaimed:     true,
+	TicketRedeemed:    true,
+	TicketCancelled:   true,
+	TicketExpired:     true,
+}
+
+// NewTicketStatus converts a string into a known TicketStatus
+//
+// Parameters:
+// - v: *string The status label
+//
+// Returns:
+// - TicketStatus: The matching status
+// - bool: false if the label is nil or unknown
+func NewTicketStatus(v *string) (TicketStatus, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	status := TicketStatus(*v)
+
+	return status, ticketStatuses[status]
+}
+
+func (s TicketStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,29 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTicketStatus(t *testing.T) {
+	t.Run("known status", func(t *testing.T) {
+		status, ok := entities.NewTicketStatus(aws.String("claimed"))
+		assert.True(t, ok)
+		assert.Equal(t, entities.TicketClaimed, status)
+		assert.Equal(t, "claimed", status.String())
+	})
+
+	t.Run("unknown status", func(t *testing.T) {
+		_, ok := entities.NewTicketStatus(aws.String("lost"))
+		assert.False(t, ok)
+	})
+
+	t.Run("nil status", func(t *testing.T) {
+		status, ok := entities.NewTicketStatus(nil)
+		assert.False(t, ok)
+		assert.Empty(t, status)
+	})
+}
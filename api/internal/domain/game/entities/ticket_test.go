@@ -19,7 +19,7 @@ func TestCreateTicket(t *testing.T) {
 	input := &transfert.Ticket{
 		ID:           aws.String(uuid.New().String()),
 		CredentialID: aws.String(uuid.New().String()),
-		Prize:        aws.String("PrizeA"),
+		PrizeID:      aws.String("PrizeA"),
 		Token:        aws.String("123456"),
 	}
 
@@ -28,7 +28,7 @@ func TestCreateTicket(t *testing.T) {
 	assert.NotNil(t, ticket)
 	assert.Equal(t, *input.ID, ticket.ID)
 	assert.Equal(t, *input.CredentialID, *ticket.CredentialID)
-	assert.Equal(t, *input.Prize, *ticket.Prize)
+	assert.Equal(t, *input.PrizeID, *ticket.PrizeID)
 	assert.Equal(t, token.Luhn("123456"), ticket.Token)
 }
 
@@ -37,7 +37,7 @@ func TestCreateTicketWithNilFields(t *testing.T) {
 	input := &transfert.Ticket{
 		ID:           nil,
 		CredentialID: nil,
-		Prize:        nil,
+		PrizeID:      nil,
 		Token:        aws.String("123456"),
 	}
 
@@ -46,7 +46,7 @@ func TestCreateTicketWithNilFields(t *testing.T) {
 	assert.NotNil(t, ticket)
 	assert.Empty(t, ticket.ID)
 	assert.Nil(t, ticket.CredentialID)
-	assert.Nil(t, ticket.Prize)
+	assert.Nil(t, ticket.PrizeID)
 	assert.Equal(t, token.Luhn("123456"), ticket.Token)
 }
 
@@ -78,6 +78,7 @@ func TestTicket_BeforeCreate(t *testing.T) {
 
 	assert.Nil(t, err)
 	assert.NotEmpty(t, ticket.ID)
+	assert.Equal(t, entities.TicketGenerated, ticket.Status)
 }
 
 func TestTicket_BeforeUpdate(t *testing.T) {
@@ -112,3 +113,13 @@ func TestTicket_AfterFind(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, ticket.ID, fetchedTicket.ID)
 }
+
+func TestNewTicketSigner(t *testing.T) {
+	// Sans secret configuré, les codes restent de simples codes Luhn de 12 chiffres
+	signer := entities.NewTicketSigner()
+	assert.False(t, signer.IsSigned())
+
+	code := signer.Generate()
+	assert.Len(t, code, entities.TicketCodeLength)
+	assert.Nil(t, signer.Verify(code))
+}
@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TransferStatus defines the state of a ticket offered to another client
+type TransferStatus string
+
+const (
+	TransferPending   TransferStatus = "pending"   // Offered, waiting for the recipient
+	TransferAccepted  TransferStatus = "accepted"  // Accepted by the recipient, who now holds the ticket
+	TransferDeclined  TransferStatus = "declined"  // Refused by the recipient, the sender keeps the ticket
+	TransferCancelled TransferStatus = "cancelled" // Withdrawn by the sender before an answer
+)
+
+var transferStatuses = map[TransferStatus]bool{
+	TransferPending:   true,
+	TransferAccepted:  true,
+	TransferDeclined:  true,
+	TransferCancelled: true,
+}
+
+var transferAnswers = map[TransferStatus]bool{
+	TransferAccepted:  true,
+	TransferDeclined:  true,
+	TransferCancelled: true,
+}
+
+// NewTransferStatus converts a string into a known TransferStatus
+//
+// Parameters:
+// - v: *string The status label
+//
+// Returns:
+// - TransferStatus: The matching status
+// - bool: false if the label is nil or unknown
+func NewTransferStatus(v *string) (TransferStatus, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	status := TransferStatus(*v)
+
+	return status, transferStatuses[status]
+}
+
+// NewTransferAnswer converts a string into an answer closing a pending transfer
+//
+// Parameters:
+// - v: *string The answer label
+//
+// Returns:
+// - TransferStatus: The matching status
+// - bool: false if the label is nil or not an answer
+func NewTransferAnswer(v *string) (TransferStatus, bool) {
+	if v == nil {
+		return "", false
+	}
+
+	status := TransferStatus(*v)
+
+	return status, transferAnswers[status]
+}
+
+func (s TransferStatus) String() string {
+	return string(s)
+}
+
+// TicketTransfer is a claimed ticket offered by a client to another one
+// The ticket changes hands only once the recipient accepts
+type TicketTransfer struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"-"`
+
+	// Relations
+	TicketID    string  `gorm:"type:varchar(36);index" json:"ticket_id"`
+	SenderID    string  `gorm:"type:varchar(36);index" json:"sender_id"`    // Client holding the ticket when offered
+	RecipientID string  `gorm:"type:varchar(36);index" json:"recipient_id"` // Client the ticket is offered to
+	Ticket      *Ticket `gorm:"foreignKey:TicketID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT;" json:"ticket,omitempty"`
+
+	// Additional fields
+	Status     TransferStatus `gorm:"type:varchar(16);index;default:pending" json:"status"`
+	AnsweredAt *time.Time     `json:"answered_at"`
+}
+
+// IsParty reports whether the credential sent or received the transfer
+func (transfer *TicketTransfer) IsParty(credentialID *string) bool {
+	if credentialID == nil {
+		return false
+	}
+
+	return *credentialID == transfer.SenderID || *credentialID == transfer.RecipientID
+}
+
+func (transfer *TicketTransfer) IsPublic() bool {
+	return false
+}
+
+func (transfer *TicketTransfer) GetOwnerID() string {
+	return transfer.SenderID
+}
+
+func (transfer *TicketTransfer) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	transfer.ID = id.String()
+
+	if transfer.Status == "" {
+		transfer.Status = TransferPending
+	}
+
+	return nil
+}
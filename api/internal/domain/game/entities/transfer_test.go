@@ -0,0 +1,57 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransferStatus(t *testing.T) {
+	for _, label := range []string{"pending", "accepted", "declined", "cancelled"} {
+		status, ok := entities.NewTransferStatus(aws.String(label))
+		assert.True(t, ok)
+		assert.Equal(t, label, status.String())
+	}
+
+	_, ok := entities.NewTransferStatus(aws.String("redeemed"))
+	assert.False(t, ok)
+
+	_, ok = entities.NewTransferStatus(nil)
+	assert.False(t, ok)
+}
+
+func TestNewTransferAnswer(t *testing.T) {
+	status, ok := entities.NewTransferAnswer(aws.String("accepted"))
+	assert.True(t, ok)
+	assert.Equal(t, entities.TransferAccepted, status)
+
+	// Une offre ne peut pas être remise en attente
+	_, ok = entities.NewTransferAnswer(aws.String("pending"))
+	assert.False(t, ok)
+
+	_, ok = entities.NewTransferAnswer(nil)
+	assert.False(t, ok)
+}
+
+func TestTicketTransfer(t *testing.T) {
+	transfer := &entities.TicketTransfer{SenderID: "sender-id", RecipientID: "recipient-id"}
+
+	assert.False(t, transfer.IsPublic())
+	assert.Equal(t, "sender-id", transfer.GetOwnerID())
+
+	assert.True(t, transfer.IsParty(aws.String("sender-id")))
+	assert.True(t, transfer.IsParty(aws.String("recipient-id")))
+	assert.False(t, transfer.IsParty(aws.String("someone-else")))
+	assert.False(t, transfer.IsParty(nil))
+}
+
+func TestTicketTransfer_BeforeCreate(t *testing.T) {
+	transfer := &entities.TicketTransfer{}
+	err := transfer.BeforeCreate(nil)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, transfer.ID)
+	assert.Equal(t, entities.TransferPending, transfer.Status)
+}
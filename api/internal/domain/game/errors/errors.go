@@ -8,5 +8,81 @@ import (
 
 var (
 	// Ticket errors
-	ErrTicketNotFound = errors.New(http.StatusNotFound, "ticket.not_found")
+	ErrTicketNotFound          = errors.New(http.StatusNotFound, "ticket.not_found")
+	ErrTicketInvalidStatus     = errors.New(http.StatusBadRequest, "ticket.invalid_status")
+	ErrTicketInvalidTransition = errors.New(http.StatusConflict, "ticket.invalid_transition")
+	ErrTicketInvalidSort       = errors.New(http.StatusBadRequest, "ticket.invalid_sort")
+	ErrTicketInvalidPage       = errors.New(http.StatusBadRequest, "ticket.invalid_page")
+	ErrTicketNotClaimed        = errors.New(http.StatusConflict, "ticket.not_claimed")
+	ErrTicketAlreadyRedeemed   = errors.New(http.StatusConflict, "ticket.already_redeemed")
+	ErrTicketWrongOwner        = errors.New(http.StatusForbidden, "ticket.wrong_owner")
+
+	// Receipt errors
+	ErrReceiptNotFound      = errors.New(http.StatusNotFound, "receipt.not_found")
+	ErrReceiptAlreadyExists = errors.New(http.StatusConflict, "receipt.already_exists")
+	ErrReceiptNotQualifying = errors.New(http.StatusBadRequest, "receipt.not_qualifying")
+	ErrReceiptNoTicket      = errors.New(http.StatusConflict, "receipt.no_ticket")
+	ErrReceiptInvalidPhoto  = errors.New(http.StatusBadRequest, "receipt.invalid_photo")
+	ErrReceiptPhotoTooLarge = errors.New(http.StatusRequestEntityTooLarge, "receipt.photo_too_large")
+
+	// Review errors
+	ErrReviewNotFound      = errors.New(http.StatusNotFound, "review.not_found")
+	ErrReviewInvalidStatus = errors.New(http.StatusBadRequest, "review.invalid_status")
+	ErrReviewClosed        = errors.New(http.StatusConflict, "review.closed")
+
+	// Transfer errors
+	ErrTransferNotFound      = errors.New(http.StatusNotFound, "transfer.not_found")
+	ErrTransferInvalidStatus = errors.New(http.StatusBadRequest, "transfer.invalid_status")
+	ErrTransferInvalidPage   = errors.New(http.StatusBadRequest, "transfer.invalid_page")
+	ErrTransferToSelf        = errors.New(http.StatusBadRequest, "transfer.to_self")
+	ErrTransferPending       = errors.New(http.StatusConflict, "transfer.pending")
+	ErrTransferClosed        = errors.New(http.StatusConflict, "transfer.closed")
+
+	// Prize errors
+	ErrPrizeNotFound             = errors.New(http.StatusNotFound, "prize.not_found")
+	ErrPrizeAlreadyExists        = errors.New(http.StatusConflict, "prize.already_exists")
+	ErrPrizeInUse                = errors.New(http.StatusConflict, "prize.in_use")
+	ErrPrizeInvalidValue         = errors.New(http.StatusBadRequest, "prize.invalid_value")
+	ErrPrizeDistributionOverflow = errors.New(http.StatusBadRequest, "prize.distribution_overflow")
+
+	// Campaign errors
+	ErrCampaignNotFound        = errors.New(http.StatusNotFound, "campaign.not_found")
+	ErrCampaignAlreadyExists   = errors.New(http.StatusConflict, "campaign.already_exists")
+	ErrCampaignInvalidDates    = errors.New(http.StatusBadRequest, "campaign.invalid_dates")
+	ErrCampaignInvalidTimezone = errors.New(http.StatusBadRequest, "campaign.invalid_timezone")
+	ErrCampaignInvalidTickets  = errors.New(http.StatusBadRequest, "campaign.invalid_tickets")
+	ErrCampaignNotStarted      = errors.New(http.StatusForbidden, "campaign.not_started")
+	ErrCampaignEnded           = errors.New(http.StatusForbidden, "campaign.ended")
+	ErrCampaignClaimClosed     = errors.New(http.StatusForbidden, "campaign.claim_closed")
+	ErrCampaignRunning         = errors.New(http.StatusConflict, "campaign.running")
+	ErrCampaignDeadlineOpen    = errors.New(http.StatusConflict, "campaign.deadline_open")
+
+	// Eligibility errors
+	ErrEligibilityInvalid            = errors.New(http.StatusBadRequest, "eligibility.invalid")
+	ErrEligibilityUnderage           = errors.New(http.StatusForbidden, "eligibility.underage")
+	ErrEligibilityResidence          = errors.New(http.StatusForbidden, "eligibility.residence")
+	ErrEligibilityHouseholdLimit     = errors.New(http.StatusForbidden, "eligibility.household_limit")
+	ErrEligibilityProfileIncomplete  = errors.New(http.StatusForbidden, "eligibility.profile_incomplete")
+	ErrEligibilityHouseholdSlotTaken = errors.New(http.StatusConflict, "eligibility.household_slot_taken")
+
+	// Draw errors
+	ErrDrawNotFound      = errors.New(http.StatusNotFound, "draw.not_found")
+	ErrDrawAlreadyDone   = errors.New(http.StatusConflict, "draw.already_done")
+	ErrDrawNoParticipant = errors.New(http.StatusConflict, "draw.no_participant")
+	ErrDrawMismatch      = errors.New(http.StatusConflict, "draw.mismatch")
+
+	// Batch errors
+	ErrBatchInvalidFormat = errors.New(http.StatusBadRequest, "batch.invalid_format")
+	ErrBatchInvalidRange  = errors.New(http.StatusBadRequest, "batch.invalid_range")
+	ErrBatchTooLarge      = errors.New(http.StatusBadRequest, "batch.too_large")
+
+	// Statistics errors
+	ErrStatisticsInvalidPeriod   = errors.New(http.StatusBadRequest, "statistics.invalid_period")
+	ErrStatisticsInvalidInterval = errors.New(http.StatusBadRequest, "statistics.invalid_interval")
+
+	// QR code errors
+	ErrQRCodeInvalidFormat = errors.New(http.StatusBadRequest, "qrcode.invalid_format")
+	ErrQRCodeInvalidSize   = errors.New(http.StatusBadRequest, "qrcode.invalid_size")
+	ErrQRCodeInvalidLevel  = errors.New(http.StatusBadRequest, "qrcode.invalid_level")
+	ErrQRCodeNoLink        = errors.New(http.StatusInternalServerError, "qrcode.no_link")
 )
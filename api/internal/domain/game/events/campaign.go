@@ -0,0 +1,53 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateCampaign Ensures a campaign exists and returns the current one
+// The first start opens a campaign without date boundaries using the prize catalogue distribution,
+// tickets created before campaigns existed are then handed to it.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+//
+// Returns:
+// - *entities.Campaign The most recent campaign
+func CreateCampaign(repo repositories.GameRepositoryInterface) *entities.Campaign {
+	campaigns, err := repo.ReadCampaigns(database.Limit(1))
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read campaigns: %v", err))
+	}
+
+	var campaign *entities.Campaign
+	if len(campaigns) > 0 {
+		campaign = campaigns[0]
+	} else {
+		campaign = &entities.Campaign{
+			Label:        aws.String("default"),
+			Distribution: loadDistribution(repo),
+		}
+
+		if err := repo.CreateCampaign(campaign); err != nil {
+			panic(fmt.Sprintf("Failed to create default campaign: %v", err))
+		}
+
+		fmt.Println("Default campaign created")
+	}
+
+	linked, err := repo.LinkOrphanTickets(campaign)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to link tickets to campaign: %v", err))
+	}
+
+	if linked > 0 {
+		fmt.Printf("%d tickets linked to campaign %s\n", linked, *campaign.Label)
+	}
+
+	return campaign
+}
@@ -0,0 +1,54 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	t.Run("opens a default campaign and links the existing tickets", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{
+			{ID: "PrizeA", Distribution: aws.Int(60)},
+			{ID: "PrizeB", Distribution: aws.Int(0)},
+		}, nil)
+		mockRepo.On("CreateCampaign", mock.MatchedBy(func(c *entities.Campaign) bool {
+			return *c.Label == "default" && c.StartAt == nil && c.EndAt == nil && len(c.Distribution) == 1 && c.Distribution["PrizeA"] == 60
+		}), mock.Anything).Return(nil)
+		mockRepo.On("LinkOrphanTickets", mock.Anything).Return(10, nil)
+
+		campaign := events.CreateCampaign(mockRepo)
+
+		assert.Equal(t, "default", *campaign.Label)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns the most recent campaign", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		current := &entities.Campaign{ID: "campaign-2", Label: aws.String("2025")}
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{current}, nil)
+		mockRepo.On("LinkOrphanTickets", current).Return(0, nil)
+
+		assert.Equal(t, current, events.CreateCampaign(mockRepo))
+		mockRepo.AssertNotCalled(t, "CreateCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("panics when the campaigns cannot be read", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		assert.Panics(t, func() {
+			events.CreateCampaign(mockRepo)
+		})
+	})
+}
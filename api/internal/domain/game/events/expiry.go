@@ -0,0 +1,98 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+)
+
+// ExpiryCheckInterval is the longest wait between two reads of the campaigns, deadlines set or moved meanwhile are caught on the next read
+const ExpiryCheckInterval = time.Hour
+
+// ScheduleExpiry Expires the tickets of each campaign once its end and then its claim deadline have passed, project.expiry.grace included
+// Deadlines passed while the server was down are caught up at start. Every instance runs its own schedule,
+// tickets already expired by another one are left as is and a run with nothing left to expire records nothing.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+// - stop: <-chan struct{} Closed to stop the schedule
+func ScheduleExpiry(repo repositories.GameRepositoryInterface, stop <-chan struct{}) {
+	since := time.Time{}
+
+	for {
+		now := time.Now()
+		wait := ExpiryCheckInterval
+		if next := ExpireDue(repo, since, now); next != nil {
+			// A deadline is passed strictly after it, the wake up is delayed by a second
+			wait = min(wait, next.Sub(now)+time.Second)
+		}
+
+		since = now
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// ExpireDue Expires the tickets of the campaigns with a deadline passed since the previous run
+// Failures are logged and the campaign is left for the next deadline or a manual expiry.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+// - since: time.Time The instant of the previous run, zero at start
+// - now: time.Time The current instant
+//
+// Returns:
+// - *time.Time The next deadline after now, nil when no campaign has one left
+func ExpireDue(repo repositories.GameRepositoryInterface, since, now time.Time) *time.Time {
+	campaigns, err := repo.ReadCampaigns()
+	if err != nil {
+		logger.Error(err)
+		return nil
+	}
+
+	service := services.Game(&security.UserAccess{Role: security.ROLE_ADMIN}, repo, nil, nil)
+	grace := entities.ExpiryGrace()
+
+	var next *time.Time
+	for _, campaign := range campaigns {
+		due := false
+		for _, status := range []entities.TicketStatus{entities.TicketGenerated, entities.TicketClaimed} {
+			deadline := campaign.ExpiresAt(status, grace)
+			switch {
+			case deadline == nil:
+			case now.After(*deadline):
+				due = due || !deadline.Before(since)
+			case next == nil || deadline.Before(*next):
+				next = deadline
+			}
+		}
+
+		if !due {
+			continue
+		}
+
+		expiry, err := service.ExpireTickets(&transfert.Campaign{ID: &campaign.ID})
+		if err != nil {
+			logger.Error(fmt.Errorf("failed to expire the tickets of campaign %s: %w", campaign.ID, err))
+			continue
+		}
+
+		if !expiry.IsEmpty() {
+			fmt.Printf("%d unclaimed and %d uncollected tickets expired in campaign %s\n", expiry.Unclaimed, expiry.Uncollected, campaign.ID)
+		}
+	}
+
+	return next
+}
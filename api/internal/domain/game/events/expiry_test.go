@@ -0,0 +1,89 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExpireDue(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-time.Hour)
+	deadline := now.Add(2 * time.Hour)
+
+	t.Run("expires a campaign whose end passed and returns its claim deadline", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		campaign := &entities.Campaign{ID: "campaign-1", EndAt: &ended, ClaimDeadline: &deadline}
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{campaign}, nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(campaign, nil)
+		mockRepo.On("ExpireTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]int{"prize-1": 3}, nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{}, nil)
+		mockRepo.On("CreateTicketExpiry", mock.MatchedBy(func(expiry *entities.TicketExpiry) bool {
+			return expiry.Unclaimed == 3 && expiry.Uncollected == 0
+		}), mock.Anything).Return(nil)
+
+		next := events.ExpireDue(mockRepo, time.Time{}, now)
+		assert.Equal(t, deadline, *next)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("leaves the deadlines already handled by a previous run", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		campaign := &entities.Campaign{ID: "campaign-1", EndAt: &ended}
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{campaign}, nil)
+
+		// La fin de la campagne est antérieure au passage précédent
+		assert.Nil(t, events.ExpireDue(mockRepo, now.Add(-time.Minute), now))
+		mockRepo.AssertNotCalled(t, "ReadCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps on with the other campaigns when an expiry fails", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		failing := &entities.Campaign{ID: "campaign-1", EndAt: &ended}
+		open := &entities.Campaign{ID: "campaign-2"}
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{failing, open}, nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		assert.Nil(t, events.ExpireDue(mockRepo, time.Time{}, now))
+		mockRepo.AssertNumberOfCalls(t, "ReadCampaign", 1)
+	})
+
+	t.Run("returns nothing when the campaigns cannot be read", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		assert.Nil(t, events.ExpireDue(mockRepo, time.Time{}, now))
+	})
+}
+
+func TestScheduleExpiry(t *testing.T) {
+	t.Run("stops when asked to", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+
+		stop, done := make(chan struct{}), make(chan struct{})
+		go func() {
+			events.ScheduleExpiry(mockRepo, stop)
+			close(done)
+		}()
+
+		close(stop)
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("the schedule did not stop")
+		}
+
+		mockRepo.AssertCalled(t, "ReadCampaigns", mock.Anything)
+	})
+}
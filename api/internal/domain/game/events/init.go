@@ -6,14 +6,35 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
-	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/schollz/progressbar/v3"
 )
 
-func HydrateDBWithTickets(repo repositories.GameRepositoryInterface, require int, dispatch map[string]int) {
-	tokenMap := loadExistingTokens(repo)
-	existingCounts := countExistingTickets(repo, dispatch)
+// HydrateDBWithTickets Generates the missing tickets of a campaign
+// The campaign ticket count and distribution win over the configuration and the prize catalogue.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+// - campaign: *entities.Campaign The campaign owning the tickets
+// - require: int The number of tickets when the campaign does not set one
+func HydrateDBWithTickets(repo repositories.GameRepositoryInterface, campaign *entities.Campaign, require int) {
+	if campaign.Tickets != nil {
+		require = *campaign.Tickets
+	}
+
+	dispatch := campaign.Distribution
+	if len(dispatch) == 0 {
+		dispatch = loadDistribution(repo)
+	}
+
+	if len(dispatch) == 0 {
+		fmt.Println("No active prize in the catalogue, no ticket to generate")
+		return
+	}
+
+	existingCounts := countExistingTickets(repo, campaign, dispatch)
 
 	totalExisting := calculateTotalExisting(existingCounts)
 	if totalExisting >= require {
@@ -21,34 +42,38 @@ func HydrateDBWithTickets(repo repositories.GameRepositoryInterface, require int
 		return
 	}
 
-	remaining := require - totalExisting
 	ticketsPerPrize := calculateTicketsPerPrize(require, dispatch, existingCounts)
 	bar := initializeProgressBar(require, totalExisting)
 
-	generateAndInsertTickets(repo, ticketsPerPrize, remaining, tokenMap, bar)
+	generateAndInsertTickets(repo, campaign, ticketsPerPrize, bar)
 	fmt.Printf("\n%d tickets are ready\n", require)
 }
 
-func loadExistingTokens(repo repositories.GameRepositoryInterface) map[string]bool {
-	tokenMap := make(map[string]bool)
-	existingTokens, err := repo.ReadTickets(&transfert.Ticket{})
+// loadDistribution returns the share of tickets, in percent, of each active prize keyed by prize ID
+func loadDistribution(repo repositories.GameRepositoryInterface) map[string]int {
+	prizes, err := repo.ReadPrizes(&transfert.Prize{Active: aws.Bool(true)})
 	if err != nil {
-		panic(fmt.Sprintf("Failed to fetch existing tokens: %v", err))
+		panic(fmt.Sprintf("Failed to fetch prizes: %v", err))
 	}
 
-	for _, existingToken := range existingTokens {
-		tokenMap[existingToken.Token.String()] = true
+	dispatch := make(map[string]int)
+	for _, prize := range prizes {
+		if prize.Distribution != nil && *prize.Distribution > 0 {
+			dispatch[prize.ID] = *prize.Distribution
+		}
 	}
-	fmt.Printf("Loaded %d existing tokens from the database\n", len(tokenMap))
-	return tokenMap
+
+	return dispatch
 }
 
-func countExistingTickets(repo repositories.GameRepositoryInterface, dispatch map[string]int) map[string]int {
+// countExistingTickets counts the tickets of each prize of the campaign, those cancelled by a rebalance excluded
+func countExistingTickets(repo repositories.GameRepositoryInterface, campaign *entities.Campaign, dispatch map[string]int) map[string]int {
 	existingCounts := make(map[string]int)
 	for prize := range dispatch {
 		count, err := repo.CountTicket(&transfert.Ticket{
-			Prize: aws.String(prize),
-		})
+			PrizeID:    aws.String(prize),
+			CampaignID: &campaign.ID,
+		}, database.Where("status <> ?", entities.TicketCancelled))
 		if err != nil {
 			panic(fmt.Sprintf("Failed to count tickets for %s: %v", prize, err))
 		}
@@ -87,36 +112,17 @@ func initializeProgressBar(require, totalExisting int) *progressbar.ProgressBar
 	return bar
 }
 
-func generateAndInsertTickets(repo repositories.GameRepositoryInterface, ticketsPerPrize map[string]int, remaining int, tokenMap map[string]bool, bar *progressbar.ProgressBar) {
-	modulo := 1000
-	generateUniqueToken := func() *string {
-		for {
-			token := token.Generate(12).PointerString()
-			if !tokenMap[*token] {
-				tokenMap[*token] = true
-				return token
-			}
-		}
-	}
-
+// generateAndInsertTickets inserts the tickets of each prize through the shared generator, by batches of repositories.TicketBatchSize
+// Only one batch lives in memory at a time, whatever the number of tickets to generate
+func generateAndInsertTickets(repo repositories.GameRepositoryInterface, campaign *entities.Campaign, ticketsPerPrize map[string]int, bar *progressbar.ProgressBar) {
 	for prize, numTickets := range ticketsPerPrize {
 		if numTickets <= 0 {
 			continue
 		}
-		tickets := []*transfert.Ticket{}
-		for i := 0; i < numTickets; i++ {
-			tickets = append(tickets, &transfert.Ticket{
-				Prize: aws.String(prize),
-				Token: generateUniqueToken(),
-			})
-
-			if len(tickets) >= modulo || i == numTickets-1 {
-				if err := repo.CreateTickets(tickets); err != nil {
-					panic(fmt.Sprintf("Failed to insert tickets for %s: %v", prize, err))
-				}
-				bar.Add(len(tickets))
-				tickets = []*transfert.Ticket{}
-			}
+
+		filter := &transfert.Ticket{PrizeID: aws.String(prize), CampaignID: &campaign.ID}
+		if _, err := repositories.GenerateTickets(repo, filter, numTickets, func(inserted int) { bar.Add(inserted) }); err != nil {
+			panic(fmt.Sprintf("Failed to insert tickets for %s: %v", prize, err))
 		}
 	}
 }
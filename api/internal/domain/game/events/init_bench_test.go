@@ -0,0 +1,30 @@
+package events_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+)
+
+// BenchmarkHydrateDBWithTickets mesure le débit de génération sur SQLite en mémoire
+//
+//	go test -run '^$' -bench HydrateDBWithTickets -benchmem ./internal/domain/game/events/
+func BenchmarkHydrateDBWithTickets(b *testing.B) {
+	for _, require := range []int{10_000, 100_000, 500_000} {
+		b.Run(fmt.Sprintf("tickets=%d", require), func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				repo := newSQLiteRepository(b)
+				campaign := newSQLiteCampaign(b, repo, require)
+				b.StartTimer()
+
+				events.HydrateDBWithTickets(repo, campaign, 0)
+			}
+
+			b.ReportMetric(float64(require*b.N)/b.Elapsed().Seconds(), "tickets/s")
+		})
+	}
+}
@@ -2,15 +2,21 @@ package events_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
-	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 // MockGameRepository simule l'interface GameRepositoryInterface
@@ -81,46 +87,671 @@ func (m *MockGameRepository) CountTicket(obj *transfert.Ticket, options ...datab
 	return args.Int(0), nil
 }
 
+// UpdateTicketStatus simule le changement de statut d'un ticket.
+func (m *MockGameRepository) UpdateTicketStatus(entity *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHistories simule la lecture de l'historique d'un ticket.
+func (m *MockGameRepository) ReadTicketHistories(obj *transfert.TicketHistory, options ...database.Option) ([]*entities.TicketHistory, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketHistory), nil
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *MockGameRepository) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *MockGameRepository) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrizes simule la lecture du catalogue des lots.
+func (m *MockGameRepository) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *MockGameRepository) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *MockGameRepository) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkLegacyPrizes simule le rattachement des anciens tickets à leur lot.
+func (m *MockGameRepository) LinkLegacyPrizes() (int, errors.ErrorInterface) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDraw simule l'enregistrement d'un tirage.
+func (m *MockGameRepository) CreateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *MockGameRepository) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDrawParticipants simule la lecture des participants au tirage.
+func (m *MockGameRepository) ReadDrawParticipants(obj *transfert.Ticket, options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *MockGameRepository) CreateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *MockGameRepository) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture des campagnes.
+func (m *MockGameRepository) ReadCampaigns(options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *MockGameRepository) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkOrphanTickets simule le rattachement des tickets sans campagne.
+func (m *MockGameRepository) LinkOrphanTickets(campaign *entities.Campaign) (int, errors.ErrorInterface) {
+	args := m.Called(campaign)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateTicketTransfer simule l'enregistrement d'un transfert de ticket
+func (m *MockGameRepository) CreateTicketTransfer(entity *entities.TicketTransfer, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketTransfer simule la lecture d'un transfert de ticket
+func (m *MockGameRepository) ReadTicketTransfer(obj *transfert.TicketTransfer, options ...database.Option) (*entities.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.TicketTransfer), nil
+}
+
+// CountTicketTransfers simule le comptage des transferts de tickets
+func (m *MockGameRepository) CountTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// ReadTicketTransfers simule la lecture des transferts de tickets
+func (m *MockGameRepository) ReadTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) ([]*entities.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketTransfer), nil
+}
+
+// UpdateTicketTransfer simule la réponse à un transfert de ticket
+func (m *MockGameRepository) UpdateTicketTransfer(entity *entities.TicketTransfer, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTickets simule l'expiration d'un lot de tickets
+func (m *MockGameRepository) ExpireTickets(obj *transfert.Ticket, from []entities.TicketStatus, limit int, history *transfert.TicketHistory, options ...database.Option) (map[string]int, errors.ErrorInterface) {
+	args := m.Called(obj, from, limit, history, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(map[string]int), nil
+}
+
+// CreateTicketExpiry simule l'enregistrement d'une expiration de tickets
+func (m *MockGameRepository) CreateTicketExpiry(entity *entities.TicketExpiry, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketExpiries simule la lecture des expirations de tickets
+func (m *MockGameRepository) ReadTicketExpiries(options ...database.Option) ([]*entities.TicketExpiry, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketExpiry), nil
+}
+
+// StreamLedger simule le parcours du registre par lots, les entrées fournies en second retour éventuel sont passées à fn
+func (m *MockGameRepository) StreamLedger(size int, fn func([]*entities.LedgerEntry) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(size, fn, options)
+	if entries, ok := args.Get(len(args) - 1).([]*entities.LedgerEntry); len(args) > 1 && ok {
+		if err := fn(entries); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// RecordTickets simule l'inscription au registre des tickets qui en sont absents
+func (m *MockGameRepository) RecordTickets(limit int) (int, errors.ErrorInterface) {
+	args := m.Called(limit)
+	if args.Get(1) == nil {
+		return args.Int(0), nil
+	}
+
+	return args.Int(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// ReadHouseholdSlots simule la lecture des places prises par un foyer pendant une campagne
+func (m *MockGameRepository) ReadHouseholdSlots(campaignID, household string, options ...database.Option) ([]int, errors.ErrorInterface) {
+	args := m.Called(campaignID, household, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]int), nil
+}
+
+// CreateBatch simule la création d'un lot d'export
+func (m *MockGameRepository) CreateBatch(entity *entities.Batch, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadBatches simule la lecture des lots d'export
+func (m *MockGameRepository) ReadBatches(options ...database.Option) ([]*entities.Batch, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Batch), nil
+}
+
+// StreamTickets simule le parcours des tickets par lots, les tickets fournis en second retour éventuel sont passés à fn
+func (m *MockGameRepository) StreamTickets(obj *transfert.Ticket, size int, fn func([]*entities.Ticket) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, size, fn, options)
+	if tickets, ok := args.Get(len(args) - 1).([]*entities.Ticket); len(args) > 1 && ok {
+		if err := fn(tickets); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// InsertTickets simule l'insertion d'un lot de tickets en ignorant les codes déjà pris
+func (m *MockGameRepository) InsertTickets(objs []*transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(objs, options)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CountTicketsByPrize simule le comptage des tickets par lot
+func (m *MockGameRepository) CountTicketsByPrize(period *entities.Period, options ...database.Option) ([]*entities.PrizeStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PrizeStatistic), nil
+}
+
+// CountClaimsByPeriod simule le comptage des réclamations par jour ou par heure
+func (m *MockGameRepository) CountClaimsByPeriod(period *entities.Period, interval string, options ...database.Option) ([]*entities.PeriodStatistic, errors.ErrorInterface) {
+	args := m.Called(period, interval, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PeriodStatistic), nil
+}
+
+// CountStatusesByCaisse simule le comptage des réclamations et remises par caisse
+func (m *MockGameRepository) CountStatusesByCaisse(period *entities.Period, options ...database.Option) ([]*entities.StoreStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.StoreStatistic), nil
+}
+
+// CreateReceipt simule l'enregistrement d'un ticket de caisse.
+func (m *MockGameRepository) CreateReceipt(entity *entities.Receipt, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadReceipt simule la lecture d'un ticket de caisse.
+func (m *MockGameRepository) ReadReceipt(obj *transfert.Receipt, options ...database.Option) (*entities.Receipt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Receipt), nil
+}
+
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *MockGameRepository) CreateClaimAttempt(obj *transfert.ClaimAttempt, failed bool, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, failed, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimSignals simule le comptage des tentatives de réclamation récentes.
+func (m *MockGameRepository) ReadClaimSignals(obj *transfert.ClaimAttempt, since time.Time, options ...database.Option) (*entities.ClaimSignals, errors.ErrorInterface) {
+	args := m.Called(obj, since, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimSignals), nil
+}
+
+// CreateClaimReview simule la mise en attente d'une réclamation suspecte.
+func (m *MockGameRepository) CreateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimReview simule la lecture d'une réclamation en attente.
+func (m *MockGameRepository) ReadClaimReview(obj *transfert.ClaimReview, options ...database.Option) (*entities.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimReview), nil
+}
+
+// ReadClaimReviews simule la lecture de la file des réclamations en attente.
+func (m *MockGameRepository) ReadClaimReviews(obj *transfert.ClaimReview, options ...database.Option) ([]*entities.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.ClaimReview), nil
+}
+
+// UpdateClaimReview simule la décision sur une réclamation en attente.
+func (m *MockGameRepository) UpdateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CountTicketPools simule le comptage des tickets par lot d'une campagne.
+func (m *MockGameRepository) CountTicketPools(obj *transfert.Ticket, options ...database.Option) ([]*entities.TicketPool, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketPool), nil
+}
+
+// CancelTickets simule l'annulation des tickets en réserve.
+func (m *MockGameRepository) CancelTickets(obj *transfert.Ticket, limit int, history *transfert.TicketHistory, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, limit, history, options)
+	if args.Error(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDistributionChange simule l'enregistrement d'un changement de répartition.
+func (m *MockGameRepository) CreateDistributionChange(entity *entities.DistributionChange, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDistributionChanges simule la lecture des changements de répartition.
+func (m *MockGameRepository) ReadDistributionChanges(options ...database.Option) ([]*entities.DistributionChange, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.DistributionChange), nil
+}
+
 // Tests pour la méthode HydrateDBWithTickets
 func TestHydrateDBWithTickets(t *testing.T) {
 	// Initialisation du MockGameRepository
 	mockRepo := new(MockGameRepository)
 
-	token1 := token.Generate(12)
-	token2 := token.Generate(12)
-
-	// Configuration du mock pour ReadTickets
-	mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{
-		{Token: token1},
-		{Token: token2},
-	}, errors.ErrorInterface(nil))
+	// Configuration du mock pour ReadPrizes
+	mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{
+		{ID: "PrizeA", Distribution: aws.Int(50)},
+		{ID: "PrizeB", Distribution: aws.Int(50)},
+	}, nil)
 
 	// Configuration du mock pour CountTicket
 	mockRepo.On("CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeA"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeA"
 	}), mock.Anything).Return(100, errors.ErrorInterface(nil))
 
 	mockRepo.On("CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeB"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeB"
 	}), mock.Anything).Return(200, errors.ErrorInterface(nil))
 
-	// Configuration du mock pour CreateTickets
-	mockRepo.On("CreateTickets", mock.Anything, mock.Anything).Return(errors.ErrorInterface(nil))
+	// Configuration du mock pour InsertTickets, chaque lot est inséré en entier
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 400 && *tickets[0].PrizeID == "PrizeA"
+	}), mock.Anything).Return(400, nil).Once()
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 300 && *tickets[0].PrizeID == "PrizeB"
+	}), mock.Anything).Return(300, nil).Once()
 
 	// Appel de la méthode HydrateDBWithTickets
-	dispatch := map[string]int{
-		"PrizeA": 50,
-		"PrizeB": 50,
-	}
-	events.HydrateDBWithTickets(mockRepo, 1000, dispatch)
+	events.HydrateDBWithTickets(mockRepo, &entities.Campaign{ID: "campaign-id"}, 1000)
 
 	// Vérifications
-	mockRepo.AssertCalled(t, "ReadTickets", mock.Anything, mock.Anything)
+	mockRepo.AssertCalled(t, "ReadPrizes", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "ReadTickets", mock.Anything, mock.Anything)
 	mockRepo.AssertCalled(t, "CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeA"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeA"
 	}), mock.Anything)
 	mockRepo.AssertCalled(t, "CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
-		return ticket != nil && ticket.Prize != nil && *ticket.Prize == "PrizeB"
+		return ticket != nil && ticket.PrizeID != nil && *ticket.PrizeID == "PrizeB"
 	}), mock.Anything)
-	mockRepo.AssertCalled(t, "CreateTickets", mock.Anything, mock.Anything)
+	mockRepo.AssertNumberOfCalls(t, "InsertTickets", 2)
+}
+
+// Les lots sont bornés à repositories.TicketBatchSize tickets
+func TestHydrateDBWithTicketsByBatches(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	campaign := &entities.Campaign{
+		ID:           "campaign-id",
+		Tickets:      aws.Int(repositories.TicketBatchSize*2 + 10),
+		Distribution: map[string]int{"PrizeA": 100},
+	}
+
+	mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == repositories.TicketBatchSize
+	}), mock.Anything).Return(repositories.TicketBatchSize, nil).Twice()
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 10
+	}), mock.Anything).Return(10, nil).Once()
+
+	events.HydrateDBWithTickets(mockRepo, campaign, 0)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// Les codes déjà pris sont tirés de nouveau
+func TestHydrateDBWithTicketsRetriesConflicts(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	campaign := &entities.Campaign{
+		ID:           "campaign-id",
+		Tickets:      aws.Int(10),
+		Distribution: map[string]int{"PrizeA": 100},
+	}
+
+	mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 10
+	}), mock.Anything).Return(7, nil).Once()
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 3
+	}), mock.Anything).Return(0, nil).Once()
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 3
+	}), mock.Anything).Return(3, nil).Once()
+
+	events.HydrateDBWithTickets(mockRepo, campaign, 0)
+
+	mockRepo.AssertExpectations(t)
+}
+
+// Un lot qui ne s'insère jamais finit par abandonner
+func TestHydrateDBWithTicketsGivesUp(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	campaign := &entities.Campaign{
+		ID:           "campaign-id",
+		Tickets:      aws.Int(10),
+		Distribution: map[string]int{"PrizeA": 100},
+	}
+
+	mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(0, nil)
+	mockRepo.On("InsertTickets", mock.Anything, mock.Anything).Return(0, nil)
+
+	assert.Panics(t, func() {
+		events.HydrateDBWithTickets(mockRepo, campaign, 0)
+	})
+
+	mockRepo.AssertNumberOfCalls(t, "InsertTickets", repositories.TicketMaxRetries+1)
+}
+
+// La campagne impose son nombre de tickets et sa répartition
+func TestHydrateDBWithTicketsFromCampaign(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	campaign := &entities.Campaign{
+		ID:           "campaign-id",
+		Tickets:      aws.Int(10),
+		Distribution: map[string]int{"PrizeA": 100},
+	}
+
+	mockRepo.On("CountTicket", mock.MatchedBy(func(ticket *transfert.Ticket) bool {
+		return *ticket.PrizeID == "PrizeA" && *ticket.CampaignID == "campaign-id"
+	}), mock.Anything).Return(4, nil)
+	mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+		return len(tickets) == 6 && *tickets[0].CampaignID == "campaign-id"
+	}), mock.Anything).Return(6, nil)
+
+	events.HydrateDBWithTickets(mockRepo, campaign, 1000)
+
+	mockRepo.AssertNotCalled(t, "ReadPrizes", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// Sans lot actif, aucun ticket n'est généré
+func TestHydrateDBWithTicketsWithoutPrize(t *testing.T) {
+	mockRepo := new(MockGameRepository)
+
+	mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{}, nil)
+
+	events.HydrateDBWithTickets(mockRepo, &entities.Campaign{ID: "campaign-id"}, 1000)
+
+	mockRepo.AssertNotCalled(t, "CountTicket", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "InsertTickets", mock.Anything, mock.Anything)
+}
+
+// newSQLiteRepository ouvre une base SQLite en mémoire, limitée à une connexion pour partager les tables
+func newSQLiteRepository(tb testing.TB) *repositories.GameRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		tb.Fatalf("Failed to open database: %v", err)
+	}
+
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	tb.Cleanup(func() { sqlDB.Close() })
+
+	store, _ := database.FromDB(db)
+
+	return repositories.NewGameRepository(store)
+}
+
+// newSQLiteCampaign crée une campagne de require tickets répartis sur deux lots
+func newSQLiteCampaign(tb testing.TB, repo *repositories.GameRepository, require int) *entities.Campaign {
+	a, _ := repo.CreatePrize(&transfert.Prize{Label: aws.String("A")})
+	b, _ := repo.CreatePrize(&transfert.Prize{Label: aws.String("B")})
+
+	campaign := &entities.Campaign{
+		Label:        aws.String("bench"),
+		Tickets:      aws.Int(require),
+		Distribution: map[string]int{a.ID: 90, b.ID: 10},
+	}
+
+	if err := repo.CreateCampaign(campaign); err != nil {
+		tb.Fatalf("Failed to create campaign: %v", err)
+	}
+
+	return campaign
+}
+
+func TestHydrateDBWithTicketsSQLite(t *testing.T) {
+	repo := newSQLiteRepository(t)
+	campaign := newSQLiteCampaign(t, repo, 2500)
+
+	events.HydrateDBWithTickets(repo, campaign, 0)
+
+	count, _ := repo.CountTicket(&transfert.Ticket{CampaignID: &campaign.ID})
+	assert.Equal(t, 2500, count)
+
+	// Une seconde génération ne crée rien de plus
+	events.HydrateDBWithTickets(repo, campaign, 0)
+
+	count, _ = repo.CountTicket(&transfert.Ticket{CampaignID: &campaign.ID})
+	assert.Equal(t, 2500, count)
 }
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+)
+
+// LedgerRecordChunk is the number of tickets entered into the ledger per transaction
+const LedgerRecordChunk = 1000
+
+// RecordLedger Enters the tickets stored before the ledger existed, so the ledger covers every ticket
+// Each chunk is recorded in its own transaction, a restart resumes with the tickets still missing.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+func RecordLedger(repo repositories.GameRepositoryInterface) {
+	total := 0
+	for {
+		recorded, err := repo.RecordTickets(LedgerRecordChunk)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to record tickets in the ledger: %v", err))
+		}
+
+		total += recorded
+		if recorded < LedgerRecordChunk {
+			break
+		}
+	}
+
+	if total > 0 {
+		fmt.Printf("%d tickets recorded in the ledger\n", total)
+	}
+}
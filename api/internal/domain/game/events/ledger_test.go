@@ -0,0 +1,31 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordLedger(t *testing.T) {
+	t.Run("records the tickets chunk by chunk until none is left", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("RecordTickets", events.LedgerRecordChunk).Return(events.LedgerRecordChunk, nil).Twice()
+		mockRepo.On("RecordTickets", events.LedgerRecordChunk).Return(12, nil).Once()
+
+		events.RecordLedger(mockRepo)
+		mockRepo.AssertNumberOfCalls(t, "RecordTickets", 3)
+	})
+
+	t.Run("panics when the tickets cannot be recorded", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("RecordTickets", events.LedgerRecordChunk).Return(0, errors.ErrInternalServer)
+
+		assert.Panics(t, func() {
+			events.RecordLedger(mockRepo)
+		})
+	})
+}
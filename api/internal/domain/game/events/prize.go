@@ -0,0 +1,61 @@
+package events
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+)
+
+// CreatePrizes Seeds the prize catalogue from the configuration when it is empty
+// Once the catalogue exists it is managed through the API and the configuration is ignored.
+// Tickets created before the catalogue are then linked to their prize.
+//
+// Parameters:
+// - repo: repositories.GameRepositoryInterface The game repository
+// - catalogue: map[string]int The prize labels with their share of the tickets, in percent
+func CreatePrizes(repo repositories.GameRepositoryInterface, catalogue map[string]int) {
+	existing, err := repo.ReadPrizes(&transfert.Prize{})
+	if err != nil {
+		panic(fmt.Sprintf("Failed to read prizes: %v", err))
+	}
+
+	if len(existing) == 0 {
+		labels := make([]string, 0, len(catalogue))
+		for label := range catalogue {
+			labels = append(labels, label)
+		}
+
+		// Les lots les plus fréquents en premier
+		sort.Slice(labels, func(i, j int) bool {
+			if catalogue[labels[i]] == catalogue[labels[j]] {
+				return labels[i] < labels[j]
+			}
+			return catalogue[labels[i]] > catalogue[labels[j]]
+		})
+
+		for i, label := range labels {
+			if _, err := repo.CreatePrize(&transfert.Prize{
+				Label:        aws.String(label),
+				Position:     aws.Int(i + 1),
+				Active:       aws.Bool(true),
+				Distribution: aws.Int(catalogue[label]),
+			}); err != nil {
+				panic(fmt.Sprintf("Failed to create prize %s: %v", label, err))
+			}
+		}
+
+		fmt.Printf("%d prizes created from configuration\n", len(labels))
+	}
+
+	linked, err := repo.LinkLegacyPrizes()
+	if err != nil {
+		panic(fmt.Sprintf("Failed to link legacy tickets to their prize: %v", err))
+	}
+
+	if linked > 0 {
+		fmt.Printf("%d legacy tickets linked to their prize\n", linked)
+	}
+}
@@ -0,0 +1,57 @@
+package events_test
+
+import (
+	"testing"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePrizes(t *testing.T) {
+	catalogue := map[string]int{
+		"Infuseur à thé":         60,
+		"Coffret découverte 69€": 40,
+	}
+
+	t.Run("seeds an empty catalogue in distribution order", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{}, nil)
+		mockRepo.On("CreatePrize", mock.MatchedBy(func(p *transfert.Prize) bool {
+			return *p.Label == "Infuseur à thé" && *p.Position == 1 && *p.Distribution == 60 && *p.Active
+		}), mock.Anything).Return(&entities.Prize{}, nil).Once()
+		mockRepo.On("CreatePrize", mock.MatchedBy(func(p *transfert.Prize) bool {
+			return *p.Label == "Coffret découverte 69€" && *p.Position == 2 && *p.Distribution == 40
+		}), mock.Anything).Return(&entities.Prize{}, nil).Once()
+		mockRepo.On("LinkLegacyPrizes").Return(10, nil)
+
+		events.CreatePrizes(mockRepo, catalogue)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("leaves an existing catalogue untouched", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{{ID: "prize"}}, nil)
+		mockRepo.On("LinkLegacyPrizes").Return(0, nil)
+
+		events.CreatePrizes(mockRepo, catalogue)
+
+		mockRepo.AssertNotCalled(t, "CreatePrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("panics when the catalogue cannot be read", func(t *testing.T) {
+		mockRepo := new(MockGameRepository)
+
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		assert.Panics(t, func() {
+			events.CreatePrizes(mockRepo, catalogue)
+		})
+	})
+}
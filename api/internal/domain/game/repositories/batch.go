@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// CreateBatch records an export of tickets
+//
+// Parameters:
+// - entity: *entities.Batch - The batch entity to persist
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateBatch(entity *entities.Batch, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Create(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadBatches lists the recorded exports
+// Returns the batches matching the options, the most recent first
+//
+// Parameters:
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Batch: The list of batches
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadBatches(options ...database.Option) ([]*entities.Batch, errors.ErrorInterface) {
+	var batches []*entities.Batch
+
+	query := r.store.Engine.Order("created_at DESC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&batches)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return batches, nil
+}
+
+// StreamTickets walks the tickets matching obj by chunks, ordered by ID
+// Offset and Limit options apply to the whole walk, so large exports never load every ticket at once
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object with search parameters
+// - size: int - The number of tickets per chunk
+// - fn: func([]*entities.Ticket) errors.ErrorInterface - Called for each chunk, an error stops the walk
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) StreamTickets(obj *transfert.Ticket, size int, fn func([]*entities.Ticket) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	var tickets []*entities.Ticket
+	var stop errors.ErrorInterface
+
+	query := r.store.Engine.Where(entities.CreateTicket(obj))
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.FindInBatches(&tickets, size, func(tx *gorm.DB, batch int) error {
+		if stop = fn(tickets); stop != nil {
+			return stop
+		}
+
+		return nil
+	})
+
+	if stop != nil {
+		return stop
+	}
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateBatch(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	batch := &entities.Batch{
+		CampaignID: aws.String("campaign-id"),
+		Format:     "pdf",
+		Limit:      500,
+		Count:      500,
+		Checksum:   "checksum",
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "batches" \("id","created_at","campaign_id","prize_id","credential_id","format","offset","limit","count","checksum"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				batch.CampaignID,
+				nil, // PrizeID
+				nil, // CredentialID
+				batch.Format,
+				batch.Offset,
+				batch.Limit,
+				batch.Count,
+				batch.Checksum,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateBatch(batch)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, batch.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "batches"`).WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateBatch(batch)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadBatches(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "batches" ORDER BY created_at DESC`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "format", "count"}).
+				AddRow("batch-2", "pdf", 10).
+				AddRow("batch-1", "csv", 20))
+
+		batches, err := repo.ReadBatches()
+		assert.Nil(t, err)
+		assert.Len(t, batches, 2)
+		assert.Equal(t, "batch-2", batches[0].ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "batches"`).WillReturnError(fmt.Errorf("db error"))
+
+		batches, err := repo.ReadBatches()
+		assert.Nil(t, batches)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestStreamTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Ticket{CampaignID: aws.String("campaign-id")}
+
+	t.Run("successful walk", func(t *testing.T) {
+		// Premier lot avec l'offset, le suivant reprend après le dernier ID lu
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."campaign_id" = \$1 AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$2 OFFSET \$3`).
+			WithArgs(dto.CampaignID, 2, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-1", "100000000008").AddRow("ticket-2", "200000000006"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."campaign_id" = \$1 AND "tickets"\."id" > \$2 AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.CampaignID, "ticket-2", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-3", "300000000004"))
+
+		var ids []string
+		err := repo.StreamTickets(dto, 2, func(tickets []*entities.Ticket) errors.ErrorInterface {
+			for _, ticket := range tickets {
+				ids = append(ids, ticket.ID)
+			}
+
+			return nil
+		}, database.Offset(1), database.Limit(3))
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"ticket-1", "ticket-2", "ticket-3"}, ids)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("callback error stops the walk", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "tickets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-1", "100000000008").AddRow("ticket-2", "200000000006"))
+
+		err := repo.StreamTickets(dto, 2, func(tickets []*entities.Ticket) errors.ErrorInterface {
+			return errors_domain_game.ErrCampaignNotFound
+		})
+
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "tickets"`).WillReturnError(fmt.Errorf("db error"))
+
+		err := repo.StreamTickets(dto, 2, func(tickets []*entities.Ticket) errors.ErrorInterface {
+			return nil
+		})
+
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateCampaign creates a new campaign
+// Inserts the campaign entity, previous campaigns and their tickets are left untouched
+//
+// Parameters:
+// - entity: *entities.Campaign - The campaign entity to persist
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Create(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadCampaign reads a campaign from the database
+// Finds and returns a campaign based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Campaign - The campaign transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Campaign: The found campaign entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	campaign := &entities.Campaign{}
+
+	query := r.store.Engine.Where(&entities.Campaign{ID: stringValue(obj.ID), Label: obj.Label})
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(campaign)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrCampaignNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return campaign, nil
+}
+
+// ReadCampaigns reads the campaigns
+// Finds and returns all the campaigns, the most recent first
+//
+// Parameters:
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Campaign: A slice of found campaign entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadCampaigns(options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	var campaigns []*entities.Campaign
+
+	query := r.store.Engine.Order("created_at DESC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&campaigns)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return campaigns, nil
+}
+
+// UpdateCampaign updates an existing campaign in the database
+// Saves the updated campaign entity
+//
+// Parameters:
+// - entity: *entities.Campaign - The campaign entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// LinkOrphanTickets attaches the tickets without campaign to the given campaign
+// Tickets created before campaigns existed are handed to the first campaign
+//
+// Parameters:
+// - campaign: *entities.Campaign - The campaign owning the orphan tickets
+//
+// Returns:
+// - int: The number of tickets linked
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) LinkOrphanTickets(campaign *entities.Campaign) (int, errors.ErrorInterface) {
+	result := r.store.Engine.Model(&entities.Ticket{}).
+		Where("campaign_id IS NULL").
+		Update("campaign_id", campaign.ID)
+
+	if result.Error != nil {
+		return 0, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
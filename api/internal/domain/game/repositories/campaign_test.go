@@ -0,0 +1,200 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaign := &entities.Campaign{
+		Label:        aws.String("2024"),
+		Distribution: map[string]int{"prize-1": 60},
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "campaigns" \("id","created_at","updated_at","deleted_at","label","start_at","end_at","claim_deadline","timezone","tickets","distribution","eligibility"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				campaign.Label,
+				nil, // StartAt
+				nil, // EndAt
+				nil, // ClaimDeadline
+				nil, // Timezone
+				nil, // Tickets
+				`{"prize-1":60}`,
+				`{"minimum_age":0,"countries":null,"household_limit":0,"household_prizes":null}`,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateCampaign(campaign)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, campaign.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "campaigns"`).WillReturnError(fmt.Errorf("duplicate key"))
+		mock.ExpectRollback()
+
+		err := repo.CreateCampaign(campaign)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Campaign{
+		Label: aws.String("2024"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns" WHERE "campaigns"\."label" = \$1 AND "campaigns"\."deleted_at" IS NULL ORDER BY "campaigns"\."id" LIMIT \$2`).
+			WithArgs(dto.Label, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label", "distribution"}).AddRow("campaign-id", "2024", `{"prize-1":60}`))
+
+		campaign, err := repo.ReadCampaign(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "campaign-id", campaign.ID)
+		assert.Equal(t, map[string]int{"prize-1": 60}, campaign.Distribution)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("campaign not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		campaign, err := repo.ReadCampaign(dto)
+		assert.Nil(t, campaign)
+		assert.Equal(t, "campaign.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		campaign, err := repo.ReadCampaign(dto)
+		assert.Nil(t, campaign)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadCampaigns(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns" WHERE "campaigns"\."deleted_at" IS NULL ORDER BY created_at DESC LIMIT \$1`).
+			WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow("campaign-id", "2024"))
+
+		campaigns, err := repo.ReadCampaigns(database.Limit(1))
+		assert.Nil(t, err)
+		assert.Len(t, campaigns, 1)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "campaigns"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		campaigns, err := repo.ReadCampaigns()
+		assert.Nil(t, campaigns)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateCampaign(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaign := &entities.Campaign{
+		ID:    "campaign-id",
+		Label: aws.String("2024"),
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET`).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateCampaign(campaign)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "campaigns" SET`).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateCampaign(campaign)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLinkOrphanTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	campaign := &entities.Campaign{ID: "campaign-id"}
+
+	t.Run("successful link", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET "campaign_id"=\$1,"updated_at"=\$2 WHERE campaign_id IS NULL AND "tickets"\."deleted_at" IS NULL`).
+			WithArgs(campaign.ID, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		linked, err := repo.LinkOrphanTickets(campaign)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, linked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("link failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		linked, err := repo.LinkOrphanTickets(campaign)
+		assert.Equal(t, 0, linked)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
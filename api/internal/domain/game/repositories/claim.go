@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// CreateClaimAttempt records a code entered by a client to claim a ticket
+//
+// Parameters:
+// - obj: *transfert.ClaimAttempt - The credential, ticket, IP and device of the attempt
+// - failed: bool - Whether the code matched no claimable ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateClaimAttempt(obj *transfert.ClaimAttempt, failed bool, options ...database.Option) errors.ErrorInterface {
+	attempt := entities.CreateClaimAttempt(obj)
+	attempt.Failed = failed
+
+	query := r.store.Engine.Create(attempt)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadClaimSignals counts the attempts made since the given instant with the credential, the IP or the device of a claim
+// An empty IP or device is not compared, attempts without one would otherwise all look alike
+//
+// Parameters:
+// - obj: *transfert.ClaimAttempt - The credential, IP and device of the claim
+// - since: time.Time - Start of the counting window
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.ClaimSignals: The counts, zero when no attempt matches
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadClaimSignals(obj *transfert.ClaimAttempt, since time.Time, options ...database.Option) (*entities.ClaimSignals, errors.ErrorInterface) {
+	signals := &entities.ClaimSignals{}
+
+	var credentialID string
+	if obj.CredentialID != nil {
+		credentialID = *obj.CredentialID
+	}
+
+	scopes, scopeArgs := []string{"credential_id = ?"}, []any{credentialID}
+	columns := []string{"COALESCE(SUM(CASE WHEN credential_id = ? AND failed = ? THEN 1 ELSE 0 END), 0) AS credential_claims"}
+	args := []any{credentialID, false}
+
+	if obj.IP != nil && *obj.IP != "" {
+		scopes, scopeArgs = append(scopes, "ip = ?"), append(scopeArgs, *obj.IP)
+		columns = append(columns,
+			"COALESCE(SUM(CASE WHEN ip = ? AND failed = ? THEN 1 ELSE 0 END), 0) AS ip_claims",
+			"COUNT(DISTINCT CASE WHEN ip = ? AND credential_id <> ? THEN credential_id END) AS ip_accounts",
+		)
+		args = append(args, *obj.IP, false, *obj.IP, credentialID)
+	}
+
+	if obj.Device != nil && *obj.Device != "" {
+		scopes, scopeArgs = append(scopes, "device = ?"), append(scopeArgs, *obj.Device)
+		columns = append(columns, "COALESCE(SUM(CASE WHEN device = ? AND failed = ? THEN 1 ELSE 0 END), 0) AS device_claims")
+		args = append(args, *obj.Device, false)
+	}
+
+	columns = append(columns, "COALESCE(SUM(CASE WHEN failed = ? THEN 1 ELSE 0 END), 0) AS failed_attempts")
+	args = append(args, true)
+
+	query := r.store.Engine.Model(&entities.ClaimAttempt{}).
+		Select(strings.Join(columns, ", "), args...).
+		Where("created_at >= ?", since).
+		Where(strings.Join(scopes, " OR "), scopeArgs...)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(signals)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return signals, nil
+}
+
+// CreateClaimReview holds a suspicious claim, moving its ticket to review and queuing it inside a single transaction
+//
+// Parameters:
+// - entity: *entities.ClaimReview - The review to queue
+// - ticket: *entities.Ticket - The claimed ticket, carrying its review status
+// - history: *transfert.TicketHistory - The status change of the ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the ticket changed in the meantime, ErrEligibilityHouseholdLimit if the household reached its limit, or the error interface if an error occurs
+func (r *GameRepository) CreateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	err := r.transaction(func(tx *gorm.DB) error {
+		if err := updateTicketStatus(tx, ticket, history); err != nil {
+			return err
+		}
+
+		query := tx.Omit("Ticket").Create(entity)
+		for _, option := range options {
+			option(query)
+		}
+
+		return query.Error
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+		if err == errors_domain_game.ErrEligibilityHouseholdSlotTaken {
+			return errors_domain_game.ErrEligibilityHouseholdSlotTaken
+		}
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	entity.Ticket = ticket
+
+	return nil
+}
+
+// ReadClaimReview reads a review from the database
+// Finds and returns a review, with its ticket, based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.ClaimReview - The review transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.ClaimReview: The found review entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadClaimReview(obj *transfert.ClaimReview, options ...database.Option) (*entities.ClaimReview, errors.ErrorInterface) {
+	review := &entities.ClaimReview{}
+
+	query := r.store.Engine.Preload("Ticket").Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(review)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrReviewNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return review, nil
+}
+
+// ReadClaimReviews reads the review queue
+// Finds and returns the reviews, with their ticket, matching the provided transfer object, oldest first
+//
+// Parameters:
+// - obj: *transfert.ClaimReview - The review transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.ClaimReview: A slice of found reviews
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadClaimReviews(obj *transfert.ClaimReview, options ...database.Option) ([]*entities.ClaimReview, errors.ErrorInterface) {
+	var reviews []*entities.ClaimReview
+
+	query := r.store.Engine.Preload("Ticket").Where(obj).Order("created_at ASC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&reviews)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return reviews, nil
+}
+
+// UpdateClaimReview records the decision on a pending review and moves its ticket, inside a single transaction
+// Only one decision is kept when two employees review the same claim concurrently
+//
+// Parameters:
+// - entity: *entities.ClaimReview - The review carrying the decision
+// - ticket: *entities.Ticket - The ticket of the review, carrying its new status
+// - history: *transfert.TicketHistory - The status change of the ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrReviewClosed if the review was decided in the meantime, ErrTicketInvalidTransition if the ticket changed, or the error interface if an error occurs
+func (r *GameRepository) UpdateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	err := r.transaction(func(tx *gorm.DB) error {
+		query := tx.Model(entity).
+			Where("status = ?", entities.ReviewPending).
+			Select("status", "reviewer_id", "reviewed_at", "updated_at").
+			Updates(entity)
+		for _, option := range options {
+			option(query)
+		}
+
+		if query.Error != nil {
+			return query.Error
+		}
+
+		if query.RowsAffected == 0 {
+			return errors_domain_game.ErrReviewClosed
+		}
+
+		return updateTicketStatus(tx, ticket, history)
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrReviewClosed {
+			return errors_domain_game.ErrReviewClosed
+		}
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	entity.Ticket = ticket
+
+	return nil
+}
@@ -0,0 +1,374 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateClaimAttempt(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.ClaimAttempt{
+		CredentialID: aws.String("client-id"),
+		IP:           aws.String("203.0.113.7"),
+		Device:       aws.String("device-42"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "claim_attempts" \("id","created_at","credential_id","ticket_id","ip","device","failed"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				dto.CredentialID,
+				nil, // TicketID
+				"203.0.113.7",
+				"device-42",
+				true,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateClaimAttempt(dto, true)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "claim_attempts"`).WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateClaimAttempt(dto, false)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadClaimSignals(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	since := time.Now().Add(-24 * time.Hour)
+	columns := []string{"credential_claims", "ip_claims", "ip_accounts", "device_claims", "failed_attempts"}
+
+	t.Run("signals of the credential, the IP and the device", func(t *testing.T) {
+		dto := &transfert.ClaimAttempt{
+			CredentialID: aws.String("client-id"),
+			IP:           aws.String("203.0.113.7"),
+			Device:       aws.String("device-42"),
+		}
+
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN credential_id = \$1 AND failed = \$2 THEN 1 ELSE 0 END\), 0\) AS credential_claims, `+
+			`COALESCE\(SUM\(CASE WHEN ip = \$3 AND failed = \$4 THEN 1 ELSE 0 END\), 0\) AS ip_claims, `+
+			`COUNT\(DISTINCT CASE WHEN ip = \$5 AND credential_id <> \$6 THEN credential_id END\) AS ip_accounts, `+
+			`COALESCE\(SUM\(CASE WHEN device = \$7 AND failed = \$8 THEN 1 ELSE 0 END\), 0\) AS device_claims, `+
+			`COALESCE\(SUM\(CASE WHEN failed = \$9 THEN 1 ELSE 0 END\), 0\) AS failed_attempts `+
+			`FROM "claim_attempts" WHERE created_at >= \$10 AND \(credential_id = \$11 OR ip = \$12 OR device = \$13\)`).
+			WithArgs("client-id", false, "203.0.113.7", false, "203.0.113.7", "client-id", "device-42", false, true,
+				since, "client-id", "203.0.113.7", "device-42").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 4, 1, 3, 5))
+
+		signals, err := repo.ReadClaimSignals(dto, since)
+		assert.Nil(t, err)
+		assert.Equal(t, &entities.ClaimSignals{
+			CredentialClaims: 2,
+			IPClaims:         4,
+			IPAccounts:       1,
+			DeviceClaims:     3,
+			FailedAttempts:   5,
+		}, signals)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty IP and device are not compared", func(t *testing.T) {
+		dto := &transfert.ClaimAttempt{
+			CredentialID: aws.String("client-id"),
+			IP:           aws.String(""),
+		}
+
+		mock.ExpectQuery(`SELECT COALESCE\(SUM\(CASE WHEN credential_id = \$1 AND failed = \$2 THEN 1 ELSE 0 END\), 0\) AS credential_claims, `+
+			`COALESCE\(SUM\(CASE WHEN failed = \$3 THEN 1 ELSE 0 END\), 0\) AS failed_attempts `+
+			`FROM "claim_attempts" WHERE created_at >= \$4 AND credential_id = \$5`).
+			WithArgs("client-id", false, true, since, "client-id").
+			WillReturnRows(sqlmock.NewRows([]string{"credential_claims", "failed_attempts"}).AddRow(0, 0))
+
+		signals, err := repo.ReadClaimSignals(dto, since)
+		assert.Nil(t, err)
+		assert.Equal(t, &entities.ClaimSignals{}, signals)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT .* FROM "claim_attempts"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		signals, err := repo.ReadClaimSignals(&transfert.ClaimAttempt{}, since)
+		assert.Nil(t, signals)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateClaimReview(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	ticket := &entities.Ticket{ID: "ticket-id", Status: entities.TicketReview, CredentialID: aws.String("client-id")}
+
+	newReview := func() *entities.ClaimReview {
+		return &entities.ClaimReview{
+			TicketID:       "ticket-id",
+			CredentialID:   "client-id",
+			PreviousStatus: entities.TicketDistributed,
+			Score:          3,
+			Signals:        []string{entities.SignalSharedIP},
+		}
+	}
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("client-id"),
+		PreviousStatus: aws.String("distributed"),
+		Status:         aws.String("review"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		review := newReview()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET .* WHERE status = \$9 AND credential_id IS NULL AND "tickets"."deleted_at" IS NULL AND "id" = \$10`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectExec(`INSERT INTO "claim_reviews" \("id","created_at","updated_at","ticket_id","credential_id","reviewer_id","previous_status","score","signals","status","reviewed_at"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				"ticket-id",
+				"client-id",
+				nil, // ReviewerID
+				entities.TicketDistributed,
+				3,
+				`["shared_ip"]`,
+				entities.ReviewPending,
+				nil, // ReviewedAt
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateClaimReview(review, ticket, history)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, review.ID)
+		assert.Equal(t, ticket, review.Ticket)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.CreateClaimReview(newReview(), ticket, history)
+		assert.Equal(t, "ticket.invalid_transition", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectExec(`INSERT INTO "claim_reviews"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateClaimReview(newReview(), ticket, history)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadClaimReview(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.ClaimReview{ID: aws.String("review-id")}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_reviews" WHERE "claim_reviews"\."id" = \$1 ORDER BY "claim_reviews"\."id" LIMIT \$2`).
+			WithArgs("review-id", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ticket_id", "status"}).AddRow("review-id", "ticket-id", "pending"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."id" = \$1 AND "tickets"\."deleted_at" IS NULL`).
+			WithArgs("ticket-id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("ticket-id", "review"))
+
+		review, err := repo.ReadClaimReview(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "review-id", review.ID)
+		assert.Equal(t, entities.ReviewPending, review.Status)
+		assert.Equal(t, entities.TicketReview, review.Ticket.Status)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("review not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_reviews"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		review, err := repo.ReadClaimReview(dto)
+		assert.Nil(t, review)
+		assert.Equal(t, "review.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_reviews"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		review, err := repo.ReadClaimReview(dto)
+		assert.Nil(t, review)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadClaimReviews(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.ClaimReview{Status: aws.String("pending")}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_reviews" WHERE "claim_reviews"\."status" = \$1 ORDER BY created_at ASC`).
+			WithArgs("pending").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ticket_id"}).AddRow("review-1", "ticket-1").AddRow("review-2", "ticket-2"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."id" IN \(\$1,\$2\) AND "tickets"\."deleted_at" IS NULL`).
+			WithArgs("ticket-1", "ticket-2").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ticket-1").AddRow("ticket-2"))
+
+		reviews, err := repo.ReadClaimReviews(dto)
+		assert.Nil(t, err)
+		assert.Len(t, reviews, 2)
+		assert.Equal(t, "ticket-2", reviews[1].Ticket.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "claim_reviews"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		reviews, err := repo.ReadClaimReviews(dto)
+		assert.Nil(t, reviews)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdateClaimReview(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	now := time.Now()
+	ticket := &entities.Ticket{ID: "ticket-id", Status: entities.TicketClaimed, CredentialID: aws.String("client-id"), StoredCredentialID: aws.String("client-id")}
+
+	newReview := func() *entities.ClaimReview {
+		return &entities.ClaimReview{
+			ID:         "review-id",
+			TicketID:   "ticket-id",
+			Status:     entities.ReviewApproved,
+			ReviewerID: aws.String("employee-id"),
+			ReviewedAt: &now,
+		}
+	}
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("employee-id"),
+		PreviousStatus: aws.String("review"),
+		Status:         aws.String("claimed"),
+	}
+
+	t.Run("successful decision", func(t *testing.T) {
+		review := newReview()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "claim_reviews" SET "updated_at"=\$1,"reviewer_id"=\$2,"status"=\$3,"reviewed_at"=\$4 WHERE status = \$5 AND "id" = \$6`).
+			WithArgs(sqlmock.AnyArg(), "employee-id", entities.ReviewApproved, now, entities.ReviewPending, "review-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets" SET .* WHERE status = \$9 AND credential_id = \$10 AND "tickets"."deleted_at" IS NULL AND "id" = \$11`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectCommit()
+
+		err := repo.UpdateClaimReview(review, ticket, history)
+		assert.Nil(t, err)
+		assert.Equal(t, ticket, review.Ticket)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("review decided in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "claim_reviews"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateClaimReview(newReview(), ticket, history)
+		assert.Equal(t, "review.closed", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "claim_reviews"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateClaimReview(newReview(), ticket, history)
+		assert.Equal(t, "ticket.invalid_transition", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "claim_reviews"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateClaimReview(newReview(), ticket, history)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
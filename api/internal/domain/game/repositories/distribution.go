@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// CountTicketPools counts, for each prize, the tickets matching obj that are not cancelled and those still spare
+// A spare ticket is still generated: it has never been handed over nor claimed
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object with search parameters, usually the campaign
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.TicketPool: One pool per prize
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CountTicketPools(obj *transfert.Ticket, options ...database.Option) ([]*entities.TicketPool, errors.ErrorInterface) {
+	var pools []*entities.TicketPool
+
+	query := r.store.Engine.Model(&entities.Ticket{}).
+		Select(
+			"prize_id, "+
+				"SUM(CASE WHEN status <> ? THEN 1 ELSE 0 END) AS live, "+
+				"SUM(CASE WHEN status = ? AND credential_id IS NULL THEN 1 ELSE 0 END) AS spare",
+			entities.TicketCancelled, entities.TicketGenerated,
+		).
+		Where(entities.CreateTicket(obj)).
+		Where("prize_id IS NOT NULL").
+		Group("prize_id")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(&pools)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return pools, nil
+}
+
+// CancelTickets cancels up to limit spare tickets matching obj and records their history, inside a single transaction
+// Nothing is cancelled when one of the picked tickets changed in the meantime
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object with search parameters, usually the campaign and the prize
+// - limit: int - The maximum number of tickets to cancel
+// - history: *transfert.TicketHistory - The status change applied to every ticket, its ticket ID is filled for each one
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - int: The number of tickets cancelled, lower than limit when no spare ticket is left
+// - errors.ErrorInterface: ErrTicketInvalidTransition if a ticket changed in the meantime, or the error interface if an error occurs
+func (r *GameRepository) CancelTickets(obj *transfert.Ticket, limit int, history *transfert.TicketHistory, options ...database.Option) (int, errors.ErrorInterface) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	var ids []string
+	err := r.transaction(func(tx *gorm.DB) error {
+		ids = nil
+		query := tx.Model(&entities.Ticket{}).
+			Where(entities.CreateTicket(obj)).
+			Where("status = ? AND credential_id IS NULL", entities.TicketGenerated).
+			Order("id").
+			Limit(limit)
+		for _, option := range options {
+			option(query)
+		}
+
+		if err := query.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		result := tx.Model(&entities.Ticket{}).
+			Where("id IN ? AND status = ?", ids, entities.TicketGenerated).
+			Updates(map[string]any{"status": entities.TicketCancelled, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if int(result.RowsAffected) != len(ids) {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+
+		histories := make([]*entities.TicketHistory, len(ids))
+		for i, id := range ids {
+			histories[i] = entities.CreateTicketHistory(history)
+			histories[i].TicketID = &id
+		}
+
+		return createHistories(tx, histories...)
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return 0, errors_domain_game.ErrTicketInvalidTransition
+		}
+		return 0, errors.ErrInternalServer.Log(err)
+	}
+
+	return len(ids), nil
+}
+
+// CreateDistributionChange records a reconfiguration of the prize distribution of a campaign
+//
+// Parameters:
+// - entity: *entities.DistributionChange - The change to persist
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateDistributionChange(entity *entities.DistributionChange, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Create(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadDistributionChanges lists the recorded reconfigurations of the prize distributions
+// Returns the changes matching the options, the most recent first
+//
+// Parameters:
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.DistributionChange: The list of changes
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadDistributionChanges(options ...database.Option) ([]*entities.DistributionChange, errors.ErrorInterface) {
+	var changes []*entities.DistributionChange
+
+	query := r.store.Engine.Order("created_at DESC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&changes)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,198 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountTicketPools(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &transfert.Ticket{CampaignID: aws.String("campaign-id")}
+
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT prize_id, SUM\(CASE WHEN status <> \$1 THEN 1 ELSE 0 END\) AS live, SUM\(CASE WHEN status = \$2 AND credential_id IS NULL THEN 1 ELSE 0 END\) AS spare FROM "tickets" WHERE "tickets"."campaign_id" = \$3 AND prize_id IS NOT NULL AND "tickets"."deleted_at" IS NULL GROUP BY "prize_id"`).
+			WithArgs(entities.TicketCancelled, entities.TicketGenerated, "campaign-id").
+			WillReturnRows(sqlmock.NewRows([]string{"prize_id", "live", "spare"}).
+				AddRow("prize-a", 60, 50).
+				AddRow("prize-b", 40, 0))
+
+		pools, err := repo.CountTicketPools(obj)
+		assert.Nil(t, err)
+		assert.Equal(t, []*entities.TicketPool{
+			{PrizeID: "prize-a", Live: 60, Spare: 50},
+			{PrizeID: "prize-b", Live: 40, Spare: 0},
+		}, pools)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT prize_id`).WillReturnError(fmt.Errorf("database error"))
+
+		pools, err := repo.CountTicketPools(obj)
+		assert.Nil(t, pools)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCancelTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &transfert.Ticket{CampaignID: aws.String("campaign-id"), PrizeID: aws.String("prize-id")}
+	history := &transfert.TicketHistory{
+		CredentialID:   aws.String("admin-id"),
+		PreviousStatus: aws.String("generated"),
+		Status:         aws.String("cancelled"),
+	}
+
+	t.Run("successful cancellation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "tickets" WHERE \("tickets"."prize_id" = \$1 AND "tickets"."campaign_id" = \$2\) AND \(status = \$3 AND credential_id IS NULL\) AND "tickets"."deleted_at" IS NULL ORDER BY id LIMIT \$4`).
+			WithArgs("prize-id", "campaign-id", entities.TicketGenerated, 2).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ticket-1").AddRow("ticket-2"))
+		mock.ExpectExec(`UPDATE "tickets" SET "status"=\$1,"updated_at"=\$2 WHERE \(id IN \(\$3,\$4\) AND status = \$5\) AND "tickets"."deleted_at" IS NULL`).
+			WithArgs(entities.TicketCancelled, sqlmock.AnyArg(), "ticket-1", "ticket-2", entities.TicketGenerated).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 2))
+		expectLedger(mock, 2)
+		mock.ExpectCommit()
+
+		cancelled, err := repo.CancelTickets(obj, 2, history)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, cancelled)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no spare ticket left", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "tickets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		cancelled, err := repo.CancelTickets(obj, 2, history)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, cancelled)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to cancel", func(t *testing.T) {
+		cancelled, err := repo.CancelTickets(obj, 0, history)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, cancelled)
+	})
+
+	t.Run("ticket changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "tickets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ticket-1").AddRow("ticket-2"))
+		mock.ExpectExec(`UPDATE "tickets"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		cancelled, err := repo.CancelTickets(obj, 2, history)
+		assert.Equal(t, 0, cancelled)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id" FROM "tickets"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		cancelled, err := repo.CancelTickets(obj, 2, history)
+		assert.Equal(t, 0, cancelled)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateDistributionChange(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	change := &entities.DistributionChange{
+		CampaignID:   aws.String("campaign-id"),
+		CredentialID: aws.String("admin-id"),
+		Tickets:      100,
+		Distribution: map[string]int{"prize-id": 100},
+		Lines:        []*entities.DistributionLine{{PrizeID: "prize-id", Share: 100, Target: 100, Generate: 100}},
+		Generated:    100,
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "distribution_changes" \("id","created_at","campaign_id","credential_id","previous_tickets","tickets","previous_distribution","distribution","lines","generated","cancelled","remaining"\)`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateDistributionChange(change)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, change.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "distribution_changes"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateDistributionChange(change)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadDistributionChanges(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "distribution_changes" WHERE campaign_id = \$1 ORDER BY created_at DESC`).
+			WithArgs("campaign-id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "tickets", "distribution", "lines"}).
+				AddRow("change-id", "campaign-id", 100, `{"prize-id":100}`, `[{"prize_id":"prize-id","target":100}]`))
+
+		changes, err := repo.ReadDistributionChanges(database.Where("campaign_id = ?", "campaign-id"))
+		assert.Nil(t, err)
+		assert.Len(t, changes, 1)
+		assert.Equal(t, "change-id", changes[0].ID)
+		assert.Equal(t, map[string]int{"prize-id": 100}, changes[0].Distribution)
+		assert.Equal(t, 100, changes[0].Lines[0].Target)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "distribution_changes"`).WillReturnError(fmt.Errorf("database error"))
+
+		changes, err := repo.ReadDistributionChanges()
+		assert.Nil(t, changes)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateDraw records the result of a draw
+// Inserts the draw entity, the unique campaign index prevents a second draw for the same campaign,
+// including one run concurrently after the check of the service
+//
+// Parameters:
+// - entity: *entities.Draw - The draw entity to persist
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrDrawAlreadyDone if the campaign already has a draw, or the error interface if an error occurs
+func (r *GameRepository) CreateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Create(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if database.IsDuplicate(r.store.Engine, query.Error) {
+		return errors_domain_game.ErrDrawAlreadyDone
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadDraw reads a draw from the database
+// Finds and returns a draw based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Draw - The draw transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Draw: The found draw entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	draw := &entities.Draw{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(draw)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrDrawNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return draw, nil
+}
+
+// ReadDrawParticipants lists the population eligible to the grand draw
+// Returns the distinct credentials owning at least one claimed or redeemed ticket matching obj, in ascending order
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object restricting the population, usually to a campaign
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []string: The ordered credential IDs of the participants
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadDrawParticipants(obj *transfert.Ticket, options ...database.Option) ([]string, errors.ErrorInterface) {
+	var participants []string
+
+	query := r.store.Engine.Model(&entities.Ticket{}).Where(obj).
+		Where("credential_id IS NOT NULL AND status IN ?", []entities.TicketStatus{entities.TicketClaimed, entities.TicketRedeemed})
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Distinct("credential_id").Order("credential_id ASC").Pluck("credential_id", &participants)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return participants, nil
+}
@@ -0,0 +1,153 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5/pgconn"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateDraw(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	draw := &entities.Draw{
+		CampaignID:     aws.String("campaign-id"),
+		Seed:           "seed",
+		Participants:   3,
+		ParticipantIDs: []string{"cred-1", "cred-2", "cred-3"},
+		Checksum:       "checksum",
+		WinnerID:       aws.String("cred-2"),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "draws" \("id","created_at","campaign_id","winner_id","credential_id","seed","participants","participant_ids","checksum"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				draw.CampaignID,
+				draw.WinnerID,
+				nil, // CredentialID
+				draw.Seed,
+				draw.Participants,
+				`["cred-1","cred-2","cred-3"]`,
+				draw.Checksum,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateDraw(draw)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, draw.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("campaign already drawn", func(t *testing.T) {
+		// Un tirage concurrent a été enregistré après la vérification du service
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "draws"`).WillReturnError(&pgconn.PgError{Code: "23505"})
+		mock.ExpectRollback()
+
+		err := repo.CreateDraw(draw)
+		assert.NotNil(t, err)
+		assert.Equal(t, "draw.already_done", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "draws"`).WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateDraw(draw)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadDraw(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Draw{
+		CampaignID: aws.String("campaign-id"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws" WHERE "draws"\."campaign_id" = \$1 ORDER BY "draws"\."id" LIMIT \$2`).
+			WithArgs(dto.CampaignID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "seed", "participant_ids"}).AddRow("draw-id", "campaign-id", "seed", `["cred-1","cred-2"]`))
+
+		draw, err := repo.ReadDraw(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "draw-id", draw.ID)
+		assert.Equal(t, "seed", draw.Seed)
+		assert.Equal(t, []string{"cred-1", "cred-2"}, draw.ParticipantIDs)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("draw not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		draw, err := repo.ReadDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, "draw.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "draws"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		draw, err := repo.ReadDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadDrawParticipants(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Ticket{
+		CampaignID: aws.String("campaign-id"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT DISTINCT "credential_id" FROM "tickets" WHERE "tickets"\."campaign_id" = \$1 AND \(credential_id IS NOT NULL AND status IN \(\$2,\$3\)\) AND "tickets"\."deleted_at" IS NULL ORDER BY credential_id ASC`).
+			WithArgs(dto.CampaignID, entities.TicketClaimed, entities.TicketRedeemed).
+			WillReturnRows(sqlmock.NewRows([]string{"credential_id"}).AddRow("cred-1").AddRow("cred-2"))
+
+		participants, err := repo.ReadDrawParticipants(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"cred-1", "cred-2"}, participants)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT DISTINCT "credential_id" FROM "tickets"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		participants, err := repo.ReadDrawParticipants(dto)
+		assert.Nil(t, participants)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
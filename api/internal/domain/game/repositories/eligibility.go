@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// ReadHouseholdSlots reads the household limit slots taken by the tickets of a household during a campaign
+//
+// Parameters:
+// - campaignID: string - The ID of the campaign the limit belongs to
+// - household: string - The key of the postal address
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []int: The taken slots
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadHouseholdSlots(campaignID, household string, options ...database.Option) ([]int, errors.ErrorInterface) {
+	var slots []int
+
+	query := r.store.Engine.Model(&entities.Ticket{}).
+		Where("campaign_id = ? AND household = ? AND household_slot IS NOT NULL", campaignID, household)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Pluck("household_slot", &slots)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return slots, nil
+}
@@ -0,0 +1,102 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5/pgconn"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadHouseholdSlots(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "household_slot" FROM "tickets" WHERE \(campaign_id = \$1 AND household = \$2 AND household_slot IS NOT NULL\) AND "tickets"."deleted_at" IS NULL`).
+			WithArgs("campaign-id", "household-1").
+			WillReturnRows(sqlmock.NewRows([]string{"household_slot"}).AddRow(1).AddRow(3))
+
+		slots, err := repo.ReadHouseholdSlots("campaign-id", "household-1")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 3}, slots)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT "household_slot" FROM "tickets"`).WillReturnError(fmt.Errorf("db error"))
+
+		slots, err := repo.ReadHouseholdSlots("campaign-id", "household-1")
+		assert.Nil(t, slots)
+		assert.Equal(t, "common.internal_error", err.Error())
+	})
+}
+
+func TestUpdateTicketStatusHousehold(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("credential-id"),
+		OwnerID:        aws.String("credential-id"),
+		PreviousStatus: aws.String("generated"),
+		Status:         aws.String("claimed"),
+	}
+
+	// ticket occupe la deuxième place du foyer de son nouveau propriétaire
+	ticket := func() *entities.Ticket {
+		return &entities.Ticket{
+			ID:            "ticket-id",
+			CredentialID:  aws.String("credential-id"),
+			Status:        entities.TicketClaimed,
+			Household:     aws.String("household-1"),
+			HouseholdSlot: aws.Int(2),
+		}
+	}
+
+	t.Run("free slot", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET .*"household"=\$7,"household_slot"=\$8 WHERE`).
+			WithArgs(sqlmock.AnyArg(), "credential-id", entities.TicketClaimed, nil, nil, nil, "household-1", 2, "generated", "ticket-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectCommit()
+
+		err := repo.UpdateTicketStatus(ticket(), history)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("slot taken by another ticket of the household", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketStatus(ticket(), history)
+		assert.Equal(t, errors_domain_game.ErrEligibilityHouseholdSlotTaken, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketStatus(ticket(), history)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,153 @@
+package repositories
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// expiring is a ticket picked to expire, with what its history entry needs
+type expiring struct {
+	ID           string
+	PrizeID      *string
+	CredentialID *string
+	Status       entities.TicketStatus
+}
+
+// ExpireTickets expires up to limit tickets matching obj in one of the given statuses and records their history, inside a single transaction
+// The tickets stay in place, flagged expired; nothing is expired when one of the picked tickets changed in the meantime
+//
+// Parameters:
+// - obj: *transfert.Ticket - The ticket transfer object with search parameters, usually the campaign
+// - from: []entities.TicketStatus - The statuses of the tickets to expire
+// - limit: int - The maximum number of tickets to expire
+// - history: *transfert.TicketHistory - The status change applied to every ticket, its ticket, previous status and owner are filled for each one
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - map[string]int: The number of tickets expired per prize ID, lower than limit in total when no ticket is left
+// - errors.ErrorInterface: ErrTicketInvalidTransition if a ticket changed in the meantime, or the error interface if an error occurs
+func (r *GameRepository) ExpireTickets(obj *transfert.Ticket, from []entities.TicketStatus, limit int, history *transfert.TicketHistory, options ...database.Option) (map[string]int, errors.ErrorInterface) {
+	expired := map[string]int{}
+	if limit <= 0 || len(from) == 0 {
+		return expired, nil
+	}
+
+	var tickets []*expiring
+	err := r.transaction(func(tx *gorm.DB) error {
+		tickets = nil
+		query := tx.Model(&entities.Ticket{}).
+			Select("id", "prize_id", "credential_id", "status").
+			Where(entities.CreateTicket(obj)).
+			Where("status IN ?", from).
+			Order("id").
+			Limit(limit)
+		for _, option := range options {
+			option(query)
+		}
+
+		if err := query.Scan(&tickets).Error; err != nil {
+			return err
+		}
+
+		if len(tickets) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			ids[i] = ticket.ID
+		}
+
+		result := tx.Model(&entities.Ticket{}).
+			Where("id IN ? AND status IN ?", ids, from).
+			Updates(map[string]any{"status": entities.TicketExpired, "updated_at": time.Now()})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if int(result.RowsAffected) != len(ids) {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+
+		histories := make([]*entities.TicketHistory, len(tickets))
+		for i, ticket := range tickets {
+			histories[i] = entities.CreateTicketHistory(history)
+			histories[i].TicketID = &ticket.ID
+			histories[i].PreviousStatus = ticket.Status
+			histories[i].OwnerID = ticket.CredentialID
+		}
+
+		return createHistories(tx, histories...)
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return nil, errors_domain_game.ErrTicketInvalidTransition
+		}
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	for _, ticket := range tickets {
+		prizeID := ""
+		if ticket.PrizeID != nil {
+			prizeID = *ticket.PrizeID
+		}
+
+		expired[prizeID]++
+	}
+
+	return expired, nil
+}
+
+// CreateTicketExpiry records the tickets of a campaign forfeited at its deadlines
+//
+// Parameters:
+// - entity: *entities.TicketExpiry - The summary to persist
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateTicketExpiry(entity *entities.TicketExpiry, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Create(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// ReadTicketExpiries lists the recorded expiries
+// Returns the expiries matching the options, the most recent first
+//
+// Parameters:
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.TicketExpiry: The list of expiries
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadTicketExpiries(options ...database.Option) ([]*entities.TicketExpiry, errors.ErrorInterface) {
+	var expiries []*entities.TicketExpiry
+
+	query := r.store.Engine.Order("created_at DESC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&expiries)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return expiries, nil
+}
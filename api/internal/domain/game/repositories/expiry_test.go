@@ -0,0 +1,177 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpireTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	obj := &transfert.Ticket{CampaignID: aws.String("campaign-id")}
+	from := []entities.TicketStatus{entities.TicketGenerated, entities.TicketClaimed}
+	history := &transfert.TicketHistory{
+		CredentialID: aws.String("admin-id"),
+		Status:       aws.String("expired"),
+	}
+
+	t.Run("successful expiry", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id","prize_id","credential_id","status" FROM "tickets" WHERE "tickets"."campaign_id" = \$1 AND status IN \(\$2,\$3\) AND "tickets"."deleted_at" IS NULL ORDER BY id LIMIT \$4`).
+			WithArgs("campaign-id", entities.TicketGenerated, entities.TicketClaimed, 3).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "credential_id", "status"}).
+				AddRow("ticket-1", "prize-a", nil, "generated").
+				AddRow("ticket-2", "prize-a", "client-id", "claimed").
+				AddRow("ticket-3", "prize-b", nil, "generated"))
+		mock.ExpectExec(`UPDATE "tickets" SET "status"=\$1,"updated_at"=\$2 WHERE \(id IN \(\$3,\$4,\$5\) AND status IN \(\$6,\$7\)\) AND "tickets"."deleted_at" IS NULL`).
+			WithArgs(entities.TicketExpired, sqlmock.AnyArg(), "ticket-1", "ticket-2", "ticket-3", entities.TicketGenerated, entities.TicketClaimed).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`INSERT INTO "ticket_histories" \("id","created_at","ticket_id","credential_id","store_id","caisse_id","owner_id","previous_status","status"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), sqlmock.AnyArg(), "ticket-1", "admin-id", nil, nil, nil, entities.TicketGenerated, entities.TicketExpired,
+				sqlmock.AnyArg(), sqlmock.AnyArg(), "ticket-2", "admin-id", nil, nil, "client-id", entities.TicketClaimed, entities.TicketExpired,
+				sqlmock.AnyArg(), sqlmock.AnyArg(), "ticket-3", "admin-id", nil, nil, nil, entities.TicketGenerated, entities.TicketExpired,
+			).
+			WillReturnResult(sqlmock.NewResult(1, 3))
+		expectLedger(mock, 3)
+		mock.ExpectCommit()
+
+		expired, err := repo.ExpireTickets(obj, from, 3, history)
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"prize-a": 2, "prize-b": 1}, expired)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no ticket left", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id","prize_id","credential_id","status" FROM "tickets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "credential_id", "status"}))
+		mock.ExpectCommit()
+
+		expired, err := repo.ExpireTickets(obj, from, 3, history)
+		assert.Nil(t, err)
+		assert.Empty(t, expired)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing to expire", func(t *testing.T) {
+		expired, err := repo.ExpireTickets(obj, from, 0, history)
+		assert.Nil(t, err)
+		assert.Empty(t, expired)
+
+		expired, err = repo.ExpireTickets(obj, nil, 3, history)
+		assert.Nil(t, err)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("ticket changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id","prize_id","credential_id","status" FROM "tickets"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "credential_id", "status"}).
+				AddRow("ticket-1", "prize-a", nil, "generated").
+				AddRow("ticket-2", "prize-a", "client-id", "claimed"))
+		mock.ExpectExec(`UPDATE "tickets"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectRollback()
+
+		expired, err := repo.ExpireTickets(obj, from, 3, history)
+		assert.Nil(t, expired)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT "id","prize_id","credential_id","status" FROM "tickets"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		expired, err := repo.ExpireTickets(obj, from, 3, history)
+		assert.Nil(t, expired)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCreateTicketExpiry(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	expiry := &entities.TicketExpiry{
+		CampaignID:     aws.String("campaign-id"),
+		Unclaimed:      2,
+		UnclaimedValue: 17.8,
+		Lines:          []*entities.ExpiryLine{{PrizeID: "prize-a", Value: 8.9, Unclaimed: 2}},
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_expiries" \("id","created_at","campaign_id","credential_id","unclaimed","uncollected","unclaimed_value","uncollected_value","lines"\)`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateTicketExpiry(expiry)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, expiry.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_expiries"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateTicketExpiry(expiry)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadTicketExpiries(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_expiries" WHERE campaign_id = \$1 ORDER BY created_at DESC`).
+			WithArgs("campaign-id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "campaign_id", "uncollected", "uncollected_value", "lines"}).
+				AddRow("expiry-id", "campaign-id", 1, 39, `[{"prize_id":"prize-b","value":39,"uncollected":1}]`))
+
+		expiries, err := repo.ReadTicketExpiries(database.Where("campaign_id = ?", "campaign-id"))
+		assert.Nil(t, err)
+		if assert.Len(t, expiries, 1) {
+			assert.Equal(t, "expiry-id", expiries[0].ID)
+			assert.Equal(t, 39.0, expiries[0].UncollectedValue)
+			assert.Equal(t, 1, expiries[0].Lines[0].Uncollected)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_expiries"`).WillReturnError(fmt.Errorf("database error"))
+
+		expiries, err := repo.ReadTicketExpiries()
+		assert.Nil(t, expiries)
+		assert.Equal(t, errors.ErrInternalServer, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,11 +1,15 @@
 package repositories
 
 import (
+	"time"
+
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GameRepository struct {
@@ -16,20 +20,92 @@ type GameRepositoryInterface interface {
 	// Ticket
 	CreateTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface)
 	CreateTickets(objs []*transfert.Ticket, options ...database.Option) errors.ErrorInterface
+	InsertTickets(objs []*transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface)
 	ReadTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface)
 	ReadTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Ticket, errors.ErrorInterface)
 	UpdateTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface
 	DeleteTicket(obj *transfert.Ticket, options ...database.Option) errors.ErrorInterface
 	CountTicket(obj *transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface)
+	UpdateTicketStatus(entity *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface
+
+	// TicketHistory
+	ReadTicketHistories(obj *transfert.TicketHistory, options ...database.Option) ([]*entities.TicketHistory, errors.ErrorInterface)
+
+	// Prize
+	CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface)
+	ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface)
+	ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface)
+	UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface
+	DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface
+	LinkLegacyPrizes() (int, errors.ErrorInterface)
+
+	// Campaign
+	CreateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface
+	ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface)
+	ReadCampaigns(options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface)
+	UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface
+	LinkOrphanTickets(campaign *entities.Campaign) (int, errors.ErrorInterface)
+
+	// Draw
+	CreateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface
+	ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface)
+	ReadDrawParticipants(obj *transfert.Ticket, options ...database.Option) ([]string, errors.ErrorInterface)
+
+	// Receipt
+	CreateReceipt(entity *entities.Receipt, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface
+	ReadReceipt(obj *transfert.Receipt, options ...database.Option) (*entities.Receipt, errors.ErrorInterface)
+
+	// Claim
+	CreateClaimAttempt(obj *transfert.ClaimAttempt, failed bool, options ...database.Option) errors.ErrorInterface
+	ReadClaimSignals(obj *transfert.ClaimAttempt, since time.Time, options ...database.Option) (*entities.ClaimSignals, errors.ErrorInterface)
+	CreateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface
+	ReadClaimReview(obj *transfert.ClaimReview, options ...database.Option) (*entities.ClaimReview, errors.ErrorInterface)
+	ReadClaimReviews(obj *transfert.ClaimReview, options ...database.Option) ([]*entities.ClaimReview, errors.ErrorInterface)
+	UpdateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface
+
+	// Distribution
+	CountTicketPools(obj *transfert.Ticket, options ...database.Option) ([]*entities.TicketPool, errors.ErrorInterface)
+	CancelTickets(obj *transfert.Ticket, limit int, history *transfert.TicketHistory, options ...database.Option) (int, errors.ErrorInterface)
+	CreateDistributionChange(entity *entities.DistributionChange, options ...database.Option) errors.ErrorInterface
+	ReadDistributionChanges(options ...database.Option) ([]*entities.DistributionChange, errors.ErrorInterface)
+
+	// Expiry
+	ExpireTickets(obj *transfert.Ticket, from []entities.TicketStatus, limit int, history *transfert.TicketHistory, options ...database.Option) (map[string]int, errors.ErrorInterface)
+	CreateTicketExpiry(entity *entities.TicketExpiry, options ...database.Option) errors.ErrorInterface
+	ReadTicketExpiries(options ...database.Option) ([]*entities.TicketExpiry, errors.ErrorInterface)
+
+	// Ledger
+	StreamLedger(size int, fn func([]*entities.LedgerEntry) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface
+	RecordTickets(limit int) (int, errors.ErrorInterface)
+
+	// Eligibility
+	ReadHouseholdSlots(campaignID, household string, options ...database.Option) ([]int, errors.ErrorInterface)
+
+	// Transfer
+	CreateTicketTransfer(entity *entities.TicketTransfer, options ...database.Option) errors.ErrorInterface
+	ReadTicketTransfer(obj *transfert.TicketTransfer, options ...database.Option) (*entities.TicketTransfer, errors.ErrorInterface)
+	CountTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) (int, errors.ErrorInterface)
+	ReadTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) ([]*entities.TicketTransfer, errors.ErrorInterface)
+	UpdateTicketTransfer(entity *entities.TicketTransfer, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface
+
+	// Batch
+	CreateBatch(entity *entities.Batch, options ...database.Option) errors.ErrorInterface
+	ReadBatches(options ...database.Option) ([]*entities.Batch, errors.ErrorInterface)
+	StreamTickets(obj *transfert.Ticket, size int, fn func([]*entities.Ticket) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface
+
+	// Statistics
+	CountTicketsByPrize(period *entities.Period, options ...database.Option) ([]*entities.PrizeStatistic, errors.ErrorInterface)
+	CountClaimsByPeriod(period *entities.Period, interval string, options ...database.Option) ([]*entities.PeriodStatistic, errors.ErrorInterface)
+	CountStatusesByCaisse(period *entities.Period, options ...database.Option) ([]*entities.StoreStatistic, errors.ErrorInterface)
 }
 
 func NewGameRepository(store *database.Database) *GameRepository {
-	store.Engine.AutoMigrate(entities.Ticket{})
+	store.Engine.AutoMigrate(entities.Prize{}, entities.Campaign{}, entities.Ticket{}, entities.TicketHistory{}, entities.Draw{}, entities.Batch{}, entities.Receipt{}, entities.ClaimAttempt{}, entities.ClaimReview{}, entities.DistributionChange{}, entities.TicketTransfer{}, entities.TicketExpiry{}, entities.LedgerEntry{})
 	return &GameRepository{store}
 }
 
 // CreateTicket creates a new ticket
-// Inserts a new ticket into the database based on the transfert.Ticket input object
+// Inserts a new ticket into the database based on the transfert.Ticket input object, and records its creation in the ledger
 //
 // Parameters:
 // - obj: *transfert.Ticket - The ticket transfer object to create
@@ -41,21 +117,29 @@ func NewGameRepository(store *database.Database) *GameRepository {
 func (r *GameRepository) CreateTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface) {
 	ticket := entities.CreateTicket(obj)
 
-	// Applique les options à la requête
-	query := r.store.Engine.Create(ticket)
-	for _, option := range options {
-		option(query)
-	}
+	err := r.transaction(func(tx *gorm.DB) error {
+		// Applique les options à la requête
+		query := tx.Create(ticket)
+		for _, option := range options {
+			option(query)
+		}
 
-	if query.Error != nil {
-		return nil, errors.ErrInternalServer.Log(query.Error)
+		if query.Error != nil {
+			return query.Error
+		}
+
+		return createdLedger(tx, ticket)
+	})
+
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
 	}
 
 	return ticket, nil
 }
 
 // CreateTickets creates multiple tickets
-// Inserts multiple tickets into the database in a single batch operation
+// Inserts multiple tickets into the database in a single batch operation, and records their creation in the ledger
 //
 // Parameters:
 // - objs: []*transfert.Ticket - The slice of ticket transfer objects to create
@@ -70,20 +154,100 @@ func (r *GameRepository) CreateTickets(objs []*transfert.Ticket, options ...data
 		tickets[i] = entities.CreateTicket(obj)
 	}
 
-	query := r.store.Engine.CreateInBatches(tickets, len(tickets))
-	for _, option := range options {
-		option(query)
-	}
+	err := r.transaction(func(tx *gorm.DB) error {
+		query := tx.CreateInBatches(tickets, len(tickets))
+		for _, option := range options {
+			option(query)
+		}
 
-	if query.Error != nil {
-		return errors.ErrInternalServer.Log(query.Error)
+		if query.Error != nil {
+			return query.Error
+		}
+
+		return createdLedger(tx, tickets...)
+	})
+
+	if err != nil {
+		return errors.ErrInternalServer.Log(err)
 	}
 
 	return nil
 }
 
+// InsertTickets inserts a batch of tickets, skipping those whose token is already taken
+// The batch is written in a single transaction, the unique index on the token settles
+// collisions in the database so concurrent generators never produce the same code,
+// and the creation of the tickets inserted is recorded in the ledger
+//
+// Parameters:
+// - objs: []*transfert.Ticket - The slice of ticket transfer objects to insert
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - int: The number of tickets inserted, lower than len(objs) when tokens collided
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) InsertTickets(objs []*transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface) {
+	if len(objs) == 0 {
+		return 0, nil
+	}
+
+	tickets := make([]*entities.Ticket, len(objs))
+	for i, obj := range objs {
+		tickets[i] = entities.CreateTicket(obj)
+	}
+
+	var inserted int64
+	err := r.transaction(func(tx *gorm.DB) error {
+		query := tx.Clauses(clause.OnConflict{DoNothing: true})
+		for _, option := range options {
+			option(query)
+		}
+
+		result := query.Create(&tickets)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		inserted = result.RowsAffected
+		if int(inserted) == len(tickets) {
+			return createdLedger(tx, tickets...)
+		}
+
+		// Only the tickets actually inserted enter the ledger, those colliding kept their generated ID
+		ids := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			ids[i] = ticket.ID
+		}
+
+		var stored []string
+		if err := tx.Model(&entities.Ticket{}).Where("id IN ?", ids).Pluck("id", &stored).Error; err != nil {
+			return err
+		}
+
+		kept := make(map[string]bool, len(stored))
+		for _, id := range stored {
+			kept[id] = true
+		}
+
+		created := make([]*entities.Ticket, 0, len(stored))
+		for _, ticket := range tickets {
+			if kept[ticket.ID] {
+				created = append(created, ticket)
+			}
+		}
+
+		return createdLedger(tx, created...)
+	})
+
+	if err != nil {
+		return 0, errors.ErrInternalServer.Log(err)
+	}
+
+	return int(inserted), nil
+}
+
 // ReadTickets reads multiple tickets from the database
-// Finds and returns a list of tickets based on the provided transfer object and options
+// Finds and returns a list of tickets, with their prize, based on the provided transfer object and options
 //
 // Parameters:
 // - obj: *transfert.Ticket - The ticket transfer object with search parameters
@@ -95,7 +259,7 @@ func (r *GameRepository) CreateTickets(objs []*transfert.Ticket, options ...data
 func (r *GameRepository) ReadTickets(obj *transfert.Ticket, options ...database.Option) ([]*entities.Ticket, errors.ErrorInterface) {
 	var tickets []*entities.Ticket
 
-	query := r.store.Engine.Where(obj)
+	query := r.store.Engine.Preload("Prize").Where(obj)
 	for _, option := range options {
 		option(query)
 	}
@@ -110,7 +274,7 @@ func (r *GameRepository) ReadTickets(obj *transfert.Ticket, options ...database.
 }
 
 // ReadTicket reads a ticket from the database
-// Finds and returns a ticket based on the provided transfer object and options
+// Finds and returns a ticket, with its prize, based on the provided transfer object and options
 //
 // Parameters:
 // - obj: *transfert.Ticket - The ticket transfer object with search parameters
@@ -122,7 +286,7 @@ func (r *GameRepository) ReadTickets(obj *transfert.Ticket, options ...database.
 func (r *GameRepository) ReadTicket(obj *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface) {
 	ticket := &entities.Ticket{}
 
-	query := r.store.Engine.Where(obj)
+	query := r.store.Engine.Preload("Prize").Where(obj)
 	for _, option := range options {
 		option(query)
 	}
@@ -140,7 +304,7 @@ func (r *GameRepository) ReadTicket(obj *transfert.Ticket, options ...database.O
 }
 
 // UpdateTicket updates an existing ticket in the database
-// Saves the updated ticket entity
+// Saves the updated ticket entity, leaving its prize untouched
 //
 // Parameters:
 // - entity: *entities.Ticket - The ticket entity to update
@@ -149,7 +313,7 @@ func (r *GameRepository) ReadTicket(obj *transfert.Ticket, options ...database.O
 // Returns:
 // - errors.ErrorInterface: The error interface if an error occurs
 func (r *GameRepository) UpdateTicket(entity *entities.Ticket, options ...database.Option) errors.ErrorInterface {
-	query := r.store.Engine.Save(entity)
+	query := r.store.Engine.Omit(clause.Associations).Save(entity)
 	for _, option := range options {
 		option(query)
 	}
@@ -211,3 +375,100 @@ func (r *GameRepository) CountTicket(obj *transfert.Ticket, options ...database.
 
 	return int(count), nil
 }
+
+// UpdateTicketStatus moves a ticket to a new status and records the transition
+// Updates the ticket only if its status in database still matches history.PreviousStatus
+// and its owner still matches the one it was read with, then appends the history entry, both inside a single transaction
+//
+// Parameters:
+// - entity: *entities.Ticket - The ticket entity carrying the new status
+// - history: *transfert.TicketHistory - The transition to record
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the ticket changed in the meantime, ErrEligibilityHouseholdLimit if the household reached its limit, or the error interface if an error occurs
+func (r *GameRepository) UpdateTicketStatus(entity *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	err := r.transaction(func(tx *gorm.DB) error {
+		return updateTicketStatus(tx, entity, history, options...)
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+		if err == errors_domain_game.ErrEligibilityHouseholdSlotTaken {
+			return errors_domain_game.ErrEligibilityHouseholdSlotTaken
+		}
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
+// updateTicketStatus saves the ticket status if it is still the previous status of the history entry
+// and the ticket still belongs to the owner it was read with, then appends the history entry, within the given transaction
+// A household slot already taken by another ticket is reported as ErrEligibilityHouseholdSlotTaken
+func updateTicketStatus(tx *gorm.DB, entity *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) error {
+	var previous string
+	if history.PreviousStatus != nil {
+		previous = *history.PreviousStatus
+	}
+
+	query := tx.Model(entity).Where("status = ?", previous)
+	if entity.StoredCredentialID == nil {
+		query = query.Where("credential_id IS NULL")
+	} else {
+		query = query.Where("credential_id = ?", *entity.StoredCredentialID)
+	}
+
+	query = query.
+		Select("status", "credential_id", "claimed_at", "redeemed_at", "receipt_photo", "household", "household_slot", "updated_at").
+		Updates(entity)
+
+	for _, option := range options {
+		option(query)
+	}
+
+	if database.IsDuplicate(tx, query.Error) {
+		return errors_domain_game.ErrEligibilityHouseholdSlotTaken
+	}
+
+	if query.Error != nil {
+		return query.Error
+	}
+
+	if query.RowsAffected == 0 {
+		return errors_domain_game.ErrTicketInvalidTransition
+	}
+
+	entity.StoredCredentialID = entity.CredentialID
+
+	return createHistories(tx, entities.CreateTicketHistory(history))
+}
+
+// ReadTicketHistories reads the status history of tickets
+// Finds and returns the history entries matching the provided transfer object, oldest first
+//
+// Parameters:
+// - obj: *transfert.TicketHistory - The history transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.TicketHistory: A slice of found history entries
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadTicketHistories(obj *transfert.TicketHistory, options ...database.Option) ([]*entities.TicketHistory, errors.ErrorInterface) {
+	var histories []*entities.TicketHistory
+
+	query := r.store.Engine.Where(obj).Order("created_at ASC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&histories)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return histories, nil
+}
@@ -14,7 +14,9 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func setup() (*repositories.GameRepository, sqlmock.Sqlmock, func()) {
@@ -31,22 +33,30 @@ func TestCreateTicket(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful creation", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID
-				sqlmock.AnyArg(), // CreatedAt
-				sqlmock.AnyArg(), // UpdatedAt
-				nil,              // DeletedAt
-				nil,              // CredentialID
-				dto.Token,        // Token
-				dto.Prize,        // Prize
+				sqlmock.AnyArg(),         // ID
+				sqlmock.AnyArg(),         // CreatedAt
+				sqlmock.AnyArg(),         // UpdatedAt
+				nil,                      // DeletedAt
+				nil,                      // CredentialID
+				dto.Token,                // Token
+				dto.PrizeID,              // Prize
+				nil,                      // CampaignID
+				entities.TicketGenerated, // Status
+				nil,                      // ClaimedAt
+				nil,                      // RedeemedAt
+				nil,                      // ReceiptPhoto
+				nil,                      // Household
+				nil,                      // HouseholdSlot
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
 		mock.ExpectCommit()
 
 		entity, err := repo.CreateTicket(dto)
@@ -62,7 +72,7 @@ func TestCreateTicket(t *testing.T) {
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -70,7 +80,14 @@ func TestCreateTicket(t *testing.T) {
 				nil,              // DeletedAt
 				nil,              // CredentialID
 				dtoWithoutPrize.Token,
-				nil, // Prize is missing
+				nil,                      // Prize is missing
+				nil,                      // CampaignID
+				entities.TicketGenerated, // Status
+				nil,                      // ClaimedAt
+				nil,                      // RedeemedAt
+				nil,                      // ReceiptPhoto
+				nil,                      // Household
+				nil,                      // HouseholdSlot
 			).WillReturnError(fmt.Errorf("constraint violation"))
 
 		mock.ExpectRollback()
@@ -85,15 +102,22 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("creation with duplicate token", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID
-				sqlmock.AnyArg(), // CreatedAt
-				sqlmock.AnyArg(), // UpdatedAt
-				nil,              // DeletedAt
-				nil,              // CredentialID
-				dto.Token,        // Token
-				dto.Prize,        // Prize
+				sqlmock.AnyArg(),         // ID
+				sqlmock.AnyArg(),         // CreatedAt
+				sqlmock.AnyArg(),         // UpdatedAt
+				nil,                      // DeletedAt
+				nil,                      // CredentialID
+				dto.Token,                // Token
+				dto.PrizeID,              // Prize
+				nil,                      // CampaignID
+				entities.TicketGenerated, // Status
+				nil,                      // ClaimedAt
+				nil,                      // RedeemedAt
+				nil,                      // ReceiptPhoto
+				nil,                      // Household
+				nil,                      // HouseholdSlot
 			).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
 
 		mock.ExpectRollback()
@@ -108,15 +132,22 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("creation with database connection error", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID
-				sqlmock.AnyArg(), // CreatedAt
-				sqlmock.AnyArg(), // UpdatedAt
-				nil,              // DeletedAt
-				nil,              // CredentialID
-				dto.Token,        // Token
-				dto.Prize,        // Prize
+				sqlmock.AnyArg(),         // ID
+				sqlmock.AnyArg(),         // CreatedAt
+				sqlmock.AnyArg(),         // UpdatedAt
+				nil,                      // DeletedAt
+				nil,                      // CredentialID
+				dto.Token,                // Token
+				dto.PrizeID,              // Prize
+				nil,                      // CampaignID
+				entities.TicketGenerated, // Status
+				nil,                      // ClaimedAt
+				nil,                      // RedeemedAt
+				nil,                      // ReceiptPhoto
+				nil,                      // Household
+				nil,                      // HouseholdSlot
 			).WillReturnError(fmt.Errorf("database is unavailable"))
 
 		mock.ExpectRollback()
@@ -131,16 +162,24 @@ func TestCreateTicket(t *testing.T) {
 
 	t.Run("successful creation with custom options", func(t *testing.T) {
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID
-				sqlmock.AnyArg(), // CreatedAt
-				sqlmock.AnyArg(), // UpdatedAt
-				nil,              // DeletedAt
-				nil,              // CredentialID
-				dto.Token,        // Token
-				dto.Prize,        // Prize
+				sqlmock.AnyArg(),         // ID
+				sqlmock.AnyArg(),         // CreatedAt
+				sqlmock.AnyArg(),         // UpdatedAt
+				nil,                      // DeletedAt
+				nil,                      // CredentialID
+				dto.Token,                // Token
+				dto.PrizeID,              // Prize
+				nil,                      // CampaignID
+				entities.TicketGenerated, // Status
+				nil,                      // ClaimedAt
+				nil,                      // RedeemedAt
+				nil,                      // ReceiptPhoto
+				nil,                      // Household
+				nil,                      // HouseholdSlot
 			).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
 		mock.ExpectCommit()
 
 		entity, err := repo.CreateTicket(dto, database.Limit(1))
@@ -157,29 +196,44 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("successful creation of multiple tickets", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID (Ticket 1)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 1)
-				nil,              // DeletedAt (Ticket 1)
-				nil,              // CredentialID (Ticket 1)
-				"TokenA",         // Token (Ticket 1)
-				"PrizeA",         // Prize (Ticket 1)
-
-				sqlmock.AnyArg(), // ID (Ticket 2)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 2)
-				nil,              // DeletedAt (Ticket 2)
-				nil,              // CredentialID (Ticket 2)
-				"TokenB",         // Token (Ticket 2)
-				"PrizeB",         // Prize (Ticket 2)
+				sqlmock.AnyArg(),         // ID (Ticket 1)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 1)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 1)
+				nil,                      // DeletedAt (Ticket 1)
+				nil,                      // CredentialID (Ticket 1)
+				"TokenA",                 // Token (Ticket 1)
+				"PrizeA",                 // Prize (Ticket 1)
+				nil,                      // CampaignID (Ticket 1)
+				entities.TicketGenerated, // Status (Ticket 1)
+				nil,                      // ClaimedAt (Ticket 1)
+				nil,                      // RedeemedAt (Ticket 1)
+				nil,                      // ReceiptPhoto (Ticket 1)
+				nil,                      // Household (Ticket 1)
+				nil,                      // HouseholdSlot (Ticket 1)
+
+				sqlmock.AnyArg(),         // ID (Ticket 2)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 2)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 2)
+				nil,                      // DeletedAt (Ticket 2)
+				nil,                      // CredentialID (Ticket 2)
+				"TokenB",                 // Token (Ticket 2)
+				"PrizeB",                 // Prize (Ticket 2)
+				nil,                      // CampaignID (Ticket 2)
+				entities.TicketGenerated, // Status (Ticket 2)
+				nil,                      // ClaimedAt (Ticket 2)
+				nil,                      // RedeemedAt (Ticket 2)
+				nil,                      // ReceiptPhoto (Ticket 2)
+				nil,                      // Household (Ticket 2)
+				nil,                      // HouseholdSlot (Ticket 2)
 			).WillReturnResult(sqlmock.NewResult(2, 2))
+		expectLedger(mock, 2)
 		mock.ExpectCommit()
 
 		err := repo.CreateTickets(tickets)
@@ -190,28 +244,42 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("creation with duplicate token", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID (Ticket 1)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 1)
-				nil,              // DeletedAt (Ticket 1)
-				nil,              // CredentialID (Ticket 1)
-				"TokenA",         // Token (Ticket 1)
-				"PrizeA",         // Prize (Ticket 1)
-
-				sqlmock.AnyArg(), // ID (Ticket 2)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 2)
-				nil,              // DeletedAt (Ticket 2)
-				nil,              // CredentialID (Ticket 2)
-				"TokenB",         // Token (Ticket 2)
-				"PrizeB",         // Prize (Ticket 2)
+				sqlmock.AnyArg(),         // ID (Ticket 1)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 1)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 1)
+				nil,                      // DeletedAt (Ticket 1)
+				nil,                      // CredentialID (Ticket 1)
+				"TokenA",                 // Token (Ticket 1)
+				"PrizeA",                 // Prize (Ticket 1)
+				nil,                      // CampaignID (Ticket 1)
+				entities.TicketGenerated, // Status (Ticket 1)
+				nil,                      // ClaimedAt (Ticket 1)
+				nil,                      // RedeemedAt (Ticket 1)
+				nil,                      // ReceiptPhoto (Ticket 1)
+				nil,                      // Household (Ticket 1)
+				nil,                      // HouseholdSlot (Ticket 1)
+
+				sqlmock.AnyArg(),         // ID (Ticket 2)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 2)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 2)
+				nil,                      // DeletedAt (Ticket 2)
+				nil,                      // CredentialID (Ticket 2)
+				"TokenB",                 // Token (Ticket 2)
+				"PrizeB",                 // Prize (Ticket 2)
+				nil,                      // CampaignID (Ticket 2)
+				entities.TicketGenerated, // Status (Ticket 2)
+				nil,                      // ClaimedAt (Ticket 2)
+				nil,                      // RedeemedAt (Ticket 2)
+				nil,                      // ReceiptPhoto (Ticket 2)
+				nil,                      // Household (Ticket 2)
+				nil,                      // HouseholdSlot (Ticket 2)
 			).WillReturnError(fmt.Errorf("duplicate key value violates unique constraint"))
 
 		mock.ExpectRollback()
@@ -225,28 +293,42 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("database unavailable", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID (Ticket 1)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 1)
-				nil,              // DeletedAt (Ticket 1)
-				nil,              // CredentialID (Ticket 1)
-				"TokenA",         // Token (Ticket 1)
-				"PrizeA",         // Prize (Ticket 1)
-
-				sqlmock.AnyArg(), // ID (Ticket 2)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 2)
-				nil,              // DeletedAt (Ticket 2)
-				nil,              // CredentialID (Ticket 2)
-				"TokenB",         // Token (Ticket 2)
-				"PrizeB",         // Prize (Ticket 2)
+				sqlmock.AnyArg(),         // ID (Ticket 1)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 1)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 1)
+				nil,                      // DeletedAt (Ticket 1)
+				nil,                      // CredentialID (Ticket 1)
+				"TokenA",                 // Token (Ticket 1)
+				"PrizeA",                 // Prize (Ticket 1)
+				nil,                      // CampaignID (Ticket 1)
+				entities.TicketGenerated, // Status (Ticket 1)
+				nil,                      // ClaimedAt (Ticket 1)
+				nil,                      // RedeemedAt (Ticket 1)
+				nil,                      // ReceiptPhoto (Ticket 1)
+				nil,                      // Household (Ticket 1)
+				nil,                      // HouseholdSlot (Ticket 1)
+
+				sqlmock.AnyArg(),         // ID (Ticket 2)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 2)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 2)
+				nil,                      // DeletedAt (Ticket 2)
+				nil,                      // CredentialID (Ticket 2)
+				"TokenB",                 // Token (Ticket 2)
+				"PrizeB",                 // Prize (Ticket 2)
+				nil,                      // CampaignID (Ticket 2)
+				entities.TicketGenerated, // Status (Ticket 2)
+				nil,                      // ClaimedAt (Ticket 2)
+				nil,                      // RedeemedAt (Ticket 2)
+				nil,                      // ReceiptPhoto (Ticket 2)
+				nil,                      // Household (Ticket 2)
+				nil,                      // HouseholdSlot (Ticket 2)
 			).WillReturnError(fmt.Errorf("database is unavailable"))
 
 		mock.ExpectRollback()
@@ -260,29 +342,44 @@ func TestCreateTickets(t *testing.T) {
 
 	t.Run("successful creation with custom options", func(t *testing.T) {
 		tickets := []*transfert.Ticket{
-			{Prize: aws.String("PrizeA"), Token: aws.String("TokenA")},
-			{Prize: aws.String("PrizeB"), Token: aws.String("TokenB")},
+			{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+			{PrizeID: aws.String("PrizeB"), Token: aws.String("TokenB")},
 		}
 
 		mock.ExpectBegin()
-		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize"\)`).
+		mock.ExpectExec(`INSERT INTO "tickets" \("id","created_at","updated_at","deleted_at","credential_id","token","prize_id","campaign_id","status","claimed_at","redeemed_at","receipt_photo","household","household_slot"\)`).
 			WithArgs(
-				sqlmock.AnyArg(), // ID (Ticket 1)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 1)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 1)
-				nil,              // DeletedAt (Ticket 1)
-				nil,              // CredentialID (Ticket 1)
-				"TokenA",         // Token (Ticket 1)
-				"PrizeA",         // Prize (Ticket 1)
-
-				sqlmock.AnyArg(), // ID (Ticket 2)
-				sqlmock.AnyArg(), // CreatedAt (Ticket 2)
-				sqlmock.AnyArg(), // UpdatedAt (Ticket 2)
-				nil,              // DeletedAt (Ticket 2)
-				nil,              // CredentialID (Ticket 2)
-				"TokenB",         // Token (Ticket 2)
-				"PrizeB",         // Prize (Ticket 2),
+				sqlmock.AnyArg(),         // ID (Ticket 1)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 1)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 1)
+				nil,                      // DeletedAt (Ticket 1)
+				nil,                      // CredentialID (Ticket 1)
+				"TokenA",                 // Token (Ticket 1)
+				"PrizeA",                 // Prize (Ticket 1)
+				nil,                      // CampaignID (Ticket 1)
+				entities.TicketGenerated, // Status (Ticket 1)
+				nil,                      // ClaimedAt (Ticket 1)
+				nil,                      // RedeemedAt (Ticket 1)
+				nil,                      // ReceiptPhoto (Ticket 1)
+				nil,                      // Household (Ticket 1)
+				nil,                      // HouseholdSlot (Ticket 1)
+
+				sqlmock.AnyArg(),         // ID (Ticket 2)
+				sqlmock.AnyArg(),         // CreatedAt (Ticket 2)
+				sqlmock.AnyArg(),         // UpdatedAt (Ticket 2)
+				nil,                      // DeletedAt (Ticket 2)
+				nil,                      // CredentialID (Ticket 2)
+				"TokenB",                 // Token (Ticket 2)
+				"PrizeB",                 // Prize (Ticket 2),
+				nil,                      // CampaignID (Ticket 2)
+				entities.TicketGenerated, // Status (Ticket 2)
+				nil,                      // ClaimedAt (Ticket 2)
+				nil,                      // RedeemedAt (Ticket 2)
+				nil,                      // ReceiptPhoto (Ticket 2)
+				nil,                      // Household (Ticket 2)
+				nil,                      // HouseholdSlot (Ticket 2)
 			).WillReturnResult(sqlmock.NewResult(2, 2))
+		expectLedger(mock, 2)
 		mock.ExpectCommit()
 
 		err := repo.CreateTickets(tickets, database.Limit(1))
@@ -292,19 +389,115 @@ func TestCreateTickets(t *testing.T) {
 	})
 }
 
+func TestInsertTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	tickets := []*transfert.Ticket{
+		{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenA")},
+		{PrizeID: aws.String("PrizeA"), Token: aws.String("TokenB")},
+	}
+
+	t.Run("conflicting tokens are skipped", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "tickets" .* ON CONFLICT DO NOTHING`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT "id" FROM "tickets" WHERE id IN \(\$1,\$2\) AND "tickets"."deleted_at" IS NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		inserted, err := repo.InsertTickets(tickets)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, inserted)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("inserted tickets enter the ledger", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "tickets" .* ON CONFLICT DO NOTHING`).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		expectLedger(mock, 2)
+		mock.ExpectCommit()
+
+		inserted, err := repo.InsertTickets(tickets)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, inserted)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert failure rolls back", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "tickets"`).WillReturnError(fmt.Errorf("db error"))
+		mock.ExpectRollback()
+
+		inserted, err := repo.InsertTickets(tickets)
+		assert.Equal(t, 0, inserted)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		inserted, err := repo.InsertTickets(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, inserted)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// L'index unique sur le code écarte les doublons, y compris au sein d'un même lot
+func TestInsertTicketsSQLite(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	// Une seule connexion, pour que toutes les requêtes partagent la même base en mémoire
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	store, _ := database.FromDB(db)
+	repo := repositories.NewGameRepository(store)
+
+	inserted, err := repo.InsertTickets([]*transfert.Ticket{
+		{Token: aws.String("100000000008")},
+		{Token: aws.String("200000000006")},
+		{Token: aws.String("100000000008")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, inserted)
+
+	inserted, err = repo.InsertTickets([]*transfert.Ticket{
+		{Token: aws.String("200000000006")},
+		{Token: aws.String("300000000004")},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, inserted)
+
+	count, _ := repo.CountTicket(&transfert.Ticket{})
+	assert.Equal(t, 3, count)
+}
+
 func TestReadTicket(t *testing.T) {
 	repo, mock, cleanup := setup()
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful read", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1). // Inclure la limite
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).AddRow("some-id", "PrizeA", "unique-token"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1). // Inclure la limite
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).AddRow("some-id", "PrizeA", "unique-token"))
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"\."id" = \$1 AND "prizes"\."deleted_at" IS NULL`).
+			WithArgs("PrizeA").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow("PrizeA", "Infuseur à thé"))
 
 		entity, err := repo.ReadTicket(dto)
 
@@ -313,8 +506,8 @@ func TestReadTicket(t *testing.T) {
 		assert.NotNil(t, entity)
 
 		// Vérification des champs retournés
-		if assert.NotNil(t, entity.Prize) { // Vérifie avant d'accéder à Prize
-			assert.Equal(t, *dto.Prize, *entity.Prize)
+		if assert.NotNil(t, entity.PrizeID) { // Vérifie avant d'accéder à Prize
+			assert.Equal(t, *dto.PrizeID, *entity.PrizeID)
 		}
 		if assert.NotNil(t, entity.Token) { // Vérifie avant d'accéder à Token
 			assert.Equal(t, "unique-token", entity.Token.String())
@@ -324,8 +517,8 @@ func TestReadTicket(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1).          // Inclure la limite
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1).        // Inclure la limite
 			WillReturnRows(sqlmock.NewRows([]string{})) // Aucune ligne retournée
 
 		entity, err := repo.ReadTicket(dto)
@@ -339,8 +532,8 @@ func TestReadTicket(t *testing.T) {
 	})
 
 	t.Run("database error", func(t *testing.T) {
-		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
-			WithArgs(dto.Prize, dto.Token, 1).
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE \("tickets"\."prize_id" = \$1 AND "tickets"\."token" = \$2\) AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$3`).
+			WithArgs(dto.PrizeID, dto.Token, 1).
 			WillReturnError(fmt.Errorf("database error"))
 
 		entity, err := repo.ReadTicket(dto, database.Limit(1))
@@ -352,6 +545,22 @@ func TestReadTicket(t *testing.T) {
 
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+
+	t.Run("store and caisse are not filters", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."token" = \$1 AND "tickets"\."deleted_at" IS NULL ORDER BY "tickets"\."id" LIMIT \$2`).
+			WithArgs(dto.Token, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("some-id", "unique-token"))
+
+		entity, err := repo.ReadTicket(&transfert.Ticket{
+			Token:    dto.Token,
+			StoreID:  aws.String("store-id"),
+			CaisseID: aws.String("caisse-id"),
+		})
+
+		assert.Nil(t, err)
+		assert.NotNil(t, entity)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 func TestReadTickets(t *testing.T) {
@@ -359,22 +568,25 @@ func TestReadTickets(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
-		Token: aws.String("unique-token"),
+		PrizeID: aws.String("PrizeA"),
+		Token:   aws.String("unique-token"),
 	}
 
 	t.Run("successful read", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).
+			WithArgs(dto.PrizeID, dto.Token).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).
 				AddRow("ticket1", "PrizeA", "unique-token").
 				AddRow("ticket2", "PrizeA", "unique-token"))
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"\."id" = \$1 AND "prizes"\."deleted_at" IS NULL`).
+			WithArgs("PrizeA").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow("PrizeA", "Infuseur à thé"))
 
 		tickets, err := repo.ReadTickets(dto)
 		assert.Nil(t, err)
 		assert.NotNil(t, tickets)
 		assert.Len(t, tickets, 2)
-		assert.Equal(t, "PrizeA", *tickets[0].Prize)
+		assert.Equal(t, "PrizeA", *tickets[0].PrizeID)
 		assert.Equal(t, "unique-token", tickets[0].Token.String())
 
 		assert.NoError(t, mock.ExpectationsWereMet())
@@ -382,7 +594,7 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("no tickets found", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
+			WithArgs(dto.PrizeID, dto.Token).
 			WillReturnRows(sqlmock.NewRows([]string{}))
 
 		tickets, err := repo.ReadTickets(dto)
@@ -395,7 +607,7 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("database error", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
+			WithArgs(dto.PrizeID, dto.Token).
 			WillReturnError(fmt.Errorf("database error"))
 
 		tickets, err := repo.ReadTickets(dto)
@@ -408,10 +620,13 @@ func TestReadTickets(t *testing.T) {
 
 	t.Run("successful read with custom options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE`).
-			WithArgs(dto.Prize, dto.Token).
-			WillReturnRows(sqlmock.NewRows([]string{"id", "prize", "token"}).
+			WithArgs(dto.PrizeID, dto.Token).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "prize_id", "token"}).
 				AddRow("ticket1", "PrizeA", "unique-token").
 				AddRow("ticket2", "PrizeA", "unique-token"))
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"\."id" = \$1 AND "prizes"\."deleted_at" IS NULL`).
+			WithArgs("PrizeA").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow("PrizeA", "Infuseur à thé"))
 
 		tickets, err := repo.ReadTickets(dto, database.Order("created_at DESC"))
 		assert.Nil(t, err)
@@ -430,7 +645,7 @@ func TestUpdateTicket(t *testing.T) {
 
 	entity := &entities.Ticket{
 		ID:           "some-id",
-		Prize:        aws.String("PrizeA"),
+		PrizeID:      aws.String("PrizeA"),
 		Token:        token1,
 		CredentialID: nil,
 		CreatedAt:    time.Now(),
@@ -446,7 +661,14 @@ func TestUpdateTicket(t *testing.T) {
 				nil,                 // DeletedAt
 				entity.CredentialID, // CredentialID
 				entity.Token,        // Token
-				entity.Prize,        // Prize
+				entity.PrizeID,      // Prize
+				nil,                 // CampaignID
+				entity.Status,       // Status
+				nil,                 // ClaimedAt
+				nil,                 // RedeemedAt
+				nil,                 // ReceiptPhoto
+				nil,                 // Household
+				nil,                 // HouseholdSlot
 				entity.ID,           // ID
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
@@ -466,7 +688,14 @@ func TestUpdateTicket(t *testing.T) {
 				nil,                 // DeletedAt
 				entity.CredentialID, // CredentialID
 				entity.Token,        // Token
-				entity.Prize,        // Prize
+				entity.PrizeID,      // Prize
+				nil,                 // CampaignID
+				entity.Status,       // Status
+				nil,                 // ClaimedAt
+				nil,                 // RedeemedAt
+				nil,                 // ReceiptPhoto
+				nil,                 // Household
+				nil,                 // HouseholdSlot
 				entity.ID,           // ID
 			).WillReturnError(fmt.Errorf("update error"))
 		mock.ExpectRollback()
@@ -524,12 +753,12 @@ func TestCountTicket(t *testing.T) {
 	defer cleanup()
 
 	dto := &transfert.Ticket{
-		Prize: aws.String("PrizeA"),
+		PrizeID: aws.String("PrizeA"),
 	}
 
 	t.Run("successful count with options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT count\(\*\) FROM "tickets" WHERE`).
-			WithArgs(dto.Prize).
+			WithArgs(dto.PrizeID).
 			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
 
 		count, err := repo.CountTicket(dto, database.Order("ASC"))
@@ -541,7 +770,7 @@ func TestCountTicket(t *testing.T) {
 
 	t.Run("count failure with options", func(t *testing.T) {
 		mock.ExpectQuery(`SELECT count\(\*\) FROM "tickets" WHERE`).
-			WithArgs(dto.Prize).
+			WithArgs(dto.PrizeID).
 			WillReturnError(fmt.Errorf("count error"))
 
 		count, err := repo.CountTicket(dto, database.Order("ASC"))
@@ -552,3 +781,151 @@ func TestCountTicket(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+func TestUpdateTicketStatus(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	now := time.Now()
+	entity := &entities.Ticket{
+		ID:           "some-id",
+		CredentialID: aws.String("credential-id"),
+		Status:       entities.TicketClaimed,
+		ClaimedAt:    &now,
+	}
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("some-id"),
+		CredentialID:   aws.String("credential-id"),
+		OwnerID:        aws.String("credential-id"),
+		PreviousStatus: aws.String("generated"),
+		Status:         aws.String("claimed"),
+	}
+
+	t.Run("successful status update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET "updated_at"=\$1,"credential_id"=\$2,"status"=\$3,"claimed_at"=\$4,"redeemed_at"=\$5,"receipt_photo"=\$6,"household"=\$7,"household_slot"=\$8 WHERE status = \$9 AND credential_id IS NULL AND "tickets"."deleted_at" IS NULL AND "id" = \$10`).
+			WithArgs(
+				sqlmock.AnyArg(),    // UpdatedAt
+				entity.CredentialID, // CredentialID
+				entity.Status,       // Status
+				sqlmock.AnyArg(),    // ClaimedAt
+				nil,                 // RedeemedAt
+				nil,                 // ReceiptPhoto
+				nil,                 // Household
+				nil,                 // HouseholdSlot
+				"generated",         // Statut précédent
+				entity.ID,           // ID
+			).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories" \("id","created_at","ticket_id","credential_id","store_id","caisse_id","owner_id","previous_status","status"\)`).
+			WithArgs(
+				sqlmock.AnyArg(),     // ID
+				sqlmock.AnyArg(),     // CreatedAt
+				history.TicketID,     // TicketID
+				history.CredentialID, // CredentialID
+				nil,                  // StoreID
+				nil,                  // CaisseID
+				history.OwnerID,      // OwnerID
+				"generated",          // PreviousStatus
+				"claimed",            // Status
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectCommit()
+
+		err := repo.UpdateTicketStatus(entity, history)
+		assert.Nil(t, err)
+		assert.Equal(t, entity.CredentialID, entity.StoredCredentialID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket given to another client in the meantime", func(t *testing.T) {
+		// Le ticket a été lu chez son ancien propriétaire, puis donné avant la remise du lot
+		redeemed := &entities.Ticket{
+			ID:                 "some-id",
+			CredentialID:       aws.String("sender-id"),
+			StoredCredentialID: aws.String("sender-id"),
+			Status:             entities.TicketRedeemed,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET .* WHERE status = \$9 AND credential_id = \$10 AND "tickets"."deleted_at" IS NULL AND "id" = \$11`).
+			WithArgs(sqlmock.AnyArg(), "sender-id", entities.TicketRedeemed, nil, nil, nil, nil, nil, "claimed", "sender-id", "some-id").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketStatus(redeemed, &transfert.TicketHistory{
+			TicketID:       aws.String("some-id"),
+			PreviousStatus: aws.String("claimed"),
+			Status:         aws.String("redeemed"),
+		})
+		assert.Equal(t, "ticket.invalid_transition", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket status changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketStatus(entity, history)
+		assert.NotNil(t, err)
+		assert.Equal(t, "ticket.invalid_transition", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("history insertion failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnError(fmt.Errorf("insert error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketStatus(entity, history)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadTicketHistories(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.TicketHistory{
+		TicketID: aws.String("some-id"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_histories" WHERE "ticket_histories"."ticket_id" = \$1 ORDER BY created_at ASC`).
+			WithArgs(dto.TicketID).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ticket_id", "previous_status", "status"}).
+				AddRow("h1", "some-id", "generated", "claimed").
+				AddRow("h2", "some-id", "claimed", "redeemed"))
+
+		histories, err := repo.ReadTicketHistories(dto)
+		assert.Nil(t, err)
+		assert.Len(t, histories, 2)
+		assert.Equal(t, entities.TicketRedeemed, histories[1].Status)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_histories"`).
+			WithArgs(dto.TicketID).
+			WillReturnError(fmt.Errorf("read error"))
+
+		histories, err := repo.ReadTicketHistories(dto)
+		assert.NotNil(t, err)
+		assert.Nil(t, histories)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"fmt"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+const (
+	// TicketBatchSize is the number of tickets inserted per transaction
+	TicketBatchSize = 1000
+	// TicketMaxRetries is the number of batches in a row allowed to collide entirely before giving up
+	TicketMaxRetries = 10
+)
+
+// GenerateTickets inserts count tickets of the prize and campaign of filter, by batches of TicketBatchSize
+// Codes colliding with existing ones are drawn again, only one batch lives in memory at a time.
+// Shared by the generation at startup and the rebalance of a running campaign
+//
+// Parameters:
+// - repo: GameRepositoryInterface - The repository the tickets are inserted through
+// - filter: *transfert.Ticket - The prize and the campaign of the tickets
+// - count: int - The number of tickets to generate
+// - progress: func(int) - Called with the number of tickets inserted by each batch, nil when unused
+//
+// Returns:
+// - int: The number of tickets inserted, even when it fails midway
+// - errors.ErrorInterface: The error interface if an error occurs or no free code is found after TicketMaxRetries batches
+func GenerateTickets(repo GameRepositoryInterface, filter *transfert.Ticket, count int, progress func(int)) (int, errors.ErrorInterface) {
+	if count <= 0 {
+		return 0, nil
+	}
+
+	signer := entities.NewTicketSigner()
+	tickets := make([]*transfert.Ticket, 0, min(count, TicketBatchSize))
+	generated := 0
+
+	for retries := 0; generated < count; {
+		tickets = tickets[:0]
+		for i := 0; i < min(count-generated, TicketBatchSize); i++ {
+			tickets = append(tickets, &transfert.Ticket{
+				PrizeID:    filter.PrizeID,
+				CampaignID: filter.CampaignID,
+				Token:      signer.Generate().PointerString(),
+			})
+		}
+
+		inserted, err := repo.InsertTickets(tickets)
+		if err != nil {
+			return generated, err
+		}
+
+		if inserted == 0 {
+			if retries++; retries > TicketMaxRetries {
+				return generated, errors.ErrInternalServer.Log(fmt.Errorf("no free ticket code after %d attempts", retries))
+			}
+		} else {
+			retries = 0
+		}
+
+		generated += inserted
+		if progress != nil {
+			progress(inserted)
+		}
+	}
+
+	return generated, nil
+}
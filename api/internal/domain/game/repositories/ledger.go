@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+const (
+	// LedgerBatchSize is the number of ledger entries inserted per statement
+	LedgerBatchSize = 500
+	// LedgerRetries is the number of attempts of a transaction beaten by a concurrent append to the ledger
+	LedgerRetries = 3
+)
+
+// ledgerConflict is returned when the entries of a transaction could not be appended to the ledger,
+// most likely because a concurrent transaction took the same sequence first
+type ledgerConflict struct {
+	err error
+}
+
+func (conflict *ledgerConflict) Error() string {
+	return "ledger conflict: " + conflict.err.Error()
+}
+
+// transaction runs fn inside a transaction, replayed from the start when a concurrent transaction
+// appended to the ledger first, so the chain never forks
+func (r *GameRepository) transaction(fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < LedgerRetries; attempt++ {
+		err = r.store.Engine.Transaction(fn)
+		if _, conflict := err.(*ledgerConflict); !conflict {
+			return err
+		}
+	}
+
+	return err
+}
+
+// appendLedger seals the entries after the last one of the ledger and appends them, within the given transaction
+func appendLedger(tx *gorm.DB, entries ...*entities.LedgerEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var last []*entities.LedgerEntry
+	if err := tx.Order("sequence DESC").Limit(1).Find(&last).Error; err != nil {
+		return err
+	}
+
+	var previous *entities.LedgerEntry
+	if len(last) > 0 {
+		previous = last[0]
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		entry.Seal(previous, now)
+		previous = entry
+	}
+
+	if err := tx.CreateInBatches(entries, LedgerBatchSize).Error; err != nil {
+		return &ledgerConflict{err}
+	}
+
+	return nil
+}
+
+// createHistories appends the history entries of tickets and their ledger entries, within the given transaction
+func createHistories(tx *gorm.DB, histories ...*entities.TicketHistory) error {
+	if err := tx.Create(&histories).Error; err != nil {
+		return err
+	}
+
+	entries := make([]*entities.LedgerEntry, len(histories))
+	for i, history := range histories {
+		entries[i] = entities.NewHistoryLedgerEntry(history)
+	}
+
+	return appendLedger(tx, entries...)
+}
+
+// createdLedger records the creation of the tickets, within the given transaction
+func createdLedger(tx *gorm.DB, tickets ...*entities.Ticket) error {
+	entries := make([]*entities.LedgerEntry, len(tickets))
+	for i, ticket := range tickets {
+		entries[i] = entities.NewTicketLedgerEntry(ticket, entities.LedgerCreated)
+	}
+
+	return appendLedger(tx, entries...)
+}
+
+// StreamLedger walks the ledger in chain order, chunk by chunk
+// Only one chunk of entries lives in memory at a time, whatever the size of the ledger
+//
+// Parameters:
+// - size: int - The number of entries per chunk
+// - fn: func([]*entities.LedgerEntry) errors.ErrorInterface - Called for each chunk, an error stops the walk
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) StreamLedger(size int, fn func([]*entities.LedgerEntry) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	var entries []*entities.LedgerEntry
+	var stop errors.ErrorInterface
+
+	query := r.store.Engine.Model(&entities.LedgerEntry{})
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.FindInBatches(&entries, size, func(tx *gorm.DB, batch int) error {
+		if stop = fn(entries); stop != nil {
+			return stop
+		}
+
+		return nil
+	})
+
+	if stop != nil {
+		return stop
+	}
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// RecordTickets enters up to limit tickets missing from the ledger as they are stored, inside a single transaction
+// Tickets stored before the ledger existed are chained this way, flagged as recorded rather than created
+//
+// Parameters:
+// - limit: int - The maximum number of tickets to record
+//
+// Returns:
+// - int: The number of tickets recorded, lower than limit when none is left
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) RecordTickets(limit int) (int, errors.ErrorInterface) {
+	if limit <= 0 {
+		return 0, nil
+	}
+
+	var tickets []*entities.Ticket
+	err := r.transaction(func(tx *gorm.DB) error {
+		tickets = nil
+		if err := tx.Where("NOT EXISTS (SELECT 1 FROM ledger_entries WHERE ledger_entries.ticket_id = tickets.id)").
+			Order("id").
+			Limit(limit).
+			Find(&tickets).Error; err != nil {
+			return err
+		}
+
+		entries := make([]*entities.LedgerEntry, len(tickets))
+		for i, ticket := range tickets {
+			entries[i] = entities.NewTicketLedgerEntry(ticket, entities.LedgerRecorded)
+		}
+
+		return appendLedger(tx, entries...)
+	})
+
+	if err != nil {
+		return 0, errors.ErrInternalServer.Log(err)
+	}
+
+	return len(tickets), nil
+}
@@ -0,0 +1,133 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+var ledgerColumns = []string{"sequence", "created_at", "ticket_id", "prize_id", "campaign_id", "owner_id", "credential_id", "event", "previous_status", "status", "digest", "previous_hash", "hash"}
+
+// expectLedger attend l'ajout de count entrées au registre, en tête d'un registre vide
+func expectLedger(mock sqlmock.Sqlmock, count int) {
+	mock.ExpectQuery(`SELECT \* FROM "ledger_entries" ORDER BY sequence DESC LIMIT \$1`).
+		WillReturnRows(sqlmock.NewRows(ledgerColumns))
+	mock.ExpectExec(`INSERT INTO "ledger_entries"`).
+		WillReturnResult(sqlmock.NewResult(0, int64(count)))
+}
+
+func TestStreamLedger(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful walk", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ledger_entries" ORDER BY "ledger_entries"."sequence" LIMIT \$1`).
+			WillReturnRows(sqlmock.NewRows(ledgerColumns).
+				AddRow(1, nil, "ticket-1", nil, nil, nil, nil, entities.LedgerCreated, "", entities.TicketGenerated, "digest", entities.LedgerGenesis, "hash-1"))
+
+		var sequences []int64
+		err := repo.StreamLedger(2, func(entries []*entities.LedgerEntry) errors.ErrorInterface {
+			for _, entry := range entries {
+				sequences = append(sequences, entry.Sequence)
+			}
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []int64{1}, sequences)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ledger_entries"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		err := repo.StreamLedger(2, func(entries []*entities.LedgerEntry) errors.ErrorInterface {
+			return nil
+		})
+		assert.NotNil(t, err)
+		assert.Equal(t, errors.ErrInternalServer.Error(), err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRecordTickets(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful recording", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE NOT EXISTS \(SELECT 1 FROM ledger_entries WHERE ledger_entries.ticket_id = tickets.id\) AND "tickets"."deleted_at" IS NULL ORDER BY id LIMIT \$1`).
+			WithArgs(10).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token", "status"}).
+				AddRow("ticket-1", "123456789015", entities.TicketClaimed).
+				AddRow("ticket-2", "123456789023", entities.TicketGenerated))
+		expectLedger(mock, 2)
+		mock.ExpectCommit()
+
+		recorded, err := repo.RecordTickets(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, recorded)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("nothing left to record", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE NOT EXISTS`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectCommit()
+
+		recorded, err := repo.RecordTickets(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, recorded)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("concurrent append is replayed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE NOT EXISTS`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-1", "123456789015"))
+		mock.ExpectQuery(`SELECT \* FROM "ledger_entries" ORDER BY sequence DESC LIMIT \$1`).
+			WillReturnRows(sqlmock.NewRows(ledgerColumns))
+		mock.ExpectExec(`INSERT INTO "ledger_entries"`).
+			WillReturnError(fmt.Errorf("duplicate key"))
+		mock.ExpectRollback()
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE NOT EXISTS`).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-1", "123456789015"))
+		expectLedger(mock, 1)
+		mock.ExpectCommit()
+
+		recorded, err := repo.RecordTickets(10)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, recorded)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("persistent conflict", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			mock.ExpectBegin()
+			mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE NOT EXISTS`).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "token"}).AddRow("ticket-1", "123456789015"))
+			mock.ExpectQuery(`SELECT \* FROM "ledger_entries"`).
+				WillReturnRows(sqlmock.NewRows(ledgerColumns))
+			mock.ExpectExec(`INSERT INTO "ledger_entries"`).
+				WillReturnError(fmt.Errorf("duplicate key"))
+			mock.ExpectRollback()
+		}
+
+		recorded, err := repo.RecordTickets(10)
+		assert.NotNil(t, err)
+		assert.Equal(t, 0, recorded)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreatePrize creates a new prize
+// Inserts a new prize into the catalogue based on the transfert.Prize input object
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object to create
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Prize: The created prize entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	prize := entities.CreatePrize(obj)
+
+	query := r.store.Engine.Create(prize)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return nil, errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return prize, nil
+}
+
+// ReadPrize reads a prize from the database
+// Finds and returns a prize based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Prize: The found prize entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	prize := &entities.Prize{}
+
+	query := r.store.Engine.Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(prize)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrPrizeNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return prize, nil
+}
+
+// ReadPrizes reads the prize catalogue
+// Finds and returns the prizes matching the provided transfer object, in display order
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.Prize: A slice of found prize entities
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	var prizes []*entities.Prize
+
+	query := r.store.Engine.Where(obj).Order("position ASC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&prizes)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return prizes, nil
+}
+
+// UpdatePrize updates an existing prize in the database
+// Saves the updated prize entity
+//
+// Parameters:
+// - entity: *entities.Prize - The prize entity to update
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Save(entity)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// DeletePrize deletes a prize from the catalogue
+// Removes a prize based on the provided transfer object
+//
+// Parameters:
+// - obj: *transfert.Prize - The prize transfer object to delete
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	prize := entities.CreatePrize(obj)
+	query := r.store.Engine.Where(obj).Delete(prize)
+	for _, option := range options {
+		option(query)
+	}
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// LinkLegacyPrizes attaches tickets created before the prize catalogue to their prize
+// Older tickets stored the prize label in a "prize" column; it is matched against the catalogue labels
+//
+// Returns:
+// - int: The number of tickets linked
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) LinkLegacyPrizes() (int, errors.ErrorInterface) {
+	if !r.store.Engine.Migrator().HasColumn(&entities.Ticket{}, "prize") {
+		return 0, nil
+	}
+
+	result := r.store.Engine.Exec(
+		"UPDATE tickets SET prize_id = (SELECT prizes.id FROM prizes WHERE prizes.label = tickets.prize) WHERE prize_id IS NULL AND prize IS NOT NULL",
+	)
+
+	if result.Error != nil {
+		return 0, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return int(result.RowsAffected), nil
+}
@@ -0,0 +1,262 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreatePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Prize{
+		Label:        aws.String("Infuseur à thé"),
+		Value:        aws.Float64(8.9),
+		Position:     aws.Int(1),
+		Distribution: aws.Int(60),
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "prizes" \("id","created_at","updated_at","deleted_at","label","description","value","image","position","active","distribution"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				nil,              // DeletedAt
+				dto.Label,
+				nil, // Description
+				dto.Value,
+				nil, // Image
+				dto.Position,
+				true, // Active par défaut
+				dto.Distribution,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		prize, err := repo.CreatePrize(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, prize)
+		assert.True(t, prize.IsActive())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "prizes"`).WillReturnError(fmt.Errorf("duplicate key"))
+		mock.ExpectRollback()
+
+		prize, err := repo.CreatePrize(dto)
+		assert.Nil(t, prize)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadPrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Prize{
+		ID: aws.String("prize-id"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"\."id" = \$1 AND "prizes"\."deleted_at" IS NULL ORDER BY "prizes"\."id" LIMIT \$2`).
+			WithArgs(dto.ID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label"}).AddRow("prize-id", "Infuseur à thé"))
+
+		prize, err := repo.ReadPrize(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, prize)
+		assert.Equal(t, "Infuseur à thé", *prize.Label)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("prize not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		prize, err := repo.ReadPrize(dto)
+		assert.Nil(t, prize)
+		assert.NotNil(t, err)
+		assert.Equal(t, "prize.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		prize, err := repo.ReadPrize(dto)
+		assert.Nil(t, prize)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadPrizes(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Prize{
+		Active: aws.Bool(true),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes" WHERE "prizes"\."active" = \$1 AND "prizes"\."deleted_at" IS NULL ORDER BY position ASC`).
+			WithArgs(true).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "label", "position"}).
+				AddRow("prize-1", "Infuseur à thé", 1).
+				AddRow("prize-2", "Boite de 100g", 2))
+
+		prizes, err := repo.ReadPrizes(dto)
+		assert.Nil(t, err)
+		assert.Len(t, prizes, 2)
+		assert.Equal(t, "prize-1", prizes[0].ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "prizes"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		prizes, err := repo.ReadPrizes(dto)
+		assert.Nil(t, prizes)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestUpdatePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	prize := &entities.Prize{
+		ID:    "prize-id",
+		Label: aws.String("Infuseur à thé"),
+	}
+
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdatePrize(prize)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET`).
+			WillReturnError(fmt.Errorf("update error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdatePrize(prize)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeletePrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Prize{
+		ID: aws.String("prize-id"),
+	}
+
+	t.Run("successful deletion", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET "deleted_at"=\$1 WHERE "prizes"\."id" = \$2 AND "prizes"\."id" = \$3 AND "prizes"\."deleted_at" IS NULL`).
+			WithArgs(sqlmock.AnyArg(), dto.ID, dto.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeletePrize(dto)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("deletion failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "prizes" SET "deleted_at"`).
+			WillReturnError(fmt.Errorf("deletion error"))
+		mock.ExpectRollback()
+
+		err := repo.DeletePrize(dto)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestLinkLegacyPrizes(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("no legacy column", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM INFORMATION_SCHEMA\.columns`).
+			WithArgs("tickets", "prize").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+		linked, err := repo.LinkLegacyPrizes()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, linked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("legacy tickets linked", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM INFORMATION_SCHEMA\.columns`).
+			WithArgs("tickets", "prize").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(`UPDATE tickets SET prize_id = \(SELECT prizes\.id FROM prizes WHERE prizes\.label = tickets\.prize\)`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+
+		linked, err := repo.LinkLegacyPrizes()
+		assert.Nil(t, err)
+		assert.Equal(t, 3, linked)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("link failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM INFORMATION_SCHEMA\.columns`).
+			WithArgs("tickets", "prize").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(`UPDATE tickets SET prize_id`).
+			WillReturnError(fmt.Errorf("update error"))
+
+		linked, err := repo.LinkLegacyPrizes()
+		assert.Equal(t, 0, linked)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// CreateReceipt records a purchase receipt and hands its ticket over, inside a single transaction
+// The unique indexes prevent a second receipt with the same number in a store, or for the same ticket,
+// a concurrent request recording the same receipt fails on them once the first one committed
+//
+// Parameters:
+// - entity: *entities.Receipt - The receipt entity to persist, linked to its ticket
+// - ticket: *entities.Ticket - The ticket handed over, carrying its new status
+// - history: *transfert.TicketHistory - The status change of the ticket, nil when its status is unchanged
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the ticket changed in the meantime, ErrReceiptAlreadyExists if the receipt or its ticket is already recorded, or the error interface if an error occurs
+func (r *GameRepository) CreateReceipt(entity *entities.Receipt, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	err := r.transaction(func(tx *gorm.DB) error {
+		if history != nil {
+			if err := updateTicketStatus(tx, ticket, history); err != nil {
+				return err
+			}
+		}
+
+		query := tx.Omit("Ticket").Create(entity)
+		for _, option := range options {
+			option(query)
+		}
+
+		if database.IsDuplicate(tx, query.Error) {
+			return errors_domain_game.ErrReceiptAlreadyExists
+		}
+
+		return query.Error
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+		if err == errors_domain_game.ErrReceiptAlreadyExists {
+			return errors_domain_game.ErrReceiptAlreadyExists
+		}
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	entity.Ticket = ticket
+
+	return nil
+}
+
+// ReadReceipt reads a receipt from the database
+// Finds and returns a receipt, with its ticket, based on the provided transfer object and options
+//
+// Parameters:
+// - obj: *transfert.Receipt - The receipt transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Receipt: The found receipt entity
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadReceipt(obj *transfert.Receipt, options ...database.Option) (*entities.Receipt, errors.ErrorInterface) {
+	receipt := &entities.Receipt{}
+
+	query := r.store.Engine.Preload("Ticket").Where(obj)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(receipt)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrReceiptNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return receipt, nil
+}
@@ -0,0 +1,175 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5/pgconn"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateReceipt(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	purchasedAt := time.Now()
+	ticket := &entities.Ticket{ID: "ticket-id", Status: entities.TicketDistributed}
+
+	newReceipt := func() *entities.Receipt {
+		return &entities.Receipt{
+			StoreID:      aws.String("store-id"),
+			CaisseID:     aws.String("caisse-id"),
+			TicketID:     aws.String("ticket-id"),
+			CredentialID: aws.String("employee-id"),
+			Number:       "0042",
+			Amount:       52.3,
+			PurchasedAt:  purchasedAt,
+		}
+	}
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("employee-id"),
+		StoreID:        aws.String("store-id"),
+		CaisseID:       aws.String("caisse-id"),
+		PreviousStatus: aws.String("generated"),
+		Status:         aws.String("distributed"),
+	}
+
+	t.Run("successful creation with the ticket status change", func(t *testing.T) {
+		receipt := newReceipt()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET .* WHERE status = \$9 AND credential_id IS NULL AND "tickets"."deleted_at" IS NULL AND "id" = \$10`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectExec(`INSERT INTO "receipts" \("id","created_at","store_id","caisse_id","ticket_id","credential_id","number","amount","purchased_at"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				receipt.StoreID,
+				receipt.CaisseID,
+				receipt.TicketID,
+				receipt.CredentialID,
+				receipt.Number,
+				receipt.Amount,
+				purchasedAt,
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateReceipt(receipt, ticket, history)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, receipt.ID)
+		assert.Equal(t, ticket, receipt.Ticket)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("successful creation without status change", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "receipts"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateReceipt(newReceipt(), ticket, nil)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket status changed in the meantime", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "tickets" SET`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.CreateReceipt(newReceipt(), ticket, history)
+		assert.NotNil(t, err)
+		assert.Equal(t, "ticket.invalid_transition", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicate receipt number", func(t *testing.T) {
+		// Une requête concurrente a enregistré le même ticket de caisse entre la lecture et l'insertion
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "receipts"`).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+		mock.ExpectRollback()
+
+		err := repo.CreateReceipt(newReceipt(), ticket, nil)
+		assert.NotNil(t, err)
+		assert.Equal(t, "receipt.already_exists", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "receipts"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateReceipt(newReceipt(), ticket, nil)
+		assert.NotNil(t, err)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadReceipt(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	dto := &transfert.Receipt{
+		StoreID: aws.String("store-id"),
+		Number:  aws.String("0042"),
+	}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "receipts" WHERE "receipts"\."store_id" = \$1 AND "receipts"\."number" = \$2 ORDER BY "receipts"\."id" LIMIT \$3`).
+			WithArgs(dto.StoreID, dto.Number, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "number", "ticket_id"}).AddRow("receipt-id", "store-id", "0042", "ticket-id"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"\."id" = \$1 AND "tickets"\."deleted_at" IS NULL`).
+			WithArgs("ticket-id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow("ticket-id", "distributed"))
+
+		receipt, err := repo.ReadReceipt(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "receipt-id", receipt.ID)
+		assert.Equal(t, "ticket-id", receipt.Ticket.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("receipt not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "receipts"`).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		receipt, err := repo.ReadReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, "receipt.not_found", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "receipts"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		receipt, err := repo.ReadReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CountTicketsByPrize counts, for each prize, the tickets claimed during the period and those still unclaimed at its end
+// Cancelled tickets are never counted as unclaimed, tickets created after the period are ignored
+//
+// Parameters:
+// - period: *entities.Period - The period to report on
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.PrizeStatistic: One line per prize, in display order
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CountTicketsByPrize(period *entities.Period, options ...database.Option) ([]*entities.PrizeStatistic, errors.ErrorInterface) {
+	var statistics []*entities.PrizeStatistic
+
+	claimed, claimedArgs := "tickets.claimed_at IS NOT NULL", []any{}
+	unclaimed, unclaimedArgs := "tickets.status <> ? AND (tickets.claimed_at IS NULL", []any{entities.TicketCancelled}
+
+	if period.From != nil {
+		claimed += " AND tickets.claimed_at >= ?"
+		claimedArgs = append(claimedArgs, *period.From)
+	}
+
+	if period.To != nil {
+		claimed += " AND tickets.claimed_at < ?"
+		claimedArgs = append(claimedArgs, *period.To)
+		unclaimed += " OR tickets.claimed_at >= ?) AND tickets.created_at < ?"
+		unclaimedArgs = append(unclaimedArgs, *period.To, *period.To)
+	} else {
+		unclaimed += ")"
+	}
+
+	query := r.store.Engine.Model(&entities.Ticket{}).
+		Select(
+			"tickets.prize_id, prizes.label, "+
+				"SUM(CASE WHEN "+claimed+" THEN 1 ELSE 0 END) AS claimed, "+
+				"SUM(CASE WHEN "+unclaimed+" THEN 1 ELSE 0 END) AS unclaimed",
+			append(claimedArgs, unclaimedArgs...)...,
+		).
+		Joins("LEFT JOIN prizes ON prizes.id = tickets.prize_id").
+		Group("tickets.prize_id, prizes.label, prizes.position").
+		Order("prizes.position ASC, prizes.label ASC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(&statistics)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return statistics, nil
+}
+
+// CountClaimsByPeriod counts the tickets claimed during the period, grouped by day or hour in UTC
+//
+// Parameters:
+// - period: *entities.Period - The period to report on
+// - interval: string - database.Day or database.Hour
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.PeriodStatistic: One line per day or hour with at least one claim, in chronological order
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CountClaimsByPeriod(period *entities.Period, interval string, options ...database.Option) ([]*entities.PeriodStatistic, errors.ErrorInterface) {
+	var statistics []*entities.PeriodStatistic
+
+	query := r.store.Engine.Model(&entities.Ticket{}).
+		Select(database.Bucket(r.store.Engine, "claimed_at", interval) + " AS period, COUNT(*) AS count").
+		Where("claimed_at IS NOT NULL").
+		Group("period").
+		Order("period ASC")
+	database.Between("claimed_at", period.From, period.To)(query)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(&statistics)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return statistics, nil
+}
+
+// CountStatusesByCaisse counts the claims and redemptions recorded during the period, grouped by store and caisse
+// Online claims are grouped under an empty store and caisse
+//
+// Parameters:
+// - period: *entities.Period - The period to report on
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.StoreStatistic: One line per store and caisse
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CountStatusesByCaisse(period *entities.Period, options ...database.Option) ([]*entities.StoreStatistic, errors.ErrorInterface) {
+	var statistics []*entities.StoreStatistic
+
+	query := r.store.Engine.Model(&entities.TicketHistory{}).
+		Select(
+			"store_id, caisse_id, "+
+				"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS claimed, "+
+				"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS redeemed",
+			entities.TicketClaimed, entities.TicketRedeemed,
+		).
+		Where("status IN ?", []entities.TicketStatus{entities.TicketClaimed, entities.TicketRedeemed}).
+		Group("store_id, caisse_id").
+		Order("store_id ASC, caisse_id ASC")
+	database.Between("created_at", period.From, period.To)(query)
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Scan(&statistics)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return statistics, nil
+}
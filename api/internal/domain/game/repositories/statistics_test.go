@@ -0,0 +1,139 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountTicketsByPrize(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT tickets\.prize_id, prizes\.label, SUM\(CASE WHEN tickets\.claimed_at IS NOT NULL AND tickets\.claimed_at >= \$1 AND tickets\.claimed_at < \$2 THEN 1 ELSE 0 END\) AS claimed, SUM\(CASE WHEN tickets\.status <> \$3 AND \(tickets\.claimed_at IS NULL OR tickets\.claimed_at >= \$4\) AND tickets\.created_at < \$5 THEN 1 ELSE 0 END\) AS unclaimed FROM "tickets" LEFT JOIN prizes ON prizes\.id = tickets\.prize_id WHERE "tickets"\."deleted_at" IS NULL GROUP BY tickets\.prize_id, prizes\.label, prizes\.position ORDER BY prizes\.position ASC, prizes\.label ASC`).
+			WithArgs(from, to, entities.TicketCancelled, to, to).
+			WillReturnRows(sqlmock.NewRows([]string{"prize_id", "label", "claimed", "unclaimed"}).
+				AddRow("prize-1", "Infuser", 12, 30).
+				AddRow("prize-2", "Tea box", 3, 9))
+
+		statistics, err := repo.CountTicketsByPrize(&entities.Period{From: &from, To: &to})
+		assert.Nil(t, err)
+		assert.Len(t, statistics, 2)
+		assert.Equal(t, "Infuser", *statistics[0].Label)
+		assert.Equal(t, 12, statistics[0].Claimed)
+		assert.Equal(t, 30, statistics[0].Unclaimed)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("open period", func(t *testing.T) {
+		mock.ExpectQuery(`SUM\(CASE WHEN tickets\.claimed_at IS NOT NULL THEN 1 ELSE 0 END\) AS claimed, SUM\(CASE WHEN tickets\.status <> \$1 AND \(tickets\.claimed_at IS NULL\) THEN 1 ELSE 0 END\) AS unclaimed`).
+			WithArgs(entities.TicketCancelled).
+			WillReturnRows(sqlmock.NewRows([]string{"prize_id", "label", "claimed", "unclaimed"}))
+
+		statistics, err := repo.CountTicketsByPrize(&entities.Period{})
+		assert.Nil(t, err)
+		assert.Empty(t, statistics)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT tickets\.prize_id`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		statistics, err := repo.CountTicketsByPrize(&entities.Period{})
+		assert.Nil(t, statistics)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountClaimsByPeriod(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("successful count by day", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT to_char\(claimed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'\) AS period, COUNT\(\*\) AS count FROM "tickets" WHERE claimed_at IS NOT NULL AND claimed_at >= \$1 AND "tickets"\."deleted_at" IS NULL GROUP BY "period" ORDER BY period ASC`).
+			WithArgs(from).
+			WillReturnRows(sqlmock.NewRows([]string{"period", "count"}).
+				AddRow("2024-10-01", 42).
+				AddRow("2024-10-02", 17))
+
+		statistics, err := repo.CountClaimsByPeriod(&entities.Period{From: &from}, database.Day)
+		assert.Nil(t, err)
+		assert.Equal(t, []*entities.PeriodStatistic{{Period: "2024-10-01", Count: 42}, {Period: "2024-10-02", Count: 17}}, statistics)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("successful count by hour", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT to_char\(claimed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:00'\) AS period`).
+			WillReturnRows(sqlmock.NewRows([]string{"period", "count"}).AddRow("2024-10-01 09:00", 5))
+
+		statistics, err := repo.CountClaimsByPeriod(&entities.Period{}, database.Hour)
+		assert.Nil(t, err)
+		assert.Equal(t, "2024-10-01 09:00", statistics[0].Period)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT to_char`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		statistics, err := repo.CountClaimsByPeriod(&entities.Period{}, database.Day)
+		assert.Nil(t, statistics)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountStatusesByCaisse(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	to := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT store_id, caisse_id, SUM\(CASE WHEN status = \$1 THEN 1 ELSE 0 END\) AS claimed, SUM\(CASE WHEN status = \$2 THEN 1 ELSE 0 END\) AS redeemed FROM "ticket_histories" WHERE status IN \(\$3,\$4\) AND created_at < \$5 GROUP BY store_id, caisse_id ORDER BY store_id ASC, caisse_id ASC`).
+			WithArgs(entities.TicketClaimed, entities.TicketRedeemed, entities.TicketClaimed, entities.TicketRedeemed, to).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "caisse_id", "claimed", "redeemed"}).
+				AddRow(nil, nil, 120, 0).
+				AddRow("store-1", "caisse-1", 8, 15))
+
+		statistics, err := repo.CountStatusesByCaisse(&entities.Period{To: &to})
+		assert.Nil(t, err)
+		assert.Len(t, statistics, 2)
+		assert.Nil(t, statistics[0].StoreID)
+		assert.Equal(t, 120, statistics[0].Claimed)
+		assert.Equal(t, "caisse-1", *statistics[1].CaisseID)
+		assert.Equal(t, 15, statistics[1].Redeemed)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT store_id, caisse_id`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		statistics, err := repo.CountStatusesByCaisse(&entities.Period{})
+		assert.Nil(t, statistics)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+)
+
+// transferFilter turns a transfer object into search conditions, the recipient email is not a column
+func transferFilter(obj *transfert.TicketTransfer) *entities.TicketTransfer {
+	filter := &entities.TicketTransfer{}
+
+	if obj.ID != nil {
+		filter.ID = *obj.ID
+	}
+
+	if obj.TicketID != nil {
+		filter.TicketID = *obj.TicketID
+	}
+
+	if obj.Status != nil {
+		filter.Status = entities.TransferStatus(*obj.Status)
+	}
+
+	return filter
+}
+
+// CreateTicketTransfer records a ticket offered to another client
+// The ticket itself is left untouched, only the transfer is inserted
+//
+// Parameters:
+// - entity: *entities.TicketTransfer - The transfer to record
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CreateTicketTransfer(entity *entities.TicketTransfer, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Omit("Ticket").Create(entity)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// ReadTicketTransfer reads a transfer with its ticket
+//
+// Parameters:
+// - obj: *transfert.TicketTransfer - The transfer transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.TicketTransfer: The found transfer
+// - errors.ErrorInterface: ErrTransferNotFound if no transfer matches
+func (r *GameRepository) ReadTicketTransfer(obj *transfert.TicketTransfer, options ...database.Option) (*entities.TicketTransfer, errors.ErrorInterface) {
+	transfer := &entities.TicketTransfer{}
+
+	query := r.store.Engine.Preload("Ticket").Where(transferFilter(obj))
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.First(transfer)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_game.ErrTransferNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return transfer, nil
+}
+
+// CountTicketTransfers counts the transfers matching the search
+//
+// Parameters:
+// - obj: *transfert.TicketTransfer - The transfer transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - int: The number of transfers found
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) CountTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) (int, errors.ErrorInterface) {
+	var count int64
+
+	query := r.store.Engine.Model(&entities.TicketTransfer{}).Where(transferFilter(obj))
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Count(&count)
+
+	if result.Error != nil {
+		return 0, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return int(count), nil
+}
+
+// ReadTicketTransfers reads transfers with their ticket, most recent first
+//
+// Parameters:
+// - obj: *transfert.TicketTransfer - The transfer transfer object with search parameters
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.TicketTransfer: A slice of found transfers
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *GameRepository) ReadTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) ([]*entities.TicketTransfer, errors.ErrorInterface) {
+	var transfers []*entities.TicketTransfer
+
+	query := r.store.Engine.Preload("Ticket").Where(transferFilter(obj)).Order("created_at DESC")
+	for _, option := range options {
+		option(query)
+	}
+
+	result := query.Find(&transfers)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return transfers, nil
+}
+
+// UpdateTicketTransfer records the answer to a pending transfer, inside a single transaction
+// With a ticket, the ticket moves to the recipient only if the sender still holds it claimed,
+// and the change of owner is appended to its history
+//
+// Parameters:
+// - entity: *entities.TicketTransfer - The transfer carrying the answer
+// - ticket: *entities.Ticket - The ticket carrying its new owner, nil when the ticket stays with the sender
+// - history: *transfert.TicketHistory - The change of owner, nil without ticket
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrTransferClosed if the transfer was answered in the meantime, ErrTicketInvalidTransition if the ticket changed,
+// ErrEligibilityHouseholdSlotTaken if the household slot of the recipient was taken in the meantime, or the error interface if an error occurs
+func (r *GameRepository) UpdateTicketTransfer(entity *entities.TicketTransfer, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	err := r.transaction(func(tx *gorm.DB) error {
+		query := tx.Model(entity).
+			Where("status = ?", entities.TransferPending).
+			Select("status", "answered_at", "updated_at").
+			Updates(entity)
+		for _, option := range options {
+			option(query)
+		}
+
+		if query.Error != nil {
+			return query.Error
+		}
+
+		if query.RowsAffected == 0 {
+			return errors_domain_game.ErrTransferClosed
+		}
+
+		if ticket == nil {
+			return nil
+		}
+
+		moved := tx.Model(ticket).
+			Where("status = ? AND credential_id = ?", entities.TicketClaimed, entity.SenderID).
+			Select("credential_id", "household", "household_slot", "updated_at").
+			Updates(ticket)
+
+		if database.IsDuplicate(tx, moved.Error) {
+			return errors_domain_game.ErrEligibilityHouseholdSlotTaken
+		}
+
+		if moved.Error != nil {
+			return moved.Error
+		}
+
+		if moved.RowsAffected == 0 {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+
+		return createHistories(tx, entities.CreateTicketHistory(history))
+	})
+
+	if err != nil {
+		if err == errors_domain_game.ErrTransferClosed {
+			return errors_domain_game.ErrTransferClosed
+		}
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return errors_domain_game.ErrTicketInvalidTransition
+		}
+		if err == errors_domain_game.ErrEligibilityHouseholdSlotTaken {
+			return errors_domain_game.ErrEligibilityHouseholdSlotTaken
+		}
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	if ticket != nil {
+		entity.Ticket = ticket
+	}
+
+	return nil
+}
@@ -0,0 +1,274 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5/pgconn"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTicketTransfer(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	transfer := &entities.TicketTransfer{
+		TicketID:    "ticket-id",
+		SenderID:    "sender-id",
+		RecipientID: "recipient-id",
+	}
+
+	t.Run("successful creation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_transfers" \("id","created_at","updated_at","ticket_id","sender_id","recipient_id","status","answered_at"\)`).
+			WithArgs(
+				sqlmock.AnyArg(), // ID
+				sqlmock.AnyArg(), // CreatedAt
+				sqlmock.AnyArg(), // UpdatedAt
+				"ticket-id",
+				"sender-id",
+				"recipient-id",
+				entities.TransferPending,
+				nil, // AnsweredAt
+			).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.CreateTicketTransfer(transfer)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, transfer.ID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("creation failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "ticket_transfers"`).WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateTicketTransfer(&entities.TicketTransfer{TicketID: "ticket-id"})
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadTicketTransfer(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	columns := []string{"id", "ticket_id", "sender_id", "recipient_id", "status"}
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_transfers" WHERE "ticket_transfers"."id" = \$1 ORDER BY "ticket_transfers"."id" LIMIT \$2`).
+			WithArgs("transfer-id", 1).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("transfer-id", "ticket-id", "sender-id", "recipient-id", "pending"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"."id" = \$1 AND "tickets"."deleted_at" IS NULL`).
+			WithArgs("ticket-id").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status", "credential_id"}).AddRow("ticket-id", "claimed", "sender-id"))
+
+		transfer, err := repo.ReadTicketTransfer(&transfert.TicketTransfer{ID: aws.String("transfer-id")})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TransferPending, transfer.Status)
+		if assert.NotNil(t, transfer.Ticket) {
+			assert.Equal(t, "sender-id", *transfer.Ticket.CredentialID)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("transfer not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_transfers"`).WillReturnError(gorm.ErrRecordNotFound)
+
+		transfer, err := repo.ReadTicketTransfer(&transfert.TicketTransfer{ID: aws.String("transfer-id")})
+		assert.Nil(t, transfer)
+		assert.Equal(t, errors_domain_game.ErrTransferNotFound, err)
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_transfers"`).WillReturnError(fmt.Errorf("db error"))
+
+		transfer, err := repo.ReadTicketTransfer(&transfert.TicketTransfer{ID: aws.String("transfer-id")})
+		assert.Nil(t, transfer)
+		assert.Equal(t, "common.internal_error", err.Error())
+	})
+}
+
+func TestCountTicketTransfers(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "ticket_transfers" WHERE "ticket_transfers"."status" = \$1 AND \(sender_id = \$2 OR recipient_id = \$3\)`).
+			WithArgs("pending", "client-id", "client-id").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+		count, err := repo.CountTicketTransfers(&transfert.TicketTransfer{Status: aws.String("pending")}, database.Where("sender_id = ? OR recipient_id = ?", "client-id", "client-id"))
+		assert.Nil(t, err)
+		assert.Equal(t, 42, count)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT count\(\*\) FROM "ticket_transfers"`).WillReturnError(fmt.Errorf("db error"))
+
+		count, err := repo.CountTicketTransfers(&transfert.TicketTransfer{})
+		assert.Equal(t, 0, count)
+		assert.Equal(t, "common.internal_error", err.Error())
+	})
+}
+
+func TestReadTicketTransfers(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_transfers" WHERE "ticket_transfers"."status" = \$1 ORDER BY created_at DESC`).
+			WithArgs("pending").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "ticket_id", "status"}).
+				AddRow("transfer-2", "ticket-2", "pending").
+				AddRow("transfer-1", "ticket-1", "pending"))
+		mock.ExpectQuery(`SELECT \* FROM "tickets" WHERE "tickets"."id" IN \(\$1,\$2\) AND "tickets"."deleted_at" IS NULL`).
+			WithArgs("ticket-2", "ticket-1").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("ticket-1").AddRow("ticket-2"))
+
+		transfers, err := repo.ReadTicketTransfers(&transfert.TicketTransfer{Status: aws.String("pending")})
+		assert.Nil(t, err)
+		if assert.Len(t, transfers, 2) {
+			assert.Equal(t, "transfer-2", transfers[0].ID)
+			assert.Equal(t, "ticket-2", transfers[0].Ticket.ID)
+		}
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "ticket_transfers"`).WillReturnError(fmt.Errorf("db error"))
+
+		transfers, err := repo.ReadTicketTransfers(&transfert.TicketTransfer{})
+		assert.Nil(t, transfers)
+		assert.Equal(t, "common.internal_error", err.Error())
+	})
+}
+
+func TestUpdateTicketTransfer(t *testing.T) {
+	repo, mock, cleanup := setup()
+	defer cleanup()
+
+	now := time.Now()
+
+	transfer := func(status entities.TransferStatus) *entities.TicketTransfer {
+		return &entities.TicketTransfer{
+			ID:          "transfer-id",
+			TicketID:    "ticket-id",
+			SenderID:    "sender-id",
+			RecipientID: "recipient-id",
+			Status:      status,
+			AnsweredAt:  &now,
+		}
+	}
+
+	ticket := func() *entities.Ticket {
+		return &entities.Ticket{ID: "ticket-id", Status: entities.TicketClaimed, CredentialID: aws.String("recipient-id")}
+	}
+
+	history := &transfert.TicketHistory{
+		TicketID:       aws.String("ticket-id"),
+		CredentialID:   aws.String("recipient-id"),
+		OwnerID:        aws.String("recipient-id"),
+		PreviousStatus: aws.String(entities.TicketClaimed.String()),
+		Status:         aws.String(entities.TicketClaimed.String()),
+	}
+
+	t.Run("declined transfer", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers" SET "updated_at"=\$1,"status"=\$2,"answered_at"=\$3 WHERE status = \$4 AND "id" = \$5`).
+			WithArgs(sqlmock.AnyArg(), "declined", sqlmock.AnyArg(), "pending", "transfer-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateTicketTransfer(transfer(entities.TransferDeclined), nil, nil)
+		assert.Nil(t, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("accepted transfer moves the ticket", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers" SET .* WHERE status = \$4 AND "id" = \$5`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets" SET "updated_at"=\$1,"credential_id"=\$2,"household"=\$3,"household_slot"=\$4 WHERE \(status = \$5 AND credential_id = \$6\) AND "tickets"."deleted_at" IS NULL AND "id" = \$7`).
+			WithArgs(sqlmock.AnyArg(), "recipient-id", nil, nil, entities.TicketClaimed, "sender-id", "ticket-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO "ticket_histories"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		expectLedger(mock, 1)
+		mock.ExpectCommit()
+
+		entity := transfer(entities.TransferAccepted)
+		err := repo.UpdateTicketTransfer(entity, ticket(), history)
+		assert.Nil(t, err)
+		assert.Equal(t, "recipient-id", *entity.Ticket.CredentialID)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("transfer already answered", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketTransfer(transfer(entities.TransferCancelled), nil, nil)
+		assert.Equal(t, errors_domain_game.ErrTransferClosed, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ticket no longer held by the sender", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets"`).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketTransfer(transfer(entities.TransferAccepted), ticket(), history)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("household slot of the recipient taken meanwhile", func(t *testing.T) {
+		gift := ticket()
+		gift.Household, gift.HouseholdSlot = aws.String("household-1"), aws.Int(1)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers"`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "tickets" SET "updated_at"=\$1,"credential_id"=\$2,"household"=\$3,"household_slot"=\$4`).
+			WithArgs(sqlmock.AnyArg(), gift.CredentialID, "household-1", 1, entities.TicketClaimed, "sender-id", gift.ID).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketTransfer(transfer(entities.TransferAccepted), gift, history)
+		assert.Equal(t, errors_domain_game.ErrEligibilityHouseholdSlotTaken, err)
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("update failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "ticket_transfers"`).WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.UpdateTicketTransfer(transfer(entities.TransferAccepted), ticket(), history)
+		assert.Equal(t, "common.internal_error", err.Error())
+
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/printer"
+)
+
+const (
+	// BatchChunk is the number of tickets loaded at once while exporting
+	BatchChunk = 500
+	// BatchPDFSheets is the number of sheets of one PDF part, the PDF and a QR code per ticket are held in memory until written
+	BatchPDFSheets = 100
+	// BatchPDFMax is the number of tickets of one PDF part, a larger export is asked part by part with offset
+	BatchPDFMax = BatchPDFSheets * printer.PageCells
+)
+
+// PrepareBatch checks an export request and resolves its filters
+// Nothing is written nor recorded, so the caller can still answer with an error.
+// A PDF holds at most BatchPDFMax tickets, its limit defaults to it
+func (s *GameService) PrepareBatch(dto *transfert.Batch) (*entities.Batch, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	batch := entities.CreateBatch(dto)
+	if batch.Format != printer.CSV && batch.Format != printer.PDF {
+		return nil, errors_domain_game.ErrBatchInvalidFormat
+	}
+
+	if batch.Offset < 0 || batch.Limit < 0 {
+		return nil, errors_domain_game.ErrBatchInvalidRange
+	}
+
+	if batch.Format == printer.PDF {
+		if batch.Limit == 0 {
+			batch.Limit = BatchPDFMax
+		}
+
+		if batch.Limit > BatchPDFMax {
+			return nil, errors_domain_game.ErrBatchTooLarge
+		}
+	}
+
+	if dto.Campaign != nil {
+		campaign, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Campaign})
+		if err != nil {
+			return nil, err
+		}
+
+		batch.CampaignID = &campaign.ID
+	} else {
+		campaign, err := s.currentCampaign()
+		if err != nil {
+			return nil, err
+		}
+
+		if campaign != nil {
+			batch.CampaignID = &campaign.ID
+		}
+	}
+
+	if batch.PrizeID != nil {
+		if _, err := s.repo.ReadPrize(&transfert.Prize{ID: batch.PrizeID}); err != nil {
+			return nil, err
+		}
+	}
+
+	batch.CredentialID = s.security.GetCredentialID()
+
+	return batch, nil
+}
+
+// WriteBatch streams the tickets of a prepared batch to w and records the batch
+// The count and the checksum of the codes are known once every ticket has been written,
+// an export interrupted midway is therefore not recorded
+func (s *GameService) WriteBatch(batch *entities.Batch, w io.Writer) errors.ErrorInterface {
+	if batch == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return errors.ErrUnauthorized
+	}
+
+	out, err := printer.New(batch.Format, w)
+	if err != nil {
+		return errors_domain_game.ErrBatchInvalidFormat
+	}
+
+	caption := ""
+	if batch.CampaignID != nil {
+		campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: batch.CampaignID})
+		if err != nil {
+			return err
+		}
+
+		if campaign.Label != nil {
+			caption = *campaign.Label
+		}
+	}
+
+	options := []database.Option{database.Offset(batch.Offset)}
+	if batch.Limit > 0 {
+		options = append(options, database.Limit(batch.Limit))
+	}
+
+	h := sha256.New()
+	count := 0
+
+	if err := s.repo.StreamTickets(&transfert.Ticket{CampaignID: batch.CampaignID, PrizeID: batch.PrizeID}, BatchChunk, func(tickets []*entities.Ticket) errors.ErrorInterface {
+		cells := make([]printer.Cell, 0, len(tickets))
+		for _, ticket := range tickets {
+			code := ticket.Token.String()
+			if code == "" {
+				continue
+			}
+
+			cells = append(cells, printer.Cell{Code: code, Caption: caption})
+			h.Write([]byte(code))
+			h.Write([]byte{'\n'})
+		}
+
+		count += len(cells)
+		if err := out.Write(cells); err != nil {
+			return errors.ErrInternalServer.Log(err)
+		}
+
+		return nil
+	}, options...); err != nil {
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	batch.Count = count
+	batch.Checksum = hex.EncodeToString(h.Sum(nil))
+
+	return s.repo.CreateBatch(batch)
+}
+
+func (s *GameService) GetBatches() ([]*entities.Batch, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return s.repo.ReadBatches()
+}
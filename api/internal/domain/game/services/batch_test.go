@@ -0,0 +1,201 @@
+package services_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_PrepareBatch(t *testing.T) {
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		batch, err := service.PrepareBatch(nil)
+		assert.Nil(t, batch)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("pdf")})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should reject an unknown format", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("docx")})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors_domain_game.ErrBatchInvalidFormat, err)
+	})
+
+	t.Run("Should reject a negative range", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("csv"), Offset: aws.Int(-1)})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors_domain_game.ErrBatchInvalidRange, err)
+	})
+
+	t.Run("Should reject a PDF larger than one part", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("pdf"), Limit: aws.Int(services.BatchPDFMax + 1)})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors_domain_game.ErrBatchTooLarge, err)
+	})
+
+	t.Run("Should bound a PDF without limit to one part", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(nil)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("pdf")})
+		assert.Nil(t, err)
+		assert.Equal(t, services.BatchPDFMax, batch.Limit)
+
+		// Un CSV est diffusé en entier
+		batch, err = service.PrepareBatch(&transfert.Batch{Format: aws.String("csv")})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, batch.Limit)
+	})
+
+	t.Run("Should return error when the campaign does not exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("csv"), Campaign: aws.String("2024")})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should return error when the prize does not exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("csv"), PrizeID: aws.String("prize-1")})
+		assert.Nil(t, batch)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, err)
+	})
+
+	t.Run("Should default to the current campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{drawCampaign}, nil)
+
+		batch, err := service.PrepareBatch(&transfert.Batch{Format: aws.String("pdf"), Limit: aws.Int(100)})
+		assert.Nil(t, err)
+		assert.Equal(t, "campaign-1", *batch.CampaignID)
+		assert.Equal(t, "admin-1", *batch.CredentialID)
+		assert.Equal(t, 100, batch.Limit)
+
+		// Rien n'est enregistré avant l'écriture
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_WriteBatch(t *testing.T) {
+	tickets := []*entities.Ticket{
+		{ID: "ticket-1", Token: token.Luhn("100000000008")},
+		{ID: "ticket-2", Token: token.Luhn("200000000006")},
+	}
+
+	t.Run("Should return error when batch is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		assert.Equal(t, errors.ErrNoDto, service.WriteBatch(nil, &bytes.Buffer{}))
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.WriteBatch(&entities.Batch{Format: "csv"}, &bytes.Buffer{}))
+	})
+
+	t.Run("Should stream the tickets and record the batch", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("StreamTickets", mock.Anything, services.BatchChunk, mock.Anything, mock.Anything).Return(nil, tickets)
+		mockRepo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+		out := &bytes.Buffer{}
+		batch := &entities.Batch{CampaignID: &drawCampaign.ID, Format: "csv"}
+
+		assert.Nil(t, service.WriteBatch(batch, out))
+		assert.Equal(t, "number,code,caption\n1,100000000008,2024\n2,200000000006,2024\n", out.String())
+		assert.Equal(t, 2, batch.Count)
+		assert.Len(t, batch.Checksum, 64)
+		mockRepo.AssertCalled(t, "CreateBatch", batch, mock.Anything)
+
+		// Le même lot donne la même empreinte
+		again := &entities.Batch{CampaignID: &drawCampaign.ID, Format: "pdf"}
+		assert.Nil(t, service.WriteBatch(again, &bytes.Buffer{}))
+		assert.Equal(t, batch.Checksum, again.Checksum)
+	})
+
+	t.Run("Should not record an interrupted export", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("StreamTickets", mock.Anything, services.BatchChunk, mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		err := service.WriteBatch(&entities.Batch{Format: "csv"}, &bytes.Buffer{})
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertNotCalled(t, "CreateBatch", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_GetBatches(t *testing.T) {
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		batches, err := service.GetBatches()
+		assert.Nil(t, batches)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should list the batches", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadBatches", mock.Anything).Return([]*entities.Batch{{ID: "batch-1"}}, nil)
+
+		batches, err := service.GetBatches()
+		assert.Nil(t, err)
+		assert.Len(t, batches, 1)
+	})
+}
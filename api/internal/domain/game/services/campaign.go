@@ -0,0 +1,199 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+func (s *GameService) GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface) {
+	return s.repo.ReadCampaigns()
+}
+
+func (s *GameService) GetCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	return s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+}
+
+// CreateCampaign opens a new campaign, the tickets of the previous ones are kept
+// Without distribution, the campaign takes a snapshot of the active prize catalogue
+func (s *GameService) CreateCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Label}); err == nil {
+		return nil, errors_domain_game.ErrCampaignAlreadyExists
+	} else if err != errors_domain_game.ErrCampaignNotFound {
+		return nil, err
+	}
+
+	campaign := &entities.Campaign{}
+	if err := campaign.Apply(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+func (s *GameService) UpdateCampaign(dto *transfert.Campaign) (*entities.Campaign, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Label != nil && (campaign.Label == nil || *dto.Label != *campaign.Label) {
+		if _, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Label}); err == nil {
+			return nil, errors_domain_game.ErrCampaignAlreadyExists
+		} else if err != errors_domain_game.ErrCampaignNotFound {
+			return nil, err
+		}
+	}
+
+	if err := campaign.Apply(dto); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// checkCampaign validates the ticket count, the distribution and the prizes of the household limit of a campaign
+func (s *GameService) checkCampaign(campaign *entities.Campaign) errors.ErrorInterface {
+	if campaign.Tickets != nil && *campaign.Tickets < 0 {
+		return errors_domain_game.ErrCampaignInvalidTickets
+	}
+
+	active := true
+	prizes, err := s.repo.ReadPrizes(&transfert.Prize{Active: &active})
+	if err != nil {
+		return err
+	}
+
+	if len(campaign.Distribution) == 0 {
+		campaign.Distribution = map[string]int{}
+		for _, prize := range prizes {
+			if prize.Distribution != nil && *prize.Distribution > 0 {
+				campaign.Distribution[prize.ID] = *prize.Distribution
+			}
+		}
+	}
+
+	known := map[string]bool{}
+	for _, prize := range prizes {
+		known[prize.ID] = true
+	}
+
+	for _, prizeID := range campaign.Eligibility.HouseholdPrizes {
+		if !known[prizeID] {
+			return errors_domain_game.ErrPrizeNotFound
+		}
+	}
+
+	total := 0
+	for prizeID, share := range campaign.Distribution {
+		if !known[prizeID] {
+			return errors_domain_game.ErrPrizeNotFound
+		}
+
+		if share < 0 || share > 100 {
+			return errors_domain_game.ErrPrizeInvalidValue
+		}
+
+		total += share
+	}
+
+	if total > 100 {
+		return errors_domain_game.ErrPrizeDistributionOverflow
+	}
+
+	return nil
+}
+
+// currentCampaign returns the most recent started campaign, nil if there is none
+func (s *GameService) currentCampaign() (*entities.Campaign, errors.ErrorInterface) {
+	campaigns, err := s.repo.ReadCampaigns(
+		database.Where("start_at IS NULL OR start_at <= ?", time.Now()),
+		database.Limit(1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(campaigns) == 0 {
+		return nil, nil
+	}
+
+	return campaigns[0], nil
+}
+
+// checkWindow rejects a status change happening outside the windows of the ticket campaign
+// Tickets are played between the start and the end, prizes are handed over until the claim deadline
+// and a ticket only expires once its deadline has passed
+func (s *GameService) checkWindow(ticket *entities.Ticket, to entities.TicketStatus) errors.ErrorInterface {
+	if ticket.CampaignID == nil {
+		return nil
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: ticket.CampaignID})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	switch to {
+	case entities.TicketClaimed, entities.TicketReview:
+		if !campaign.IsStarted(now) {
+			return errors_domain_game.ErrCampaignNotStarted
+		}
+		if campaign.IsEnded(now) {
+			return errors_domain_game.ErrCampaignEnded
+		}
+	case entities.TicketRedeemed:
+		if !campaign.IsClaimOpen(now) {
+			return errors_domain_game.ErrCampaignClaimClosed
+		}
+	case entities.TicketExpired:
+		if !campaign.IsExpired(ticket.Status, entities.ExpiryGrace(), now) {
+			return errors_domain_game.ErrCampaignDeadlineOpen
+		}
+	}
+
+	return nil
+}
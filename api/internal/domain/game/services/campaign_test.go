@@ -0,0 +1,323 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var campaignRoles = []security.Role{security.ROLE_ADMIN, user.ROLE_EMPLOYEE}
+
+var campaignPrizes = []*entities.Prize{
+	{ID: "prize-1", Distribution: aws.Int(60)},
+	{ID: "prize-2", Distribution: aws.Int(20)},
+}
+
+func Test_GetCampaigns(t *testing.T) {
+	t.Run("Should return campaigns", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{{ID: "campaign-1"}}, nil)
+
+		campaigns, err := service.GetCampaigns()
+		assert.Nil(t, err)
+		assert.Len(t, campaigns, 1)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		campaigns, err := service.GetCampaigns()
+		assert.Nil(t, campaigns)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+func Test_GetCampaign(t *testing.T) {
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		campaign, err := service.GetCampaign(nil)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should return the campaign", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		dto := &transfert.Campaign{ID: aws.String("campaign-1")}
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(&entities.Campaign{ID: "campaign-1"}, nil)
+
+		campaign, err := service.GetCampaign(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "campaign-1", campaign.ID)
+	})
+}
+
+func Test_CreateCampaign(t *testing.T) {
+	dto := &transfert.Campaign{
+		Label:   aws.String("2024"),
+		StartAt: aws.String("2024-10-01"),
+		EndAt:   aws.String("2024-10-31"),
+	}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		campaign, err := service.CreateCampaign(nil)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CreateCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a label already used", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Label}, mock.Anything).Return(&entities.Campaign{ID: "campaign-1"}, nil)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrCampaignAlreadyExists, err)
+	})
+
+	t.Run("Should refuse invalid dates", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		campaign, err := service.CreateCampaign(&transfert.Campaign{
+			Label:   aws.String("2024"),
+			StartAt: aws.String("2024-10-31"),
+			EndAt:   aws.String("2024-10-01"),
+		})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidDates, err)
+	})
+
+	t.Run("Should refuse a negative ticket count", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		campaign, err := service.CreateCampaign(&transfert.Campaign{Label: aws.String("2024"), Tickets: aws.Int(-1)})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrCampaignInvalidTickets, err)
+	})
+
+	t.Run("Should refuse an unknown prize in the distribution", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+
+		campaign, err := service.CreateCampaign(&transfert.Campaign{Label: aws.String("2024"), Distribution: map[string]int{"prize-3": 10}})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, err)
+	})
+
+	t.Run("Should refuse a distribution over 100 percent", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+
+		campaign, err := service.CreateCampaign(&transfert.Campaign{Label: aws.String("2024"), Distribution: map[string]int{"prize-1": 80, "prize-2": 30}})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrPrizeDistributionOverflow, err)
+	})
+
+	t.Run("Should snapshot the catalogue distribution", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CreateCampaign", mock.Anything, mock.Anything).Return(nil)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "2024", *campaign.Label)
+		assert.Equal(t, map[string]int{"prize-1": 60, "prize-2": 20}, campaign.Distribution)
+		mockRepo.AssertCalled(t, "CreateCampaign", campaign, mock.Anything)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CreateCampaign", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		campaign, err := service.CreateCampaign(dto)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+func Test_UpdateCampaign(t *testing.T) {
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		campaign, err := service.UpdateCampaign(nil)
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: aws.String("campaign-1")})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when campaign not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: aws.String("campaign-1")})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should refuse a label used by another campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: aws.String("campaign-1")}, mock.Anything).Return(&entities.Campaign{ID: "campaign-1", Label: aws.String("2024")}, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: aws.String("2025")}, mock.Anything).Return(&entities.Campaign{ID: "campaign-2"}, nil)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{ID: aws.String("campaign-1"), Label: aws.String("2025")})
+		assert.Nil(t, campaign)
+		assert.Equal(t, errors_domain_game.ErrCampaignAlreadyExists, err)
+	})
+
+	t.Run("Should move the claim deadline", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		end := time.Date(2024, 10, 31, 0, 0, 0, 0, time.UTC)
+		existing := &entities.Campaign{ID: "campaign-1", Label: aws.String("2024"), EndAt: &end, Distribution: map[string]int{"prize-1": 60}}
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: aws.String("campaign-1")}, mock.Anything).Return(existing, nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("UpdateCampaign", existing, mock.Anything).Return(nil)
+
+		campaign, err := service.UpdateCampaign(&transfert.Campaign{
+			ID:            aws.String("campaign-1"),
+			Label:         aws.String("2024"),
+			ClaimDeadline: aws.String("2024-11-30"),
+		})
+		assert.Nil(t, err)
+		assert.True(t, campaign.ClaimDeadline.Equal(time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC)))
+		assert.Equal(t, map[string]int{"prize-1": 60}, campaign.Distribution)
+	})
+}
+
+func Test_CampaignWindows(t *testing.T) {
+	eid := aws.String("employee-123")
+	cid := aws.String("client-123")
+	campaignID := "campaign-1"
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		campaign *entities.Campaign
+		from     entities.TicketStatus
+		to       string
+		expected errors.ErrorInterface
+	}{
+		{"claim before the start", &entities.Campaign{StartAt: &future}, entities.TicketGenerated, "claimed", errors_domain_game.ErrCampaignNotStarted},
+		{"claim after the end", &entities.Campaign{EndAt: &past}, entities.TicketGenerated, "claimed", errors_domain_game.ErrCampaignEnded},
+		{"claim during the campaign", &entities.Campaign{StartAt: &past, EndAt: &future}, entities.TicketGenerated, "claimed", nil},
+		{"redeem after the deadline", &entities.Campaign{ClaimDeadline: &past}, entities.TicketClaimed, "redeemed", errors_domain_game.ErrCampaignClaimClosed},
+		{"redeem after the end, before the deadline", &entities.Campaign{EndAt: &past, ClaimDeadline: &future}, entities.TicketClaimed, "redeemed", nil},
+		{"cancel after the deadline", &entities.Campaign{EndAt: &past, ClaimDeadline: &past}, entities.TicketClaimed, "cancelled", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, mockPerms := setup()
+
+			ticket := &entities.Ticket{ID: "ticket-123", Status: tt.from, CampaignID: &campaignID, CredentialID: cid}
+
+			mockPerms.On("IsAuthenticated").Return(true)
+			mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+			mockPerms.On("GetCredentialID").Return(eid)
+			mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+			mockRepo.On("ReadCampaign", &transfert.Campaign{ID: &campaignID}, mock.Anything).Return(tt.campaign, nil)
+			mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+			mockRepo.On("CreateClaimAttempt", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+			// Chaque statut passe par son propre parcours : réclamation, retrait ou changement manuel
+			var result *entities.Ticket
+			var err errors.ErrorInterface
+			switch tt.to {
+			case "claimed":
+				ticket.CredentialID = nil
+				result, err = service.UpdateTicket(&transfert.Ticket{Token: aws.String("79927398713")}, nil)
+			case "redeemed":
+				result, err = service.RedeemTicket(&transfert.Ticket{Token: aws.String("79927398713"), CredentialID: cid})
+			default:
+				result, err = service.UpdateTicketStatus(&transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String(tt.to)})
+			}
+
+			if tt.expected != nil {
+				assert.Nil(t, result)
+				assert.Equal(t, tt.expected, err)
+				mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tt.to, result.Status.String())
+			}
+		})
+	}
+
+	t.Run("Should skip tickets without campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		ticket := &entities.Ticket{ID: "ticket-123", Status: entities.TicketClaimed}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.UpdateTicketStatus(&transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String("cancelled")})
+		assert.Nil(t, err)
+		mockRepo.AssertNotCalled(t, "ReadCampaign", mock.Anything, mock.Anything)
+	})
+}
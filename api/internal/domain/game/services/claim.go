@@ -0,0 +1,199 @@
+package services
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// failClaim records a code which matched no claimable ticket, a signal for the next claims of the client
+//
+// Parameters:
+// - attempt: *transfert.ClaimAttempt The origin of the claim
+// - cause: errors.ErrorInterface The reason the code was refused
+//
+// Returns:
+// - errors.ErrorInterface: The cause, or the error interface if the attempt cannot be recorded
+func (s *GameService) failClaim(attempt *transfert.ClaimAttempt, cause errors.ErrorInterface) errors.ErrorInterface {
+	if err := s.repo.CreateClaimAttempt(attempt, true); err != nil {
+		return err
+	}
+
+	return cause
+}
+
+// scoreClaim weighs the recent attempts sharing the credential, the IP or the device of a claim
+// The claim itself is recorded among them by recordClaim, once its transition committed
+//
+// Parameters:
+// - attempt: *transfert.ClaimAttempt The origin of the claim and the claimed ticket
+//
+// Returns:
+// - *entities.ClaimReview: The review to queue when the claim is suspicious, nil otherwise
+// - errors.ErrorInterface: The error interface if an error occurs
+func (s *GameService) scoreClaim(attempt *transfert.ClaimAttempt) (*entities.ClaimReview, errors.ErrorInterface) {
+	policy := entities.NewFraudPolicy()
+
+	signals, err := s.repo.ReadClaimSignals(attempt, time.Now().Add(-policy.Window))
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.RegisteredAt != nil {
+		age := time.Since(*attempt.RegisteredAt)
+		signals.AccountAge = &age
+	}
+
+	score, raised := policy.Score(signals)
+	if !policy.IsSuspicious(score) {
+		return nil, nil
+	}
+
+	review := &entities.ClaimReview{
+		Score:   score,
+		Signals: raised,
+	}
+
+	if attempt.CredentialID != nil {
+		review.CredentialID = *attempt.CredentialID
+	}
+
+	if attempt.TicketID != nil {
+		review.TicketID = *attempt.TicketID
+	}
+
+	return review, nil
+}
+
+// recordClaim records an accepted or held claim among the recent attempts, once its transition committed
+// A claim refused by the transition is not counted. The claim stands when it cannot be recorded,
+// the failure is logged by the repository
+//
+// Parameters:
+// - attempt: *transfert.ClaimAttempt The origin of the claim and the claimed ticket
+func (s *GameService) recordClaim(attempt *transfert.ClaimAttempt) {
+	_ = s.repo.CreateClaimAttempt(attempt, false)
+}
+
+// holdClaim moves a claimed ticket to review and queues its review
+//
+// Parameters:
+// - ticket: *entities.Ticket The claimed ticket, carrying the client credential
+// - review: *entities.ClaimReview The review to queue
+// - quota: *entities.HouseholdQuota The household limit of the client, held during the review, nil when none applies
+//
+// Returns:
+// - *entities.Ticket: The ticket, in review status
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the ticket cannot be claimed, a campaign error outside its windows,
+// ErrEligibilityHouseholdLimit when the household reached its limit
+func (s *GameService) holdClaim(ticket *entities.Ticket, review *entities.ClaimReview, quota *entities.HouseholdQuota) (*entities.Ticket, errors.ErrorInterface) {
+	review.PreviousStatus = ticket.Status
+	if review.PreviousStatus == "" {
+		review.PreviousStatus = entities.TicketGenerated
+	}
+
+	history, err := s.prepareTransition(ticket, entities.TicketReview, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reserveHousehold(ticket, quota, func() errors.ErrorInterface {
+		return s.repo.CreateClaimReview(review, ticket, history)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// GetClaimReviews lists the held claims with the given decision, pending ones by default, oldest first
+//
+// Parameters:
+// - dto: *transfert.ClaimReview The status of the listed reviews
+//
+// Returns:
+// - []*entities.ClaimReview: The reviews with their ticket
+// - errors.ErrorInterface: ErrReviewInvalidStatus if the status is unknown
+func (s *GameService) GetClaimReviews(dto *transfert.ClaimReview) ([]*entities.ClaimReview, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	status := entities.ReviewPending.String()
+	if dto != nil && dto.Status != nil {
+		if _, ok := entities.NewReviewDecision(dto.Status); !ok && *dto.Status != status {
+			return nil, errors_domain_game.ErrReviewInvalidStatus
+		}
+
+		status = *dto.Status
+	}
+
+	return s.repo.ReadClaimReviews(&transfert.ClaimReview{Status: &status}, database.Limit(TicketPageMax))
+}
+
+// ReviewClaim approves or rejects a held claim
+// An approved claim is accepted and the client is told by mail its prize is ready,
+// a rejected one releases the ticket in the status it had before the claim
+//
+// Parameters:
+// - dto: *transfert.ClaimReview The review ID and the decision, approved or rejected
+//
+// Returns:
+// - *entities.ClaimReview: The decided review with its ticket
+// - errors.ErrorInterface: ErrReviewInvalidStatus for an unknown decision, ErrReviewClosed if the review was already decided
+func (s *GameService) ReviewClaim(dto *transfert.ClaimReview) (*entities.ClaimReview, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	decision, ok := entities.NewReviewDecision(dto.Status)
+	if !ok {
+		return nil, errors_domain_game.ErrReviewInvalidStatus
+	}
+
+	review, err := s.repo.ReadClaimReview(&transfert.ClaimReview{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if review.Status != entities.ReviewPending {
+		return nil, errors_domain_game.ErrReviewClosed
+	}
+
+	ticket := review.Ticket
+	if ticket == nil {
+		return nil, errors_domain_game.ErrTicketNotFound
+	}
+
+	to := entities.TicketClaimed
+	if decision == entities.ReviewRejected {
+		to = review.PreviousStatus
+		ticket.CredentialID = nil
+		ticket.ReceiptPhoto = nil
+	}
+
+	history, err := s.prepareTransition(ticket, to, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	review.Status = decision
+	review.ReviewerID = s.security.GetCredentialID()
+	review.ReviewedAt = &now
+
+	if err := s.repo.UpdateClaimReview(review, ticket, history); err != nil {
+		return nil, err
+	}
+
+	if decision == entities.ReviewApproved {
+		go s.notifyWinner(ticket, PrizeTemplate)
+	}
+
+	return review, nil
+}
@@ -0,0 +1,218 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetClaimReviews(t *testing.T) {
+	t.Run("Should list the pending reviews by default", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		reviews := []*entities.ClaimReview{{ID: "review-1"}, {ID: "review-2"}}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadClaimReviews", &transfert.ClaimReview{Status: aws.String("pending")}, mock.Anything).Return(reviews, nil)
+
+		result, err := service.GetClaimReviews(nil)
+		assert.Nil(t, err)
+		assert.Equal(t, reviews, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should list the reviews with the given decision", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadClaimReviews", &transfert.ClaimReview{Status: aws.String("rejected")}, mock.Anything).Return([]*entities.ClaimReview{}, nil)
+
+		result, err := service.GetClaimReviews(&transfert.ClaimReview{Status: aws.String("rejected")})
+		assert.Nil(t, err)
+		assert.Empty(t, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should reject an unknown status", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		result, err := service.GetClaimReviews(&transfert.ClaimReview{Status: aws.String("lost")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrReviewInvalidStatus, err)
+
+		mockRepo.AssertNotCalled(t, "ReadClaimReviews", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return unauthorized for clients", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		result, err := service.GetClaimReviews(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_ReviewClaim(t *testing.T) {
+	eid := aws.String("employee-123")
+	cid := aws.String("client-123")
+
+	newReview := func() *entities.ClaimReview {
+		return &entities.ClaimReview{
+			ID:             "review-123",
+			TicketID:       "ticket-123",
+			CredentialID:   *cid,
+			PreviousStatus: entities.TicketDistributed,
+			Status:         entities.ReviewPending,
+			Ticket: &entities.Ticket{
+				ID:           "ticket-123",
+				CredentialID: cid,
+				Status:       entities.TicketReview,
+				ReceiptPhoto: aws.String("receipts/0042.jpg"),
+			},
+		}
+	}
+
+	lookup := &transfert.ClaimReview{ID: aws.String("review-123")}
+
+	t.Run("Should approve a held claim", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		review := newReview()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(review, nil)
+		mockRepo.On("UpdateClaimReview", review, review.Ticket, mock.MatchedBy(func(history *transfert.TicketHistory) bool {
+			return *history.PreviousStatus == "review" && *history.Status == "claimed" && history.CredentialID == eid
+		}), mock.Anything).Return(nil)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("approved")})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.ReviewApproved, result.Status)
+		assert.Equal(t, eid, result.ReviewerID)
+		assert.NotNil(t, result.ReviewedAt)
+		assert.Equal(t, entities.TicketClaimed, result.Ticket.Status)
+		assert.Equal(t, cid, result.Ticket.CredentialID)
+		assert.NotNil(t, result.Ticket.ClaimedAt)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse to approve a claim whose ticket has no owner", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		review := newReview()
+		review.Ticket.CredentialID = nil
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(review, nil)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("approved")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		// Le ticket reste en revue
+		assert.Equal(t, entities.TicketReview, review.Ticket.Status)
+		assert.Nil(t, review.Ticket.ClaimedAt)
+		mockRepo.AssertNotCalled(t, "UpdateClaimReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should release the ticket of a rejected claim", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		review := newReview()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(review, nil)
+		mockRepo.On("UpdateClaimReview", review, review.Ticket, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("rejected")})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.ReviewRejected, result.Status)
+		assert.Equal(t, entities.TicketDistributed, result.Ticket.Status)
+		assert.Nil(t, result.Ticket.CredentialID)
+		assert.Nil(t, result.Ticket.ReceiptPhoto)
+		assert.Nil(t, result.Ticket.ClaimedAt)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should reject an unknown decision", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("pending")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrReviewInvalidStatus, err)
+
+		mockRepo.AssertNotCalled(t, "ReadClaimReview", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a review already decided", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		review := newReview()
+		review.Status = entities.ReviewApproved
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(review, nil)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("rejected")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrReviewClosed, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateClaimReview", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return not found for an unknown review", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReviewNotFound)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("approved")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrReviewNotFound, err)
+	})
+
+	t.Run("Should return the conflict of a concurrent decision", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		review := newReview()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadClaimReview", lookup, mock.Anything).Return(review, nil)
+		mockRepo.On("UpdateClaimReview", review, review.Ticket, mock.Anything, mock.Anything).Return(errors_domain_game.ErrReviewClosed)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("approved")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrReviewClosed, err)
+	})
+
+	t.Run("Should return unauthorized for clients", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		result, err := service.ReviewClaim(&transfert.ClaimReview{ID: lookup.ID, Status: aws.String("approved")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
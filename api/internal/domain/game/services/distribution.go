@@ -0,0 +1,187 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// DistributionStepSize is the largest number of tickets one call generates and cancels,
+// the rest is left to the next call so a request never runs for the whole rebalance
+const DistributionStepSize = 10 * repositories.TicketBatchSize
+
+// PreviewDistribution computes the tickets to generate or cancel for a campaign to match a new distribution
+// Nothing is written, the preview is the change UpdateDistribution would apply at this instant
+func (s *GameService) PreviewDistribution(dto *transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	_, change, err := s.planDistribution(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// UpdateDistribution changes the ticket count and the distribution of a running campaign
+// Missing tickets are generated and spare ones cancelled, tickets already handed over or claimed are kept.
+// At most DistributionStepSize tickets are handled per call, the change tells how many remain and a new call
+// resumes from the tickets in database, the target being saved on the campaign by the first one.
+// The change is recorded with what was actually done, even when the rebalance stops midway
+func (s *GameService) UpdateDistribution(dto *transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, change, err := s.planDistribution(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Tickets = &change.Tickets
+	campaign.Distribution = change.Distribution
+	if err := s.repo.UpdateCampaign(campaign); err != nil {
+		return nil, err
+	}
+
+	rebalanced := s.rebalance(change)
+
+	if err := s.repo.CreateDistributionChange(change); err != nil {
+		return nil, err
+	}
+
+	if rebalanced != nil {
+		return nil, rebalanced
+	}
+
+	return change, nil
+}
+
+// GetDistributionChanges lists the changes of the distribution of a campaign, the most recent first
+func (s *GameService) GetDistributionChanges(dto *transfert.Campaign) ([]*entities.DistributionChange, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadDistributionChanges(database.Where("campaign_id = ?", campaign.ID))
+}
+
+// planDistribution validates the requested distribution against the campaign and computes the change
+// The ticket count falls back on the campaign one, then on project.tickets.required like at startup
+func (s *GameService) planDistribution(dto *transfert.Campaign) (*entities.Campaign, *entities.DistributionChange, errors.ErrorInterface) {
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if campaign.IsEnded(time.Now()) {
+		return nil, nil, errors_domain_game.ErrCampaignEnded
+	}
+
+	target := &entities.Campaign{
+		Tickets:      campaign.Tickets,
+		Distribution: campaign.Distribution,
+	}
+
+	if dto.Tickets != nil {
+		target.Tickets = dto.Tickets
+	}
+
+	if dto.Distribution != nil {
+		target.Distribution = dto.Distribution
+	}
+
+	if err := s.checkCampaign(target); err != nil {
+		return nil, nil, err
+	}
+
+	tickets := config.GetInt("project.tickets.required", 10000)
+	if target.Tickets != nil {
+		tickets = *target.Tickets
+	}
+
+	pools, err := s.repo.CountTicketPools(&transfert.Ticket{CampaignID: &campaign.ID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	change := entities.NewDistributionChange(campaign, tickets, target.Distribution, pools)
+	change.CredentialID = s.security.GetCredentialID()
+
+	return campaign, change, nil
+}
+
+// rebalance generates and cancels the tickets of each line of a change, within DistributionStepSize tickets,
+// counting what was done and what is left to the next call
+func (s *GameService) rebalance(change *entities.DistributionChange) errors.ErrorInterface {
+	previous, status := entities.TicketGenerated.String(), entities.TicketCancelled.String()
+
+	history := &transfert.TicketHistory{
+		CredentialID:   change.CredentialID,
+		PreviousStatus: &previous,
+		Status:         &status,
+	}
+
+	budget := DistributionStepSize
+
+	for _, line := range change.Lines {
+		filter := &transfert.Ticket{CampaignID: change.CampaignID, PrizeID: &line.PrizeID}
+
+		generate := min(line.Generate, budget)
+		change.Remaining += line.Generate - generate
+
+		generated, err := repositories.GenerateTickets(s.repo, filter, generate, nil)
+		change.Generated += generated
+		budget -= generated
+		if err != nil {
+			return err
+		}
+
+		cancel := min(line.Cancel, budget)
+		change.Remaining += line.Cancel - cancel
+
+		for remaining := cancel; remaining > 0; {
+			cancelled, err := s.repo.CancelTickets(filter, min(remaining, repositories.TicketBatchSize), history)
+			if err != nil {
+				return err
+			}
+
+			if cancelled == 0 {
+				break
+			}
+
+			change.Cancelled += cancelled
+			budget -= cancelled
+			remaining -= cancelled
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// distributionCampaign retourne une campagne de 100 tickets répartis à 60/20 entre les deux lots
+func distributionCampaign() *entities.Campaign {
+	return &entities.Campaign{
+		ID:           "campaign-1",
+		Tickets:      aws.Int(100),
+		Distribution: map[string]int{"prize-1": 60, "prize-2": 20},
+	}
+}
+
+var distributionPools = []*entities.TicketPool{
+	{PrizeID: "prize-1", Live: 60, Spare: 40},
+	{PrizeID: "prize-2", Live: 20, Spare: 20},
+}
+
+func Test_PreviewDistribution(t *testing.T) {
+	dto := &transfert.Campaign{
+		ID:           aws.String("campaign-1"),
+		Tickets:      aws.Int(200),
+		Distribution: map[string]int{"prize-1": 20, "prize-2": 20},
+	}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		change, err := service.PreviewDistribution(nil)
+		assert.Nil(t, change)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		change, err := service.PreviewDistribution(dto)
+		assert.Nil(t, change)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an ended campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		ended := time.Now().Add(-time.Hour)
+		campaign := distributionCampaign()
+		campaign.EndAt = &ended
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: dto.ID}, mock.Anything).Return(campaign, nil)
+
+		change, err := service.PreviewDistribution(dto)
+		assert.Nil(t, change)
+		assert.Equal(t, errors_domain_game.ErrCampaignEnded, err)
+	})
+
+	t.Run("Should refuse a distribution above 100 percent", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+
+		change, err := service.PreviewDistribution(&transfert.Campaign{
+			ID:           aws.String("campaign-1"),
+			Distribution: map[string]int{"prize-1": 90, "prize-2": 20},
+		})
+		assert.Nil(t, change)
+		assert.Equal(t, errors_domain_game.ErrPrizeDistributionOverflow, err)
+	})
+
+	t.Run("Should compute the delta without writing anything", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", &transfert.Ticket{CampaignID: aws.String("campaign-1")}, mock.Anything).Return(distributionPools, nil)
+
+		change, err := service.PreviewDistribution(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "admin-1", *change.CredentialID)
+		assert.Equal(t, 100, *change.PreviousTickets)
+		assert.Equal(t, 200, change.Tickets)
+		assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-1", Share: 20, Target: 40, Current: 60, Cancel: 20}, change.Lines[0])
+		assert.Equal(t, &entities.DistributionLine{PrizeID: "prize-2", Share: 20, Target: 40, Current: 20, Generate: 20}, change.Lines[1])
+
+		mockRepo.AssertNotCalled(t, "UpdateCampaign", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateDistributionChange", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_UpdateDistribution(t *testing.T) {
+	dto := &transfert.Campaign{
+		ID:           aws.String("campaign-1"),
+		Tickets:      aws.Int(200),
+		Distribution: map[string]int{"prize-1": 20, "prize-2": 20},
+	}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		change, err := service.UpdateDistribution(nil)
+		assert.Nil(t, change)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		change, err := service.UpdateDistribution(dto)
+		assert.Nil(t, change)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "UpdateCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should rebalance the tickets and record the change", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", mock.Anything, mock.Anything).Return(distributionPools, nil)
+		mockRepo.On("UpdateCampaign", mock.MatchedBy(func(campaign *entities.Campaign) bool {
+			return *campaign.Tickets == 200 && campaign.Distribution["prize-1"] == 20
+		}), mock.Anything).Return(nil)
+		mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+			return len(tickets) == 20 && *tickets[0].PrizeID == "prize-2" && *tickets[0].CampaignID == "campaign-1"
+		}), mock.Anything).Return(20, nil)
+		mockRepo.On("CancelTickets", &transfert.Ticket{CampaignID: aws.String("campaign-1"), PrizeID: aws.String("prize-1")}, 20, mock.MatchedBy(func(history *transfert.TicketHistory) bool {
+			return *history.CredentialID == "admin-1" && *history.PreviousStatus == "generated" && *history.Status == "cancelled"
+		}), mock.Anything).Return(20, nil)
+		mockRepo.On("CreateDistributionChange", mock.Anything, mock.Anything).Return(nil)
+
+		change, err := service.UpdateDistribution(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, 20, change.Generated)
+		assert.Equal(t, 20, change.Cancelled)
+		assert.Equal(t, 0, change.Remaining)
+		mockRepo.AssertCalled(t, "CreateDistributionChange", change, mock.Anything)
+	})
+
+	t.Run("Should leave what exceeds the step to the next call", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", mock.Anything, mock.Anything).Return(distributionPools, nil)
+		mockRepo.On("UpdateCampaign", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("InsertTickets", mock.MatchedBy(func(tickets []*transfert.Ticket) bool {
+			return len(tickets) == repositories.TicketBatchSize
+		}), mock.Anything).Return(repositories.TicketBatchSize, nil)
+		mockRepo.On("CreateDistributionChange", mock.Anything, mock.Anything).Return(nil)
+
+		// Le lot 1 manque de 500 tickets de plus que le pas, le lot 2 a 20 tickets à annuler
+		change, err := service.UpdateDistribution(&transfert.Campaign{
+			ID:           aws.String("campaign-1"),
+			Tickets:      aws.Int(60 + services.DistributionStepSize + 500),
+			Distribution: map[string]int{"prize-1": 100},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, services.DistributionStepSize, change.Generated)
+		assert.Equal(t, 0, change.Cancelled)
+		assert.Equal(t, 520, change.Remaining)
+		mockRepo.AssertNumberOfCalls(t, "InsertTickets", services.DistributionStepSize/repositories.TicketBatchSize)
+		mockRepo.AssertNotCalled(t, "CancelTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should stop cancelling when no spare ticket is left", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", mock.Anything, mock.Anything).Return([]*entities.TicketPool{{PrizeID: "prize-1", Live: 60, Spare: 60}}, nil)
+		mockRepo.On("UpdateCampaign", mock.Anything, mock.Anything).Return(nil)
+		// Des tickets ont été remis en caisse entre l'aperçu et l'annulation
+		mockRepo.On("CancelTickets", mock.Anything, 60, mock.Anything, mock.Anything).Return(15, nil).Once()
+		mockRepo.On("CancelTickets", mock.Anything, 45, mock.Anything, mock.Anything).Return(0, nil).Once()
+		mockRepo.On("CreateDistributionChange", mock.Anything, mock.Anything).Return(nil)
+
+		change, err := service.UpdateDistribution(&transfert.Campaign{
+			ID:           aws.String("campaign-1"),
+			Tickets:      aws.Int(0),
+			Distribution: map[string]int{"prize-1": 10},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, change.Generated)
+		assert.Equal(t, 15, change.Cancelled)
+	})
+
+	t.Run("Should record what was done when the rebalance fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", mock.Anything, mock.Anything).Return(distributionPools, nil)
+		mockRepo.On("UpdateCampaign", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("CancelTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, errors_domain_game.ErrTicketInvalidTransition)
+		mockRepo.On("CreateDistributionChange", mock.MatchedBy(func(change *entities.DistributionChange) bool {
+			return change.Generated == 0 && change.Cancelled == 0
+		}), mock.Anything).Return(nil)
+
+		change, err := service.UpdateDistribution(dto)
+		assert.Nil(t, change)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+		mockRepo.AssertCalled(t, "CreateDistributionChange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when the campaign can't be updated", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(campaignPrizes, nil)
+		mockRepo.On("CountTicketPools", mock.Anything, mock.Anything).Return(distributionPools, nil)
+		mockRepo.On("UpdateCampaign", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		change, err := service.UpdateDistribution(dto)
+		assert.Nil(t, change)
+		assert.Equal(t, errors.ErrInternalServer, err)
+		mockRepo.AssertNotCalled(t, "CancelTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_GetDistributionChanges(t *testing.T) {
+	dto := &transfert.Campaign{ID: aws.String("campaign-1")}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		changes, err := service.GetDistributionChanges(nil)
+		assert.Nil(t, changes)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		changes, err := service.GetDistributionChanges(dto)
+		assert.Nil(t, changes)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when the campaign doesn't exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		changes, err := service.GetDistributionChanges(dto)
+		assert.Nil(t, changes)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should return the changes of the campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(distributionCampaign(), nil)
+		mockRepo.On("ReadDistributionChanges", mock.Anything).Return([]*entities.DistributionChange{{ID: "change-1"}}, nil)
+
+		changes, err := service.GetDistributionChanges(dto)
+		assert.Nil(t, err)
+		assert.Len(t, changes, 1)
+	})
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// NewDrawSeed generates a random seed for a draw
+//
+// Returns:
+// - string: 32 random bytes, hex encoded
+// - error: The error if the system random source fails
+func NewDrawSeed() (string, error) {
+	b := make([]byte, 32)
+	if _, err := crand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// DrawChecksum fingerprints the ordered list of participants
+//
+// Parameters:
+// - participants: []string The ordered credential IDs
+//
+// Returns:
+// - string: The hex encoded SHA-256 of the IDs, one per line
+func DrawChecksum(participants []string) string {
+	h := sha256.New()
+	for _, participant := range participants {
+		h.Write([]byte(participant))
+		h.Write([]byte{'\n'})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// DrawWinner picks the winner among the participants for a given seed
+// The ChaCha8 stream keyed by SHA-256(seed) is specified and stable, so the same
+// seed and participants always give the same winner; rejection sampling avoids modulo bias
+//
+// Parameters:
+// - seed: string The draw seed
+// - participants: []string The ordered credential IDs
+//
+// Returns:
+// - string: The credential ID of the winner, empty if there is no participant
+func DrawWinner(seed string, participants []string) string {
+	n := uint64(len(participants))
+	if n == 0 {
+		return ""
+	}
+
+	source := rand.NewChaCha8(sha256.Sum256([]byte(seed)))
+	limit := math.MaxUint64 - math.MaxUint64%n
+
+	for {
+		if v := source.Uint64(); v < limit {
+			return participants[v%n]
+		}
+	}
+}
+
+// RunDraw runs the grand draw of a campaign and records its result
+// The seed is always generated here, once the participants are known, so nobody can pick it
+func (s *GameService) RunDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Campaign})
+	if err != nil {
+		return nil, err
+	}
+
+	if campaign.EndAt != nil && !campaign.IsEnded(time.Now()) {
+		return nil, errors_domain_game.ErrCampaignRunning
+	}
+
+	if _, err := s.repo.ReadDraw(&transfert.Draw{CampaignID: &campaign.ID}); err == nil {
+		return nil, errors_domain_game.ErrDrawAlreadyDone
+	} else if err != errors_domain_game.ErrDrawNotFound {
+		return nil, err
+	}
+
+	participants, err := s.repo.ReadDrawParticipants(&transfert.Ticket{CampaignID: &campaign.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(participants) == 0 {
+		return nil, errors_domain_game.ErrDrawNoParticipant
+	}
+
+	seed, serr := NewDrawSeed()
+	if serr != nil {
+		return nil, errors.ErrInternalServer.Log(serr)
+	}
+
+	draw := entities.CreateDraw(&transfert.Draw{CampaignID: &campaign.ID})
+	draw.Seed = seed
+
+	winner := DrawWinner(draw.Seed, participants)
+
+	draw.WinnerID = &winner
+	draw.Participants = len(participants)
+	draw.ParticipantIDs = participants
+	draw.Checksum = DrawChecksum(participants)
+	draw.CredentialID = s.security.GetCredentialID()
+
+	if err := s.repo.CreateDraw(draw); err != nil {
+		return nil, err
+	}
+
+	return draw, nil
+}
+
+func (s *GameService) GetDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{Label: dto.Campaign})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadDraw(&transfert.Draw{CampaignID: &campaign.ID})
+}
+
+// VerifyDraw replays a recorded draw against the participants frozen when it ran
+// Tickets expired or transferred since then do not change the result, draws recorded
+// without their participants are replayed against the current ones
+// It fails with ErrDrawMismatch if the population does not match its checksum or the winner differs
+func (s *GameService) VerifyDraw(dto *transfert.Draw) (*entities.Draw, errors.ErrorInterface) {
+	draw, err := s.GetDraw(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	participants := draw.ParticipantIDs
+	if len(participants) == 0 {
+		if participants, err = s.repo.ReadDrawParticipants(&transfert.Ticket{CampaignID: draw.CampaignID}); err != nil {
+			return nil, err
+		}
+	}
+
+	if DrawChecksum(participants) != draw.Checksum {
+		return nil, errors_domain_game.ErrDrawMismatch
+	}
+
+	if draw.WinnerID == nil || DrawWinner(draw.Seed, participants) != *draw.WinnerID {
+		return nil, errors_domain_game.ErrDrawMismatch
+	}
+
+	return draw, nil
+}
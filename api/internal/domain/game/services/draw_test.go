@@ -0,0 +1,334 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var drawRoles = []security.Role{security.ROLE_ADMIN, user.ROLE_EMPLOYEE}
+
+var drawCampaign = &entities.Campaign{ID: "campaign-1", Label: aws.String("2024")}
+
+func TestNewDrawSeed(t *testing.T) {
+	a, err := services.NewDrawSeed()
+	assert.Nil(t, err)
+	assert.Len(t, a, 64)
+
+	b, err := services.NewDrawSeed()
+	assert.Nil(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestDrawChecksum(t *testing.T) {
+	participants := []string{"a", "b", "c"}
+
+	assert.Equal(t, services.DrawChecksum(participants), services.DrawChecksum([]string{"a", "b", "c"}))
+	assert.NotEqual(t, services.DrawChecksum(participants), services.DrawChecksum([]string{"a", "b"}))
+	// "ab" + "c" ne doit pas se confondre avec "a" + "bc"
+	assert.NotEqual(t, services.DrawChecksum([]string{"ab", "c"}), services.DrawChecksum([]string{"a", "bc"}))
+}
+
+func TestDrawWinner(t *testing.T) {
+	participants := []string{"a", "b", "c", "d", "e"}
+
+	assert.Equal(t, "", services.DrawWinner("seed", nil))
+
+	// Le même seed rejoue toujours le même tirage
+	winner := services.DrawWinner("seed", participants)
+	assert.Contains(t, participants, winner)
+	assert.Equal(t, winner, services.DrawWinner("seed", participants))
+
+	// Tous les participants peuvent gagner
+	winners := map[string]bool{}
+	for i := 0; i < 200; i++ {
+		winners[services.DrawWinner(string(rune('A'+i%26))+string(rune(i)), participants)] = true
+	}
+	assert.Len(t, winners, len(participants))
+}
+
+func Test_RunDraw(t *testing.T) {
+	dto := &transfert.Draw{Campaign: aws.String("2024")}
+	participants := []string{"cred-1", "cred-2", "cred-3"}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		draw, err := service.RunDraw(nil)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when the campaign does not exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should refuse to run while the campaign is running", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		end := time.Now().Add(time.Hour)
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(&entities.Campaign{ID: "campaign-1", EndAt: &end}, nil)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrCampaignRunning, err)
+		mockRepo.AssertNotCalled(t, "ReadDrawParticipants", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse to run twice for the same campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(&entities.Draw{ID: "draw-1"}, nil)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, err)
+		mockRepo.AssertNotCalled(t, "CreateDraw", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when there is no participant", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("ReadDrawParticipants", &transfert.Ticket{CampaignID: &drawCampaign.ID}, mock.Anything).Return([]string{}, nil)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawNoParticipant, err)
+	})
+
+	t.Run("Should record the draw with a generated seed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+		mockRepo.On("CreateDraw", mock.Anything, mock.Anything).Return(nil)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, err)
+		assert.Len(t, draw.Seed, 64)
+		assert.Equal(t, 3, draw.Participants)
+		assert.Equal(t, participants, draw.ParticipantIDs)
+		assert.Equal(t, services.DrawChecksum(participants), draw.Checksum)
+		assert.Equal(t, services.DrawWinner(draw.Seed, participants), *draw.WinnerID)
+		assert.Equal(t, "employee-1", *draw.CredentialID)
+		assert.Equal(t, drawCampaign.ID, *draw.CampaignID)
+	})
+
+	t.Run("Should generate a new seed for every draw", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+		mockRepo.On("CreateDraw", mock.Anything, mock.Anything).Return(nil)
+
+		first, err := service.RunDraw(dto)
+		assert.Nil(t, err)
+		assert.Nil(t, first.CredentialID)
+
+		second, err := service.RunDraw(dto)
+		assert.Nil(t, err)
+		assert.NotEqual(t, first.Seed, second.Seed)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+		mockRepo.On("CreateDraw", mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("Should refuse a draw recorded concurrently after the check", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+		mockRepo.On("CreateDraw", mock.Anything, mock.Anything).Return(errors_domain_game.ErrDrawAlreadyDone)
+
+		draw, err := service.RunDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawAlreadyDone, err)
+	})
+}
+
+func Test_GetDraw(t *testing.T) {
+	dto := &transfert.Draw{Campaign: aws.String("2024")}
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		draw, err := service.GetDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when the campaign does not exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		draw, err := service.GetDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should return the draw", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(&entities.Draw{ID: "draw-1"}, nil)
+
+		draw, err := service.GetDraw(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "draw-1", draw.ID)
+	})
+}
+
+func Test_VerifyDraw(t *testing.T) {
+	dto := &transfert.Draw{Campaign: aws.String("2024")}
+	participants := []string{"cred-1", "cred-2", "cred-3"}
+	recorded := &entities.Draw{
+		ID:         "draw-1",
+		CampaignID: &drawCampaign.ID,
+		Seed:       "seed",
+		Checksum:   services.DrawChecksum(participants),
+		WinnerID:   aws.String(services.DrawWinner("seed", participants)),
+	}
+
+	t.Run("Should verify a draw against its recorded participants after a ticket expired", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		var ran *entities.Draw
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-1"))
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrDrawNotFound).Once()
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil).Once()
+		mockRepo.On("CreateDraw", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			ran = args.Get(0).(*entities.Draw)
+		}).Return(nil)
+
+		_, err := service.RunDraw(&transfert.Draw{Campaign: dto.Campaign})
+		assert.Nil(t, err)
+
+		// Le ticket du premier participant a expiré depuis, il ne fait plus partie de la population courante
+		mockRepo.On("ReadDraw", mock.Anything, mock.Anything).Return(ran, nil)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants[1:], nil)
+
+		draw, err := service.VerifyDraw(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, ran, draw)
+		mockRepo.AssertNumberOfCalls(t, "ReadDrawParticipants", 1)
+	})
+
+	t.Run("Should detect a tampered participants list", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		tampered := *recorded
+		tampered.ParticipantIDs = []string{"cred-1", "cred-2"}
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(&tampered, nil)
+
+		draw, err := service.VerifyDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawMismatch, err)
+		mockRepo.AssertNotCalled(t, "ReadDrawParticipants", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should verify a draw recorded without its participants against the current ones", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(recorded, nil)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+
+		draw, err := service.VerifyDraw(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, recorded, draw)
+	})
+
+	t.Run("Should detect a changed population", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(recorded, nil)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return([]string{"cred-1", "cred-2"}, nil)
+
+		draw, err := service.VerifyDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawMismatch, err)
+	})
+
+	t.Run("Should detect a tampered winner", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		tampered := *recorded
+		tampered.WinnerID = aws.String("someone-else")
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{Label: dto.Campaign}, mock.Anything).Return(drawCampaign, nil)
+		mockRepo.On("ReadDraw", &transfert.Draw{CampaignID: &drawCampaign.ID}, mock.Anything).Return(&tampered, nil)
+		mockRepo.On("ReadDrawParticipants", mock.Anything, mock.Anything).Return(participants, nil)
+
+		draw, err := service.VerifyDraw(dto)
+		assert.Nil(t, draw)
+		assert.Equal(t, errors_domain_game.ErrDrawMismatch, err)
+	})
+}
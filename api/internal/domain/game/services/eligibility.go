@@ -0,0 +1,137 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// checkEligibility rejects a ticket won by a client the rules of its campaign exclude
+// The age and the residence are checked here, the household limit is returned as a quota
+// and enforced when the ticket is saved, through reserveHousehold
+//
+// Parameters:
+// - ticket: *entities.Ticket The ticket about to be claimed or received
+// - credentialID: *string The credential of the client
+//
+// Returns:
+// - *entities.HouseholdQuota: The household limit the ticket must fit in, nil when none applies
+// - errors.ErrorInterface: ErrEligibilityProfileIncomplete, ErrEligibilityUnderage or ErrEligibilityResidence
+func (s *GameService) checkEligibility(ticket *entities.Ticket, credentialID *string) (*entities.HouseholdQuota, errors.ErrorInterface) {
+	if ticket.CampaignID == nil {
+		return nil, nil
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: ticket.CampaignID})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := campaign.Eligibility
+	if rules.IsEmpty() {
+		return nil, nil
+	}
+
+	if credentialID == nil {
+		return nil, errors_domain_game.ErrEligibilityProfileIncomplete
+	}
+
+	claimant, err := s.readClaimant(*credentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rules.Check(claimant, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if !rules.Covers(ticket.PrizeID) {
+		return nil, nil
+	}
+
+	if claimant.Household == nil {
+		return nil, errors_domain_game.ErrEligibilityProfileIncomplete
+	}
+
+	return &entities.HouseholdQuota{
+		Household:  *claimant.Household,
+		CampaignID: campaign.ID,
+		Limit:      rules.HouseholdLimit,
+	}, nil
+}
+
+// readClaimant reads, from the user domain, the profile of the client holding a credential
+// Employees are not clients and have no profile
+//
+// Parameters:
+// - credentialID: string The credential of the client
+//
+// Returns:
+// - *entities.Claimant: The birth date, the country of residence and the household of the client
+// - errors.ErrorInterface: ErrEligibilityProfileIncomplete if no client holds the credential
+func (s *GameService) readClaimant(credentialID string) (*entities.Claimant, errors.ErrorInterface) {
+	if s.users == nil {
+		return nil, errors.ErrInternalServer.Log(fmt.Errorf("no user repository, the clients cannot be read"))
+	}
+
+	client, err := s.users.ReadClient(&userTransfert.Client{CredentialID: &credentialID})
+	if err != nil {
+		if err == errors_domain_user.ErrClientNotFound {
+			return nil, errors_domain_game.ErrEligibilityProfileIncomplete
+		}
+		return nil, err
+	}
+
+	return &entities.Claimant{
+		BirthDate: client.BirthDate,
+		Country:   client.Country,
+		Household: client.Household,
+	}, nil
+}
+
+// reserveHousehold saves a ticket with a free slot of its household quota, or without slot when no quota applies
+// Two claims of the same household may pick the same slot, the unique index lets one through
+// and the other picks the next free slot, until the household reaches its limit
+//
+// Parameters:
+// - ticket: *entities.Ticket The ticket about to be saved with its new owner
+// - quota: *entities.HouseholdQuota The household limit, nil when none applies
+// - save: func() errors.ErrorInterface Saves the ticket, ErrEligibilityHouseholdSlotTaken when the slot was taken meanwhile
+//
+// Returns:
+// - errors.ErrorInterface: ErrEligibilityHouseholdLimit when no slot is left, or the error raised by save
+func (s *GameService) reserveHousehold(ticket *entities.Ticket, quota *entities.HouseholdQuota, save func() errors.ErrorInterface) errors.ErrorInterface {
+	if quota == nil {
+		ticket.Household, ticket.HouseholdSlot = nil, nil
+		return save()
+	}
+
+	// Each conflict means another ticket took a slot, the limit bounds the attempts
+	for attempt := 0; attempt < quota.Limit; attempt++ {
+		taken, err := s.repo.ReadHouseholdSlots(quota.CampaignID, quota.Household)
+		if err != nil {
+			return err
+		}
+
+		slot := quota.FreeSlot(taken)
+		if slot == 0 {
+			break
+		}
+
+		ticket.Household, ticket.HouseholdSlot = &quota.Household, &slot
+
+		if err := save(); err != errors_domain_game.ErrEligibilityHouseholdSlotTaken {
+			return err
+		}
+	}
+
+	ticket.Household, ticket.HouseholdSlot = nil, nil
+
+	return errors_domain_game.ErrEligibilityHouseholdLimit
+}
@@ -0,0 +1,203 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_UpdateTicket_Eligibility(t *testing.T) {
+	cid := aws.String("client-123")
+	campaignID := aws.String("campaign-2024")
+	adult := time.Now().AddDate(-30, 0, 0).Format(entities.BirthDateLayout)
+	minor := time.Now().AddDate(-17, 0, 0).Format(entities.BirthDateLayout)
+
+	rules := entities.Eligibility{MinimumAge: 18, Countries: []string{"FR", "BE"}, HouseholdLimit: 2, HouseholdPrizes: []string{"prize-1"}}
+
+	// claim prépare un ticket de la campagne et le service, le client est lu dans le domaine utilisateur
+	claim := func(prizeID string, client *user.Client, clientErr errors.ErrorInterface) (*services.GameService, *GameRepositoryMock, *entities.Ticket) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+		ticket := &entities.Ticket{ID: "ticket-123", CampaignID: campaignID, PrizeID: aws.String(prizeID)}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(&entities.Campaign{ID: *campaignID, Eligibility: rules}, nil)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil).Maybe()
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil).Maybe()
+		mockUsers.On("ReadClient", &userTransfert.Client{CredentialID: cid}, mock.Anything).Return(client, clientErr)
+
+		return service, mockRepo, ticket
+	}
+
+	dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
+	t.Run("Should refuse an underage client without recording a failed attempt", func(t *testing.T) {
+		service, mockRepo, _ := claim("prize-1", &user.Client{BirthDate: &minor, Country: aws.String("FR"), Household: aws.String("household-1")}, nil)
+
+		ticket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrEligibilityUnderage, err)
+		mockRepo.AssertNotCalled(t, "CreateClaimAttempt", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a client living outside the allowed countries", func(t *testing.T) {
+		service, _, _ := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("DE"), Household: aws.String("household-1")}, nil)
+
+		ticket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrEligibilityResidence, err)
+	})
+
+	t.Run("Should ask for the missing parts of the profile", func(t *testing.T) {
+		service, _, _ := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("FR")}, nil)
+
+		ticket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrEligibilityProfileIncomplete, err)
+
+		// Les employés n'ont pas de profil client
+		service, _, _ = claim("prize-1", nil, errors_domain_user.ErrClientNotFound)
+
+		ticket, err = service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrEligibilityProfileIncomplete, err)
+	})
+
+	t.Run("Should return the error of the user repository", func(t *testing.T) {
+		service, _, _ := claim("prize-1", nil, errors.ErrInternalServer)
+
+		ticket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+
+	t.Run("Should claim the ticket of an eligible client in a free slot of the household", func(t *testing.T) {
+		service, mockRepo, ticket := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("be"), Household: aws.String("household-1")}, nil)
+		mockRepo.On("ReadHouseholdSlots", *campaignID, "household-1", mock.Anything).Return([]int{1}, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, result.Status)
+		assert.Equal(t, "household-1", *result.Household)
+		assert.Equal(t, 2, *result.HouseholdSlot)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a client whose household reached its limit", func(t *testing.T) {
+		service, mockRepo, _ := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("FR"), Household: aws.String("household-1")}, nil)
+		mockRepo.On("ReadHouseholdSlots", *campaignID, "household-1", mock.Anything).Return([]int{1, 2}, nil)
+
+		ticket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors_domain_game.ErrEligibilityHouseholdLimit, err)
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should pick another slot when a claim of the same household took it meanwhile", func(t *testing.T) {
+		service, mockRepo, ticket := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("FR"), Household: aws.String("household-1")}, nil)
+		mockRepo.On("ReadHouseholdSlots", *campaignID, "household-1", mock.Anything).Return([]int{}, nil).Once()
+		mockRepo.On("ReadHouseholdSlots", *campaignID, "household-1", mock.Anything).Return([]int{1}, nil).Once()
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(errors_domain_game.ErrEligibilityHouseholdSlotTaken).Once()
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, *result.HouseholdSlot)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse the claim when every slot was taken meanwhile", func(t *testing.T) {
+		service, mockRepo, ticket := claim("prize-1", &user.Client{BirthDate: &adult, Country: aws.String("FR"), Household: aws.String("household-1")}, nil)
+		mockRepo.On("ReadHouseholdSlots", *campaignID, "household-1", mock.Anything).Return([]int{1}, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(errors_domain_game.ErrEligibilityHouseholdSlotTaken)
+
+		result, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrEligibilityHouseholdLimit, err)
+		assert.Nil(t, ticket.HouseholdSlot)
+
+		// Une tentative par place au plus
+		mockRepo.AssertNumberOfCalls(t, "UpdateTicketStatus", rules.HouseholdLimit)
+	})
+
+	t.Run("Should not count the household for the prizes out of the limit", func(t *testing.T) {
+		service, mockRepo, ticket := claim("prize-2", &user.Client{BirthDate: &adult, Country: aws.String("FR")}, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, result.Status)
+		assert.Nil(t, result.HouseholdSlot)
+		mockRepo.AssertNotCalled(t, "ReadHouseholdSlots", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_AnswerTicketTransfer_Eligibility(t *testing.T) {
+	sid := aws.String("sender-123")
+	rid := aws.String("recipient-123")
+	campaignID := aws.String("campaign-2024")
+	minor := time.Now().AddDate(-12, 0, 0).Format(entities.BirthDateLayout)
+
+	t.Run("Should refuse a gift to a client the campaign excludes", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+		transfer := &entities.TicketTransfer{
+			ID:          "transfer-123",
+			TicketID:    "ticket-123",
+			SenderID:    *sid,
+			RecipientID: *rid,
+			Status:      entities.TransferPending,
+			Ticket:      &entities.Ticket{ID: "ticket-123", CredentialID: sid, CampaignID: campaignID, Status: entities.TicketClaimed},
+		}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", mock.Anything, mock.Anything).Return(transfer, nil)
+		mockRepo.On("ReadCampaign", &transfert.Campaign{ID: campaignID}, mock.Anything).Return(&entities.Campaign{ID: *campaignID, Eligibility: entities.Eligibility{MinimumAge: 18}}, nil)
+		mockUsers.On("ReadClient", &userTransfert.Client{CredentialID: rid}, mock.Anything).Return(&user.Client{BirthDate: &minor}, nil)
+
+		result, err := service.AnswerTicketTransfer(&transfert.TicketTransfer{ID: aws.String("transfer-123"), Status: aws.String("accepted")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrEligibilityUnderage, err)
+
+		// Le ticket reste au donateur
+		assert.Equal(t, sid, transfer.Ticket.CredentialID)
+		mockRepo.AssertNotCalled(t, "UpdateTicketTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should give back the household slot of the sender", func(t *testing.T) {
+		service, mockRepo, mockPerms, _ := setupUsers()
+		transfer := &entities.TicketTransfer{
+			ID:          "transfer-123",
+			TicketID:    "ticket-123",
+			SenderID:    *sid,
+			RecipientID: *rid,
+			Status:      entities.TransferPending,
+			Ticket:      &entities.Ticket{ID: "ticket-123", CredentialID: sid, Status: entities.TicketClaimed, Household: aws.String("household-1"), HouseholdSlot: aws.Int(1)},
+		}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", mock.Anything, mock.Anything).Return(transfer, nil)
+		mockRepo.On("UpdateTicketTransfer", transfer, transfer.Ticket, mock.Anything, mock.Anything).Return(nil)
+
+		result, err := service.AnswerTicketTransfer(&transfert.TicketTransfer{ID: aws.String("transfer-123"), Status: aws.String("accepted")})
+		assert.Nil(t, err)
+		assert.Equal(t, rid, result.Ticket.CredentialID)
+		assert.Nil(t, result.Ticket.Household)
+		assert.Nil(t, result.Ticket.HouseholdSlot)
+	})
+}
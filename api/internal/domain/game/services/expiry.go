@@ -0,0 +1,134 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+var (
+	// unclaimedStatuses are the statuses of the tickets never played, expiring at the end of the campaign
+	unclaimedStatuses = []entities.TicketStatus{entities.TicketGenerated, entities.TicketDistributed}
+	// uncollectedStatuses are the statuses of the prizes won but not collected, expiring at the claim deadline
+	uncollectedStatuses = []entities.TicketStatus{entities.TicketClaimed}
+)
+
+// ExpireTickets forfeits the tickets of a campaign whose deadline has passed, project.expiry.grace minutes included
+// Unplayed tickets expire after the end of the campaign, prizes left uncollected after the claim deadline;
+// claims held for review wait for their decision. Expired tickets stay in place, left out of the ticket listings.
+// The forfeit is recorded with what was actually expired, even when it stops midway, and nothing is recorded
+// when no ticket was left to expire
+//
+// Parameters:
+// - dto: *transfert.Campaign The campaign ID
+//
+// Returns:
+// - *entities.TicketExpiry: The summary of the expired tickets and of the value of their prizes
+// - errors.ErrorInterface: ErrCampaignDeadlineOpen when no deadline of the campaign has passed yet
+func (s *GameService) ExpireTickets(dto *transfert.Campaign) (*entities.TicketExpiry, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	now, grace := time.Now(), entities.ExpiryGrace()
+	ended := campaign.IsExpired(entities.TicketGenerated, grace, now)
+	closed := campaign.IsExpired(entities.TicketClaimed, grace, now)
+
+	if !ended && !closed {
+		return nil, errors_domain_game.ErrCampaignDeadlineOpen
+	}
+
+	unclaimed, uncollected := map[string]int{}, map[string]int{}
+
+	var expired errors.ErrorInterface
+	if ended {
+		expired = s.expire(campaign, unclaimedStatuses, unclaimed)
+	}
+
+	if closed && expired == nil {
+		expired = s.expire(campaign, uncollectedStatuses, uncollected)
+	}
+
+	prizes, err := s.repo.ReadPrizes(&transfert.Prize{})
+	if err != nil {
+		return nil, err
+	}
+
+	expiry := entities.NewTicketExpiry(campaign, unclaimed, uncollected, prizes)
+	expiry.CredentialID = s.security.GetCredentialID()
+
+	if !expiry.IsEmpty() {
+		if err := s.repo.CreateTicketExpiry(expiry); err != nil {
+			return nil, err
+		}
+	}
+
+	if expired != nil {
+		return nil, expired
+	}
+
+	return expiry, nil
+}
+
+// GetTicketExpiries lists the expiries of the tickets of a campaign, the most recent first
+func (s *GameService) GetTicketExpiries(dto *transfert.Campaign) ([]*entities.TicketExpiry, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.ReadTicketExpiries(database.Where("campaign_id = ?", campaign.ID))
+}
+
+// expire expires the tickets of the campaign in the given statuses by batches of project.expiry.batch,
+// adding the number of tickets expired per prize to counts
+func (s *GameService) expire(campaign *entities.Campaign, from []entities.TicketStatus, counts map[string]int) errors.ErrorInterface {
+	status := entities.TicketExpired.String()
+	history := &transfert.TicketHistory{
+		CredentialID: s.security.GetCredentialID(),
+		Status:       &status,
+	}
+
+	filter := &transfert.Ticket{CampaignID: &campaign.ID}
+	batch := entities.ExpiryBatchSize()
+
+	for {
+		expired, err := s.repo.ExpireTickets(filter, from, batch, history)
+		if err != nil {
+			return err
+		}
+
+		total := 0
+		for prizeID, count := range expired {
+			counts[prizeID] += count
+			total += count
+		}
+
+		if total < batch {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// expiryCampaign retourne une campagne terminée depuis end, dont les lots se retirent jusqu'à deadline
+func expiryCampaign(end, deadline time.Duration) *entities.Campaign {
+	endAt, claimDeadline := time.Now().Add(end), time.Now().Add(deadline)
+	return &entities.Campaign{ID: "campaign-1", EndAt: &endAt, ClaimDeadline: &claimDeadline}
+}
+
+var expiryPrizes = []*entities.Prize{
+	{ID: "prize-1", Value: aws.Float64(39)},
+	{ID: "prize-2", Value: aws.Float64(69)},
+}
+
+func Test_ExpireTickets(t *testing.T) {
+	dto := &transfert.Campaign{ID: aws.String("campaign-1")}
+	unclaimed := []entities.TicketStatus{entities.TicketGenerated, entities.TicketDistributed}
+	uncollected := []entities.TicketStatus{entities.TicketClaimed}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		expiry, err := service.ExpireTickets(nil)
+		assert.Nil(t, expiry)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, expiry)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "ReadCampaign", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when the campaign doesn't exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, expiry)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should refuse a campaign still running", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(time.Hour, 2*time.Hour), nil)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, expiry)
+		assert.Equal(t, errors_domain_game.ErrCampaignDeadlineOpen, err)
+		mockRepo.AssertNotCalled(t, "ExpireTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should only expire the unclaimed tickets before the claim deadline", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(-time.Hour, time.Hour), nil)
+		mockRepo.On("ExpireTickets", mock.Anything, unclaimed, entities.ExpiryBatch, mock.Anything, mock.Anything).Return(map[string]int{"prize-1": 2, "prize-2": 1}, nil).Once()
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(expiryPrizes, nil)
+		mockRepo.On("CreateTicketExpiry", mock.Anything, mock.Anything).Return(nil)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, expiry.Unclaimed)
+		assert.Equal(t, 0, expiry.Uncollected)
+		assert.Equal(t, 147.0, expiry.UnclaimedValue)
+		assert.Equal(t, "admin-1", *expiry.CredentialID)
+		mockRepo.AssertNotCalled(t, "ExpireTickets", mock.Anything, uncollected, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should expire by batches until the campaign is exhausted", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(-2*time.Hour, -time.Hour), nil)
+		mockRepo.On("ExpireTickets", mock.Anything, unclaimed, entities.ExpiryBatch, mock.Anything, mock.Anything).Return(map[string]int{"prize-1": entities.ExpiryBatch}, nil).Once()
+		mockRepo.On("ExpireTickets", mock.Anything, unclaimed, entities.ExpiryBatch, mock.Anything, mock.Anything).Return(map[string]int{"prize-1": 10}, nil).Once()
+		mockRepo.On("ExpireTickets", mock.Anything, uncollected, entities.ExpiryBatch, mock.Anything, mock.Anything).Return(map[string]int{"prize-2": 1}, nil).Once()
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(expiryPrizes, nil)
+		mockRepo.On("CreateTicketExpiry", mock.Anything, mock.Anything).Return(nil)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.ExpiryBatch+10, expiry.Unclaimed)
+		assert.Equal(t, 1, expiry.Uncollected)
+		assert.Equal(t, 69.0, expiry.UncollectedValue)
+		mockRepo.AssertNumberOfCalls(t, "ExpireTickets", 3)
+	})
+
+	t.Run("Should not record an expiry when no ticket is left", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(-2*time.Hour, -time.Hour), nil)
+		mockRepo.On("ExpireTickets", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(map[string]int{}, nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(expiryPrizes, nil)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, err)
+		assert.True(t, expiry.IsEmpty())
+		mockRepo.AssertNotCalled(t, "CreateTicketExpiry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should record the tickets expired before a failure", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("admin-1"))
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(-2*time.Hour, -time.Hour), nil)
+		mockRepo.On("ExpireTickets", mock.Anything, unclaimed, mock.Anything, mock.Anything, mock.Anything).Return(map[string]int{"prize-1": entities.ExpiryBatch}, nil).Once()
+		mockRepo.On("ExpireTickets", mock.Anything, unclaimed, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketInvalidTransition).Once()
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(expiryPrizes, nil)
+		mockRepo.On("CreateTicketExpiry", mock.MatchedBy(func(expiry *entities.TicketExpiry) bool {
+			return expiry.Unclaimed == entities.ExpiryBatch && expiry.Uncollected == 0
+		}), mock.Anything).Return(nil)
+
+		expiry, err := service.ExpireTickets(dto)
+		assert.Nil(t, expiry)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+		mockRepo.AssertCalled(t, "CreateTicketExpiry", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "ExpireTickets", mock.Anything, uncollected, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func Test_GetTicketExpiries(t *testing.T) {
+	dto := &transfert.Campaign{ID: aws.String("campaign-1")}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		expiries, err := service.GetTicketExpiries(nil)
+		assert.Nil(t, expiries)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(false)
+
+		expiries, err := service.GetTicketExpiries(dto)
+		assert.Nil(t, expiries)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when the campaign doesn't exist", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(nil, errors_domain_game.ErrCampaignNotFound)
+
+		expiries, err := service.GetTicketExpiries(dto)
+		assert.Nil(t, expiries)
+		assert.Equal(t, errors_domain_game.ErrCampaignNotFound, err)
+	})
+
+	t.Run("Should return the expiries of the campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", campaignRoles).Return(true)
+		mockRepo.On("ReadCampaign", dto, mock.Anything).Return(expiryCampaign(-2*time.Hour, -time.Hour), nil)
+		mockRepo.On("ReadTicketExpiries", mock.Anything).Return([]*entities.TicketExpiry{{ID: "expiry-1"}}, nil)
+
+		expiries, err := service.GetTicketExpiries(dto)
+		assert.Nil(t, err)
+		assert.Len(t, expiries, 1)
+	})
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// LedgerChunk is the number of ledger entries or tickets loaded at once while exporting or verifying the ledger
+const LedgerChunk = 500
+
+// PrepareLedger checks that the caller may read the ledger
+// Nothing is written, so the caller can still answer with an error before streaming the export
+func (s *GameService) PrepareLedger() errors.ErrorInterface {
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_AUDITOR) {
+		return errors.ErrUnauthorized
+	}
+
+	return nil
+}
+
+// WriteLedger streams the whole ledger to w as CSV, in chain order, headed by entities.LedgerHeader
+// The export carries the hashes, so the bailiff can recompute the chain without access to the database
+//
+// Parameters:
+// - w: io.Writer The destination of the export
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (s *GameService) WriteLedger(w io.Writer) errors.ErrorInterface {
+	if err := s.PrepareLedger(); err != nil {
+		return err
+	}
+
+	out := csv.NewWriter(w)
+	if err := out.Write(entities.LedgerHeader); err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	if err := s.repo.StreamLedger(LedgerChunk, func(entries []*entities.LedgerEntry) errors.ErrorInterface {
+		for _, entry := range entries {
+			if err := out.Write(entry.Record()); err != nil {
+				return errors.ErrInternalServer.Log(err)
+			}
+		}
+
+		out.Flush()
+		if err := out.Error(); err != nil {
+			return errors.ErrInternalServer.Log(err)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return errors.ErrInternalServer.Log(err)
+	}
+
+	return nil
+}
+
+// VerifyLedger recomputes the hash chain of the ledger and compares the state it vouches for with the stored tickets
+// The ledger and the tickets are read one after the other, a ticket changed meanwhile is reported as altered:
+// the verification is meant to run while the game is quiet
+//
+// Returns:
+// - *entities.LedgerReport: The report, valid when the chain holds and every ticket matches its ledger
+// - errors.ErrorInterface: The error interface if an error occurs
+func (s *GameService) VerifyLedger() (*entities.LedgerReport, errors.ErrorInterface) {
+	if err := s.PrepareLedger(); err != nil {
+		return nil, err
+	}
+
+	report := &entities.LedgerReport{Samples: []string{}}
+	states := map[string]*entities.LedgerState{}
+
+	var previous *entities.LedgerEntry
+	if err := s.repo.StreamLedger(LedgerChunk, func(entries []*entities.LedgerEntry) errors.ErrorInterface {
+		for _, entry := range entries {
+			if report.BrokenAt == nil && !entry.Follows(previous) {
+				sequence := entry.Sequence
+				report.BrokenAt = &sequence
+			}
+
+			states[entry.TicketID] = entry.Apply(states[entry.TicketID])
+
+			last := *entry
+			previous = &last
+			report.Entries++
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if previous != nil {
+		report.Head = previous.Hash
+	}
+
+	if err := s.repo.StreamTickets(&transfert.Ticket{}, LedgerChunk, func(tickets []*entities.Ticket) errors.ErrorInterface {
+		for _, ticket := range tickets {
+			state, ok := states[ticket.ID]
+			delete(states, ticket.ID)
+
+			if !ok || *state != *entities.NewLedgerState(ticket) {
+				report.Alter(ticket.ID)
+			}
+
+			report.Tickets++
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Tickets the ledger vouches for but which are gone from the tickets
+	missing := make([]string, 0, len(states))
+	for id := range states {
+		missing = append(missing, id)
+	}
+
+	sort.Strings(missing)
+	for _, id := range missing {
+		report.Alter(id)
+	}
+
+	report.Valid = report.BrokenAt == nil && report.Altered == 0
+
+	return report, nil
+}
@@ -0,0 +1,186 @@
+package services_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var ledgerRoles = []security.Role{security.ROLE_ADMIN, user.ROLE_AUDITOR}
+
+// ledgerFixture retourne deux tickets, dont le second réclamé, et le registre scellé de leur histoire
+func ledgerFixture() ([]*entities.Ticket, []*entities.LedgerEntry) {
+	tickets := []*entities.Ticket{
+		{ID: "ticket-1", Token: token.NewLuhn("123456789015"), PrizeID: aws.String("prize-1"), Status: entities.TicketGenerated},
+		{ID: "ticket-2", Token: token.NewLuhn("123456789023"), PrizeID: aws.String("prize-2"), Status: entities.TicketGenerated},
+	}
+
+	entries := []*entities.LedgerEntry{
+		entities.NewTicketLedgerEntry(tickets[0], entities.LedgerCreated),
+		entities.NewTicketLedgerEntry(tickets[1], entities.LedgerCreated),
+		entities.NewHistoryLedgerEntry(&entities.TicketHistory{
+			TicketID:       aws.String("ticket-2"),
+			CredentialID:   aws.String("client-1"),
+			OwnerID:        aws.String("client-1"),
+			PreviousStatus: entities.TicketGenerated,
+			Status:         entities.TicketClaimed,
+		}),
+	}
+
+	var previous *entities.LedgerEntry
+	for _, entry := range entries {
+		entry.Seal(previous, time.Now())
+		previous = entry
+	}
+
+	tickets[1].Status = entities.TicketClaimed
+	tickets[1].CredentialID = aws.String("client-1")
+
+	return tickets, entries
+}
+
+func Test_PrepareLedger(t *testing.T) {
+	t.Run("Should refuse the employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.PrepareLedger())
+	})
+
+	t.Run("Should accept the auditors", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+
+		assert.Nil(t, service.PrepareLedger())
+	})
+}
+
+func Test_WriteLedger(t *testing.T) {
+	t.Run("Should refuse the employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(false)
+
+		err := service.WriteLedger(&bytes.Buffer{})
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "StreamLedger", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should write the ledger as CSV", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		_, entries := ledgerFixture()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, entries)
+
+		var out bytes.Buffer
+		require.Nil(t, service.WriteLedger(&out))
+
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 4)
+		assert.Equal(t, entities.LedgerHeader, records[0])
+		assert.Equal(t, entries[2].Record(), records[3])
+	})
+
+	t.Run("Should return the error of the repository", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		assert.Equal(t, errors.ErrInternalServer, service.WriteLedger(&bytes.Buffer{}))
+	})
+}
+
+func Test_VerifyLedger(t *testing.T) {
+	t.Run("Should refuse the employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(false)
+
+		report, err := service.VerifyLedger()
+		assert.Nil(t, report)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should validate an intact ledger", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		tickets, entries := ledgerFixture()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, entries)
+		mockRepo.On("StreamTickets", mock.Anything, services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, tickets)
+
+		report, err := service.VerifyLedger()
+		require.Nil(t, err)
+		assert.True(t, report.Valid)
+		assert.Equal(t, 3, report.Entries)
+		assert.Equal(t, 2, report.Tickets)
+		assert.Equal(t, entries[2].Hash, report.Head)
+		assert.Nil(t, report.BrokenAt)
+		assert.Empty(t, report.Samples)
+	})
+
+	t.Run("Should locate an altered entry", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		tickets, entries := ledgerFixture()
+		entries[1].PrizeID = aws.String("prize-1")
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, entries)
+		mockRepo.On("StreamTickets", mock.Anything, services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, tickets)
+
+		report, err := service.VerifyLedger()
+		require.Nil(t, err)
+		assert.False(t, report.Valid)
+		require.NotNil(t, report.BrokenAt)
+		assert.Equal(t, int64(2), *report.BrokenAt)
+		assert.Equal(t, []string{"ticket-2"}, report.Samples)
+	})
+
+	t.Run("Should detect tickets altered, added or removed behind the ledger", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		tickets, entries := ledgerFixture()
+		tickets[1].CredentialID = aws.String("client-2")
+		tickets[0] = &entities.Ticket{ID: "ticket-3", Token: token.NewLuhn("123456789031")}
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, entries)
+		mockRepo.On("StreamTickets", mock.Anything, services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, tickets)
+
+		report, err := service.VerifyLedger()
+		require.Nil(t, err)
+		assert.False(t, report.Valid)
+		assert.Nil(t, report.BrokenAt)
+		assert.Equal(t, 3, report.Altered)
+		assert.Equal(t, []string{"ticket-3", "ticket-2", "ticket-1"}, report.Samples)
+	})
+
+	t.Run("Should return the error of the repository", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		_, entries := ledgerFixture()
+
+		mockPerms.On("IsGrantedByRoles", ledgerRoles).Return(true)
+		mockRepo.On("StreamLedger", services.LedgerChunk, mock.Anything, mock.Anything).Return(nil, entries)
+		mockRepo.On("StreamTickets", mock.Anything, services.LedgerChunk, mock.Anything, mock.Anything).Return(errors.ErrInternalServer)
+
+		report, err := service.VerifyLedger()
+		assert.Nil(t, report)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/env"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+)
+
+// Mail templates sent to the winners
+const (
+	ClaimTemplate = "claim" // Claim confirmed right away
+	PrizeTemplate = "prize" // Prize ready to collect, right after a confirmed claim or once a held claim is approved
+)
+
+// DeadlineLayout formats the claim deadline in the mails, in the campaign timezone
+const DeadlineLayout = "02/01/2006 à 15:04"
+
+var subjects = map[string]string{
+	ClaimTemplate: "Félicitations, vous avez gagné !",
+	PrizeTemplate: "Votre lot est prêt",
+}
+
+// notifyWinner mails the client holding a ticket which prize was won and how to collect it
+// Nothing is sent without mail service or without holder, a failure is logged and never undoes the claim
+//
+// Parameters:
+// - ticket: *entities.Ticket The claimed ticket, with its prize when preloaded
+// - templateNames: ...string ClaimTemplate and/or PrizeTemplate, sent in this order
+func (s *GameService) notifyWinner(ticket *entities.Ticket, templateNames ...string) {
+	if s.mail == nil || ticket == nil || ticket.CredentialID == nil {
+		return
+	}
+
+	for _, templateName := range templateNames {
+		if err := s.sendWinnerMail(ticket, templateName); err != nil {
+			logger.Warn(err)
+			return
+		}
+	}
+}
+
+// sendWinnerMail renders a winner template and sends it, with up to 3 attempts
+//
+// Parameters:
+// - ticket: *entities.Ticket The claimed ticket
+// - templateName: string The name of the mail template
+//
+// Returns:
+// - errors.ErrorInterface: ErrMailTemplateNotFound, ErrMailSendFailed or the error raised while reading the recipient
+func (s *GameService) sendWinnerMail(ticket *entities.Ticket, templateName string) errors.ErrorInterface {
+	tpl := template.NewTemplate(templateName)
+	if tpl == nil {
+		return errors.ErrMailTemplateNotFound
+	}
+
+	email, err := s.readWinnerEmail(*ticket.CredentialID)
+	if err != nil {
+		return err
+	}
+
+	prize := ticket.Prize
+	if prize == nil && ticket.PrizeID != nil {
+		if prize, err = s.repo.ReadPrize(&transfert.Prize{ID: ticket.PrizeID}); err != nil {
+			return err
+		}
+	}
+
+	data := template.Data{
+		"AppName":  env.APP_NAME,
+		"Token":    ticket.Token.String(),
+		"Prize":    "",
+		"Deadline": "",
+	}
+
+	if prize != nil && prize.Label != nil {
+		data["Prize"] = *prize.Label
+	}
+
+	if ticket.CampaignID != nil {
+		campaign, err := s.repo.ReadCampaign(&transfert.Campaign{ID: ticket.CampaignID})
+		if err != nil {
+			return err
+		}
+
+		if campaign.ClaimDeadline != nil {
+			data["Deadline"] = campaign.ClaimDeadline.In(campaign.Location()).Format(DeadlineLayout)
+		}
+	}
+
+	text, html, err := tpl.Inject(data)
+	if err != nil {
+		return err
+	}
+
+	m := &mail.Mail{
+		To:      []string{email},
+		Subject: subjects[templateName],
+		Text:    text,
+		Html:    html,
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.mail.Send(m); err == nil {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	return errors.ErrMailSendFailed
+}
+
+// readWinnerEmail reads the email address of the winner from the user domain, which owns the credentials
+//
+// Parameters:
+// - credentialID: string The ID of the credential holding the ticket
+//
+// Returns:
+// - string: The email address
+// - errors.ErrorInterface: ErrCredentialNotFound if the credential does not exist or has no email
+func (s *GameService) readWinnerEmail(credentialID string) (string, errors.ErrorInterface) {
+	if s.users == nil {
+		return "", errors.ErrInternalServer.Log(fmt.Errorf("no user repository, the credentials cannot be read"))
+	}
+
+	credential, err := s.users.ReadCredential(&userTransfert.Credential{ID: &credentialID})
+	if err != nil {
+		return "", err
+	}
+
+	if credential.Email == nil || *credential.Email == "" {
+		return "", errors_domain_user.ErrCredentialNotFound
+	}
+
+	return *credential.Email, nil
+}
@@ -0,0 +1,171 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// waitMail attend le mail envoyé en arrière-plan par le service
+func waitMail(t *testing.T, sent chan *mail.Mail) *mail.Mail {
+	select {
+	case m := <-sent:
+		return m
+	case <-time.After(2 * time.Second):
+		t.Fatal("no mail sent")
+		return nil
+	}
+}
+
+func Test_NotifyWinner(t *testing.T) {
+	cid := aws.String("client-123")
+	code := "79927398713"
+
+	t.Run("Should confirm a claim by mail and tell the prize is ready", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMailer := setupMail()
+
+		sent := make(chan *mail.Mail, 2)
+		dto := &transfert.Ticket{Token: aws.String(code)}
+		ticket := &entities.Ticket{
+			ID:     "ticket-123",
+			Token:  token.NewLuhn(code),
+			Prize:  &entities.Prize{Label: aws.String("Infuseur à thé")},
+			Status: entities.TicketDistributed,
+		}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+		mockUsers.On("ReadCredential", &userTransfert.Credential{ID: cid}, mock.Anything).Return(&user.Credential{Email: aws.String("winner@thetiptop.fr")}, nil)
+		mockMailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent <- args.Get(0).(*mail.Mail)
+		}).Return(nil)
+
+		_, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+
+		m := waitMail(t, sent)
+		assert.Equal(t, []string{"winner@thetiptop.fr"}, m.To)
+		assert.Equal(t, "Félicitations, vous avez gagné !", m.Subject)
+		assert.Contains(t, string(m.Text), "Infuseur à thé")
+		assert.Contains(t, string(m.Text), code)
+		assert.Contains(t, string(m.Html), code)
+		assert.NotContains(t, string(m.Text), "Vous avez jusqu'au")
+
+		// Le lot d'une participation acceptée d'emblée peut être retiré tout de suite
+		m = waitMail(t, sent)
+		assert.Equal(t, []string{"winner@thetiptop.fr"}, m.To)
+		assert.Equal(t, "Votre lot est prêt", m.Subject)
+		assert.Contains(t, string(m.Text), "Infuseur à thé")
+
+		mockRepo.AssertNotCalled(t, "ReadPrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should tell the prize is ready once a held claim is approved", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMailer := setupMail()
+
+		sent := make(chan *mail.Mail, 1)
+		deadline := time.Date(2099, 12, 31, 22, 59, 0, 0, time.UTC)
+		campaign := &entities.Campaign{
+			ID:            "campaign-123",
+			StartAt:       aws.Time(time.Now().Add(-time.Hour)),
+			ClaimDeadline: &deadline,
+			Timezone:      aws.String("Europe/Paris"),
+		}
+		review := &entities.ClaimReview{
+			ID:             "review-123",
+			PreviousStatus: entities.TicketDistributed,
+			Status:         entities.ReviewPending,
+			Ticket: &entities.Ticket{
+				ID:           "ticket-123",
+				Token:        token.NewLuhn(code),
+				CredentialID: cid,
+				PrizeID:      aws.String("prize-123"),
+				CampaignID:   &campaign.ID,
+				Status:       entities.TicketReview,
+			},
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-123"))
+		mockRepo.On("ReadClaimReview", mock.Anything, mock.Anything).Return(review, nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(campaign, nil)
+		mockRepo.On("UpdateClaimReview", review, review.Ticket, mock.Anything, mock.Anything).Return(nil)
+		mockUsers.On("ReadCredential", &userTransfert.Credential{ID: cid}, mock.Anything).Return(&user.Credential{Email: aws.String("winner@thetiptop.fr")}, nil)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: aws.String("prize-123")}, mock.Anything).Return(&entities.Prize{Label: aws.String("Coffret découverte 69€")}, nil)
+		mockMailer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+			sent <- args.Get(0).(*mail.Mail)
+		}).Return(nil)
+
+		_, err := service.ReviewClaim(&transfert.ClaimReview{ID: aws.String("review-123"), Status: aws.String("approved")})
+		assert.Nil(t, err)
+
+		m := waitMail(t, sent)
+		assert.Equal(t, "Votre lot est prêt", m.Subject)
+		assert.Contains(t, string(m.Text), "Coffret découverte 69€")
+		// L'échéance est affichée dans le fuseau de la campagne
+		assert.Contains(t, string(m.Text), "31/12/2099 à 23:59")
+	})
+
+	t.Run("Should not mail a rejected claim", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMailer := setupMail()
+
+		review := &entities.ClaimReview{
+			ID:             "review-123",
+			PreviousStatus: entities.TicketDistributed,
+			Status:         entities.ReviewPending,
+			Ticket:         &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketReview},
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(aws.String("employee-123"))
+		mockRepo.On("ReadClaimReview", mock.Anything, mock.Anything).Return(review, nil)
+		mockRepo.On("UpdateClaimReview", review, review.Ticket, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.ReviewClaim(&transfert.ClaimReview{ID: aws.String("review-123"), Status: aws.String("rejected")})
+		assert.Nil(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+		mockUsers.AssertNotCalled(t, "ReadCredential", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should not mail a winner without address", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers, mockMailer := setupMail()
+
+		done := make(chan struct{})
+		dto := &transfert.Ticket{Token: aws.String(code)}
+		ticket := &entities.Ticket{ID: "ticket-123", Token: token.NewLuhn(code)}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+		mockUsers.On("ReadCredential", &userTransfert.Credential{ID: cid}, mock.Anything).Run(func(args mock.Arguments) {
+			close(done)
+		}).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		_, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+
+		<-done
+		time.Sleep(50 * time.Millisecond)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/data"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// GetPrizes lists the catalogue; only employees see inactive prizes
+func (s *GameService) GetPrizes() ([]*entities.Prize, errors.ErrorInterface) {
+	filter := &transfert.Prize{}
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		active := true
+		filter.Active = &active
+	}
+
+	prizes, err := s.repo.ReadPrizes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return prizes, nil
+}
+
+func (s *GameService) GetPrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !prize.IsPublic() && !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors_domain_game.ErrPrizeNotFound
+	}
+
+	return prize, nil
+}
+
+func (s *GameService) CreatePrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if _, err := s.repo.ReadPrize(&transfert.Prize{Label: dto.Label}); err == nil {
+		return nil, errors_domain_game.ErrPrizeAlreadyExists
+	} else if err != errors_domain_game.ErrPrizeNotFound {
+		return nil, err
+	}
+
+	if dto.Active == nil {
+		active := true
+		dto.Active = &active
+	}
+
+	if err := s.checkPrize(entities.CreatePrize(dto)); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.CreatePrize(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *GameService) UpdatePrize(dto *transfert.Prize) (*entities.Prize, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if dto.Label != nil && (prize.Label == nil || *dto.Label != *prize.Label) {
+		if _, err := s.repo.ReadPrize(&transfert.Prize{Label: dto.Label}); err == nil {
+			return nil, errors_domain_game.ErrPrizeAlreadyExists
+		} else if err != errors_domain_game.ErrPrizeNotFound {
+			return nil, err
+		}
+	}
+
+	data.UpdateEntityWithDto(prize, dto)
+
+	if err := s.checkPrize(prize); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePrize(prize); err != nil {
+		return nil, err
+	}
+
+	return prize, nil
+}
+
+func (s *GameService) DeletePrize(dto *transfert.Prize) errors.ErrorInterface {
+	if dto == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return errors.ErrUnauthorized
+	}
+
+	prize, err := s.repo.ReadPrize(&transfert.Prize{ID: dto.ID})
+	if err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountTicket(&transfert.Ticket{PrizeID: &prize.ID})
+	if err != nil {
+		return err
+	}
+
+	// Un lot déjà attribué à des tickets se désactive, il ne se supprime pas
+	if count > 0 {
+		return errors_domain_game.ErrPrizeInUse
+	}
+
+	return s.repo.DeletePrize(&transfert.Prize{ID: &prize.ID})
+}
+
+// checkPrize validates the values of a prize and ensures the active prizes never share more than 100% of the tickets
+func (s *GameService) checkPrize(prize *entities.Prize) errors.ErrorInterface {
+	if prize.Value != nil && *prize.Value < 0 {
+		return errors_domain_game.ErrPrizeInvalidValue
+	}
+
+	if prize.Distribution != nil && (*prize.Distribution < 0 || *prize.Distribution > 100) {
+		return errors_domain_game.ErrPrizeInvalidValue
+	}
+
+	if !prize.IsActive() || prize.Distribution == nil {
+		return nil
+	}
+
+	active := true
+	prizes, err := s.repo.ReadPrizes(&transfert.Prize{Active: &active})
+	if err != nil {
+		return err
+	}
+
+	total := *prize.Distribution
+	for _, other := range prizes {
+		if other.ID != prize.ID && other.Distribution != nil {
+			total += *other.Distribution
+		}
+	}
+
+	if total > 100 {
+		return errors_domain_game.ErrPrizeDistributionOverflow
+	}
+
+	return nil
+}
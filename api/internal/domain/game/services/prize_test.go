@@ -0,0 +1,269 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetPrizes(t *testing.T) {
+	t.Run("Should return only active prizes to clients", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+		mockRepo.On("ReadPrizes", &transfert.Prize{Active: aws.Bool(true)}, mock.Anything).Return([]*entities.Prize{{ID: "prize-1"}}, nil)
+
+		prizes, err := service.GetPrizes()
+		assert.Nil(t, err)
+		assert.Len(t, prizes, 1)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return the whole catalogue to employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrizes", &transfert.Prize{}, mock.Anything).Return([]*entities.Prize{{ID: "prize-1"}, {ID: "prize-2"}}, nil)
+
+		prizes, err := service.GetPrizes()
+		assert.Nil(t, err)
+		assert.Len(t, prizes, 2)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		prizes, err := service.GetPrizes()
+		assert.Nil(t, prizes)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+func Test_GetPrize(t *testing.T) {
+	dto := &transfert.Prize{ID: aws.String("prize-1")}
+
+	t.Run("Should return an active prize", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-1", Active: aws.Bool(true)}, nil)
+
+		prize, err := service.GetPrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "prize-1", prize.ID)
+	})
+
+	t.Run("Should hide an inactive prize from clients", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-1", Active: aws.Bool(false)}, nil)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		prize, err := service.GetPrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, err)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		prize, err := service.GetPrize(nil)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
+
+func Test_CreatePrize(t *testing.T) {
+	newDto := func() *transfert.Prize {
+		return &transfert.Prize{
+			Label:        aws.String("Infuseur à thé"),
+			Value:        aws.Float64(8),
+			Distribution: aws.Int(60),
+		}
+	}
+
+	t.Run("Should create prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := newDto()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{Label: dto.Label}, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+		mockRepo.On("ReadPrizes", &transfert.Prize{Active: aws.Bool(true)}, mock.Anything).Return([]*entities.Prize{{ID: "other", Distribution: aws.Int(40)}}, nil)
+		mockRepo.On("CreatePrize", dto, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "prize-1", prize.ID)
+		assert.True(t, *dto.Active)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		prize, err := service.CreatePrize(newDto())
+		assert.Nil(t, prize)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "CreatePrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when label already exists", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := newDto()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{Label: dto.Label}, mock.Anything).Return(&entities.Prize{ID: "prize-1"}, nil)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, err)
+	})
+
+	t.Run("Should return error when value is negative", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := newDto()
+		dto.Value = aws.Float64(-1)
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeInvalidValue, err)
+	})
+
+	t.Run("Should return error when distribution exceeds 100 percent", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+		dto := newDto()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{{ID: "other", Distribution: aws.Int(50)}}, nil)
+
+		prize, err := service.CreatePrize(dto)
+		assert.Nil(t, prize)
+		assert.Equal(t, errors_domain_game.ErrPrizeDistributionOverflow, err)
+
+		mockRepo.AssertNotCalled(t, "CreatePrize", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_UpdatePrize(t *testing.T) {
+	t.Run("Should update prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Prize{ID: aws.String("prize-1"), Description: aws.String("Un infuseur en inox")}
+		prize := &entities.Prize{ID: "prize-1", Label: aws.String("Infuseur à thé"), Active: aws.Bool(true), Distribution: aws.Int(60)}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: dto.ID}, mock.Anything).Return(prize, nil)
+		mockRepo.On("ReadPrizes", mock.Anything, mock.Anything).Return([]*entities.Prize{prize}, nil)
+		mockRepo.On("UpdatePrize", prize, mock.Anything).Return(nil)
+
+		updated, err := service.UpdatePrize(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "Un infuseur en inox", *updated.Description)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when renaming to an existing label", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Prize{ID: aws.String("prize-1"), Label: aws.String("Coffret découverte 39€")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", &transfert.Prize{ID: dto.ID}, mock.Anything).Return(&entities.Prize{ID: "prize-1", Label: aws.String("Infuseur à thé")}, nil)
+		mockRepo.On("ReadPrize", &transfert.Prize{Label: dto.Label}, mock.Anything).Return(&entities.Prize{ID: "prize-2"}, nil)
+
+		updated, err := service.UpdatePrize(dto)
+		assert.Nil(t, updated)
+		assert.Equal(t, errors_domain_game.ErrPrizeAlreadyExists, err)
+	})
+
+	t.Run("Should return error when prize not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Prize{ID: aws.String("prize-1")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrPrizeNotFound)
+
+		updated, err := service.UpdatePrize(dto)
+		assert.Nil(t, updated)
+		assert.Equal(t, errors_domain_game.ErrPrizeNotFound, err)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		updated, err := service.UpdatePrize(&transfert.Prize{ID: aws.String("prize-1")})
+		assert.Nil(t, updated)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_DeletePrize(t *testing.T) {
+	dto := &transfert.Prize{ID: aws.String("prize-1")}
+	prize := &entities.Prize{ID: "prize-1"}
+
+	t.Run("Should delete an unused prize", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(prize, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{PrizeID: &prize.ID}, mock.Anything).Return(0, nil)
+		mockRepo.On("DeletePrize", &transfert.Prize{ID: &prize.ID}, mock.Anything).Return(nil)
+
+		err := service.DeletePrize(dto)
+		assert.Nil(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse to delete a prize linked to tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadPrize", dto, mock.Anything).Return(prize, nil)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(12, nil)
+
+		err := service.DeletePrize(dto)
+		assert.Equal(t, errors_domain_game.ErrPrizeInUse, err)
+
+		mockRepo.AssertNotCalled(t, "DeletePrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		err := service.DeletePrize(dto)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should return error when dto is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		err := service.DeletePrize(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+}
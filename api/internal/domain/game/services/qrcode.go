@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+)
+
+// RenderQRCode renders the token of a ticket as a QR code for printed tickets and prize vouchers
+// Employees may render any ticket, a client only the tickets it holds
+//
+// Parameters:
+// - dto: *transfert.QRCode The token and the rendering options
+//
+// Returns:
+// - *entities.QRCode: The QR code with its image
+// - errors.ErrorInterface: ErrQRCodeInvalidFormat, ErrQRCodeInvalidSize or ErrQRCodeInvalidLevel on a bad option, ErrQRCodeNoLink when a link is asked but project.tickets.link is not set
+func (s *GameService) RenderQRCode(dto *transfert.QRCode) (*entities.QRCode, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	code := entities.CreateQRCode(dto)
+	if !qr.IsFormat(code.Format) {
+		return nil, errors_domain_game.ErrQRCodeInvalidFormat
+	}
+
+	if code.Size < qr.MinSize || code.Size > qr.MaxSize {
+		return nil, errors_domain_game.ErrQRCodeInvalidSize
+	}
+
+	if !qr.IsLevel(code.Level) {
+		return nil, errors_domain_game.ErrQRCodeInvalidLevel
+	}
+
+	if err := entities.NewTicketSigner().Verify(token.NewLuhnP(dto.Token)); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: dto.Token})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.security.CanRead(ticket) && !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if dto.Link != nil && *dto.Link {
+		link, err := claimLink(code.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		code.Content = link
+	}
+
+	image, encodeErr := qr.Encode(code.Content, code.Format, code.Size, code.Level)
+	if encodeErr != nil {
+		return nil, errors.ErrInternalServer.Log(encodeErr)
+	}
+
+	code.Image = image
+
+	return code, nil
+}
+
+// claimLink builds the deep link to the claim screen, the token is set in the query
+// so the screen can be opened already filled in
+func claimLink(code string) (string, errors.ErrorInterface) {
+	base := config.GetString("project.tickets.link", "")
+	if base == "" {
+		return "", errors_domain_game.ErrQRCodeNoLink.Log(fmt.Errorf("project.tickets.link is not set, claim links cannot be encoded"))
+	}
+
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", errors.ErrInternalServer.Log(err)
+	}
+
+	query := link.Query()
+	query.Set("token", code)
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"bytes"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/config"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_RenderQRCode(t *testing.T) {
+	code := "79927398713"
+	ticket := &entities.Ticket{ID: "ticket-1", Token: token.NewLuhn(code), CredentialID: aws.String("client-1")}
+
+	t.Run("Should return error when DTO is nil", func(t *testing.T) {
+		service, _, _ := setup()
+
+		qrcode, err := service.RenderQRCode(nil)
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should reject invalid options", func(t *testing.T) {
+		service, _, _ := setup()
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code), Format: aws.String("gif")})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors_domain_game.ErrQRCodeInvalidFormat, err)
+
+		qrcode, err = service.RenderQRCode(&transfert.QRCode{Token: aws.String(code), Size: aws.Int(qr.MaxSize + 1)})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors_domain_game.ErrQRCodeInvalidSize, err)
+
+		qrcode, err = service.RenderQRCode(&transfert.QRCode{Token: aws.String(code), Level: aws.String("X")})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors_domain_game.ErrQRCodeInvalidLevel, err)
+	})
+
+	t.Run("Should return error when the ticket does not exist", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code)})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should refuse a ticket held by someone else", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(false)
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code)})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should render the token as a PNG by default", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(true)
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code)})
+		assert.Nil(t, err)
+		assert.Equal(t, code, qrcode.Content)
+		assert.Equal(t, qr.PNG, qrcode.Format)
+
+		img, decodeErr := png.Decode(bytes.NewReader(qrcode.Image))
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, qr.DefaultSize, img.Bounds().Dx())
+	})
+
+	t.Run("Should refuse a link when no claim screen is configured", func(t *testing.T) {
+		config.Reset()
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(false)
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code), Link: aws.Bool(true)})
+		assert.Nil(t, qrcode)
+		assert.Equal(t, errors_domain_game.ErrQRCodeNoLink, err)
+
+		// Une configuration manquante est une erreur du serveur
+		assert.Equal(t, http.StatusInternalServerError, err.Code())
+	})
+
+	t.Run("Should encode the claim link for employees", func(t *testing.T) {
+		config.Load(aws.String("../../../../config.test.yml"))
+		defer config.Reset()
+
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(false)
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		qrcode, err := service.RenderQRCode(&transfert.QRCode{Token: aws.String(code), Format: aws.String(qr.SVG), Link: aws.Bool(true)})
+		assert.Nil(t, err)
+		assert.Equal(t, "https://thetiptop.local/claim?token="+code, qrcode.Content)
+		assert.Contains(t, string(qrcode.Image), "<svg")
+	})
+}
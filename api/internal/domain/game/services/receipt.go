@@ -0,0 +1,123 @@
+package services
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+)
+
+// IssueReceipt records a qualifying purchase at a caisse and hands a ticket over for it
+// The printed ticket scanned by the employee is used when given, a random generated ticket is issued otherwise
+//
+// Parameters:
+// - dto: *transfert.Receipt The store, the caisse, the receipt number, amount and date, and the optional printed code
+//
+// Returns:
+// - *entities.Receipt: The recorded receipt, with its ticket
+// - errors.ErrorInterface: ErrReceiptNotQualifying, ErrReceiptAlreadyExists or ErrReceiptNoTicket when no ticket can be handed over
+func (s *GameService) IssueReceipt(dto *transfert.Receipt) (*entities.Receipt, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	receipt := entities.CreateReceipt(dto)
+	if !receipt.IsQualifying() {
+		return nil, errors_domain_game.ErrReceiptNotQualifying
+	}
+
+	purchasedAt, err := entities.NewPurchaseDate(dto.PurchasedAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	receipt.PurchasedAt = purchasedAt
+	receipt.CredentialID = s.security.GetCredentialID()
+
+	if _, err := s.repo.ReadReceipt(&transfert.Receipt{StoreID: dto.StoreID, Number: dto.Number}); err == nil {
+		return nil, errors_domain_game.ErrReceiptAlreadyExists
+	} else if err != errors_domain_game.ErrReceiptNotFound {
+		return nil, err
+	}
+
+	ticket, err := s.receiptTicket(dto.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt.TicketID = &ticket.ID
+
+	// Tickets already sent to the store keep their status, the receipt records the hand over
+	var history *transfert.TicketHistory
+	if ticket.Status != entities.TicketDistributed {
+		history, err = s.prepareTransition(ticket, entities.TicketDistributed, &transfert.Ticket{StoreID: dto.StoreID, CaisseID: dto.CaisseID})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CreateReceipt(receipt, ticket, history); err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return nil, errors_domain_game.ErrReceiptNoTicket
+		}
+		return nil, err
+	}
+
+	return receipt, nil
+}
+
+// receiptTicket finds the ticket to hand over with a receipt, the printed one when a code is given
+// or a random ticket of the current campaign never handed over otherwise
+func (s *GameService) receiptTicket(code *string) (*entities.Ticket, errors.ErrorInterface) {
+	if code == nil {
+		campaign, err := s.currentCampaign()
+		if err != nil {
+			return nil, err
+		}
+
+		filter := &transfert.Ticket{}
+		if campaign != nil {
+			filter.CampaignID = &campaign.ID
+		}
+
+		ticket, err := s.sampleTicket(filter,
+			database.Where("credential_id IS NULL"),
+			database.Where("status = ?", entities.TicketGenerated),
+		)
+		if err == errors_domain_game.ErrTicketNotFound {
+			return nil, errors_domain_game.ErrReceiptNoTicket
+		}
+
+		return ticket, err
+	}
+
+	if err := entities.NewTicketSigner().Verify(token.NewLuhnP(code)); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: code})
+	if err != nil {
+		return nil, err
+	}
+
+	if ticket.CredentialID != nil || (ticket.Status != "" && ticket.Status != entities.TicketGenerated && ticket.Status != entities.TicketDistributed) {
+		return nil, errors_domain_game.ErrReceiptNoTicket
+	}
+
+	if _, err := s.repo.ReadReceipt(&transfert.Receipt{}, database.Where("ticket_id = ?", ticket.ID)); err == nil {
+		return nil, errors_domain_game.ErrReceiptNoTicket
+	} else if err != errors_domain_game.ErrReceiptNotFound {
+		return nil, err
+	}
+
+	return ticket, nil
+}
@@ -0,0 +1,200 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_IssueReceipt(t *testing.T) {
+	eid := aws.String("employee-123")
+
+	newDTO := func() *transfert.Receipt {
+		return &transfert.Receipt{
+			StoreID:  aws.String("store-123"),
+			CaisseID: aws.String("caisse-123"),
+			Number:   aws.String("0042"),
+			Amount:   aws.Float64(52.3),
+		}
+	}
+
+	lookup := &transfert.Receipt{StoreID: aws.String("store-123"), Number: aws.String("0042")}
+
+	t.Run("Should issue a random generated ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		ticket := &entities.Ticket{ID: "ticket-123", Status: entities.TicketGenerated}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(3, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("CreateReceipt", mock.MatchedBy(func(r *entities.Receipt) bool {
+			return r.Number == "0042" && *r.TicketID == "ticket-123" && r.CredentialID == eid && !r.PurchasedAt.IsZero()
+		}), ticket, mock.MatchedBy(func(h *transfert.TicketHistory) bool {
+			return *h.PreviousStatus == "generated" && *h.Status == "distributed" && *h.StoreID == "store-123" && *h.CaisseID == "caisse-123"
+		}), mock.Anything).Return(nil)
+
+		receipt, err := service.IssueReceipt(newDTO())
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-123", *receipt.TicketID)
+		assert.Equal(t, entities.TicketDistributed, ticket.Status)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should hand over the printed ticket without changing its status", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		dto.Token = aws.String("79927398713")
+		ticket := &entities.Ticket{ID: "ticket-123", Status: entities.TicketDistributed}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadReceipt", &transfert.Receipt{}, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("CreateReceipt", mock.Anything, ticket, (*transfert.TicketHistory)(nil), mock.Anything).Return(nil)
+
+		receipt, err := service.IssueReceipt(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "ticket-123", *receipt.TicketID)
+
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadCampaigns", mock.Anything)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		receipt, err := service.IssueReceipt(newDTO())
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "ReadReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a purchase below the minimum", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		dto.Amount = aws.Float64(49)
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		receipt, err := service.IssueReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptNotQualifying, err)
+
+		mockRepo.AssertNotCalled(t, "ReadReceipt", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a purchase in the future", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		dto := newDTO()
+		dto.PurchasedAt = aws.String("2999-01-01")
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		receipt, err := service.IssueReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors.ErrValueIsNotDate, err)
+	})
+
+	t.Run("Should refuse a duplicate receipt number", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(&entities.Receipt{ID: "receipt-123"}, nil)
+
+		receipt, err := service.IssueReceipt(newDTO())
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptAlreadyExists, err)
+
+		mockRepo.AssertNotCalled(t, "CreateReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a printed ticket already handed over", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		dto.Token = aws.String("79927398713")
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketDistributed}, nil)
+		mockRepo.On("ReadReceipt", &transfert.Receipt{}, mock.Anything).Return(&entities.Receipt{ID: "receipt-456"}, nil)
+
+		receipt, err := service.IssueReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptNoTicket, err)
+
+		mockRepo.AssertNotCalled(t, "CreateReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a printed ticket already claimed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		dto.Token = aws.String("79927398713")
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(&entities.Ticket{
+			ID: "ticket-123", CredentialID: aws.String("client-123"), Status: entities.TicketClaimed,
+		}, nil)
+
+		receipt, err := service.IssueReceipt(dto)
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptNoTicket, err)
+	})
+
+	t.Run("Should return error when no ticket is left", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+
+		receipt, err := service.IssueReceipt(newDTO())
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptNoTicket, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should fail when the ticket was handed over in the meantime", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadReceipt", lookup, mock.Anything).Return(nil, errors_domain_game.ErrReceiptNotFound)
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(1, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123", Status: entities.TicketGenerated}, nil)
+		mockRepo.On("CreateReceipt", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketInvalidTransition)
+
+		receipt, err := service.IssueReceipt(newDTO())
+		assert.Nil(t, receipt)
+		assert.Equal(t, errors_domain_game.ErrReceiptNoTicket, err)
+	})
+}
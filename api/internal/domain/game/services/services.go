@@ -1,25 +1,88 @@
 package services
 
 import (
+	"io"
+
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 )
 
+// UserReaderInterface is the part of the user repository the game reads,
+// the clients and their credentials belong to the user domain and may be stored in another database
+type UserReaderInterface interface {
+	ReadCredential(obj *userTransfert.Credential, options ...database.Option) (*user.Credential, errors.ErrorInterface)
+	ReadClient(obj *userTransfert.Client, options ...database.Option) (*user.Client, errors.ErrorInterface)
+}
+
 type GameService struct {
 	security security.PermissionInterface
 	repo     repositories.GameRepositoryInterface
+	users    UserReaderInterface   // Clients and their credentials are not read when nil, as from the CLI
+	mail     mail.ServiceInterface // Winners are not notified when nil, as from the CLI
 }
 
-func Game(security security.PermissionInterface, repo repositories.GameRepositoryInterface) *GameService {
-	return &GameService{security, repo}
+func Game(security security.PermissionInterface, repo repositories.GameRepositoryInterface, users UserReaderInterface, mail mail.ServiceInterface) *GameService {
+	return &GameService{security, repo, users, mail}
 }
 
 type GameServiceInterface interface {
-	GetTickets() ([]*entities.Ticket, errors.ErrorInterface)
+	GetTickets(*transfert.TicketSearch) (*entities.TicketPage, errors.ErrorInterface)
 	GetRandomTicket() (*entities.Ticket, errors.ErrorInterface)
-	UpdateTicket(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	UpdateTicket(*transfert.Ticket, *transfert.ClaimAttempt) (*entities.Ticket, errors.ErrorInterface)
 	GetTicketById(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	UpdateTicketStatus(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	RedeemTicket(*transfert.Ticket) (*entities.Ticket, errors.ErrorInterface)
+	GetTicketHistory(*transfert.Ticket) ([]*entities.TicketHistory, errors.ErrorInterface)
+
+	GiftTicket(*transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface)
+	GetTicketTransfers(*transfert.TicketTransfer) (*entities.TransferPage, errors.ErrorInterface)
+	AnswerTicketTransfer(*transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface)
+
+	IssueReceipt(*transfert.Receipt) (*entities.Receipt, errors.ErrorInterface)
+
+	GetClaimReviews(*transfert.ClaimReview) ([]*entities.ClaimReview, errors.ErrorInterface)
+	ReviewClaim(*transfert.ClaimReview) (*entities.ClaimReview, errors.ErrorInterface)
+
+	GetPrizes() ([]*entities.Prize, errors.ErrorInterface)
+	GetPrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	CreatePrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	UpdatePrize(*transfert.Prize) (*entities.Prize, errors.ErrorInterface)
+	DeletePrize(*transfert.Prize) errors.ErrorInterface
+
+	GetCampaigns() ([]*entities.Campaign, errors.ErrorInterface)
+	GetCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+	CreateCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+	UpdateCampaign(*transfert.Campaign) (*entities.Campaign, errors.ErrorInterface)
+
+	PreviewDistribution(*transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface)
+	UpdateDistribution(*transfert.Campaign) (*entities.DistributionChange, errors.ErrorInterface)
+	GetDistributionChanges(*transfert.Campaign) ([]*entities.DistributionChange, errors.ErrorInterface)
+
+	ExpireTickets(*transfert.Campaign) (*entities.TicketExpiry, errors.ErrorInterface)
+	GetTicketExpiries(*transfert.Campaign) ([]*entities.TicketExpiry, errors.ErrorInterface)
+
+	RunDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+	GetDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+	VerifyDraw(*transfert.Draw) (*entities.Draw, errors.ErrorInterface)
+
+	PrepareBatch(*transfert.Batch) (*entities.Batch, errors.ErrorInterface)
+	WriteBatch(*entities.Batch, io.Writer) errors.ErrorInterface
+	GetBatches() ([]*entities.Batch, errors.ErrorInterface)
+
+	PrepareLedger() errors.ErrorInterface
+	WriteLedger(io.Writer) errors.ErrorInterface
+	VerifyLedger() (*entities.LedgerReport, errors.ErrorInterface)
+
+	RenderQRCode(*transfert.QRCode) (*entities.QRCode, errors.ErrorInterface)
+
+	GetPrizeStatistics(*transfert.Statistics) ([]*entities.PrizeStatistic, errors.ErrorInterface)
+	GetClaimStatistics(*transfert.Statistics) ([]*entities.PeriodStatistic, errors.ErrorInterface)
+	GetStoreStatistics(*transfert.Statistics) ([]*entities.StoreStatistic, errors.ErrorInterface)
 }
@@ -1,12 +1,17 @@
 package services_test
 
 import (
+	"time"
+
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -85,7 +90,498 @@ func (m *GameRepositoryMock) CountTicket(obj *transfert.Ticket, options ...datab
 	return args.Int(0), nil
 }
 
+// UpdateTicketStatus simule le changement de statut d'un ticket.
+func (m *GameRepositoryMock) UpdateTicketStatus(entity *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHistories simule la lecture de l'historique d'un ticket.
+func (m *GameRepositoryMock) ReadTicketHistories(obj *transfert.TicketHistory, options ...database.Option) ([]*entities.TicketHistory, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketHistory), nil
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *GameRepositoryMock) CreatePrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *GameRepositoryMock) ReadPrize(obj *transfert.Prize, options ...database.Option) (*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Prize), nil
+}
+
+// ReadPrizes simule la lecture du catalogue des lots.
+func (m *GameRepositoryMock) ReadPrizes(obj *transfert.Prize, options ...database.Option) ([]*entities.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *GameRepositoryMock) UpdatePrize(entity *entities.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *GameRepositoryMock) DeletePrize(obj *transfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkLegacyPrizes simule le rattachement des anciens tickets à leur lot.
+func (m *GameRepositoryMock) LinkLegacyPrizes() (int, errors.ErrorInterface) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDraw simule l'enregistrement d'un tirage.
+func (m *GameRepositoryMock) CreateDraw(entity *entities.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *GameRepositoryMock) ReadDraw(obj *transfert.Draw, options ...database.Option) (*entities.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Draw), nil
+}
+
+// ReadDrawParticipants simule la lecture des participants au tirage.
+func (m *GameRepositoryMock) ReadDrawParticipants(obj *transfert.Ticket, options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *GameRepositoryMock) CreateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *GameRepositoryMock) ReadCampaign(obj *transfert.Campaign, options ...database.Option) (*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture des campagnes.
+func (m *GameRepositoryMock) ReadCampaigns(options ...database.Option) ([]*entities.Campaign, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *GameRepositoryMock) UpdateCampaign(entity *entities.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkOrphanTickets simule le rattachement des tickets sans campagne.
+func (m *GameRepositoryMock) LinkOrphanTickets(campaign *entities.Campaign) (int, errors.ErrorInterface) {
+	args := m.Called(campaign)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateTicketTransfer simule l'enregistrement d'un transfert de ticket
+func (m *GameRepositoryMock) CreateTicketTransfer(entity *entities.TicketTransfer, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketTransfer simule la lecture d'un transfert de ticket
+func (m *GameRepositoryMock) ReadTicketTransfer(obj *transfert.TicketTransfer, options ...database.Option) (*entities.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.TicketTransfer), nil
+}
+
+// CountTicketTransfers simule le comptage des transferts de tickets
+func (m *GameRepositoryMock) CountTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// ReadTicketTransfers simule la lecture des transferts de tickets
+func (m *GameRepositoryMock) ReadTicketTransfers(obj *transfert.TicketTransfer, options ...database.Option) ([]*entities.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketTransfer), nil
+}
+
+// UpdateTicketTransfer simule la réponse à un transfert de ticket
+func (m *GameRepositoryMock) UpdateTicketTransfer(entity *entities.TicketTransfer, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTickets simule l'expiration d'un lot de tickets
+func (m *GameRepositoryMock) ExpireTickets(obj *transfert.Ticket, from []entities.TicketStatus, limit int, history *transfert.TicketHistory, options ...database.Option) (map[string]int, errors.ErrorInterface) {
+	args := m.Called(obj, from, limit, history, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(map[string]int), nil
+}
+
+// CreateTicketExpiry simule l'enregistrement d'une expiration de tickets
+func (m *GameRepositoryMock) CreateTicketExpiry(entity *entities.TicketExpiry, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketExpiries simule la lecture des expirations de tickets
+func (m *GameRepositoryMock) ReadTicketExpiries(options ...database.Option) ([]*entities.TicketExpiry, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketExpiry), nil
+}
+
+// StreamLedger simule le parcours du registre par lots, les entrées fournies en second retour éventuel sont passées à fn
+func (m *GameRepositoryMock) StreamLedger(size int, fn func([]*entities.LedgerEntry) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(size, fn, options)
+	if entries, ok := args.Get(len(args) - 1).([]*entities.LedgerEntry); len(args) > 1 && ok {
+		if err := fn(entries); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// RecordTickets simule l'inscription au registre des tickets qui en sont absents
+func (m *GameRepositoryMock) RecordTickets(limit int) (int, errors.ErrorInterface) {
+	args := m.Called(limit)
+	if args.Get(1) == nil {
+		return args.Int(0), nil
+	}
+
+	return args.Int(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// ReadHouseholdSlots simule la lecture des places prises par un foyer pendant une campagne
+func (m *GameRepositoryMock) ReadHouseholdSlots(campaignID, household string, options ...database.Option) ([]int, errors.ErrorInterface) {
+	args := m.Called(campaignID, household, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]int), nil
+}
+
+// CreateBatch simule la création d'un lot d'export
+func (m *GameRepositoryMock) CreateBatch(entity *entities.Batch, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadBatches simule la lecture des lots d'export
+func (m *GameRepositoryMock) ReadBatches(options ...database.Option) ([]*entities.Batch, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.Batch), nil
+}
+
+// StreamTickets simule le parcours des tickets par lots, les tickets fournis en second retour éventuel sont passés à fn
+func (m *GameRepositoryMock) StreamTickets(obj *transfert.Ticket, size int, fn func([]*entities.Ticket) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, size, fn, options)
+	if tickets, ok := args.Get(len(args) - 1).([]*entities.Ticket); len(args) > 1 && ok {
+		if err := fn(tickets); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// InsertTickets simule l'insertion d'un lot de tickets en ignorant les codes déjà pris
+func (m *GameRepositoryMock) InsertTickets(objs []*transfert.Ticket, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(objs, options)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CountTicketsByPrize simule le comptage des tickets par lot
+func (m *GameRepositoryMock) CountTicketsByPrize(period *entities.Period, options ...database.Option) ([]*entities.PrizeStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PrizeStatistic), nil
+}
+
+// CountClaimsByPeriod simule le comptage des réclamations par jour ou par heure
+func (m *GameRepositoryMock) CountClaimsByPeriod(period *entities.Period, interval string, options ...database.Option) ([]*entities.PeriodStatistic, errors.ErrorInterface) {
+	args := m.Called(period, interval, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.PeriodStatistic), nil
+}
+
+// CountStatusesByCaisse simule le comptage des réclamations et remises par caisse
+func (m *GameRepositoryMock) CountStatusesByCaisse(period *entities.Period, options ...database.Option) ([]*entities.StoreStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.StoreStatistic), nil
+}
+
+// CreateReceipt simule l'enregistrement d'un ticket de caisse.
+func (m *GameRepositoryMock) CreateReceipt(entity *entities.Receipt, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadReceipt simule la lecture d'un ticket de caisse.
+func (m *GameRepositoryMock) ReadReceipt(obj *transfert.Receipt, options ...database.Option) (*entities.Receipt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.Receipt), nil
+}
+
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *GameRepositoryMock) CreateClaimAttempt(obj *transfert.ClaimAttempt, failed bool, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, failed, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimSignals simule le comptage des tentatives de réclamation récentes.
+func (m *GameRepositoryMock) ReadClaimSignals(obj *transfert.ClaimAttempt, since time.Time, options ...database.Option) (*entities.ClaimSignals, errors.ErrorInterface) {
+	args := m.Called(obj, since, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimSignals), nil
+}
+
+// CreateClaimReview simule la mise en attente d'une réclamation suspecte.
+func (m *GameRepositoryMock) CreateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimReview simule la lecture d'une réclamation en attente.
+func (m *GameRepositoryMock) ReadClaimReview(obj *transfert.ClaimReview, options ...database.Option) (*entities.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*entities.ClaimReview), nil
+}
+
+// ReadClaimReviews simule la lecture de la file des réclamations en attente.
+func (m *GameRepositoryMock) ReadClaimReviews(obj *transfert.ClaimReview, options ...database.Option) ([]*entities.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.ClaimReview), nil
+}
+
+// UpdateClaimReview simule la décision sur une réclamation en attente.
+func (m *GameRepositoryMock) UpdateClaimReview(entity *entities.ClaimReview, ticket *entities.Ticket, history *transfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CountTicketPools simule le comptage des tickets par lot d'une campagne.
+func (m *GameRepositoryMock) CountTicketPools(obj *transfert.Ticket, options ...database.Option) ([]*entities.TicketPool, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.TicketPool), nil
+}
+
+// CancelTickets simule l'annulation des tickets en réserve.
+func (m *GameRepositoryMock) CancelTickets(obj *transfert.Ticket, limit int, history *transfert.TicketHistory, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, limit, history, options)
+	if args.Error(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDistributionChange simule l'enregistrement d'un changement de répartition.
+func (m *GameRepositoryMock) CreateDistributionChange(entity *entities.DistributionChange, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDistributionChanges simule la lecture des changements de répartition.
+func (m *GameRepositoryMock) ReadDistributionChanges(options ...database.Option) ([]*entities.DistributionChange, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*entities.DistributionChange), nil
+}
+
 // PermissionMock est le mock pour PermissionInterface
+type MailServiceMock struct {
+	mock.Mock
+}
+
+func (m *MailServiceMock) Send(mail *mail.Mail) error {
+	args := m.Called(mail)
+	return args.Error(0)
+}
+
+func (m *MailServiceMock) From() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *MailServiceMock) Expeditor() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 type PermissionMock struct {
 	mock.Mock
 }
@@ -134,11 +630,66 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetSessionID() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
+// UserReaderMock simule la lecture des clients du domaine utilisateur
+type UserReaderMock struct {
+	mock.Mock
+}
+
+func (m *UserReaderMock) ReadCredential(obj *userTransfert.Credential, options ...database.Option) (*user.Credential, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*user.Credential), nil
+}
+
+func (m *UserReaderMock) ReadClient(obj *userTransfert.Client, options ...database.Option) (*user.Client, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*user.Client), nil
+}
+
 func setup() (*services.GameService, *GameRepositoryMock, *PermissionMock) {
 	mockRepository := new(GameRepositoryMock)
 	mockSecurity := new(PermissionMock)
 
-	service := services.Game(mockSecurity, mockRepository)
+	service := services.Game(mockSecurity, mockRepository, nil, nil)
 
 	return service, mockRepository, mockSecurity
 }
+
+// setupUsers prépare un service qui lit les clients par le dépôt utilisateur simulé
+func setupUsers() (*services.GameService, *GameRepositoryMock, *PermissionMock, *UserReaderMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+	mockUsers := new(UserReaderMock)
+
+	service := services.Game(mockSecurity, mockRepository, mockUsers, nil)
+
+	return service, mockRepository, mockSecurity, mockUsers
+}
+
+// setupMail prépare un service qui notifie les gagnants par le mailer simulé
+func setupMail() (*services.GameService, *GameRepositoryMock, *PermissionMock, *UserReaderMock, *MailServiceMock) {
+	mockRepository := new(GameRepositoryMock)
+	mockSecurity := new(PermissionMock)
+	mockUsers := new(UserReaderMock)
+	mockMailer := new(MailServiceMock)
+
+	service := services.Game(mockSecurity, mockRepository, mockUsers, mockMailer)
+
+	return service, mockRepository, mockSecurity, mockUsers, mockMailer
+}
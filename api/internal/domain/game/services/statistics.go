@@ -0,0 +1,54 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// statisticsPeriod checks that the caller may read statistics and reads the requested period
+func (s *GameService) statisticsPeriod(dto *transfert.Statistics) (*entities.Period, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return entities.NewPeriod(dto.From, dto.To)
+}
+
+func (s *GameService) GetPrizeStatistics(dto *transfert.Statistics) ([]*entities.PrizeStatistic, errors.ErrorInterface) {
+	period, err := s.statisticsPeriod(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CountTicketsByPrize(period)
+}
+
+func (s *GameService) GetClaimStatistics(dto *transfert.Statistics) ([]*entities.PeriodStatistic, errors.ErrorInterface) {
+	period, err := s.statisticsPeriod(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := entities.NewInterval(dto.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CountClaimsByPeriod(period, interval)
+}
+
+func (s *GameService) GetStoreStatistics(dto *transfert.Statistics) ([]*entities.StoreStatistic, errors.ErrorInterface) {
+	period, err := s.statisticsPeriod(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CountStatusesByCaisse(period)
+}
@@ -0,0 +1,120 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GetPrizeStatistics(t *testing.T) {
+	t.Run("Should refuse a nil dto", func(t *testing.T) {
+		service, _, _ := setup()
+
+		statistics, err := service.GetPrizeStatistics(nil)
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		statistics, err := service.GetPrizeStatistics(&transfert.Statistics{})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CountTicketsByPrize", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an unreadable period", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		statistics, err := service.GetPrizeStatistics(&transfert.Statistics{From: aws.String("yesterday")})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrValueIsNotDate, err)
+	})
+
+	t.Run("Should count the tickets of the period", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountTicketsByPrize", mock.MatchedBy(func(p *entities.Period) bool {
+			return p.From.Equal(time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)) && p.To.Equal(time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC))
+		}), mock.Anything).Return([]*entities.PrizeStatistic{{Label: aws.String("Infuser"), Claimed: 3, Unclaimed: 7}}, nil)
+
+		statistics, err := service.GetPrizeStatistics(&transfert.Statistics{From: aws.String("2024-10-01"), To: aws.String("2024-10-31")})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, statistics[0].Claimed)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func Test_GetClaimStatistics(t *testing.T) {
+	t.Run("Should refuse an unknown interval", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		statistics, err := service.GetClaimStatistics(&transfert.Statistics{Interval: aws.String("week")})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors_domain_game.ErrStatisticsInvalidInterval, err)
+	})
+
+	t.Run("Should count the claims by day by default", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountClaimsByPeriod", &entities.Period{}, database.Day, mock.Anything).Return([]*entities.PeriodStatistic{{Period: "2024-10-01", Count: 4}}, nil)
+
+		statistics, err := service.GetClaimStatistics(&transfert.Statistics{})
+		assert.Nil(t, err)
+		assert.Equal(t, 4, statistics[0].Count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should count the claims by hour", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountClaimsByPeriod", mock.Anything, database.Hour, mock.Anything).Return([]*entities.PeriodStatistic{}, nil)
+
+		statistics, err := service.GetClaimStatistics(&transfert.Statistics{Interval: aws.String("hour")})
+		assert.Nil(t, err)
+		assert.Empty(t, statistics)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func Test_GetStoreStatistics(t *testing.T) {
+	t.Run("Should refuse non-employees", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+
+		statistics, err := service.GetStoreStatistics(&transfert.Statistics{})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("Should count the claims and redemptions by caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountStatusesByCaisse", mock.Anything, mock.Anything).Return([]*entities.StoreStatistic{{StoreID: aws.String("store-1"), Redeemed: 2}}, nil)
+
+		statistics, err := service.GetStoreStatistics(&transfert.Statistics{})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, statistics[0].Redeemed)
+		mockRepo.AssertExpectations(t)
+	})
+}
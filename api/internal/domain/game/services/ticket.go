@@ -1,19 +1,49 @@
 package services
 
 import (
+	"math/rand/v2"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+)
+
+const (
+	TicketPageSize = 20  // Tickets per page when the search gives no limit
+	TicketPageMax  = 100 // Largest page a caller may ask for
 )
 
+// ticketSorts maps the accepted sort values to their ORDER BY clause
+// Unclaimed tickets always come last and the ID keeps the pages stable
+var ticketSorts = map[string]string{
+	"claimed_at":  "claimed_at IS NULL, claimed_at ASC, id ASC",
+	"-claimed_at": "claimed_at IS NULL, claimed_at DESC, id ASC",
+}
+
 func (s *GameService) GetRandomTicket() (*entities.Ticket, errors.ErrorInterface) {
 	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
 		return nil, errors.ErrUnauthorized
 	}
 
-	ticket, err := s.repo.ReadTicket(&transfert.Ticket{}, database.Where("credential_id IS NULL"), database.Order("RANDOM()"))
+	campaign, err := s.currentCampaign()
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &transfert.Ticket{}
+	if campaign != nil {
+		filter.CampaignID = &campaign.ID
+	}
+
+	ticket, err := s.sampleTicket(filter,
+		database.Where("credential_id IS NULL"),
+		database.Where("status IN ?", []entities.TicketStatus{entities.TicketGenerated, entities.TicketDistributed}),
+	)
 	if err != nil {
 		return nil, errors.ErrNoData
 	}
@@ -21,35 +51,181 @@ func (s *GameService) GetRandomTicket() (*entities.Ticket, errors.ErrorInterface
 	return ticket, nil
 }
 
-func (s *GameService) GetTickets() ([]*entities.Ticket, errors.ErrorInterface) {
-	tickets, err := s.repo.ReadTickets(&transfert.Ticket{
-		CredentialID: s.security.GetCredentialID(),
-	})
-
+// sampleTicket reads a ticket drawn uniformly among those matching the filter and the options
+// The matching tickets are counted, then the one at a random rank in ID order is read,
+// falling back to the first one when tickets left the selection between both queries
+func (s *GameService) sampleTicket(filter *transfert.Ticket, options ...database.Option) (*entities.Ticket, errors.ErrorInterface) {
+	count, err := s.repo.CountTicket(filter, options...)
 	if err != nil {
-		return nil, errors.ErrNoData
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, errors_domain_game.ErrTicketNotFound
 	}
 
-	return tickets, nil
+	ticket, err := s.repo.ReadTicket(filter, append(options, database.Sample("id", rand.IntN(count)))...)
+	if err == errors_domain_game.ErrTicketNotFound {
+		ticket, err = s.repo.ReadTicket(filter, append(options, database.Sample("id", 0))...)
+	}
+
+	return ticket, err
 }
 
-func (s *GameService) UpdateTicket(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
-	ticket, err := s.repo.ReadTicket(dto, database.Where("credential_id IS NULL"))
+// GetTickets lists one page of tickets matching the search, with the total count
+// Clients only see their own tickets, employees search across all tickets
+func (s *GameService) GetTickets(dto *transfert.TicketSearch) (*entities.TicketPage, errors.ErrorInterface) {
+	if dto == nil {
+		dto = &transfert.TicketSearch{}
+	}
+
+	filter := &transfert.Ticket{
+		PrizeID:    dto.PrizeID,
+		CampaignID: dto.CampaignID,
+		Token:      dto.Token,
+	}
+
+	if s.security.IsGrantedByRoles(security.ROLE_ADMIN, user.ROLE_EMPLOYEE) {
+		filter.CredentialID = dto.CredentialID
+	} else if filter.CredentialID = s.security.GetCredentialID(); filter.CredentialID == nil {
+		return nil, errors.ErrUnauthorized
+	}
+
+	// Expired tickets are only listed on demand
+	options := []database.Option{database.Where("status <> ?", entities.TicketExpired)}
+	if dto.Status != nil {
+		if _, ok := entities.NewTicketStatus(dto.Status); !ok {
+			return nil, errors_domain_game.ErrTicketInvalidStatus
+		}
+
+		filter.Status = dto.Status
+		options = nil
+	}
+
+	sort := "-claimed_at"
+	if dto.Sort != nil {
+		sort = *dto.Sort
+	}
+
+	order, ok := ticketSorts[sort]
+	if !ok {
+		return nil, errors_domain_game.ErrTicketInvalidSort
+	}
+
+	page, limit := 1, TicketPageSize
+	if dto.Page != nil {
+		page = *dto.Page
+	}
+
+	if dto.Limit != nil {
+		limit = *dto.Limit
+	}
+
+	if page < 1 || limit < 1 || limit > TicketPageMax {
+		return nil, errors_domain_game.ErrTicketInvalidPage
+	}
+
+	total, err := s.repo.CountTicket(filter, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	if offset >= total {
+		return entities.NewTicketPage(nil, total, page, limit), nil
+	}
 
+	tickets, err := s.repo.ReadTickets(filter, append(options, database.Order(order), database.Limit(limit), database.Offset(offset))...)
 	if err != nil {
 		return nil, err
 	}
 
+	return entities.NewTicketPage(tickets, total, page, limit), nil
+}
+
+// UpdateTicket claims a ticket for the authenticated client
+// Every code entered is recorded with the origin of the claim, refused codes at once and claims once their
+// transition committed; a claim whose fraud score reaches the threshold is held for review instead of being accepted
+// An accepted claim is confirmed to the client by mail, followed by the mail telling the prize is ready
+//
+// Parameters:
+// - dto: *transfert.Ticket The printed code of the ticket, with the receipt photo
+// - claim: *transfert.ClaimAttempt The IP, device and registration date of the client, nil when unknown
+//
+// Returns:
+// - *entities.Ticket: The claimed ticket, in review status when the claim is held
+// - errors.ErrorInterface: The error interface if the ticket cannot be claimed
+func (s *GameService) UpdateTicket(dto *transfert.Ticket, claim *transfert.ClaimAttempt) (*entities.Ticket, errors.ErrorInterface) {
 	if !s.security.IsAuthenticated() {
 		return nil, errors.ErrUnauthorized
 	}
 
-	ticket.CredentialID = s.security.GetCredentialID()
+	attempt := &transfert.ClaimAttempt{CredentialID: s.security.GetCredentialID()}
+	if claim != nil {
+		attempt.IP, attempt.Device, attempt.RegisteredAt = claim.IP, claim.Device, claim.RegisteredAt
+	}
+
+	// Missing and forged codes are rejected offline, before reaching the tickets
+	if dto.Token == nil || *dto.Token == "" {
+		return nil, s.failClaim(attempt, errors.ErrValueRequired)
+	}
+
+	if err := entities.NewTicketSigner().Verify(token.NewLuhnP(dto.Token)); err != nil {
+		return nil, s.failClaim(attempt, err)
+	}
+
+	// Only the printed code designates the ticket, whatever else the client sends
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: dto.Token}, database.Where("credential_id IS NULL"))
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketNotFound {
+			return nil, s.failClaim(attempt, err)
+		}
+		return nil, err
+	}
+
+	attempt.TicketID = &ticket.ID
 
-	if err := s.repo.UpdateTicket(ticket); err != nil {
+	// An excluded client is not suspicious, the refusal is not counted as a failed attempt
+	quota, err := s.checkEligibility(ticket, attempt.CredentialID)
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.scoreClaim(attempt)
+	if err != nil {
 		return nil, err
 	}
 
+	ticket.CredentialID = attempt.CredentialID
+	ticket.ReceiptPhoto = dto.ReceiptPhoto
+
+	if review != nil {
+		held, err := s.holdClaim(ticket, review, quota)
+		if err != nil {
+			return nil, err
+		}
+
+		s.recordClaim(attempt)
+
+		return held, nil
+	}
+
+	history, err := s.prepareTransition(ticket, entities.TicketClaimed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reserveHousehold(ticket, quota, func() errors.ErrorInterface {
+		return s.repo.UpdateTicketStatus(ticket, history)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.recordClaim(attempt)
+
+	go s.notifyWinner(ticket, ClaimTemplate, PrizeTemplate)
+
 	return ticket, nil
 }
 
@@ -66,3 +242,103 @@ func (s *GameService) GetTicketById(dto *transfert.Ticket) (*entities.Ticket, er
 
 	return ticket, nil
 }
+
+// UpdateTicketStatus lets an employee distribute or cancel a ticket
+// Review, claimed and redeemed are refused, they are only reached through their own flows
+//
+// Parameters:
+// - dto: *transfert.Ticket The ID of the ticket, the requested status, the store and the caisse
+//
+// Returns:
+// - *entities.Ticket: The updated ticket
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the status cannot be set directly or the move is not allowed
+func (s *GameService) UpdateTicketStatus(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	status, ok := entities.NewTicketStatus(dto.Status)
+	if !ok {
+		return nil, errors_domain_game.ErrTicketInvalidStatus
+	}
+
+	if !manualStatuses[status] {
+		return nil, errors_domain_game.ErrTicketInvalidTransition
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.transition(ticket, status, dto); err != nil {
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+// RedeemTicket hands over the prize of a ticket at a caisse
+// The ticket is found by its printed code and must be claimed by the presenting client,
+// the redemption is recorded with the store, the caisse and the employee
+//
+// Parameters:
+// - dto: *transfert.Ticket The printed token, the client credential, the store and the caisse
+//
+// Returns:
+// - *entities.Ticket: The redeemed ticket
+// - errors.ErrorInterface: ErrTicketWrongOwner, ErrTicketNotClaimed or ErrTicketAlreadyRedeemed when the prize cannot be handed over
+func (s *GameService) RedeemTicket(dto *transfert.Ticket) (*entities.Ticket, errors.ErrorInterface) {
+	if !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if err := entities.NewTicketSigner().Verify(token.NewLuhnP(dto.Token)); err != nil {
+		return nil, err
+	}
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{Token: dto.Token})
+	if err != nil {
+		return nil, err
+	}
+
+	switch ticket.Status {
+	case entities.TicketRedeemed:
+		return nil, errors_domain_game.ErrTicketAlreadyRedeemed
+	case entities.TicketClaimed:
+	default:
+		return nil, errors_domain_game.ErrTicketNotClaimed
+	}
+
+	if ticket.CredentialID == nil || dto.CredentialID == nil || *ticket.CredentialID != *dto.CredentialID {
+		return nil, errors_domain_game.ErrTicketWrongOwner
+	}
+
+	// A concurrent redemption makes the guarded update fail, only one caisse hands the prize over
+	if err := s.transition(ticket, entities.TicketRedeemed, dto); err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return nil, errors_domain_game.ErrTicketAlreadyRedeemed
+		}
+		return nil, err
+	}
+
+	return ticket, nil
+}
+
+func (s *GameService) GetTicketHistory(dto *transfert.Ticket) ([]*entities.TicketHistory, errors.ErrorInterface) {
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.security.CanRead(ticket) && !s.security.IsGrantedByRoles(user.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	histories, err := s.repo.ReadTicketHistories(&transfert.TicketHistory{TicketID: &ticket.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
@@ -2,13 +2,17 @@ package services_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
 	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -17,6 +21,8 @@ func Test_GetRandomTicket(t *testing.T) {
 	t.Run("Should return ticket", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(5, nil)
 		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{}, nil)
 		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
 
@@ -27,6 +33,65 @@ func Test_GetRandomTicket(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Should pick the ticket in the current campaign", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		campaignID := "campaign-1"
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{{ID: campaignID}}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{CampaignID: &campaignID}, mock.Anything).Return(5, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{CampaignID: &campaignID}, mock.Anything).Return(&entities.Ticket{CampaignID: &campaignID}, nil)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		ticket, err := service.GetRandomTicket()
+		assert.Nil(t, err)
+		assert.Equal(t, campaignID, *ticket.CampaignID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should fall back to the first ticket when the selection shrank", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(5, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound).Once()
+		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(&entities.Ticket{ID: "first"}, nil).Once()
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		ticket, err := service.GetRandomTicket()
+		assert.Nil(t, err)
+		assert.Equal(t, "first", ticket.ID)
+
+		mockRepo.AssertNumberOfCalls(t, "ReadTicket", 2)
+	})
+
+	t.Run("Should return no data when no ticket is left", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(0, nil)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		ticket, err := service.GetRandomTicket()
+		assert.Equal(t, errors.ErrNoData, err)
+		assert.Nil(t, ticket)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when campaigns cannot be read", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadCampaigns", mock.Anything).Return(nil, errors.ErrInternalServer)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		ticket, err := service.GetRandomTicket()
+		assert.Equal(t, errors.ErrInternalServer, err)
+		assert.Nil(t, ticket)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Should return error when unauthorized", func(t *testing.T) {
 		service, _, mockPerms := setup()
 
@@ -42,6 +107,8 @@ func Test_GetRandomTicket(t *testing.T) {
 	t.Run("Should return error when repository return error", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
+		mockRepo.On("ReadCampaigns", mock.Anything).Return([]*entities.Campaign{}, nil)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(5, nil)
 		mockRepo.On("ReadTicket", &transfert.Ticket{}, mock.Anything).Return(nil, errors.ErrNoData)
 		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
 
@@ -54,44 +121,147 @@ func Test_GetRandomTicket(t *testing.T) {
 }
 
 func Test_GetTickets(t *testing.T) {
-	t.Run("Should return tickets", func(t *testing.T) {
+	credentialID := "valid-credential-id"
+
+	t.Run("Should return the first page of the client tickets", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		credentialID := "valid-credential-id"
+		filter := &transfert.Ticket{CredentialID: &credentialID}
 
 		// Configuration des mocks
-		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return([]*entities.Ticket{{ID: "123"}}, nil)
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
 		mockPerms.On("GetCredentialID").Return(&credentialID)
+		mockRepo.On("CountTicket", filter, mock.Anything).Return(1, nil)
+		mockRepo.On("ReadTickets", filter, mock.Anything).Return([]*entities.Ticket{{ID: "123"}}, nil)
 
 		// Appeler la méthode testée
-		tickets, err := service.GetTickets()
+		page, err := service.GetTickets(nil)
 
 		// Assertions
-		assert.Nil(t, err)        // Vérifie qu'il n'y a pas d'erreur
-		assert.NotNil(t, tickets) // Vérifie que des tickets sont retournés
-		assert.Len(t, tickets, 1) // Vérifie le nombre de tickets
+		assert.Nil(t, err)
+		assert.Len(t, page.Tickets, 1)
+		assert.Equal(t, 1, page.Total)
+		assert.Equal(t, 1, page.Page)
+		assert.Equal(t, services.TicketPageSize, page.Limit)
+		assert.Equal(t, 1, page.Pages)
 
 		// Vérifications des attentes
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when repository return error", func(t *testing.T) {
+	t.Run("Should restrict clients to their own tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		// Un client ne peut pas lire les tickets d'un autre
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+		mockPerms.On("GetCredentialID").Return(&credentialID)
+		mockRepo.On("CountTicket", &transfert.Ticket{CredentialID: &credentialID}, mock.Anything).Return(0, nil)
+
+		page, err := service.GetTickets(&transfert.TicketSearch{CredentialID: aws.String("someone-else")})
+
+		assert.Nil(t, err)
+		assert.Empty(t, page.Tickets)
+		mockRepo.AssertNotCalled(t, "ReadTickets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an anonymous caller", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
+		mockPerms.On("GetCredentialID").Return(nil)
+
+		page, err := service.GetTickets(&transfert.TicketSearch{})
+
+		assert.Nil(t, page)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CountTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should let employees search across all tickets", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.TicketSearch{
+			CredentialID: aws.String("client-1"),
+			PrizeID:      aws.String("prize-1"),
+			Status:       aws.String("claimed"),
+			Sort:         aws.String("claimed_at"),
+			Page:         aws.Int(3),
+			Limit:        aws.Int(10),
+		}
+
+		filter := &transfert.Ticket{CredentialID: dto.CredentialID, PrizeID: dto.PrizeID, Status: dto.Status}
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountTicket", filter, mock.Anything).Return(25, nil)
+		mockRepo.On("ReadTickets", filter, mock.MatchedBy(func(options []database.Option) bool {
+			return len(options) == 3
+		})).Return([]*entities.Ticket{{ID: "ticket-21"}, {ID: "ticket-22"}}, nil)
+
+		page, err := service.GetTickets(dto)
+
+		assert.Nil(t, err)
+		assert.Len(t, page.Tickets, 2)
+		assert.Equal(t, 25, page.Total)
+		assert.Equal(t, 3, page.Page)
+		assert.Equal(t, 3, page.Pages)
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertNotCalled(t, "GetCredentialID")
+	})
+
+	t.Run("Should not read past the last page", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+		mockRepo.On("CountTicket", &transfert.Ticket{}, mock.Anything).Return(20, nil)
+
+		page, err := service.GetTickets(&transfert.TicketSearch{Page: aws.Int(2)})
+
+		assert.Nil(t, err)
+		assert.Empty(t, page.Tickets)
+		assert.Equal(t, 20, page.Total)
+		assert.Equal(t, 1, page.Pages)
+		mockRepo.AssertNotCalled(t, "ReadTickets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should reject invalid searches", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		credentialID := "valid-credential-id"
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(true)
+
+		page, err := service.GetTickets(&transfert.TicketSearch{Status: aws.String("lost")})
+		assert.Nil(t, page)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidStatus, err)
+
+		page, err = service.GetTickets(&transfert.TicketSearch{Sort: aws.String("token")})
+		assert.Nil(t, page)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidSort, err)
+
+		page, err = service.GetTickets(&transfert.TicketSearch{Page: aws.Int(0)})
+		assert.Nil(t, page)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidPage, err)
+
+		page, err = service.GetTickets(&transfert.TicketSearch{Limit: aws.Int(services.TicketPageMax + 1)})
+		assert.Nil(t, page)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidPage, err)
+
+		mockRepo.AssertNotCalled(t, "CountTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when repository return error", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
 
 		// Configuration des mocks
+		mockPerms.On("IsGrantedByRoles", drawRoles).Return(false)
 		mockPerms.On("GetCredentialID").Return(&credentialID)
-		mockRepo.On("ReadTickets", mock.Anything, mock.Anything).Return(nil, errors.ErrNoData)
+		mockRepo.On("CountTicket", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
 
 		// Appeler la méthode testée
-		tickets, err := service.GetTickets()
+		page, err := service.GetTickets(&transfert.TicketSearch{})
 
 		// Assertions
-		assert.NotNil(t, err)
-		assert.Nil(t, tickets)
-		assert.Equal(t, errors.ErrNoData, err)
+		assert.Nil(t, page)
+		assert.Equal(t, errors.ErrInternalServer, err)
 
 		// Vérifications des attentes
 		mockRepo.AssertExpectations(t)
@@ -101,44 +271,197 @@ func Test_GetTickets(t *testing.T) {
 
 func Test_UpdateTicket(t *testing.T) {
 	cid := aws.String("client-123")
+
+	t.Run("Should reject a forged token before reading the database", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("CreateClaimAttempt", &transfert.ClaimAttempt{CredentialID: cid}, true, mock.Anything).Return(nil)
+
+		ticket, err := service.UpdateTicket(&transfert.Ticket{Token: aws.String("79927398710")}, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrValueIsNotLuhn, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should reject a claim without token before reading the database", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("CreateClaimAttempt", &transfert.ClaimAttempt{CredentialID: cid}, true, mock.Anything).Return(nil)
+
+		ticket, err := service.UpdateTicket(&transfert.Ticket{ID: aws.String("ticket-123")}, nil)
+		assert.Nil(t, ticket)
+		assert.Equal(t, errors.ErrValueRequired, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Should return updated ticket", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
 
 		ticket := &entities.Ticket{
 			ID:           "ticket-123",
 			CredentialID: cid,
 		}
 
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
 		mockPerms.On("IsAuthenticated").Return(true)
 		mockPerms.On("GetCredentialID").Return(cid)
-		mockRepo.On("UpdateTicket", ticket, mock.Anything).Return(nil)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
 
-		updatedTicket, err := service.UpdateTicket(dto)
+		updatedTicket, err := service.UpdateTicket(dto, nil)
 		assert.Nil(t, err)
 		assert.NotNil(t, updatedTicket)
 
 		assert.Equal(t, ticket, updatedTicket)
+		assert.Equal(t, entities.TicketClaimed, updatedTicket.Status)
+		assert.NotNil(t, updatedTicket.ClaimedAt)
 
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when ticket not found", func(t *testing.T) {
+	t.Run("Should keep the receipt photo attached when claiming", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
 		dto := &transfert.Ticket{
+			ID:           aws.String("another-ticket"),
+			Token:        aws.String("79927398713"),
+			ReceiptPhoto: aws.String("receipts/0042.jpg"),
+		}
+
+		ticket := &entities.Ticket{ID: "ticket-123"}
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+		updatedTicket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, dto.ReceiptPhoto, updatedTicket.ReceiptPhoto)
+	})
+
+	t.Run("Should record the origin of the claim", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+		ticket := &entities.Ticket{ID: "ticket-123"}
+		claim := &transfert.ClaimAttempt{
+			IP:     aws.String("203.0.113.7"),
+			Device: aws.String("device-42"),
+		}
+
+		expected := &transfert.ClaimAttempt{
 			CredentialID: cid,
+			TicketID:     &ticket.ID,
+			IP:           claim.IP,
+			Device:       claim.Device,
 		}
 
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(nil, errors.ErrNoData)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimSignals", expected, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("CreateClaimAttempt", expected, false, mock.Anything).Return(nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := service.UpdateTicket(dto, claim)
+		assert.Nil(t, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should hold a suspicious claim for review", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+		ticket := &entities.Ticket{ID: "ticket-123", Status: entities.TicketDistributed}
+		registeredAt := time.Now().Add(-time.Minute)
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{CredentialClaims: 7}, nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(nil)
+		mockRepo.On("CreateClaimReview", mock.MatchedBy(func(review *entities.ClaimReview) bool {
+			return review.TicketID == "ticket-123" &&
+				review.CredentialID == *cid &&
+				review.PreviousStatus == entities.TicketDistributed &&
+				review.Score == 3
+		}), ticket, mock.MatchedBy(func(history *transfert.TicketHistory) bool {
+			return *history.PreviousStatus == "distributed" && *history.Status == "review"
+		}), mock.Anything).Return(nil)
+
+		updatedTicket, err := service.UpdateTicket(dto, &transfert.ClaimAttempt{RegisteredAt: &registeredAt})
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketReview, updatedTicket.Status)
+		assert.Equal(t, cid, updatedTicket.CredentialID)
+		assert.Nil(t, updatedTicket.ClaimedAt)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should record a code matching no ticket as failed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("CreateClaimAttempt", &transfert.ClaimAttempt{CredentialID: cid}, true, mock.Anything).Return(nil)
+
+		updatedTicket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, updatedTicket)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should keep the claim when the attempt cannot be recorded", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(&entities.Ticket{ID: "ticket-123"}, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("CreateClaimAttempt", mock.Anything, false, mock.Anything).Return(errors.ErrInternalServer)
+
+		// La réclamation est validée avant l'enregistrement de la tentative
+		updatedTicket, err := service.UpdateTicket(dto, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketClaimed, updatedTicket.Status)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(nil, errors.ErrNoData)
 		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
 
-		updatedTicket, err := service.UpdateTicket(dto)
+		updatedTicket, err := service.UpdateTicket(dto, nil)
 		assert.NotNil(t, err)
 		assert.Nil(t, updatedTicket)
 		assert.Equal(t, errors.ErrNoData, err)
@@ -149,53 +472,71 @@ func Test_UpdateTicket(t *testing.T) {
 	t.Run("Should return error when unauthorized", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
-		}
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
 
-		ticket := &entities.Ticket{
-			ID:           "ticket-123",
-			CredentialID: cid,
-		}
-
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
 		mockPerms.On("IsAuthenticated").Return(false)
 
-		updatedTicket, err := service.UpdateTicket(dto)
+		updatedTicket, err := service.UpdateTicket(dto, nil)
 		assert.NotNil(t, err)
 		assert.Nil(t, updatedTicket)
 		assert.Equal(t, errors.ErrUnauthorized, err)
 
-		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
 		mockPerms.AssertExpectations(t)
 	})
 
-	t.Run("Should return error when update fails", func(t *testing.T) {
+	t.Run("Should return error when ticket is already redeemed", func(t *testing.T) {
 		service, mockRepo, mockPerms := setup()
 
-		dto := &transfert.Ticket{
-			CredentialID: cid,
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
+		ticket := &entities.Ticket{
+			ID:     "ticket-123",
+			Status: entities.TicketRedeemed,
 		}
 
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+
+		updatedTicket, err := service.UpdateTicket(dto, nil)
+		assert.NotNil(t, err)
+		assert.Nil(t, updatedTicket)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateClaimAttempt", mock.Anything, false, mock.Anything)
+	})
+
+	t.Run("Should return error when update fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{Token: aws.String("79927398713")}
+
 		ticket := &entities.Ticket{
 			ID:           "ticket-123",
 			CredentialID: cid,
 		}
 
 		// Configuration des mocks
-		mockRepo.On("ReadTicket", dto, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
 		mockPerms.On("IsAuthenticated").Return(true)
 		mockPerms.On("GetCredentialID").Return(cid)
-		mockRepo.On("UpdateTicket", ticket, mock.Anything).Return(errors.ErrNoData)
+		mockRepo.On("ReadClaimSignals", mock.Anything, mock.Anything, mock.Anything).Return(&entities.ClaimSignals{}, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.Anything, mock.Anything).Return(errors.ErrNoData)
 
 		// Appel de la méthode à tester
-		updatedTicket, err := service.UpdateTicket(dto)
+		updatedTicket, err := service.UpdateTicket(dto, nil)
 
 		// Vérification des résultats
 		assert.NotNil(t, err)
 		assert.Nil(t, updatedTicket)
 		assert.Equal(t, errors.ErrNoData, err)
 
+		// Une réclamation refusée par la transition n'est pas comptée comme réussie
+		mockRepo.AssertNotCalled(t, "CreateClaimAttempt", mock.Anything, false, mock.Anything)
+
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
@@ -298,3 +639,302 @@ func Test_GetTicketById(t *testing.T) {
 	})
 
 }
+
+func Test_UpdateTicketStatus(t *testing.T) {
+	eid := aws.String("employee-123")
+
+	t.Run("Should move ticket to the requested status", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{
+			ID:       aws.String("ticket-123"),
+			Status:   aws.String("cancelled"),
+			StoreID:  aws.String("store-123"),
+			CaisseID: aws.String("caisse-123"),
+		}
+
+		ticket := &entities.Ticket{
+			ID:     "ticket-123",
+			Status: entities.TicketClaimed,
+		}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.MatchedBy(func(h *transfert.TicketHistory) bool {
+			return *h.PreviousStatus == "claimed" && *h.Status == "cancelled" && h.CredentialID == eid &&
+				h.StoreID == dto.StoreID && h.CaisseID == dto.CaisseID
+		}), mock.Anything).Return(nil)
+
+		result, err := service.UpdateTicketStatus(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketCancelled, result.Status)
+
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		result, err := service.UpdateTicketStatus(&transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String("redeemed")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when status is unknown", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		result, err := service.UpdateTicketStatus(&transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String("lost")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidStatus, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String("cancelled")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		result, err := service.UpdateTicketStatus(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should return error when transition is not allowed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := &transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String("distributed")}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(&entities.Ticket{
+			ID:     "ticket-123",
+			Status: entities.TicketRedeemed,
+		}, nil)
+
+		result, err := service.UpdateTicketStatus(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse statuses reached through their own flows", func(t *testing.T) {
+		for _, status := range []string{"review", "claimed", "redeemed"} {
+			service, mockRepo, mockPerms := setup()
+
+			mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+			result, err := service.UpdateTicketStatus(&transfert.Ticket{ID: aws.String("ticket-123"), Status: aws.String(status)})
+			assert.Nil(t, result)
+			assert.Equal(t, errors_domain_game.ErrTicketInvalidTransition, err, status)
+
+			// Ni lecture ni écriture : la réclamation, la revue et le retrait ont leurs propres contrôles
+			mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+			mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func Test_RedeemTicket(t *testing.T) {
+	eid := aws.String("employee-123")
+	cid := aws.String("client-123")
+
+	newDTO := func() *transfert.Ticket {
+		return &transfert.Ticket{
+			Token:        aws.String("79927398713"),
+			CredentialID: cid,
+			StoreID:      aws.String("store-123"),
+			CaisseID:     aws.String("caisse-123"),
+		}
+	}
+
+	t.Run("Should redeem the ticket and record the caisse", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		ticket := &entities.Ticket{ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed}
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadTicket", &transfert.Ticket{Token: dto.Token}, mock.Anything).Return(ticket, nil)
+		mockRepo.On("UpdateTicketStatus", ticket, mock.MatchedBy(func(h *transfert.TicketHistory) bool {
+			return *h.PreviousStatus == "claimed" && *h.Status == "redeemed" && h.CredentialID == eid &&
+				h.StoreID == dto.StoreID && h.CaisseID == dto.CaisseID
+		}), mock.Anything).Return(nil)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TicketRedeemed, result.Status)
+		assert.NotNil(t, result.RedeemedAt)
+
+		mockRepo.AssertExpectations(t)
+		mockPerms.AssertExpectations(t)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should reject a forged code before reading", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		dto := newDTO()
+		dto.Token = aws.String("79927398710")
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+
+		result, err := service.RedeemTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrValueIsNotLuhn, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicket", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when ticket not found", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(nil, errors_domain_game.ErrTicketNotFound)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotFound, err)
+	})
+
+	t.Run("Should refuse a ticket already redeemed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{
+			ID: "ticket-123", CredentialID: cid, Status: entities.TicketRedeemed,
+		}, nil)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a ticket not claimed", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{
+			ID: "ticket-123", Status: entities.TicketDistributed,
+		}, nil)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, err)
+	})
+
+	t.Run("Should refuse a ticket claimed by another client", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{
+			ID: "ticket-123", CredentialID: aws.String("client-456"), Status: entities.TicketClaimed,
+		}, nil)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketWrongOwner, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should fail when another caisse redeemed it first", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockPerms.On("GetCredentialID").Return(eid)
+		mockRepo.On("ReadTicket", mock.Anything, mock.Anything).Return(&entities.Ticket{
+			ID: "ticket-123", CredentialID: cid, Status: entities.TicketClaimed,
+		}, nil)
+		mockRepo.On("UpdateTicketStatus", mock.Anything, mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketInvalidTransition)
+
+		result, err := service.RedeemTicket(newDTO())
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+	})
+}
+
+func Test_GetTicketHistory(t *testing.T) {
+	dto := &transfert.Ticket{ID: aws.String("ticket-123")}
+	ticket := &entities.Ticket{ID: "ticket-123", CredentialID: aws.String("client-123")}
+
+	t.Run("Should return history to the ticket owner", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		histories := []*entities.TicketHistory{{TicketID: &ticket.ID, Status: entities.TicketClaimed}}
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(true)
+		mockRepo.On("ReadTicketHistories", &transfert.TicketHistory{TicketID: &ticket.ID}, mock.Anything).Return(histories, nil)
+
+		result, err := service.GetTicketHistory(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, histories, result)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return history to an employee", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(true)
+		mockRepo.On("ReadTicketHistories", mock.Anything, mock.Anything).Return([]*entities.TicketHistory{}, nil)
+
+		result, err := service.GetTicketHistory(dto)
+		assert.Nil(t, err)
+		assert.NotNil(t, result)
+	})
+
+	t.Run("Should return error when unauthorized", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(false)
+		mockPerms.On("IsGrantedByRoles", []security.Role{user.ROLE_EMPLOYEE}).Return(false)
+
+		result, err := service.GetTicketHistory(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicketHistories", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return error when repository fails", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockRepo.On("ReadTicket", &transfert.Ticket{ID: dto.ID}, mock.Anything).Return(ticket, nil)
+		mockPerms.On("CanRead", ticket).Return(true)
+		mockRepo.On("ReadTicketHistories", mock.Anything, mock.Anything).Return(nil, errors.ErrInternalServer)
+
+		result, err := service.GetTicketHistory(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
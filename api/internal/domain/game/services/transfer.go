@@ -0,0 +1,271 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// GiftTicket offers a ticket of the authenticated client to another client, found by email
+// The ticket must be claimed and its prize still collectable, it changes hands once the recipient accepts
+//
+// Parameters:
+// - dto: *transfert.TicketTransfer The ticket ID and the email of the recipient
+//
+// Returns:
+// - *entities.TicketTransfer: The pending transfer
+// - errors.ErrorInterface: ErrTransferToSelf, ErrTransferPending, or a ticket or campaign error when the ticket cannot be given
+func (s *GameService) GiftTicket(dto *transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface) {
+	if !s.security.IsAuthenticated() {
+		return nil, errors.ErrUnauthorized
+	}
+
+	sender := s.security.GetCredentialID()
+
+	ticket, err := s.repo.ReadTicket(&transfert.Ticket{ID: dto.TicketID})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkGift(ticket, sender); err != nil {
+		return nil, err
+	}
+
+	recipient, err := s.readRecipient(*dto.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	if recipient == *sender {
+		return nil, errors_domain_game.ErrTransferToSelf
+	}
+
+	pending := entities.TransferPending.String()
+	transfers, err := s.repo.ReadTicketTransfers(&transfert.TicketTransfer{TicketID: &ticket.ID, Status: &pending}, database.Limit(1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(transfers) > 0 {
+		return nil, errors_domain_game.ErrTransferPending
+	}
+
+	transfer := &entities.TicketTransfer{
+		TicketID:    ticket.ID,
+		SenderID:    *sender,
+		RecipientID: recipient,
+	}
+
+	if err := s.repo.CreateTicketTransfer(transfer); err != nil {
+		return nil, err
+	}
+
+	transfer.Ticket = ticket
+
+	return transfer, nil
+}
+
+// readRecipient finds the credential of the client registered with an email address, through the user domain
+// Employees are not clients and cannot be found
+//
+// Parameters:
+// - email: string The email address of the client
+//
+// Returns:
+// - string: The ID of the credential
+// - errors.ErrorInterface: ErrCredentialNotFound if no client uses the address
+func (s *GameService) readRecipient(email string) (string, errors.ErrorInterface) {
+	if s.users == nil {
+		return "", errors.ErrInternalServer.Log(fmt.Errorf("no user repository, the credentials cannot be read"))
+	}
+
+	credential, err := s.users.ReadCredential(&userTransfert.Credential{Email: &email})
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := s.users.ReadClient(&userTransfert.Client{CredentialID: &credential.ID}); err != nil {
+		if err == errors_domain_user.ErrClientNotFound {
+			return "", errors_domain_user.ErrCredentialNotFound
+		}
+		return "", err
+	}
+
+	return credential.ID, nil
+}
+
+// GetTicketTransfers lists one page of the transfers sent or received by the authenticated client, the most recent first
+//
+// Parameters:
+// - dto: *transfert.TicketTransfer The status to filter on, every status when nil, with the page and its limit
+//
+// Returns:
+// - *entities.TransferPage: The transfers of the page with their ticket, and the total count
+// - errors.ErrorInterface: ErrTransferInvalidStatus for an unknown status, ErrTransferInvalidPage for a page out of bounds
+func (s *GameService) GetTicketTransfers(dto *transfert.TicketTransfer) (*entities.TransferPage, errors.ErrorInterface) {
+	if !s.security.IsAuthenticated() {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if dto.Status != nil {
+		if _, ok := entities.NewTransferStatus(dto.Status); !ok {
+			return nil, errors_domain_game.ErrTransferInvalidStatus
+		}
+	}
+
+	page, limit := 1, TicketPageSize
+	if dto.Page != nil {
+		page = *dto.Page
+	}
+
+	if dto.Limit != nil {
+		limit = *dto.Limit
+	}
+
+	if page < 1 || limit < 1 || limit > TicketPageMax {
+		return nil, errors_domain_game.ErrTransferInvalidPage
+	}
+
+	credentialID := s.security.GetCredentialID()
+	filter := &transfert.TicketTransfer{Status: dto.Status}
+	options := []database.Option{database.Where("sender_id = ? OR recipient_id = ?", *credentialID, *credentialID)}
+
+	total, err := s.repo.CountTicketTransfers(filter, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * limit
+	if offset >= total {
+		return entities.NewTransferPage(nil, total, page, limit), nil
+	}
+
+	transfers, err := s.repo.ReadTicketTransfers(filter, append(options, database.Limit(limit), database.Offset(offset))...)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewTransferPage(transfers, total, page, limit), nil
+}
+
+// AnswerTicketTransfer closes a pending transfer
+// The recipient accepts or declines it, the sender may cancel it; on acceptance the ticket moves
+// to the recipient and the change of owner is appended to its history
+//
+// Parameters:
+// - dto: *transfert.TicketTransfer The transfer ID and the answer, accepted, declined or cancelled
+//
+// Returns:
+// - *entities.TicketTransfer: The closed transfer with its ticket
+// - errors.ErrorInterface: ErrTransferInvalidStatus for an unknown answer, ErrTransferClosed if the transfer was already answered,
+// or a ticket or campaign error when the ticket can no longer be given
+func (s *GameService) AnswerTicketTransfer(dto *transfert.TicketTransfer) (*entities.TicketTransfer, errors.ErrorInterface) {
+	if !s.security.IsAuthenticated() {
+		return nil, errors.ErrUnauthorized
+	}
+
+	answer, ok := entities.NewTransferAnswer(dto.Status)
+	if !ok {
+		return nil, errors_domain_game.ErrTransferInvalidStatus
+	}
+
+	transfer, err := s.repo.ReadTicketTransfer(&transfert.TicketTransfer{ID: dto.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the sender withdraws an offer, only the recipient answers it
+	credentialID := s.security.GetCredentialID()
+	answerer := transfer.RecipientID
+	if answer == entities.TransferCancelled {
+		answerer = transfer.SenderID
+	}
+
+	if credentialID == nil || *credentialID != answerer {
+		return nil, errors.ErrUnauthorized
+	}
+
+	if transfer.Status != entities.TransferPending {
+		return nil, errors_domain_game.ErrTransferClosed
+	}
+
+	var ticket *entities.Ticket
+	var history *transfert.TicketHistory
+	var quota *entities.HouseholdQuota
+
+	if answer == entities.TransferAccepted {
+		ticket = transfer.Ticket
+		if ticket == nil {
+			return nil, errors_domain_game.ErrTicketNotFound
+		}
+
+		if err := s.checkGift(ticket, &transfer.SenderID); err != nil {
+			return nil, err
+		}
+
+		// A gift cannot bypass the participation rules of the campaign
+		if quota, err = s.checkEligibility(ticket, &transfer.RecipientID); err != nil {
+			return nil, err
+		}
+
+		status := ticket.Status.String()
+		history = &transfert.TicketHistory{
+			TicketID:       &ticket.ID,
+			CredentialID:   &transfer.RecipientID,
+			OwnerID:        &transfer.RecipientID,
+			PreviousStatus: &status,
+			Status:         &status,
+		}
+
+		ticket.CredentialID = &transfer.RecipientID
+	}
+
+	now := time.Now()
+	transfer.Status = answer
+	transfer.AnsweredAt = &now
+
+	save := func() errors.ErrorInterface {
+		return s.repo.UpdateTicketTransfer(transfer, ticket, history)
+	}
+
+	// The ticket takes a slot of the household of the recipient and gives back the one of the sender
+	if ticket != nil {
+		err = s.reserveHousehold(ticket, quota, save)
+	} else {
+		err = save()
+	}
+
+	if err != nil {
+		if err == errors_domain_game.ErrTicketInvalidTransition {
+			return nil, errors_domain_game.ErrTicketWrongOwner
+		}
+		return nil, err
+	}
+
+	return transfer, nil
+}
+
+// checkGift rejects a ticket that the holder can no longer give
+// Only a claimed ticket changes hands, until the claim deadline of its campaign
+func (s *GameService) checkGift(ticket *entities.Ticket, holder *string) errors.ErrorInterface {
+	if ticket.CredentialID == nil || holder == nil || *ticket.CredentialID != *holder {
+		return errors_domain_game.ErrTicketWrongOwner
+	}
+
+	switch ticket.Status {
+	case entities.TicketRedeemed:
+		return errors_domain_game.ErrTicketAlreadyRedeemed
+	case entities.TicketClaimed:
+	default:
+		return errors_domain_game.ErrTicketNotClaimed
+	}
+
+	return s.checkWindow(ticket, entities.TicketRedeemed)
+}
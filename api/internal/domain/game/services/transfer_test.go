@@ -0,0 +1,420 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	user "github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_GiftTicket(t *testing.T) {
+	sid := aws.String("sender-123")
+	dto := &transfert.TicketTransfer{TicketID: aws.String("ticket-123"), Email: aws.String("friend@thetiptop.fr")}
+	lookup := &transfert.Ticket{ID: dto.TicketID}
+
+	newTicket := func(status entities.TicketStatus) *entities.Ticket {
+		return &entities.Ticket{ID: "ticket-123", CredentialID: sid, Status: status, CampaignID: aws.String("campaign-123")}
+	}
+
+	openCampaign := &entities.Campaign{ID: "campaign-123", ClaimDeadline: aws.Time(time.Now().Add(24 * time.Hour))}
+
+	// found simule le compte client inscrit avec l'adresse du destinataire
+	found := func(mockUsers *UserReaderMock, id string) {
+		mockUsers.On("ReadCredential", &userTransfert.Credential{Email: dto.Email}, mock.Anything).Return(&user.Credential{ID: id}, nil)
+		mockUsers.On("ReadClient", &userTransfert.Client{CredentialID: &id}, mock.Anything).Return(&user.Client{}, nil)
+	}
+
+	t.Run("Should offer a claimed ticket to another client", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		ticket := newTicket(entities.TicketClaimed)
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(ticket, nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(openCampaign, nil)
+		found(mockUsers, "recipient-123")
+		mockRepo.On("ReadTicketTransfers", &transfert.TicketTransfer{TicketID: aws.String("ticket-123"), Status: aws.String("pending")}, mock.Anything).Return([]*entities.TicketTransfer{}, nil)
+		mockRepo.On("CreateTicketTransfer", mock.MatchedBy(func(transfer *entities.TicketTransfer) bool {
+			return transfer.TicketID == "ticket-123" && transfer.SenderID == *sid && transfer.RecipientID == "recipient-123"
+		}), mock.Anything).Return(nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, err)
+		assert.Equal(t, "recipient-123", result.RecipientID)
+
+		// Le ticket reste au donateur tant que le destinataire n'a pas accepté
+		assert.Equal(t, sid, ticket.CredentialID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should refuse a ticket held by another client", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		ticket := newTicket(entities.TicketClaimed)
+		ticket.CredentialID = aws.String("someone-else")
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(ticket, nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketWrongOwner, err)
+
+		mockRepo.AssertNotCalled(t, "CreateTicketTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a redeemed ticket", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketRedeemed), nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+	})
+
+	t.Run("Should refuse a ticket held for review", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketReview), nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketNotClaimed, err)
+	})
+
+	t.Run("Should refuse a ticket after the claim deadline", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		closed := &entities.Campaign{ID: "campaign-123", ClaimDeadline: aws.Time(time.Now().Add(-time.Hour))}
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketClaimed), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(closed, nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrCampaignClaimClosed, err)
+
+		mockUsers.AssertNotCalled(t, "ReadCredential", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse an unknown recipient", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketClaimed), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(openCampaign, nil)
+		mockUsers.On("ReadCredential", &userTransfert.Credential{Email: dto.Email}, mock.Anything).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, err)
+	})
+
+	t.Run("Should refuse an employee as recipient", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketClaimed), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(openCampaign, nil)
+		mockUsers.On("ReadCredential", &userTransfert.Credential{Email: dto.Email}, mock.Anything).Return(&user.Credential{ID: "employee-123"}, nil)
+		mockUsers.On("ReadClient", &userTransfert.Client{CredentialID: aws.String("employee-123")}, mock.Anything).Return(nil, errors_domain_user.ErrClientNotFound)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, err)
+
+		mockRepo.AssertNotCalled(t, "CreateTicketTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a gift to oneself", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketClaimed), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(openCampaign, nil)
+		found(mockUsers, *sid)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferToSelf, err)
+	})
+
+	t.Run("Should refuse a second pending offer", func(t *testing.T) {
+		service, mockRepo, mockPerms, mockUsers := setupUsers()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicket", lookup, mock.Anything).Return(newTicket(entities.TicketClaimed), nil)
+		mockRepo.On("ReadCampaign", mock.Anything, mock.Anything).Return(openCampaign, nil)
+		found(mockUsers, "recipient-123")
+		mockRepo.On("ReadTicketTransfers", mock.Anything, mock.Anything).Return([]*entities.TicketTransfer{{ID: "transfer-123"}}, nil)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferPending, err)
+
+		mockRepo.AssertNotCalled(t, "CreateTicketTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return unauthorized for anonymous users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(false)
+
+		result, err := service.GiftTicket(dto)
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_GetTicketTransfers(t *testing.T) {
+	cid := aws.String("client-123")
+
+	t.Run("Should list the transfers of the client", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("CountTicketTransfers", &transfert.TicketTransfer{Status: aws.String("pending")}, mock.Anything).Return(21, nil)
+		mockRepo.On("ReadTicketTransfers", &transfert.TicketTransfer{Status: aws.String("pending")}, mock.Anything).Return([]*entities.TicketTransfer{{ID: "transfer-123"}}, nil)
+
+		result, err := service.GetTicketTransfers(&transfert.TicketTransfer{Status: aws.String("pending"), Page: aws.Int(2)})
+		assert.Nil(t, err)
+		assert.Len(t, result.Transfers, 1)
+		assert.Equal(t, 21, result.Total)
+		assert.Equal(t, 2, result.Page)
+		assert.Equal(t, services.TicketPageSize, result.Limit)
+		assert.Equal(t, 2, result.Pages)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should return an empty page past the last one", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(cid)
+		mockRepo.On("CountTicketTransfers", mock.Anything, mock.Anything).Return(3, nil)
+
+		result, err := service.GetTicketTransfers(&transfert.TicketTransfer{Page: aws.Int(2)})
+		assert.Nil(t, err)
+		assert.Empty(t, result.Transfers)
+		assert.Equal(t, 3, result.Total)
+
+		mockRepo.AssertNotCalled(t, "ReadTicketTransfers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should reject a page out of bounds", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		result, err := service.GetTicketTransfers(&transfert.TicketTransfer{Limit: aws.Int(services.TicketPageMax + 1)})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferInvalidPage, err)
+
+		result, err = service.GetTicketTransfers(&transfert.TicketTransfer{Page: aws.Int(0)})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferInvalidPage, err)
+
+		mockRepo.AssertNotCalled(t, "CountTicketTransfers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should reject an unknown status", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		result, err := service.GetTicketTransfers(&transfert.TicketTransfer{Status: aws.String("lost")})
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferInvalidStatus, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicketTransfers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return unauthorized for anonymous users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(false)
+
+		result, err := service.GetTicketTransfers(&transfert.TicketTransfer{})
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
+
+func Test_AnswerTicketTransfer(t *testing.T) {
+	sid := aws.String("sender-123")
+	rid := aws.String("recipient-123")
+	lookup := &transfert.TicketTransfer{ID: aws.String("transfer-123")}
+
+	newTransfer := func() *entities.TicketTransfer {
+		return &entities.TicketTransfer{
+			ID:          "transfer-123",
+			TicketID:    "ticket-123",
+			SenderID:    *sid,
+			RecipientID: *rid,
+			Status:      entities.TransferPending,
+			Ticket:      &entities.Ticket{ID: "ticket-123", CredentialID: sid, Status: entities.TicketClaimed},
+		}
+	}
+
+	answer := func(status string) *transfert.TicketTransfer {
+		return &transfert.TicketTransfer{ID: lookup.ID, Status: aws.String(status)}
+	}
+
+	t.Run("Should move the ticket to the recipient on acceptance", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		transfer := newTransfer()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(transfer, nil)
+		mockRepo.On("UpdateTicketTransfer", transfer, transfer.Ticket, mock.MatchedBy(func(history *transfert.TicketHistory) bool {
+			return *history.PreviousStatus == "claimed" && *history.Status == "claimed" && *history.OwnerID == *rid && *history.CredentialID == *rid
+		}), mock.Anything).Return(nil)
+
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TransferAccepted, result.Status)
+		assert.NotNil(t, result.AnsweredAt)
+		assert.Equal(t, *rid, *result.Ticket.CredentialID)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Should leave the ticket to the sender on refusal", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		transfer := newTransfer()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(transfer, nil)
+		mockRepo.On("UpdateTicketTransfer", transfer, (*entities.Ticket)(nil), (*transfert.TicketHistory)(nil), mock.Anything).Return(nil)
+
+		result, err := service.AnswerTicketTransfer(answer("declined"))
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TransferDeclined, result.Status)
+		assert.Equal(t, sid, result.Ticket.CredentialID)
+	})
+
+	t.Run("Should let the sender cancel the offer", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		transfer := newTransfer()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(transfer, nil)
+		mockRepo.On("UpdateTicketTransfer", transfer, (*entities.Ticket)(nil), (*transfert.TicketHistory)(nil), mock.Anything).Return(nil)
+
+		result, err := service.AnswerTicketTransfer(answer("cancelled"))
+		assert.Nil(t, err)
+		assert.Equal(t, entities.TransferCancelled, result.Status)
+	})
+
+	t.Run("Should refuse an answer from the wrong party", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(sid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(newTransfer(), nil)
+
+		// Le donateur ne peut pas accepter son propre don
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+
+		mockRepo.AssertNotCalled(t, "UpdateTicketTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should refuse a transfer already answered", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		transfer := newTransfer()
+		transfer.Status = entities.TransferDeclined
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(transfer, nil)
+
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferClosed, err)
+	})
+
+	t.Run("Should refuse a ticket redeemed since the offer", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		transfer := newTransfer()
+		transfer.Ticket.Status = entities.TicketRedeemed
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(transfer, nil)
+
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketAlreadyRedeemed, err)
+	})
+
+	t.Run("Should refuse a ticket that changed hands during the acceptance", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+		mockPerms.On("GetCredentialID").Return(rid)
+		mockRepo.On("ReadTicketTransfer", lookup, mock.Anything).Return(newTransfer(), nil)
+		mockRepo.On("UpdateTicketTransfer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors_domain_game.ErrTicketInvalidTransition)
+
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTicketWrongOwner, err)
+	})
+
+	t.Run("Should reject an unknown answer", func(t *testing.T) {
+		service, mockRepo, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(true)
+
+		result, err := service.AnswerTicketTransfer(answer("pending"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors_domain_game.ErrTransferInvalidStatus, err)
+
+		mockRepo.AssertNotCalled(t, "ReadTicketTransfer", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Should return unauthorized for anonymous users", func(t *testing.T) {
+		service, _, mockPerms := setup()
+
+		mockPerms.On("IsAuthenticated").Return(false)
+
+		result, err := service.AnswerTicketTransfer(answer("accepted"))
+		assert.Nil(t, result)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"time"
+
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// transitions lists, for each status, the statuses a ticket is allowed to move to
+var transitions = map[entities.TicketStatus][]entities.TicketStatus{
+	entities.TicketGenerated:   {entities.TicketDistributed, entities.TicketReview, entities.TicketClaimed, entities.TicketCancelled, entities.TicketExpired},
+	entities.TicketDistributed: {entities.TicketReview, entities.TicketClaimed, entities.TicketCancelled, entities.TicketExpired},
+	entities.TicketReview:      {entities.TicketClaimed, entities.TicketGenerated, entities.TicketDistributed, entities.TicketCancelled},
+	entities.TicketClaimed:     {entities.TicketRedeemed, entities.TicketCancelled, entities.TicketExpired},
+	entities.TicketRedeemed:    {},
+	entities.TicketCancelled:   {},
+	entities.TicketExpired:     {},
+}
+
+// manualStatuses lists the statuses an employee may set directly,
+// review, claimed and redeemed are only reached through the claim, review and redemption flows
+var manualStatuses = map[entities.TicketStatus]bool{
+	entities.TicketDistributed: true,
+	entities.TicketCancelled:   true,
+}
+
+// CanTransition reports whether a ticket in status from may move to status to
+//
+// Parameters:
+// - from: entities.TicketStatus The current status
+// - to: entities.TicketStatus The requested status
+//
+// Returns:
+// - bool: true if the transition is allowed
+func CanTransition(from, to entities.TicketStatus) bool {
+	for _, status := range transitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transition moves the ticket to the given status and persists the change with its history entry
+//
+// Parameters:
+// - ticket: *entities.Ticket The ticket to update
+// - to: entities.TicketStatus The requested status
+// - origin: *transfert.Ticket The store and caisse where the change happens, nil online
+//
+// Returns:
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the move is not allowed, a campaign error outside its windows
+func (s *GameService) transition(ticket *entities.Ticket, to entities.TicketStatus, origin *transfert.Ticket) errors.ErrorInterface {
+	history, err := s.prepareTransition(ticket, to, origin)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateTicketStatus(ticket, history)
+}
+
+// prepareTransition moves the ticket to the given status in memory and builds its history entry,
+// leaving the caller to persist both
+//
+// Parameters:
+// - ticket: *entities.Ticket The ticket to update
+// - to: entities.TicketStatus The requested status
+// - origin: *transfert.Ticket The store and caisse where the change happens, nil online
+//
+// Returns:
+// - *transfert.TicketHistory: The history entry of the change
+// - errors.ErrorInterface: ErrTicketInvalidTransition if the move is not allowed or a claimed ticket has no owner, a campaign error outside its windows
+func (s *GameService) prepareTransition(ticket *entities.Ticket, to entities.TicketStatus, origin *transfert.Ticket) (*transfert.TicketHistory, errors.ErrorInterface) {
+	from := ticket.Status
+	if from == "" {
+		from = entities.TicketGenerated
+	}
+
+	if !CanTransition(from, to) {
+		return nil, errors_domain_game.ErrTicketInvalidTransition
+	}
+
+	// A claimed ticket always belongs to a client, only a claim gives it one
+	if to == entities.TicketClaimed && ticket.CredentialID == nil {
+		return nil, errors_domain_game.ErrTicketInvalidTransition
+	}
+
+	// A held claim was checked against the campaign windows when it was made
+	if from != entities.TicketReview {
+		if err := s.checkWindow(ticket, to); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	switch to {
+	case entities.TicketReview:
+	case entities.TicketClaimed:
+		ticket.ClaimedAt = &now
+	case entities.TicketRedeemed:
+		ticket.RedeemedAt = &now
+	default:
+		// A released, cancelled or expired ticket gives its household slot back
+		ticket.Household, ticket.HouseholdSlot = nil, nil
+	}
+
+	ticket.Status = to
+
+	previous, status := from.String(), to.String()
+
+	history := &transfert.TicketHistory{
+		TicketID:       &ticket.ID,
+		CredentialID:   s.security.GetCredentialID(),
+		PreviousStatus: &previous,
+		Status:         &status,
+		OwnerID:        ticket.CredentialID,
+	}
+
+	if origin != nil {
+		history.StoreID = origin.StoreID
+		history.CaisseID = origin.CaisseID
+	}
+
+	return history, nil
+}
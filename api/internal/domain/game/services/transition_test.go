@@ -0,0 +1,45 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CanTransition(t *testing.T) {
+	tests := []struct {
+		from    entities.TicketStatus
+		to      entities.TicketStatus
+		allowed bool
+	}{
+		{entities.TicketGenerated, entities.TicketDistributed, true},
+		{entities.TicketGenerated, entities.TicketClaimed, true},
+		{entities.TicketGenerated, entities.TicketCancelled, true},
+		{entities.TicketGenerated, entities.TicketRedeemed, false},
+		{entities.TicketDistributed, entities.TicketClaimed, true},
+		{entities.TicketDistributed, entities.TicketGenerated, false},
+		{entities.TicketDistributed, entities.TicketReview, true},
+		{entities.TicketReview, entities.TicketClaimed, true},
+		{entities.TicketReview, entities.TicketDistributed, true},
+		{entities.TicketReview, entities.TicketRedeemed, false},
+		{entities.TicketClaimed, entities.TicketReview, false},
+		{entities.TicketClaimed, entities.TicketRedeemed, true},
+		{entities.TicketClaimed, entities.TicketCancelled, true},
+		{entities.TicketClaimed, entities.TicketClaimed, false},
+		{entities.TicketRedeemed, entities.TicketCancelled, false},
+		{entities.TicketCancelled, entities.TicketClaimed, false},
+		{entities.TicketGenerated, entities.TicketExpired, true},
+		{entities.TicketClaimed, entities.TicketExpired, true},
+		{entities.TicketReview, entities.TicketExpired, false},
+		{entities.TicketRedeemed, entities.TicketExpired, false},
+		{entities.TicketExpired, entities.TicketClaimed, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+" to "+tt.to.String(), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, services.CanTransition(tt.from, tt.to))
+		})
+	}
+}
@@ -288,6 +288,14 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetSessionID() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*string)
+}
+
 // setup function initializes a StoreService with mocked repository and permissions
 // Parameters:
 // - None
@@ -1,12 +1,18 @@
 package entities
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"gorm.io/gorm"
 )
 
@@ -14,6 +20,9 @@ const (
 	ROLE_CLIENT security.Role = "client"
 )
 
+// BirthDateLayout is the format of the birth date of the clients, as read by the eligibility rules of the game
+const BirthDateLayout = entities.BirthDateLayout
+
 type ClientData struct {
 	Client      *Client
 	Credential  *Credential
@@ -36,6 +45,14 @@ type Client struct {
 	// Additional fields
 	CGU        *bool `gorm:"type:boolean;default:false" json:"cgu"`
 	Newsletter *bool `gorm:"type:boolean;default:false" json:"newsletter"`
+
+	// Eligibility fields
+	BirthDate  *string `gorm:"type:varchar(10)" json:"birth_date"` // BirthDateLayout
+	Address    *string `gorm:"type:varchar(255)" json:"address"`
+	PostalCode *string `gorm:"type:varchar(16)" json:"postal_code"`
+	City       *string `gorm:"type:varchar(128)" json:"city"`
+	Country    *string `gorm:"type:varchar(2)" json:"country"`  // ISO 3166-1 alpha-2 code of the country of residence
+	Household  *string `gorm:"type:varchar(64);index" json:"-"` // Key of the postal address, shared by the clients living there
 }
 
 func (client *Client) HasSuccessValidation(validationType ValidationType) *Validation {
@@ -59,6 +76,64 @@ func (client *Client) HasNotExpiredValidation(validationType ValidationType) *Va
 	return nil
 }
 
+// CheckProfile validates the birth date and the country of residence, the country code is upper-cased
+//
+// Returns:
+// - errors.ErrorInterface: ErrValueIsNotDate for a malformed or future birth date, ErrClientInvalidCountry for an unknown country format
+func (client *Client) CheckProfile() errors.ErrorInterface {
+	if client.BirthDate != nil {
+		birth, err := time.Parse(BirthDateLayout, *client.BirthDate)
+		if err != nil || birth.After(time.Now()) {
+			return errors.ErrValueIsNotDate
+		}
+	}
+
+	if client.Country != nil {
+		country := strings.ToUpper(strings.TrimSpace(*client.Country))
+		if len(country) != 2 || country[0] < 'A' || country[0] > 'Z' || country[1] < 'A' || country[1] > 'Z' {
+			return errors_domain_user.ErrClientInvalidCountry
+		}
+
+		client.Country = &country
+	}
+
+	return nil
+}
+
+// HouseholdKey fingerprints a postal address, so that the clients living at the same address share the key
+// Case, punctuation and spacing are ignored
+//
+// Returns:
+// - *string: The key, nil when a part of the address is missing
+func HouseholdKey(address, postalCode, country *string) *string {
+	if address == nil || postalCode == nil || country == nil {
+		return nil
+	}
+
+	parts := []string{normalizeAddress(*address), normalizeAddress(*postalCode), normalizeAddress(*country)}
+	for _, part := range parts {
+		if part == "" {
+			return nil
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "|")))
+	key := hex.EncodeToString(sum[:])
+
+	return &key
+}
+
+func normalizeAddress(value string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func (client *Client) BeforeSave(tx *gorm.DB) error {
+	client.Household = HouseholdKey(client.Address, client.PostalCode, client.Country)
+	return nil
+}
+
 func (client *Client) BeforeUpdate(tx *gorm.DB) error {
 	client.UpdatedAt = time.Now()
 	return nil
@@ -102,6 +177,11 @@ func CreateClient(obj *transfert.Client) *Client {
 		CGU:          obj.CGU,
 		Newsletter:   obj.Newsletter,
 		CredentialID: obj.CredentialID,
+		BirthDate:    obj.BirthDate,
+		Address:      obj.Address,
+		PostalCode:   obj.PostalCode,
+		City:         obj.City,
+		Country:      obj.Country,
 	}
 
 	if obj.ID != nil {
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -180,3 +182,40 @@ func TestCreateClientWithNilFields(t *testing.T) {
 	assert.NotNil(t, client.Validations)
 	assert.Equal(t, 0, len(client.Validations))
 }
+
+func TestClient_CheckProfile(t *testing.T) {
+	client := &entities.Client{BirthDate: aws.String("1990-05-17"), Country: aws.String(" fr")}
+	assert.Nil(t, client.CheckProfile())
+	assert.Equal(t, "FR", *client.Country)
+
+	// Les clients sans profil restent valides
+	assert.Nil(t, (&entities.Client{}).CheckProfile())
+
+	client = &entities.Client{BirthDate: aws.String("17/05/1990")}
+	assert.Equal(t, errors.ErrValueIsNotDate, client.CheckProfile())
+
+	client = &entities.Client{BirthDate: aws.String(time.Now().AddDate(0, 0, 2).Format(entities.BirthDateLayout))}
+	assert.Equal(t, errors.ErrValueIsNotDate, client.CheckProfile())
+
+	for _, country := range []string{"FRA", "F", "1A"} {
+		client = &entities.Client{Country: aws.String(country)}
+		assert.Equal(t, errors_domain_user.ErrClientInvalidCountry, client.CheckProfile())
+	}
+}
+
+func TestHouseholdKey(t *testing.T) {
+	key := entities.HouseholdKey(aws.String("12, rue de la Paix"), aws.String("75002"), aws.String("FR"))
+	assert.NotNil(t, key)
+	assert.Len(t, *key, 64)
+
+	// La casse, la ponctuation et les espaces ne changent pas le foyer
+	assert.Equal(t, key, entities.HouseholdKey(aws.String("12 RUE DE LA  PAIX"), aws.String(" 75002"), aws.String("fr")))
+	assert.NotEqual(t, key, entities.HouseholdKey(aws.String("14 rue de la Paix"), aws.String("75002"), aws.String("FR")))
+
+	assert.Nil(t, entities.HouseholdKey(aws.String("12 rue de la Paix"), nil, aws.String("FR")))
+	assert.Nil(t, entities.HouseholdKey(aws.String(" - "), aws.String("75002"), aws.String("FR")))
+
+	client := &entities.Client{Address: aws.String("12 rue de la Paix"), PostalCode: aws.String("75002"), Country: aws.String("FR")}
+	assert.Nil(t, client.BeforeSave(nil))
+	assert.Equal(t, key, client.Household)
+}
@@ -11,6 +11,7 @@ import (
 
 const (
 	ROLE_EMPLOYEE security.Role = "employee"
+	ROLE_AUDITOR  security.Role = "auditor" // Employee limited to reading the ticket ledger, granted to the bailiff
 )
 
 type Employee struct {
@@ -23,6 +24,15 @@ type Employee struct {
 	// Relations
 	CredentialID *string     `gorm:"type:varchar(36);index;" json:"-"` // Foreign key to Credential
 	Validations  Validations `gorm:"foreignKey:EmployeeID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	// Additional fields
+	Auditor bool `json:"auditor"` // Signs in as ROLE_AUDITOR instead of ROLE_EMPLOYEE
+
+	// Second factor
+	TOTPSecret    *string  `gorm:"type:varchar(64)" json:"-"` // Base32 secret, set at enrolment
+	TOTPEnabled   bool     `json:"totp"`                      // Sign-ins require a TOTP or a recovery code once the enrolment is confirmed
+	TOTPStep      int64    `json:"-"`                         // Last accepted period, a code is only accepted once
+	RecoveryCodes []string `gorm:"serializer:json" json:"-"`  // SHA256 hashes of the unused recovery codes
 }
 
 func (employee *Employee) HasSuccessValidation(validationType ValidationType) *Validation {
@@ -0,0 +1,149 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/kodmain/thetiptop/api/config"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"gorm.io/gorm"
+)
+
+type LockoutScope string
+
+// Scopes of the failed sign-ins tracking
+const (
+	LockoutCredential LockoutScope = "credential" // Failures against an account, keyed by credential ID
+	LockoutIP         LockoutScope = "ip"         // Failures from an address, whatever the account
+)
+
+// Lockout tracks the failed sign-ins in a row of a credential or an IP
+// It is kept in database so that a back-off or a lock survives restarts
+type Lockout struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	Scope   LockoutScope `gorm:"type:varchar(16);uniqueIndex:idx_lockout_subject" json:"scope"`
+	Subject string       `gorm:"type:varchar(45);uniqueIndex:idx_lockout_subject" json:"subject"` // Credential ID or IP
+
+	Failures      int        `json:"failures"`        // Failed sign-ins in a row, restarted after a lock
+	LastFailureAt *time.Time `json:"last_failure_at"` // Failures older than the policy window are forgotten
+	RetryAt       *time.Time `json:"retry_at"`        // Attempts are refused until this instant, nil without back-off
+	LockedUntil   *time.Time `json:"locked_until"`    // Attempts are refused until this instant, nil when not locked
+}
+
+// NewLockout starts the tracking of a credential or an IP
+func NewLockout(scope LockoutScope, subject string) *Lockout {
+	return &Lockout{Scope: scope, Subject: subject}
+}
+
+// Check rejects an attempt during a lock or a back-off
+//
+// Returns:
+// - errors.ErrorInterface: ErrCredentialLocked during a lock, ErrCredentialThrottled during a back-off
+func (lockout *Lockout) Check(at time.Time) errors.ErrorInterface {
+	if lockout.IsLocked(at) {
+		return errors_domain_user.ErrCredentialLocked
+	}
+
+	if lockout.RetryAt != nil && at.Before(*lockout.RetryAt) {
+		return errors_domain_user.ErrCredentialThrottled
+	}
+
+	return nil
+}
+
+// IsLocked reports whether the credential or the IP is locked at the given instant
+func (lockout *Lockout) IsLocked(at time.Time) bool {
+	return lockout.LockedUntil != nil && at.Before(*lockout.LockedUntil)
+}
+
+// Penalize computes the back-off or the lock following a failed sign-in
+// The failure is already part of Failures, counted by the database
+//
+// Parameters:
+// - policy: *LockoutPolicy The limits of the tracking
+// - at: time.Time The instant of the failure
+//
+// Returns:
+// - bool: true when the failure locks the credential or the IP
+func (lockout *Lockout) Penalize(policy *LockoutPolicy, at time.Time) bool {
+	lockout.RetryAt = nil
+
+	limit, free := policy.Attempts, 1
+	if lockout.Scope == LockoutIP {
+		// Addresses are shared, the back-off only starts past the failures allowed to a single account
+		limit, free = policy.IPAttempts, policy.Attempts
+	}
+
+	if lockout.Failures >= limit {
+		until := at.Add(policy.Duration)
+		lockout.LockedUntil = &until
+		return true
+	}
+
+	if lockout.Failures > free {
+		retry := at.Add(policy.Backoff(lockout.Failures - free))
+		lockout.RetryAt = &retry
+	}
+
+	return false
+}
+
+func (lockout *Lockout) BeforeUpdate(tx *gorm.DB) error {
+	lockout.UpdatedAt = time.Now()
+	return nil
+}
+
+func (lockout *Lockout) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	lockout.ID = id.String()
+
+	return nil
+}
+
+// LockoutPolicy holds the limits of the failed sign-ins tracking
+type LockoutPolicy struct {
+	Attempts   int           // Failures in a row locking a credential
+	IPAttempts int           // Failures in a row locking an IP
+	Delay      time.Duration // First back-off, doubled on each further failure
+	MaxDelay   time.Duration // Longest back-off
+	Duration   time.Duration // Length of a lock
+	Window     time.Duration // Failures older than this are forgotten
+	Mail       bool          // Mail the client when its account gets locked
+}
+
+// NewLockoutPolicy builds the lockout policy from the security.lockout configuration
+// Delays are read in seconds, durations in minutes, a limit lower than 1 falls back to its default
+//
+// Returns:
+// - *LockoutPolicy: The policy
+func NewLockoutPolicy() *LockoutPolicy {
+	mail, _ := config.Get("security.lockout.mail", false).(bool)
+
+	return &LockoutPolicy{
+		Attempts:   config.GetPositiveInt("security.lockout.attempts", 5),
+		IPAttempts: config.GetPositiveInt("security.lockout.ip_attempts", 20),
+		Delay:      time.Duration(config.GetPositiveInt("security.lockout.delay", 1)) * time.Second,
+		MaxDelay:   time.Duration(config.GetPositiveInt("security.lockout.max_delay", 60)) * time.Second,
+		Duration:   time.Duration(config.GetPositiveInt("security.lockout.duration", 15)) * time.Minute,
+		Window:     time.Duration(config.GetPositiveInt("security.lockout.window", 15)) * time.Minute,
+		Mail:       mail,
+	}
+}
+
+// Backoff returns the wait imposed after the given number of penalized failures
+func (policy *LockoutPolicy) Backoff(failures int) time.Duration {
+	delay := policy.Delay
+	for i := 1; i < failures && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, policy.MaxDelay)
+}
@@ -0,0 +1,108 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func lockoutPolicy() *entities.LockoutPolicy {
+	return &entities.LockoutPolicy{
+		Attempts:   3,
+		IPAttempts: 6,
+		Delay:      time.Second,
+		MaxDelay:   4 * time.Second,
+		Duration:   15 * time.Minute,
+		Window:     15 * time.Minute,
+	}
+}
+
+func TestLockoutPenalizeCredential(t *testing.T) {
+	policy := lockoutPolicy()
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	lockout := entities.NewLockout(entities.LockoutCredential, "credential-id")
+
+	// Le premier échec n'impose aucun délai
+	lockout.Failures = 1
+	assert.False(t, lockout.Penalize(policy, now))
+	assert.Nil(t, lockout.RetryAt)
+	assert.NoError(t, lockout.Check(now))
+
+	// Le second impose un délai avant la tentative suivante
+	lockout.Failures = 2
+	assert.False(t, lockout.Penalize(policy, now))
+	assert.Equal(t, now.Add(time.Second), *lockout.RetryAt)
+	assert.Equal(t, errors_domain_user.ErrCredentialThrottled, lockout.Check(now))
+	assert.NoError(t, lockout.Check(now.Add(time.Second)))
+
+	// Le troisième verrouille le compte, le compteur repart de zéro en base à la fin du verrouillage
+	lockout.Failures = 3
+	assert.True(t, lockout.Penalize(policy, now))
+	assert.Equal(t, now.Add(15*time.Minute), *lockout.LockedUntil)
+	assert.Nil(t, lockout.RetryAt)
+	assert.True(t, lockout.IsLocked(now))
+	assert.Equal(t, errors_domain_user.ErrCredentialLocked, lockout.Check(now.Add(14*time.Minute)))
+	assert.False(t, lockout.IsLocked(now.Add(15*time.Minute)))
+	assert.NoError(t, lockout.Check(now.Add(15*time.Minute)))
+}
+
+func TestLockoutPenalizeIP(t *testing.T) {
+	policy := lockoutPolicy()
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	lockout := entities.NewLockout(entities.LockoutIP, "192.0.2.1")
+
+	// Une adresse peut échouer autant qu'un compte avant d'être ralentie
+	for lockout.Failures = 1; lockout.Failures <= policy.Attempts; lockout.Failures++ {
+		assert.False(t, lockout.Penalize(policy, now))
+	}
+	assert.Nil(t, lockout.RetryAt)
+
+	lockout.Failures = policy.Attempts + 1
+	assert.False(t, lockout.Penalize(policy, now))
+	assert.Equal(t, now.Add(time.Second), *lockout.RetryAt)
+
+	lockout.Failures = policy.Attempts + 2
+	assert.False(t, lockout.Penalize(policy, now))
+	assert.Equal(t, now.Add(2*time.Second), *lockout.RetryAt)
+
+	// Le verrouillage intervient à la limite propre aux adresses
+	lockout.Failures = policy.IPAttempts
+	assert.True(t, lockout.Penalize(policy, now))
+	assert.True(t, lockout.IsLocked(now))
+}
+
+func TestLockoutPenalizeConcurrent(t *testing.T) {
+	policy := lockoutPolicy()
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// Des échecs simultanés comptés en base au-delà de la limite verrouillent tous le compte
+	lockout := entities.NewLockout(entities.LockoutCredential, "credential-id")
+	lockout.Failures = policy.Attempts + 2
+	assert.True(t, lockout.Penalize(policy, now))
+	assert.True(t, lockout.IsLocked(now))
+}
+
+func TestLockoutPolicyBackoff(t *testing.T) {
+	policy := lockoutPolicy()
+
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 4*time.Second, policy.Backoff(10))
+}
+
+func TestNewLockoutPolicy(t *testing.T) {
+	// Sans configuration, les valeurs par défaut s'appliquent
+	policy := entities.NewLockoutPolicy()
+
+	assert.Equal(t, 5, policy.Attempts)
+	assert.Equal(t, 20, policy.IPAttempts)
+	assert.Equal(t, time.Second, policy.Delay)
+	assert.Equal(t, time.Minute, policy.MaxDelay)
+	assert.Equal(t, 15*time.Minute, policy.Duration)
+	assert.Equal(t, 15*time.Minute, policy.Window)
+	assert.False(t, policy.Mail)
+}
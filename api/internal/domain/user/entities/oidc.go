@@ -0,0 +1,67 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OIDCRequestLifetime is the time left to a client to sign in with the external provider
+const OIDCRequestLifetime = 10 * time.Minute
+
+// OIDCRequest is a sign-in started with an external OpenID Connect provider, consumed by its callback
+// The nonce and the PKCE verifier never leave the server, the consents are those given to register a new client
+type OIDCRequest struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time `json:"-"`
+
+	Provider   string    `gorm:"type:varchar(32)" json:"-"`
+	State      string    `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	Nonce      string    `gorm:"type:varchar(64)" json:"-"`
+	Verifier   string    `gorm:"type:varchar(64)" json:"-"`
+	CGU        *bool     `gorm:"type:boolean" json:"-"`
+	Newsletter *bool     `gorm:"type:boolean" json:"-"`
+	ExpiresAt  time.Time `gorm:"index" json:"-"`
+}
+
+// TableName keeps the acronym in one piece, gorm would name the table o_id_c_requests
+func (OIDCRequest) TableName() string {
+	return "oidc_requests"
+}
+
+// HasExpired reports whether the callback comes too late
+func (request *OIDCRequest) HasExpired(at time.Time) bool {
+	return !at.Before(request.ExpiresAt)
+}
+
+func (request *OIDCRequest) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	request.ID = id.String()
+	return nil
+}
+
+// Identity links the account of a client at an external provider to its credential
+type Identity struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"id"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	Provider     string  `gorm:"type:varchar(32);uniqueIndex:idx_identity_subject" json:"provider"`
+	Subject      string  `gorm:"type:varchar(255);uniqueIndex:idx_identity_subject" json:"-"` // Stable ID of the account at the provider
+	CredentialID *string `gorm:"type:varchar(36);index" json:"-"`
+}
+
+func (identity *Identity) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	identity.ID = id.String()
+	return nil
+}
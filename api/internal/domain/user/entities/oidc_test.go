@@ -0,0 +1,29 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOIDCRequestHasExpired(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	request := &entities.OIDCRequest{ExpiresAt: now.Add(entities.OIDCRequestLifetime)}
+
+	assert.False(t, request.HasExpired(now))
+	assert.False(t, request.HasExpired(now.Add(entities.OIDCRequestLifetime-time.Second)))
+	assert.True(t, request.HasExpired(now.Add(entities.OIDCRequestLifetime)))
+}
+
+func TestOIDCBeforeCreate(t *testing.T) {
+	request := &entities.OIDCRequest{}
+	assert.NoError(t, request.BeforeCreate(nil))
+	assert.NotEmpty(t, request.ID)
+	assert.Equal(t, "oidc_requests", request.TableName())
+
+	identity := &entities.Identity{}
+	assert.NoError(t, identity.BeforeCreate(nil))
+	assert.NotEmpty(t, identity.ID)
+}
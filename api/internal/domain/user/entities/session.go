@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a refresh token issued to a credential, known server side by the jti claim of the token
+// Each renewal rotates it, the tokens renewed from one sign-in share its family, the session
+// The current token of a session describes it to its owner, the device it was opened on and its last use
+type RefreshToken struct {
+	ID        string    `gorm:"type:varchar(36);primaryKey;" json:"-"`
+	CreatedAt time.Time `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+
+	Family       string     `gorm:"type:varchar(36);index" json:"id"` // ID of the first token of the sign-in
+	CredentialID *string    `gorm:"type:varchar(36);index" json:"-"`
+	ExpiresAt    time.Time  `gorm:"index" json:"expires_at"`
+	RotatedAt    *time.Time `json:"-"` // Set once renewed, any later use of the token revokes its family
+	RevokedAt    *time.Time `json:"-"`
+
+	Device     *string   `gorm:"type:varchar(255)" json:"device"` // Device name sent by the client app at sign-in
+	UserAgent  *string   `gorm:"type:varchar(512)" json:"user_agent"`
+	IP         *string   `gorm:"type:varchar(45)" json:"ip"`
+	StartedAt  time.Time `json:"started_at"` // Sign-in of the session, kept by the renewals
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `gorm:"-" json:"current"` // Session of the token of the request, never a column
+}
+
+// NewRefreshToken creates the first token of a session, or the next one of the session of previous
+//
+// Parameters:
+// - credentialID: *string The owner of the session
+// - previous: *RefreshToken The token renewed, nil for a sign-in
+// - client: *transfert.Session The device, user agent and IP of the request, nil when unknown
+// - at: time.Time The instant of the sign-in or the renewal
+// - lifetime: time.Duration The validity of the token
+func NewRefreshToken(credentialID *string, previous *RefreshToken, client *transfert.Session, at time.Time, lifetime time.Duration) *RefreshToken {
+	token := &RefreshToken{
+		CredentialID: credentialID,
+		ExpiresAt:    at.Add(lifetime),
+		StartedAt:    at,
+		LastUsedAt:   at,
+	}
+
+	if previous != nil {
+		token.Family = previous.Family
+		token.StartedAt = previous.StartedAt
+		token.Device, token.UserAgent, token.IP = previous.Device, previous.UserAgent, previous.IP
+	}
+
+	// Le nom de l'appareil est celui de la connexion, l'agent et l'adresse suivent la dernière utilisation
+	if client != nil {
+		if client.Device != nil && *client.Device != "" && token.Device == nil {
+			token.Device = client.Device
+		}
+
+		if client.UserAgent != nil && *client.UserAgent != "" {
+			token.UserAgent = client.UserAgent
+		}
+
+		if client.IP != nil && *client.IP != "" {
+			token.IP = client.IP
+		}
+	}
+
+	return token
+}
+
+// HasExpired reports whether the token outlived the refresh lifetime
+func (token *RefreshToken) HasExpired(at time.Time) bool {
+	return !at.Before(token.ExpiresAt)
+}
+
+// IsRevoked reports whether the session of the token was closed
+func (token *RefreshToken) IsRevoked() bool {
+	return token.RevokedAt != nil
+}
+
+// IsRotated reports whether the token was already exchanged for a new one
+func (token *RefreshToken) IsRotated() bool {
+	return token.RotatedAt != nil
+}
+
+// BeforeCreate identifies the token, a token without family starts a new session
+func (token *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+
+	token.ID = id.String()
+	if token.Family == "" {
+		token.Family = token.ID
+	}
+
+	return nil
+}
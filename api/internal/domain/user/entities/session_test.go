@@ -0,0 +1,78 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshTokenState(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	token := &entities.RefreshToken{ExpiresAt: now.Add(time.Hour)}
+
+	assert.False(t, token.HasExpired(now))
+	assert.True(t, token.HasExpired(now.Add(time.Hour)))
+	assert.False(t, token.IsRotated())
+	assert.False(t, token.IsRevoked())
+
+	token.RotatedAt = &now
+	token.RevokedAt = &now
+	assert.True(t, token.IsRotated())
+	assert.True(t, token.IsRevoked())
+}
+
+func TestRefreshTokenBeforeCreate(t *testing.T) {
+	// Le premier jeton d'une connexion ouvre sa famille
+	first := &entities.RefreshToken{}
+	assert.NoError(t, first.BeforeCreate(nil))
+	assert.NotEmpty(t, first.ID)
+	assert.Equal(t, first.ID, first.Family)
+
+	// Les jetons suivants la conservent
+	next := &entities.RefreshToken{Family: first.Family}
+	assert.NoError(t, next.BeforeCreate(nil))
+	assert.NotEqual(t, first.ID, next.ID)
+	assert.Equal(t, first.Family, next.Family)
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	credentialID := aws.String("credential-id")
+
+	// Une connexion ouvre une session décrite par la requête
+	first := entities.NewRefreshToken(credentialID, nil, &transfert.Session{
+		Device:    aws.String("Pixel 8"),
+		UserAgent: aws.String("okhttp/4.12"),
+		IP:        aws.String("192.0.2.1"),
+	}, now, time.Hour)
+
+	assert.Empty(t, first.Family)
+	assert.Equal(t, now.Add(time.Hour), first.ExpiresAt)
+	assert.Equal(t, now, first.StartedAt)
+	assert.Equal(t, now, first.LastUsedAt)
+	assert.Equal(t, "Pixel 8", *first.Device)
+	first.Family = "family-id"
+
+	// Un renouvellement garde l'appareil et le début de la session, l'adresse suit la requête
+	later := now.Add(time.Minute)
+	next := entities.NewRefreshToken(credentialID, first, &transfert.Session{
+		Device: aws.String("Other"),
+		IP:     aws.String("192.0.2.9"),
+	}, later, time.Hour)
+
+	assert.Equal(t, "family-id", next.Family)
+	assert.Equal(t, now, next.StartedAt)
+	assert.Equal(t, later, next.LastUsedAt)
+	assert.Equal(t, "Pixel 8", *next.Device)
+	assert.Equal(t, "okhttp/4.12", *next.UserAgent)
+	assert.Equal(t, "192.0.2.9", *next.IP)
+
+	// Sans requête connue, rien n'est décrit
+	blank := entities.NewRefreshToken(credentialID, nil, nil, now, time.Hour)
+	assert.Nil(t, blank.Device)
+	assert.Nil(t, blank.IP)
+}
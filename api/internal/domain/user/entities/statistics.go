@@ -0,0 +1,8 @@
+package entities
+
+// NewsletterStatistic counts the clients registered during a period and those who subscribed to the newsletter
+type NewsletterStatistic struct {
+	Clients     int     `json:"clients"`
+	Subscribers int     `json:"subscribers"`
+	Rate        float64 `json:"rate"` // Subscribers over clients, 0 without client
+}
@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"crypto/subtle"
+	"strings"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/env"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/password"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/totp"
+)
+
+// TOTPEnrolment is handed to an employee starting the enrolment of its authenticator app
+type TOTPEnrolment struct {
+	Secret string `json:"secret"` // Base32 secret, for a manual entry
+	URI    string `json:"uri"`    // otpauth URI, to render as a QR code
+}
+
+// TOTPPolicy holds the second factor settings
+type TOTPPolicy struct {
+	Required bool   // Employees without second factor only get tokens limited to its enrolment
+	Issuer   string // Service name shown by the authenticator apps
+}
+
+// NewTOTPPolicy builds the second factor policy from the security.totp configuration
+//
+// Returns:
+// - *TOTPPolicy: The policy
+func NewTOTPPolicy() *TOTPPolicy {
+	required, _ := config.Get("security.totp.required", false).(bool)
+
+	issuer := config.GetString("security.totp.issuer", env.APP_NAME)
+	if issuer == "" {
+		issuer = env.APP_NAME
+	}
+
+	return &TOTPPolicy{
+		Required: required,
+		Issuer:   issuer,
+	}
+}
+
+// RecoveryCodes is the number of recovery codes handed out when the second factor is confirmed
+const RecoveryCodes = 10
+
+// EnrollTOTP starts an enrolment with a new secret, the second factor is only required once confirmed
+func (employee *Employee) EnrollTOTP(secret string) {
+	employee.TOTPSecret = &secret
+	employee.TOTPEnabled = false
+	employee.TOTPStep = 0
+	employee.RecoveryCodes = nil
+}
+
+// ConfirmTOTP enables the second factor with a first code of the enrolled secret and hands out new recovery codes
+//
+// Returns:
+// - []string: The recovery codes in clear, only their hashes are kept
+// - errors.ErrorInterface: ErrTOTPNotEnrolled without enrolment, ErrTOTPInvalid for a wrong code
+func (employee *Employee) ConfirmTOTP(code string, at time.Time) ([]string, errors.ErrorInterface) {
+	if employee.TOTPSecret == nil {
+		return nil, errors_domain_user.ErrTOTPNotEnrolled
+	}
+
+	step, ok := totp.Validate(*employee.TOTPSecret, code, at, employee.TOTPStep)
+	if !ok {
+		return nil, errors_domain_user.ErrTOTPInvalid
+	}
+
+	codes := make([]string, RecoveryCodes)
+	hashes := make([]string, RecoveryCodes)
+	for i := range codes {
+		code, err := password.GeneratePassword(10, password.Lowercase|password.Digits)
+		if err != nil {
+			return nil, errors.ErrInternalServer.Log(err)
+		}
+
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = recoveryHash(codes[i])
+	}
+
+	employee.TOTPEnabled = true
+	employee.TOTPStep = step
+	employee.RecoveryCodes = hashes
+
+	return codes, nil
+}
+
+// CheckTOTP checks a TOTP code, or consumes a recovery code, of an employee with the second factor enabled
+// The caller saves the employee on success, the accepted period or the remaining recovery codes changed
+//
+// Returns:
+// - errors.ErrorInterface: ErrTOTPInvalid for a wrong, reused or already consumed code
+func (employee *Employee) CheckTOTP(code string, at time.Time) errors.ErrorInterface {
+	if !employee.TOTPEnabled || employee.TOTPSecret == nil {
+		return errors_domain_user.ErrTOTPNotEnrolled
+	}
+
+	if step, ok := totp.Validate(*employee.TOTPSecret, code, at, employee.TOTPStep); ok {
+		employee.TOTPStep = step
+		return nil
+	}
+
+	hashed := recoveryHash(code)
+	for i, recovery := range employee.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(recovery), []byte(hashed)) == 1 {
+			employee.RecoveryCodes = append(employee.RecoveryCodes[:i:i], employee.RecoveryCodes[i+1:]...)
+			return nil
+		}
+	}
+
+	return errors_domain_user.ErrTOTPInvalid
+}
+
+// recoveryHash hashes a recovery code, they are random enough for a plain SHA256
+func recoveryHash(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	hashed, _ := hash.Hash(&code, hash.SHA256) // ignorer l'erreur car la donnée n'est jamais nil
+	return *hashed
+}
@@ -0,0 +1,106 @@
+package entities_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enrolledEmployee(t *testing.T) (*entities.Employee, string) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err)
+
+	employee := &entities.Employee{}
+	employee.EnrollTOTP(secret)
+
+	return employee, secret
+}
+
+func TestEmployeeConfirmTOTP(t *testing.T) {
+	now := time.Now()
+
+	t.Run("not enrolled", func(t *testing.T) {
+		codes, err := (&entities.Employee{}).ConfirmTOTP("123456", now)
+		assert.Nil(t, codes)
+		assert.Equal(t, errors_domain_user.ErrTOTPNotEnrolled, err)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		employee, _ := enrolledEmployee(t)
+
+		codes, err := employee.ConfirmTOTP("000000x", now)
+		assert.Nil(t, codes)
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, err)
+		assert.False(t, employee.TOTPEnabled)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		employee, secret := enrolledEmployee(t)
+		code, _ := totp.Code(secret, totp.Step(now))
+
+		codes, err := employee.ConfirmTOTP(code, now)
+		require.Nil(t, err)
+		assert.True(t, employee.TOTPEnabled)
+		assert.Equal(t, totp.Step(now), employee.TOTPStep)
+		assert.Len(t, codes, entities.RecoveryCodes)
+		assert.Len(t, employee.RecoveryCodes, entities.RecoveryCodes)
+
+		// Seuls les hachés des codes de secours sont conservés
+		for i, recovery := range codes {
+			assert.Regexp(t, `^[a-z0-9]{5}-[a-z0-9]{5}$`, recovery)
+			assert.NotEqual(t, recovery, employee.RecoveryCodes[i])
+		}
+	})
+}
+
+func TestEmployeeCheckTOTP(t *testing.T) {
+	now := time.Now()
+
+	employee, secret := enrolledEmployee(t)
+	code, _ := totp.Code(secret, totp.Step(now)-1)
+	recoveries, err := employee.ConfirmTOTP(code, now.Add(-totp.Period))
+	require.Nil(t, err)
+
+	t.Run("valid code", func(t *testing.T) {
+		code, _ := totp.Code(secret, totp.Step(now))
+		assert.Nil(t, employee.CheckTOTP(code, now))
+		assert.Equal(t, totp.Step(now), employee.TOTPStep)
+
+		// Un code ne sert qu'une fois
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, employee.CheckTOTP(code, now))
+	})
+
+	t.Run("recovery code", func(t *testing.T) {
+		assert.Nil(t, employee.CheckTOTP(recoveries[3], now))
+		assert.Len(t, employee.RecoveryCodes, entities.RecoveryCodes-1)
+
+		// Un code de secours est consommé
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, employee.CheckTOTP(recoveries[3], now))
+
+		// Les autres restent utilisables, sans tenir compte de la casse
+		assert.Nil(t, employee.CheckTOTP(" "+recoveries[0]+" ", now))
+		assert.Len(t, employee.RecoveryCodes, entities.RecoveryCodes-2)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, employee.CheckTOTP("abcde-fghij", now))
+	})
+
+	t.Run("not enabled", func(t *testing.T) {
+		pending, _ := enrolledEmployee(t)
+		assert.Equal(t, errors_domain_user.ErrTOTPNotEnrolled, pending.CheckTOTP("123456", now))
+	})
+}
+
+func TestNewTOTPPolicy(t *testing.T) {
+	// Sans configuration, le second facteur est facultatif
+	policy := entities.NewTOTPPolicy()
+
+	assert.False(t, policy.Required)
+	assert.Equal(t, "TheTipTop", policy.Issuer)
+}
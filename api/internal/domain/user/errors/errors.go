@@ -16,6 +16,7 @@ var (
 	ErrClientNotFound         = errors.New(http.StatusNotFound, "client.not_found")
 	ErrClientAlreadyExists    = errors.New(http.StatusConflict, "client.already_exists")
 	ErrClientAlreadyValidated = errors.New(http.StatusConflict, "client.already_validated")
+	ErrClientInvalidCountry   = errors.New(http.StatusBadRequest, "client.invalid_country")
 
 	// Employee errors
 	ErrEmployeeNotValidate      = errors.New(http.StatusBadRequest, "employee.not_validate")
@@ -27,6 +28,34 @@ var (
 	ErrCredentialNotFound      = errors.New(http.StatusNotFound, "credential.not_found")
 	ErrCredentialNotValid      = errors.New(http.StatusBadRequest, "credential.not_valid")
 	ErrCredentialAlreadyExists = errors.New(http.StatusConflict, "credential.already_exists")
+	ErrCredentialLocked        = errors.New(http.StatusLocked, "credential.locked")
+	ErrCredentialThrottled     = errors.New(http.StatusTooManyRequests, "credential.throttled")
+
+	// TOTP errors
+	ErrTOTPInvalid        = errors.New(http.StatusUnauthorized, "totp.invalid")
+	ErrTOTPNotEnrolled    = errors.New(http.StatusConflict, "totp.not_enrolled")
+	ErrTOTPAlreadyEnabled = errors.New(http.StatusConflict, "totp.already_enabled")
+
+	// OIDC errors
+	ErrOIDCStateInvalid     = errors.New(http.StatusBadRequest, "oidc.state_invalid")
+	ErrOIDCEmailNotVerified = errors.New(http.StatusForbidden, "oidc.email_not_verified")
+	ErrOIDCConsentRequired  = errors.New(http.StatusBadRequest, "oidc.cgu_required")
+	ErrOIDCNotClient        = errors.New(http.StatusForbidden, "oidc.not_client")
+
+	// Identity errors
+	ErrIdentityNotFound = errors.New(http.StatusNotFound, "identity.not_found")
+
+	// Refresh token errors
+	ErrRefreshTokenNotFound = errors.New(http.StatusUnauthorized, "refresh_token.not_found")
+	ErrRefreshTokenRevoked  = errors.New(http.StatusUnauthorized, "refresh_token.revoked")
+	ErrRefreshTokenReused   = errors.New(http.StatusUnauthorized, "refresh_token.reused")
+
+	// Session errors
+	ErrSessionNotFound = errors.New(http.StatusNotFound, "session.not_found")
+	ErrSessionCurrent  = errors.New(http.StatusConflict, "session.current")
+
+	// Lockout errors
+	ErrLockoutNotFound = errors.New(http.StatusNotFound, "lockout.not_found")
 
 	// Validation errors
 	ErrValidationNotFound         = errors.New(http.StatusNotFound, "validation.not_found")
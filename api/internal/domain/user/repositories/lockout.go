@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReadLockout reads the failed sign-ins tracked for a credential or an IP
+//
+// Parameters:
+// - scope: entities.LockoutScope - LockoutCredential or LockoutIP
+// - subject: string - The credential ID or the IP
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Lockout: The tracking
+// - errors.ErrorInterface: ErrLockoutNotFound if no failure is tracked
+func (r *UserRepository) ReadLockout(scope entities.LockoutScope, subject string, options ...database.Option) (*entities.Lockout, errors.ErrorInterface) {
+	lockout := &entities.Lockout{}
+
+	query := r.store.Engine.Where("scope = ? AND subject = ?", scope, subject)
+	r.applyOptions(query, options...)
+
+	result := query.First(lockout)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_user.ErrLockoutNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return lockout, nil
+}
+
+// FailLockout counts a failed sign-in of a credential or an IP and reads the tracking back
+// The database increments the count, so parallel sign-ins are all counted; the count restarts
+// when the last failure is older than since or when the previous lock has ended, which lifts it
+//
+// Parameters:
+// - scope: entities.LockoutScope - LockoutCredential or LockoutIP
+// - subject: string - The credential ID or the IP
+// - at: time.Time - The instant of the failure
+// - since: time.Time - The start of the policy window
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Lockout: The tracking with the stored count
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) FailLockout(scope entities.LockoutScope, subject string, at, since time.Time, options ...database.Option) (*entities.Lockout, errors.ErrorInterface) {
+	lockout := entities.NewLockout(scope, subject)
+	lockout.Failures = 1
+	lockout.LastFailureAt = &at
+
+	// The count is assigned first, MySQL evaluates the assignments in order on the updated row
+	query := r.store.Engine.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "subject"}},
+		DoUpdates: clause.Set{
+			{Column: clause.Column{Name: "failures"}, Value: gorm.Expr("CASE WHEN lockouts.last_failure_at < ? OR lockouts.locked_until <= ? THEN 1 ELSE lockouts.failures + 1 END", since, at)},
+			{Column: clause.Column{Name: "locked_until"}, Value: gorm.Expr("CASE WHEN lockouts.locked_until <= ? THEN NULL ELSE lockouts.locked_until END", at)},
+			{Column: clause.Column{Name: "last_failure_at"}, Value: at},
+			{Column: clause.Column{Name: "updated_at"}, Value: at},
+		},
+	})
+	r.applyOptions(query, options...)
+
+	if err := query.Create(lockout).Error; err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return r.ReadLockout(scope, subject)
+}
+
+// SaveLockout records the back-off or the lock decided from the stored count of a tracking
+// Only the instants set are written, so a concurrent failure never lifts a lock
+//
+// Parameters:
+// - entity: *entities.Lockout - The tracking read back by FailLockout
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) SaveLockout(entity *entities.Lockout, options ...database.Option) errors.ErrorInterface {
+	if entity.RetryAt == nil && entity.LockedUntil == nil {
+		return nil
+	}
+
+	query := r.store.Engine.Model(entity)
+	r.applyOptions(query, options...)
+
+	query = query.Updates(&entities.Lockout{RetryAt: entity.RetryAt, LockedUntil: entity.LockedUntil})
+
+	if query.Error != nil {
+		return errors.ErrInternalServer.Log(query.Error)
+	}
+
+	return nil
+}
+
+// DeleteLockout forgets the failed sign-ins of a credential or an IP, lifting its back-off or lock
+//
+// Parameters:
+// - scope: entities.LockoutScope - LockoutCredential or LockoutIP
+// - subject: string - The credential ID or the IP
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrLockoutNotFound if no failure was tracked
+func (r *UserRepository) DeleteLockout(scope entities.LockoutScope, subject string, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where("scope = ? AND subject = ?", scope, subject)
+	r.applyOptions(query, options...)
+
+	result := query.Delete(&entities.Lockout{})
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_user.ErrLockoutNotFound
+	}
+
+	return nil
+}
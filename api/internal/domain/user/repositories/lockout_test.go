@@ -0,0 +1,201 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadLockout(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	// Cas de lecture réussie
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "lockouts" WHERE scope = \$1 AND subject = \$2 ORDER BY "lockouts"\."id" LIMIT \$3`).
+			WithArgs(entities.LockoutIP, "192.0.2.1", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "scope", "subject", "failures"}).
+				AddRow("lockout-id", "ip", "192.0.2.1", 4))
+
+		lockout, err := repo.ReadLockout(entities.LockoutIP, "192.0.2.1")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "lockout-id", lockout.ID)
+		assert.Equal(t, 4, lockout.Failures)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'un sujet sans échec enregistré
+	t.Run("lockout not found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "lockouts"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		lockout, err := repo.ReadLockout(entities.LockoutCredential, "credential-id")
+
+		assert.Nil(t, lockout)
+		assert.Equal(t, errors_domain_user.ErrLockoutNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de la requête
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "lockouts"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		lockout, err := repo.ReadLockout(entities.LockoutCredential, "credential-id")
+
+		assert.Nil(t, lockout)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestFailLockout(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	now := time.Now()
+	since := now.Add(-15 * time.Minute)
+
+	// L'échec est compté par la base puis le suivi est relu avec le compteur enregistré
+	t.Run("successful increment", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "lockouts" .* ON CONFLICT \("scope","subject"\) DO UPDATE SET "failures"=CASE WHEN lockouts\.last_failure_at < \$\d+ OR lockouts\.locked_until <= \$\d+ THEN 1 ELSE lockouts\.failures \+ 1 END,"locked_until"=CASE WHEN lockouts\.locked_until <= \$\d+ THEN NULL ELSE lockouts\.locked_until END,"last_failure_at"=\$\d+,"updated_at"=\$\d+`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+		mock.ExpectQuery(`SELECT \* FROM "lockouts" WHERE scope = \$1 AND subject = \$2`).
+			WithArgs(entities.LockoutIP, "192.0.2.1", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "scope", "subject", "failures"}).
+				AddRow("lockout-id", "ip", "192.0.2.1", 7))
+
+		lockout, err := repo.FailLockout(entities.LockoutIP, "192.0.2.1", now, since)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "lockout-id", lockout.ID)
+		assert.Equal(t, 7, lockout.Failures)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de l'incrément
+	t.Run("increment failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "lockouts"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		lockout, err := repo.FailLockout(entities.LockoutIP, "192.0.2.1", now, since)
+
+		assert.Nil(t, lockout)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestSaveLockout(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	now := time.Now()
+
+	// Seuls le délai et le verrouillage sont écrits, jamais le compteur
+	t.Run("successful update", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "lockouts" SET "updated_at"=\$1,"locked_until"=\$2 WHERE "id" = \$3`).
+			WithArgs(sqlmock.AnyArg(), now, "lockout-id").
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		lockout := entities.NewLockout(entities.LockoutCredential, "credential-id")
+		lockout.ID = "lockout-id"
+		lockout.Failures = 5
+		lockout.LockedUntil = &now
+
+		err := repo.SaveLockout(lockout)
+
+		assert.Nil(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Sans délai ni verrouillage, rien n'est écrit
+	t.Run("nothing to save", func(t *testing.T) {
+		lockout := entities.NewLockout(entities.LockoutCredential, "credential-id")
+		lockout.ID = "lockout-id"
+		lockout.Failures = 1
+
+		err := repo.SaveLockout(lockout)
+
+		assert.Nil(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de l'enregistrement
+	t.Run("save failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "lockouts"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		lockout := entities.NewLockout(entities.LockoutIP, "192.0.2.1")
+		lockout.ID = "lockout-id"
+		lockout.RetryAt = &now
+
+		err := repo.SaveLockout(lockout)
+
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteLockout(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	// Cas de suppression réussie
+	t.Run("successful delete", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "lockouts" WHERE scope = \$1 AND subject = \$2`).
+			WithArgs(entities.LockoutCredential, "credential-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.DeleteLockout(entities.LockoutCredential, "credential-id")
+
+		assert.Nil(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'un sujet sans échec enregistré
+	t.Run("lockout not found", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "lockouts"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := repo.DeleteLockout(entities.LockoutIP, "192.0.2.1")
+
+		assert.Equal(t, errors_domain_user.ErrLockoutNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de la suppression
+	t.Run("delete failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "lockouts"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.DeleteLockout(entities.LockoutIP, "192.0.2.1")
+
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateOIDCRequest records a sign-in started with an external provider
+//
+// Parameters:
+// - entity: *entities.OIDCRequest - The sign-in to record
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) CreateOIDCRequest(entity *entities.OIDCRequest, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine
+	r.applyOptions(query, options...)
+
+	if result := query.Create(entity); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// ReadOIDCRequest reads a sign-in started with an external provider by its state
+//
+// Parameters:
+// - state: string - The state sent back by the provider
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.OIDCRequest: The sign-in
+// - errors.ErrorInterface: ErrOIDCStateInvalid if no sign-in has this state
+func (r *UserRepository) ReadOIDCRequest(state string, options ...database.Option) (*entities.OIDCRequest, errors.ErrorInterface) {
+	request := &entities.OIDCRequest{}
+
+	query := r.store.Engine.Where("state = ?", state)
+	r.applyOptions(query, options...)
+
+	result := query.First(request)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_user.ErrOIDCStateInvalid
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return request, nil
+}
+
+// DeleteOIDCRequest consumes a sign-in started with an external provider, so that its state is only used once
+//
+// Parameters:
+// - state: string - The state sent back by the provider
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrOIDCStateInvalid if the sign-in was already consumed
+func (r *UserRepository) DeleteOIDCRequest(state string, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where("state = ?", state)
+	r.applyOptions(query, options...)
+
+	result := query.Delete(&entities.OIDCRequest{})
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_user.ErrOIDCStateInvalid
+	}
+
+	return nil
+}
+
+// DeleteExpiredOIDCRequests forgets the sign-ins abandoned at the external provider
+//
+// Parameters:
+// - at: time.Time - The sign-ins expired at this instant are deleted
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) DeleteExpiredOIDCRequests(at time.Time, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where("expires_at <= ?", at)
+	r.applyOptions(query, options...)
+
+	if result := query.Delete(&entities.OIDCRequest{}); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// CreateIdentity links the account of a client at an external provider to its credential
+//
+// Parameters:
+// - entity: *entities.Identity - The link to record
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) CreateIdentity(entity *entities.Identity, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine
+	r.applyOptions(query, options...)
+
+	if result := query.Create(entity); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// ReadIdentity reads the link of an account at an external provider
+//
+// Parameters:
+// - provider: string - The name of the provider
+// - subject: string - The ID of the account at the provider
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.Identity: The link
+// - errors.ErrorInterface: ErrIdentityNotFound if the account is not linked yet
+func (r *UserRepository) ReadIdentity(provider, subject string, options ...database.Option) (*entities.Identity, errors.ErrorInterface) {
+	identity := &entities.Identity{}
+
+	query := r.store.Engine.Where("provider = ? AND subject = ?", provider, subject)
+	r.applyOptions(query, options...)
+
+	result := query.First(identity)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_user.ErrIdentityNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return identity, nil
+}
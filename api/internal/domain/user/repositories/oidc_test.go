@@ -0,0 +1,161 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOIDCRequest(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "oidc_requests"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		request := &entities.OIDCRequest{Provider: "google", State: "state", ExpiresAt: time.Now().Add(entities.OIDCRequestLifetime)}
+		err := repo.CreateOIDCRequest(request)
+
+		assert.Nil(t, err)
+		assert.NotEmpty(t, request.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "oidc_requests"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateOIDCRequest(&entities.OIDCRequest{State: "state"})
+
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadOIDCRequest(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "oidc_requests" WHERE state = \$1 ORDER BY "oidc_requests"\."id" LIMIT \$2`).
+			WithArgs("state", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "state", "nonce", "verifier"}).
+				AddRow("request-id", "google", "state", "nonce", "verifier"))
+
+		request, err := repo.ReadOIDCRequest("state")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "google", request.Provider)
+		assert.Equal(t, "verifier", request.Verifier)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "oidc_requests"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		request, err := repo.ReadOIDCRequest("other")
+
+		assert.Nil(t, request)
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDeleteOIDCRequest(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful delete", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "oidc_requests" WHERE state = \$1`).
+			WithArgs("state").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteOIDCRequest("state"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Un état déjà consommé ne peut plus servir
+	t.Run("already consumed", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "oidc_requests" WHERE state = \$1`).
+			WithArgs("state").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, repo.DeleteOIDCRequest("state"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		now := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "oidc_requests" WHERE expires_at <= \$1`).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteExpiredOIDCRequests(now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestIdentity(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "identities"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		identity := &entities.Identity{Provider: "google", Subject: "subject", CredentialID: aws.String("credential-id")}
+
+		assert.Nil(t, repo.CreateIdentity(identity))
+		assert.NotEmpty(t, identity.ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "identities" WHERE provider = \$1 AND subject = \$2 ORDER BY "identities"\."id" LIMIT \$3`).
+			WithArgs("google", "subject", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "provider", "subject", "credential_id"}).
+				AddRow("identity-id", "google", "subject", "credential-id"))
+
+		identity, err := repo.ReadIdentity("google", "subject")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "credential-id", *identity.CredentialID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not linked", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "identities"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		identity, err := repo.ReadIdentity("google", "other")
+
+		assert.Nil(t, identity)
+		assert.Equal(t, errors_domain_user.ErrIdentityNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CreateRefreshToken records a refresh token before it is signed
+//
+// Parameters:
+// - entity: *entities.RefreshToken - The token to record, a new session when it has no family
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) CreateRefreshToken(entity *entities.RefreshToken, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine
+	r.applyOptions(query, options...)
+
+	if result := query.Create(entity); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// ReadRefreshToken reads a refresh token by its jti
+//
+// Parameters:
+// - id: string - The jti claim of the token
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.RefreshToken: The token
+// - errors.ErrorInterface: ErrRefreshTokenNotFound if no token has this jti
+func (r *UserRepository) ReadRefreshToken(id string, options ...database.Option) (*entities.RefreshToken, errors.ErrorInterface) {
+	token := &entities.RefreshToken{}
+
+	query := r.store.Engine.Where("id = ?", id)
+	r.applyOptions(query, options...)
+
+	result := query.First(token)
+
+	if result.Error != nil {
+		if result.Error.Error() == "record not found" {
+			return nil, errors_domain_user.ErrRefreshTokenNotFound
+		}
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks a refresh token as exchanged, only one renewal can ever succeed with it
+//
+// Parameters:
+// - id: string - The jti claim of the token
+// - at: time.Time - The instant of the renewal
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrRefreshTokenReused if the token was already rotated or revoked meanwhile
+func (r *UserRepository) RotateRefreshToken(id string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(&entities.RefreshToken{}).Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id)
+	r.applyOptions(query, options...)
+
+	result := query.Update("rotated_at", at)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_user.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+// RevokeSession revokes every refresh token of a session of a credential
+//
+// Parameters:
+// - credentialID: string - The owner of the session
+// - family: string - The session
+// - at: time.Time - The instant of the revocation
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: ErrSessionNotFound if the credential has no such session left to revoke
+func (r *UserRepository) RevokeSession(credentialID, family string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(&entities.RefreshToken{}).Where("credential_id = ? AND family = ? AND revoked_at IS NULL", credentialID, family)
+	r.applyOptions(query, options...)
+
+	result := query.Update("revoked_at", at)
+
+	if result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors_domain_user.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// ReadSessions reads the current refresh token of each open session of a credential, the last used first
+//
+// Parameters:
+// - credentialID: string - The owner of the sessions
+// - at: time.Time - The sessions expired at this instant are left out
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*entities.RefreshToken: The sessions, empty when none is open
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) ReadSessions(credentialID string, at time.Time, options ...database.Option) ([]*entities.RefreshToken, errors.ErrorInterface) {
+	var tokens []*entities.RefreshToken
+
+	query := r.store.Engine.Where("credential_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?", credentialID, at).Order("last_used_at DESC")
+	r.applyOptions(query, options...)
+
+	if result := query.Find(&tokens); result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return tokens, nil
+}
+
+// RevokeSessions revokes every refresh token of a credential, signing it out everywhere
+//
+// Parameters:
+// - credentialID: string - The owner of the sessions
+// - at: time.Time - The instant of the revocation
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) RevokeSessions(credentialID string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Model(&entities.RefreshToken{}).Where("credential_id = ? AND revoked_at IS NULL", credentialID)
+	r.applyOptions(query, options...)
+
+	if result := query.Update("revoked_at", at); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
+
+// DeleteExpiredRefreshTokens forgets the refresh tokens their signature already refuses
+//
+// Parameters:
+// - at: time.Time - The tokens expired at this instant are deleted
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) DeleteExpiredRefreshTokens(at time.Time, options ...database.Option) errors.ErrorInterface {
+	query := r.store.Engine.Where("expires_at <= ?", at)
+	r.applyOptions(query, options...)
+
+	if result := query.Delete(&entities.RefreshToken{}); result.Error != nil {
+		return errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return nil
+}
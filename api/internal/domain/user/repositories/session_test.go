@@ -0,0 +1,203 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRefreshToken(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful create", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "refresh_tokens"`).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		token := &entities.RefreshToken{CredentialID: aws.String("credential-id"), ExpiresAt: time.Now().Add(time.Hour)}
+		err := repo.CreateRefreshToken(token)
+
+		assert.Nil(t, err)
+		assert.Equal(t, token.ID, token.Family)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("create failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "refresh_tokens"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		err := repo.CreateRefreshToken(&entities.RefreshToken{})
+
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadRefreshToken(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "refresh_tokens" WHERE id = \$1 ORDER BY "refresh_tokens"\."id" LIMIT \$2`).
+			WithArgs("token-id", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "family", "credential_id"}).
+				AddRow("token-id", "family-id", "credential-id"))
+
+		token, err := repo.ReadRefreshToken("token-id")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "family-id", token.Family)
+		assert.Equal(t, "credential-id", *token.CredentialID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "refresh_tokens"`).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		token, err := repo.ReadRefreshToken("other")
+
+		assert.Nil(t, token)
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	now := time.Now()
+
+	t.Run("successful rotation", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens" SET "rotated_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND rotated_at IS NULL AND revoked_at IS NULL`).
+			WithArgs(now, sqlmock.AnyArg(), "token-id").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.RotateRefreshToken("token-id", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Un jeton déjà échangé ne peut l'être une seconde fois
+	t.Run("already rotated", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenReused, repo.RotateRefreshToken("token-id", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestRevokeSessions(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	now := time.Now()
+
+	t.Run("one session", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens" SET "revoked_at"=\$1,"updated_at"=\$2 WHERE credential_id = \$3 AND family = \$4 AND revoked_at IS NULL`).
+			WithArgs(now, sqlmock.AnyArg(), "credential-id", "family-id").
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.RevokeSession("credential-id", "family-id", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Une session d'un autre compte, ou déjà révoquée, est introuvable
+	t.Run("unknown session", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens"`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		assert.Equal(t, errors_domain_user.ErrSessionNotFound, repo.RevokeSession("credential-id", "other-id", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("all sessions", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens" SET "revoked_at"=\$1,"updated_at"=\$2 WHERE credential_id = \$3 AND revoked_at IS NULL`).
+			WithArgs(now, sqlmock.AnyArg(), "credential-id").
+			WillReturnResult(sqlmock.NewResult(0, 5))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.RevokeSessions("credential-id", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("revoke failure", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE "refresh_tokens"`).
+			WillReturnError(fmt.Errorf("database error"))
+		mock.ExpectRollback()
+
+		assert.Equal(t, "common.internal_error", repo.RevokeSessions("credential-id", now).Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "refresh_tokens" WHERE expires_at <= \$1`).
+			WithArgs(now).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+
+		assert.Nil(t, repo.DeleteExpiredRefreshTokens(now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestReadSessions(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	now := time.Now()
+
+	t.Run("successful read", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "refresh_tokens" WHERE credential_id = \$1 AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > \$2 ORDER BY last_used_at DESC`).
+			WithArgs("credential-id", now).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "family", "device", "user_agent", "ip"}).
+				AddRow("token-1", "family-1", "Pixel 8", "okhttp/4.12", "192.0.2.1").
+				AddRow("token-2", "family-2", nil, "Mozilla/5.0", "192.0.2.2"))
+
+		sessions, err := repo.ReadSessions("credential-id", now)
+
+		assert.Nil(t, err)
+		assert.Len(t, sessions, 2)
+		assert.Equal(t, "Pixel 8", *sessions[0].Device)
+		assert.Nil(t, sessions[1].Device)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("read failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT \* FROM "refresh_tokens"`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		sessions, err := repo.ReadSessions("credential-id", now)
+
+		assert.Nil(t, sessions)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+)
+
+// CountClientsByPeriod counts the clients registered during the period, grouped by day or hour in UTC
+//
+// Parameters:
+// - period: *gameEntity.Period - The period to report on
+// - interval: string - database.Day or database.Hour
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - []*gameEntity.PeriodStatistic: One line per day or hour with at least one registration, in chronological order
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) CountClientsByPeriod(period *gameEntity.Period, interval string, options ...database.Option) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface) {
+	var statistics []*gameEntity.PeriodStatistic
+
+	query := r.store.Engine.Model(&entities.Client{}).
+		Select(database.Bucket(r.store.Engine, "created_at", interval) + " AS period, COUNT(*) AS count").
+		Group("period").
+		Order("period ASC")
+	database.Between("created_at", period.From, period.To)(query)
+	r.applyOptions(query, options...)
+
+	result := query.Scan(&statistics)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	return statistics, nil
+}
+
+// CountNewsletter counts the clients registered during the period and the newsletter subscribers among them
+//
+// Parameters:
+// - period: *gameEntity.Period - The period to report on
+// - options: ...database.Option - Additional options to customize the query
+//
+// Returns:
+// - *entities.NewsletterStatistic: The counts and the opt-in rate
+// - errors.ErrorInterface: The error interface if an error occurs
+func (r *UserRepository) CountNewsletter(period *gameEntity.Period, options ...database.Option) (*entities.NewsletterStatistic, errors.ErrorInterface) {
+	statistic := &entities.NewsletterStatistic{}
+
+	query := r.store.Engine.Model(&entities.Client{}).
+		Select("COUNT(*) AS clients, COALESCE(SUM(CASE WHEN newsletter THEN 1 ELSE 0 END), 0) AS subscribers")
+	database.Between("created_at", period.From, period.To)(query)
+	r.applyOptions(query, options...)
+
+	result := query.Scan(statistic)
+
+	if result.Error != nil {
+		return nil, errors.ErrInternalServer.Log(result.Error)
+	}
+
+	if statistic.Clients > 0 {
+		statistic.Rate = float64(statistic.Subscribers) / float64(statistic.Clients)
+	}
+
+	return statistic, nil
+}
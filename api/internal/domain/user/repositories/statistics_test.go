@@ -0,0 +1,96 @@
+package repositories_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountClientsByPeriod(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	// Cas de comptage réussi, un jour par ligne
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT to_char\(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD'\) AS period, COUNT\(\*\) AS count FROM "clients" WHERE created_at >= \$1 AND created_at < \$2 AND "clients"\."deleted_at" IS NULL GROUP BY "period" ORDER BY period ASC`).
+			WithArgs(from, to).
+			WillReturnRows(sqlmock.NewRows([]string{"period", "count"}).
+				AddRow("2024-10-01", 25).
+				AddRow("2024-10-02", 31))
+
+		statistics, err := repo.CountClientsByPeriod(&gameEntity.Period{From: &from, To: &to}, database.Day)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []*gameEntity.PeriodStatistic{{Period: "2024-10-01", Count: 25}, {Period: "2024-10-02", Count: 31}}, statistics)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de la requête
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT to_char`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		statistics, err := repo.CountClientsByPeriod(&gameEntity.Period{}, database.Hour)
+
+		assert.Nil(t, statistics)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountNewsletter(t *testing.T) {
+	// Initialisation du repository, du mock et de la base de données
+	repo, mock, db := setup()
+	defer db.Close()
+
+	from := time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	// Cas de comptage réussi, le taux est calculé à partir des deux compteurs
+	t.Run("successful count", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) AS clients, COALESCE\(SUM\(CASE WHEN newsletter THEN 1 ELSE 0 END\), 0\) AS subscribers FROM "clients" WHERE created_at >= \$1 AND "clients"\."deleted_at" IS NULL`).
+			WithArgs(from).
+			WillReturnRows(sqlmock.NewRows([]string{"clients", "subscribers"}).AddRow(200, 50))
+
+		statistic, err := repo.CountNewsletter(&gameEntity.Period{From: &from})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 200, statistic.Clients)
+		assert.Equal(t, 50, statistic.Subscribers)
+		assert.Equal(t, 0.25, statistic.Rate)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas sans client sur la période, le taux reste nul
+	t.Run("no client", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) AS clients`).
+			WillReturnRows(sqlmock.NewRows([]string{"clients", "subscribers"}).AddRow(0, 0))
+
+		statistic, err := repo.CountNewsletter(&gameEntity.Period{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 0, statistic.Clients)
+		assert.Equal(t, float64(0), statistic.Rate)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	// Cas d'échec de la requête
+	t.Run("count failure", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) AS clients`).
+			WillReturnError(fmt.Errorf("database error"))
+
+		statistic, err := repo.CountNewsletter(&gameEntity.Period{})
+
+		assert.Nil(t, statistic)
+		assert.Equal(t, "common.internal_error", err.Error())
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -1,8 +1,11 @@
 package repositories
 
 import (
+	"time"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
@@ -43,10 +46,37 @@ type UserRepositoryInterface interface {
 	ReadCredential(obj *transfert.Credential, options ...database.Option) (*entities.Credential, errors.ErrorInterface)
 	UpdateCredential(entity *entities.Credential, options ...database.Option) errors.ErrorInterface
 	DeleteCredential(obj *transfert.Credential, options ...database.Option) errors.ErrorInterface
+
+	// Lockout
+	ReadLockout(scope entities.LockoutScope, subject string, options ...database.Option) (*entities.Lockout, errors.ErrorInterface)
+	FailLockout(scope entities.LockoutScope, subject string, at, since time.Time, options ...database.Option) (*entities.Lockout, errors.ErrorInterface)
+	SaveLockout(entity *entities.Lockout, options ...database.Option) errors.ErrorInterface
+	DeleteLockout(scope entities.LockoutScope, subject string, options ...database.Option) errors.ErrorInterface
+
+	// OIDC
+	CreateOIDCRequest(entity *entities.OIDCRequest, options ...database.Option) errors.ErrorInterface
+	ReadOIDCRequest(state string, options ...database.Option) (*entities.OIDCRequest, errors.ErrorInterface)
+	DeleteOIDCRequest(state string, options ...database.Option) errors.ErrorInterface
+	DeleteExpiredOIDCRequests(at time.Time, options ...database.Option) errors.ErrorInterface
+	CreateIdentity(entity *entities.Identity, options ...database.Option) errors.ErrorInterface
+	ReadIdentity(provider, subject string, options ...database.Option) (*entities.Identity, errors.ErrorInterface)
+
+	// Session
+	CreateRefreshToken(entity *entities.RefreshToken, options ...database.Option) errors.ErrorInterface
+	ReadRefreshToken(id string, options ...database.Option) (*entities.RefreshToken, errors.ErrorInterface)
+	RotateRefreshToken(id string, at time.Time, options ...database.Option) errors.ErrorInterface
+	ReadSessions(credentialID string, at time.Time, options ...database.Option) ([]*entities.RefreshToken, errors.ErrorInterface)
+	RevokeSession(credentialID, family string, at time.Time, options ...database.Option) errors.ErrorInterface
+	RevokeSessions(credentialID string, at time.Time, options ...database.Option) errors.ErrorInterface
+	DeleteExpiredRefreshTokens(at time.Time, options ...database.Option) errors.ErrorInterface
+
+	// Statistics
+	CountClientsByPeriod(period *gameEntity.Period, interval string, options ...database.Option) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface)
+	CountNewsletter(period *gameEntity.Period, options ...database.Option) (*entities.NewsletterStatistic, errors.ErrorInterface)
 }
 
 func NewUserRepository(store *database.Database) *UserRepository {
-	store.Engine.AutoMigrate(entities.Client{}, entities.Employee{}, entities.Validation{}, entities.Credential{})
+	store.Engine.AutoMigrate(entities.Client{}, entities.Employee{}, entities.Validation{}, entities.Credential{}, entities.Lockout{}, entities.OIDCRequest{}, entities.Identity{}, entities.RefreshToken{})
 	return &UserRepository{store}
 }
 
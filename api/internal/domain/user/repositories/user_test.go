@@ -621,7 +621,7 @@ func TestCreateClient(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Insertion dans la table clients avec la colonne credential_id ajoutée
-		mock.ExpectExec(`INSERT INTO "clients" \("id","created_at","updated_at","deleted_at","credential_id","cgu","newsletter"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\)`).
+		mock.ExpectExec(`INSERT INTO "clients" \("id","created_at","updated_at","deleted_at","credential_id","cgu","newsletter","birth_date","address","postal_code","city","country","household"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,\$12,\$13\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID
 				sqlmock.AnyArg(), // CreatedAt
@@ -630,6 +630,12 @@ func TestCreateClient(t *testing.T) {
 				nil,              // CredentialID
 				true,             // CGU
 				false,            // Newsletter
+				nil,              // BirthDate
+				nil,              // Address
+				nil,              // PostalCode
+				nil,              // City
+				nil,              // Country
+				nil,              // Household
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		// Validation de la transaction
@@ -651,7 +657,7 @@ func TestCreateClient(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Corriger l'expression régulière pour inclure credential_id
-		mock.ExpectExec(`INSERT INTO "clients" \("id","created_at","updated_at","deleted_at","credential_id","cgu","newsletter"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7\)`).
+		mock.ExpectExec(`INSERT INTO "clients" \("id","created_at","updated_at","deleted_at","credential_id","cgu","newsletter","birth_date","address","postal_code","city","country","household"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,\$12,\$13\)`).
 			WithArgs(
 				sqlmock.AnyArg(), // ID (UUID)
 				sqlmock.AnyArg(), // CreatedAt
@@ -660,6 +666,12 @@ func TestCreateClient(t *testing.T) {
 				nil,              // CredentialID
 				true,             // CGU
 				false,            // Newsletter
+				nil,              // BirthDate
+				nil,              // Address
+				nil,              // PostalCode
+				nil,              // City
+				nil,              // Country
+				nil,              // Household
 			).WillReturnError(fmt.Errorf("some other error"))
 
 		mock.ExpectRollback()
@@ -844,7 +856,7 @@ func TestUpdateClient(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction : ajout de la colonne `credential_id` dans l'instruction SQL
-		mock.ExpectExec(`UPDATE "clients" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"cgu"=\$5,"newsletter"=\$6 WHERE "clients"\."deleted_at" IS NULL AND "id" = \$7`).
+		mock.ExpectExec(`UPDATE "clients" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"cgu"=\$5,"newsletter"=\$6,"birth_date"=\$7,"address"=\$8,"postal_code"=\$9,"city"=\$10,"country"=\$11,"household"=\$12 WHERE "clients"\."deleted_at" IS NULL AND "id" = \$13`).
 			WithArgs(
 				sqlmock.AnyArg(),  // created_at
 				sqlmock.AnyArg(),  // updated_at
@@ -852,6 +864,12 @@ func TestUpdateClient(t *testing.T) {
 				nil,               // credential_id
 				entity.CGU,        // mise à jour de CGU
 				entity.Newsletter, // mise à jour de la newsletter
+				nil,               // birth_date
+				nil,               // address
+				nil,               // postal_code
+				nil,               // city
+				nil,               // country
+				nil,               // household
 				entity.ID,         // ID du client
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1)) // Succès (1 ligne affectée)
@@ -868,7 +886,7 @@ func TestUpdateClient(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Correction : ajout de la colonne `credential_id`
-		mock.ExpectExec(`UPDATE "clients" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"cgu"=\$5,"newsletter"=\$6 WHERE "clients"\."deleted_at" IS NULL AND "id" = \$7`).
+		mock.ExpectExec(`UPDATE "clients" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"cgu"=\$5,"newsletter"=\$6,"birth_date"=\$7,"address"=\$8,"postal_code"=\$9,"city"=\$10,"country"=\$11,"household"=\$12 WHERE "clients"\."deleted_at" IS NULL AND "id" = \$13`).
 			WithArgs(
 				sqlmock.AnyArg(),  // created_at
 				sqlmock.AnyArg(),  // updated_at
@@ -876,6 +894,12 @@ func TestUpdateClient(t *testing.T) {
 				nil,               // credential_id
 				entity.CGU,        // mise à jour de CGU
 				entity.Newsletter, // mise à jour de la newsletter
+				nil,               // birth_date
+				nil,               // address
+				nil,               // postal_code
+				nil,               // city
+				nil,               // country
+				nil,               // household
 				entity.ID,         // ID du client
 			).WillReturnError(fmt.Errorf("some update error"))
 
@@ -959,13 +983,18 @@ func TestCreateEmployee(t *testing.T) {
 		mock.ExpectBegin()
 
 		// Insertion dans la table employees avec la colonne credential_id
-		mock.ExpectExec(`INSERT INTO "employees" \("id","created_at","updated_at","deleted_at","credential_id"\) VALUES \(\$1,\$2,\$3,\$4,\$5\)`).
+		mock.ExpectExec(`INSERT INTO "employees" \("id","created_at","updated_at","deleted_at","credential_id","auditor","totp_secret","totp_enabled","totp_step","recovery_codes"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10\)`).
 			WithArgs(
 				sqlmock.AnyArg(),  // ID (UUID)
 				sqlmock.AnyArg(),  // CreatedAt
 				sqlmock.AnyArg(),  // UpdatedAt
 				nil,               // DeletedAt
 				"credential-uuid", // CredentialID (mis à jour pour refléter la valeur correcte)
+				false,             // Auditor
+				nil,               // TOTPSecret
+				false,             // TOTPEnabled
+				0,                 // TOTPStep
+				sqlmock.AnyArg(),  // RecoveryCodes
 			).WillReturnResult(sqlmock.NewResult(1, 1))
 
 		mock.ExpectCommit()
@@ -984,13 +1013,18 @@ func TestCreateEmployee(t *testing.T) {
 	t.Run("error during creation", func(t *testing.T) {
 		mock.ExpectBegin()
 
-		mock.ExpectExec(`INSERT INTO "employees" \("id","created_at","updated_at","deleted_at","credential_id"\) VALUES \(\$1,\$2,\$3,\$4,\$5\)`).
+		mock.ExpectExec(`INSERT INTO "employees" \("id","created_at","updated_at","deleted_at","credential_id","auditor","totp_secret","totp_enabled","totp_step","recovery_codes"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10\)`).
 			WithArgs(
 				sqlmock.AnyArg(),  // ID (UUID)
 				sqlmock.AnyArg(),  // CreatedAt
 				sqlmock.AnyArg(),  // UpdatedAt
 				nil,               // DeletedAt
 				"credential-uuid", // CredentialID (mis à jour pour refléter la valeur correcte)
+				false,             // Auditor
+				nil,               // TOTPSecret
+				false,             // TOTPEnabled
+				0,                 // TOTPStep
+				sqlmock.AnyArg(),  // RecoveryCodes
 			).WillReturnError(fmt.Errorf("creation error"))
 
 		mock.ExpectRollback()
@@ -1063,12 +1097,17 @@ func TestUpdateEmployee(t *testing.T) {
 	t.Run("successful update", func(t *testing.T) {
 		mock.ExpectBegin()
 
-		mock.ExpectExec(`UPDATE "employees" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4 WHERE "employees"\."deleted_at" IS NULL AND "id" = \$5`).
+		mock.ExpectExec(`UPDATE "employees" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"auditor"=\$5,"totp_secret"=\$6,"totp_enabled"=\$7,"totp_step"=\$8,"recovery_codes"=\$9 WHERE "employees"\."deleted_at" IS NULL AND "id" = \$10`).
 			WithArgs(
 				sqlmock.AnyArg(),  // created_at
 				sqlmock.AnyArg(),  // updated_at
 				nil,               // deleted_at
 				"credential-uuid", // CredentialID
+				false,             // Auditor
+				nil,               // TOTPSecret
+				false,             // TOTPEnabled
+				0,                 // TOTPStep
+				sqlmock.AnyArg(),  // RecoveryCodes
 				entity.ID,         // ID de l'employé
 			).
 			WillReturnResult(sqlmock.NewResult(1, 1))
@@ -1084,12 +1123,17 @@ func TestUpdateEmployee(t *testing.T) {
 	t.Run("update failure", func(t *testing.T) {
 		mock.ExpectBegin()
 
-		mock.ExpectExec(`UPDATE "employees" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4 WHERE "employees"\."deleted_at" IS NULL AND "id" = \$5`).
+		mock.ExpectExec(`UPDATE "employees" SET "created_at"=\$1,"updated_at"=\$2,"deleted_at"=\$3,"credential_id"=\$4,"auditor"=\$5,"totp_secret"=\$6,"totp_enabled"=\$7,"totp_step"=\$8,"recovery_codes"=\$9 WHERE "employees"\."deleted_at" IS NULL AND "id" = \$10`).
 			WithArgs(
 				sqlmock.AnyArg(),  // created_at
 				sqlmock.AnyArg(),  // updated_at
 				nil,               // deleted_at
 				"credential-uuid", // CredentialID
+				false,             // Auditor
+				nil,               // TOTPSecret
+				false,             // TOTPEnabled
+				0,                 // TOTPStep
+				sqlmock.AnyArg(),  // RecoveryCodes
 				entity.ID,         // ID de l'employé
 			).WillReturnError(fmt.Errorf("update error"))
 
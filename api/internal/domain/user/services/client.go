@@ -14,6 +14,13 @@ func (s *UserService) RegisterClient(dtoCredential *transfert.Credential, dtoCli
 		return nil, errors.ErrNoDto
 	}
 
+	profile := entities.CreateClient(dtoClient)
+	if err := profile.CheckProfile(); err != nil {
+		return nil, err
+	}
+
+	dtoClient.Country = profile.Country
+
 	_, err := s.repo.ReadCredential(dtoCredential)
 	if err == nil {
 		return nil, errors_domain_user.ErrClientAlreadyExists
@@ -68,6 +75,10 @@ func (s *UserService) UpdateClient(dtoClient *transfert.Client) (*entities.Clien
 
 	data.UpdateEntityWithDto(client, dtoClient)
 
+	if err := client.CheckProfile(); err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.UpdateClient(client); err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/google/uuid"
@@ -67,6 +68,16 @@ func TestClientRegister(t *testing.T) {
 		require.Equal(t, errors.ErrNoDto, err)
 	})
 
+	t.Run("invalid profile", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Un pays de résidence qui n'est pas un code ISO est refusé avant toute écriture
+		client, err := service.RegisterClient(inputCredential, &transfert.Client{CGU: aws.Bool(true), Country: aws.String("France")})
+		assert.Nil(t, client)
+		assert.Equal(t, errors_domain_user.ErrClientInvalidCountry, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
 	t.Run("client already exists", func(t *testing.T) {
 		service, mockRepo, _, _, _ := setup()
 		dtoCredential := &transfert.Credential{Email: aws.String("existing@example.com")}
@@ -269,6 +280,42 @@ func TestUpdateClient(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockPerms.AssertExpectations(t)
 	})
+
+	t.Run("update client profile", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		mockClient := &entities.Client{ID: "42debee6-2063-4566-baf1-37a7bdd139ff"}
+
+		mockRepo.On("ReadClient", mock.AnythingOfType("*transfert.Client")).Return(mockClient, nil)
+		mockPerms.On("CanUpdate", mockClient, mock.Anything).Return(true)
+		mockRepo.On("UpdateClient", mockClient).Return(nil)
+
+		client, err := service.UpdateClient(&transfert.Client{
+			ID:        aws.String("valid-id"),
+			BirthDate: aws.String("1990-05-17"),
+			Country:   aws.String(" fr "),
+		})
+
+		// Le code pays est normalisé avant l'enregistrement
+		require.NoError(t, err)
+		assert.Equal(t, "1990-05-17", *client.BirthDate)
+		assert.Equal(t, "FR", *client.Country)
+	})
+
+	t.Run("update client invalid birth date", func(t *testing.T) {
+		for _, birthDate := range []string{"17/05/1990", time.Now().AddDate(1, 0, 0).Format(entities.BirthDateLayout)} {
+			service, mockRepo, _, mockPerms, _ := setup()
+			mockClient := &entities.Client{ID: "42debee6-2063-4566-baf1-37a7bdd139ff"}
+
+			mockRepo.On("ReadClient", mock.AnythingOfType("*transfert.Client")).Return(mockClient, nil)
+			mockPerms.On("CanUpdate", mockClient, mock.Anything).Return(true)
+
+			// Une date mal formée ou dans le futur est refusée
+			client, err := service.UpdateClient(&transfert.Client{ID: aws.String("valid-id"), BirthDate: aws.String(birthDate)})
+			assert.Nil(t, client)
+			assert.Equal(t, errors.ErrValueIsNotDate, err)
+			mockRepo.AssertNotCalled(t, "UpdateClient", mock.Anything)
+		}
+	})
 }
 
 func TestGetClient(t *testing.T) {
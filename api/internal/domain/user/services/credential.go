@@ -15,9 +15,32 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
 )
 
-func (s *UserService) UserAuth(dtoCredential *transfert.Credential) (*string, security.Role, errors.ErrorInterface) {
+// UserAuth checks the credentials of a client or an employee and returns its role
+// Failed sign-ins are tracked per account and per IP: they are slowed down by a growing back-off,
+// then locked for a while, and the hash is not even compared meanwhile
+// Employees with a second factor also give a TOTP or recovery code, without it the sign-in is only pending
+//
+// Returns:
+// - *string: The credential ID
+// - security.Role: The role of the account
+// - bool: true when the second factor is still to be given, or to be enrolled when the policy requires it
+// - errors.ErrorInterface: The error if the sign-in is refused
+func (s *UserService) UserAuth(dtoCredential *transfert.Credential) (*string, security.Role, bool, errors.ErrorInterface) {
 	if dtoCredential == nil {
-		return nil, "", errors.ErrNoDto
+		return nil, "", false, errors.ErrNoDto
+	}
+
+	policy := entities.NewLockoutPolicy()
+	now := time.Now()
+
+	// Une adresse IP verrouillée ne peut plus tenter de connexion, quel que soit le compte
+	var ipLockout *entities.Lockout
+	if dtoCredential.IP != nil && *dtoCredential.IP != "" {
+		lockout, err := s.readLockout(entities.LockoutIP, *dtoCredential.IP, now)
+		if err != nil {
+			return nil, "", false, err
+		}
+		ipLockout = lockout
 	}
 
 	// Lire les informations d'identification de l'utilisateur
@@ -26,27 +49,94 @@ func (s *UserService) UserAuth(dtoCredential *transfert.Credential) (*string, se
 	})
 
 	if err != nil {
-		return nil, "", err
+		if err == errors_domain_user.ErrCredentialNotFound {
+			s.failLockout(ipLockout, policy, now)
+		}
+		return nil, "", false, err
+	}
+
+	// Le hash n'est pas comparé pendant un délai ou un verrouillage
+	lockout, err := s.readLockout(entities.LockoutCredential, credential.ID, now)
+	if err != nil {
+		return nil, "", false, err
 	}
 
 	// Comparer les hashs si les credentials existent
 	if !credential.CompareHash(*dtoCredential.Password) {
-		return nil, "", errors_domain_user.ErrCredentialNotValid
+		s.failLockout(ipLockout, policy, now)
+
+		if s.failCredential(credential, lockout, policy, now) {
+			return nil, "", false, errors_domain_user.ErrCredentialLocked
+		}
+
+		return nil, "", false, errors_domain_user.ErrCredentialNotValid
 	}
 
-	client, _, err := s.repo.ReadUser(&transfert.User{
+	client, employee, err := s.repo.ReadUser(&transfert.User{
 		CredentialID: &credential.ID,
 	})
 
 	if err != nil {
-		return nil, "", errors_domain_user.ErrUserNotFound
+		return nil, "", false, errors_domain_user.ErrUserNotFound
 	}
 
-	if client != nil {
-		return &credential.ID, entities.ROLE_CLIENT, nil
+	role := entities.ROLE_CLIENT
+	pending := false
+
+	if client == nil && employee != nil {
+		role = employeeRole(employee)
+		pending, err = s.secondFactor(credential, employee, dtoCredential.TOTP, lockout, policy, now)
+		if err != nil {
+			return nil, "", false, err
+		}
+	}
+
+	// Une connexion complète efface les échecs du compte, pas ceux de l'adresse IP
+	if !pending {
+		if err := s.clearLockout(lockout); err != nil {
+			return nil, "", false, err
+		}
+	}
+
+	return &credential.ID, role, pending, nil
+}
+
+// failCredential records a failed sign-in of a credential and mails its owner when it gets locked
+//
+// Returns:
+// - bool: true when the failure locks the credential
+func (s *UserService) failCredential(credential *entities.Credential, lockout *entities.Lockout, policy *entities.LockoutPolicy, at time.Time) bool {
+	if !s.failLockout(lockout, policy, at) {
+		return false
+	}
+
+	if policy.Mail {
+		go s.notifyLockout(credential, lockout)
+	}
+
+	return true
+}
+
+// clearLockout forgets the failures of a tracking recorded in database
+func (s *UserService) clearLockout(lockout *entities.Lockout) errors.ErrorInterface {
+	if lockout == nil || lockout.ID == "" {
+		return nil
+	}
+
+	if err := s.repo.DeleteLockout(lockout.Scope, lockout.Subject); err != nil && err != errors_domain_user.ErrLockoutNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// employeeRole returns the role an employee signs in with
+func employeeRole(employee *entities.Employee) security.Role {
+	if employee.Auditor {
+		return entities.ROLE_AUDITOR
 	}
 
-	return &credential.ID, entities.ROLE_EMPLOYEE, nil
+	return entities.ROLE_EMPLOYEE
 }
 
 func (s *UserService) PasswordUpdate(dto *transfert.Credential) errors.ErrorInterface {
@@ -69,7 +69,7 @@ func TestUserAuth(t *testing.T) {
 			Return(nil, errors_domain_user.ErrCredentialNotFound)
 
 		// Appeler UserAuth avec un credential dont l'ID est nil (pour simuler un credential non trouvé)
-		user, userType, err := service.UserAuth(&transfert.Credential{
+		user, userType, _, err := service.UserAuth(&transfert.Credential{
 			Email:    aws.String("test@example.com"),
 			Password: aws.String("wrongpassword"),
 			ID:       nil, // L'ID est nil, car on cherche à simuler un credential non trouvé
@@ -88,7 +88,7 @@ func TestUserAuth(t *testing.T) {
 		service, mockRepo, _, _, _ := setup()
 
 		// Appeler le service avec un mot de passe incorrect
-		user, userType, err := service.UserAuth(nil)
+		user, userType, _, err := service.UserAuth(nil)
 
 		// Vérification que le user est nul et que l'erreur concerne un mot de passe incorrect
 		assert.Nil(t, user)
@@ -110,9 +110,14 @@ func TestUserAuth(t *testing.T) {
 		// Simuler un credential valide
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
 			Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("FailLockout", entities.LockoutCredential, mock.Anything).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Failures: 1}, nil)
+		mockRepo.On("SaveLockout", mock.AnythingOfType("*entities.Lockout")).Return(nil)
 
 		// Appeler le service avec un mot de passe incorrect
-		user, userType, err := service.UserAuth(&transfert.Credential{
+		user, userType, _, err := service.UserAuth(&transfert.Credential{
 			Email:    email,
 			Password: aws.String("wrongpassword"),
 		})
@@ -137,9 +142,14 @@ func TestUserAuth(t *testing.T) {
 		// Le mock retourne un credential valide
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
 			Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("FailLockout", entities.LockoutCredential, mock.Anything).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Failures: 1}, nil)
+		mockRepo.On("SaveLockout", mock.AnythingOfType("*entities.Lockout")).Return(nil)
 
 		// Appel du service avec un mot de passe incorrect
-		user, userType, err := service.UserAuth(&transfert.Credential{
+		user, userType, _, err := service.UserAuth(&transfert.Credential{
 			Email:    email,
 			Password: failpassword, // Mot de passe incorrect
 		})
@@ -158,12 +168,14 @@ func TestUserAuth(t *testing.T) {
 		mockRepo.On("ReadCredential", mock.MatchedBy(func(cred *transfert.Credential) bool {
 			return cred.Email != nil && *cred.Email == *email
 		})).Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
 
 		// Simuler que le user n'est pas trouvé
 		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, nil, errors_domain_user.ErrUserNotFound)
 
 		// Appel du service avec un credential valide
-		user, userType, err := service.UserAuth(inputCredential)
+		user, userType, _, err := service.UserAuth(inputCredential)
 
 		// Vérification des résultats
 		require.Error(t, err)
@@ -181,13 +193,15 @@ func TestUserAuth(t *testing.T) {
 		// Simuler un appel `ReadCredential` qui retourne le credential attendu
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
 			Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
 
 		// Simuler un user valide
 		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).
 			Return(expectedClient, nil, nil)
 
 		// Appel du service avec un credential valide
-		user, userType, err := service.UserAuth(inputCredential)
+		user, userType, _, err := service.UserAuth(inputCredential)
 
 		// Vérification des résultats
 		require.NoError(t, err)
@@ -204,13 +218,15 @@ func TestUserAuth(t *testing.T) {
 		// Simuler un appel `ReadCredential` qui retourne le credential attendu
 		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
 			Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
 
 		// Simuler un client valide
 		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).
 			Return(nil, expectedEmployee, nil)
 
 		// Appel du service avec un credential valide
-		user, userType, err := service.UserAuth(inputCredential)
+		user, userType, _, err := service.UserAuth(inputCredential)
 
 		// Vérification des résultats
 		require.NoError(t, err)
@@ -220,6 +236,27 @@ func TestUserAuth(t *testing.T) {
 		// Vérifier que les attentes sur le mock sont satisfaites
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("auditor found", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).
+			Return(expectedCredential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, mock.Anything).
+			Return(nil, errors_domain_user.ErrLockoutNotFound)
+
+		// Simuler un employé auditeur
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).
+			Return(nil, &entities.Employee{ID: clientID, Auditor: true}, nil)
+
+		user, userType, _, err := service.UserAuth(inputCredential)
+
+		require.NoError(t, err)
+		require.NotNil(t, user)
+		assert.Equal(t, entities.ROLE_AUDITOR, userType)
+
+		mockRepo.AssertExpectations(t)
+	})
 }
 
 func TestPasswordUpdate(t *testing.T) {
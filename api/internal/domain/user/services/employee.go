@@ -1,6 +1,7 @@
 package services
 
 import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
@@ -123,3 +124,47 @@ func (s *UserService) GetEmployee(dtoEmployee *transfert.Employee) (*entities.Em
 
 	return employee, nil
 }
+
+// SetAuditor grants or revokes the read-only auditor role of the employee signing in with the given email
+// The role is left out of the employee DTO, so that employees cannot grant it to themselves
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential The email of the employee
+// - auditor: bool Whether the employee signs in as an auditor from the next sign-in
+//
+// Returns:
+// - *entities.Employee: The updated employee
+// - errors.ErrorInterface: ErrEmployeeNotFound when the email does not belong to an employee
+func (s *UserService) SetAuditor(dtoCredential *transfert.Credential, auditor bool) (*entities.Employee, errors.ErrorInterface) {
+	if dtoCredential == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{
+		Email: dtoCredential.Email,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	employee, err := s.repo.ReadEmployee(&transfert.Employee{
+		CredentialID: &credential.ID,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	employee.Auditor = auditor
+
+	if err := s.repo.UpdateEmployee(employee); err != nil {
+		return nil, err
+	}
+
+	return employee, nil
+}
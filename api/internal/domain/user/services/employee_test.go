@@ -453,3 +453,60 @@ func TestUpdateEmployee(t *testing.T) {
 		mockPerms.AssertExpectations(t)
 	})
 }
+
+func TestSetAuditor(t *testing.T) {
+	dtoCredential := &transfert.Credential{Email: aws.String("bailiff@example.com")}
+	credential := &entities.Credential{ID: "credential-id"}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		employee, err := service.SetAuditor(nil, true)
+		assert.EqualError(t, err, errors.ErrNoDto.Error())
+		assert.Nil(t, employee)
+	})
+
+	t.Run("unauthorized role", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(false)
+
+		employee, err := service.SetAuditor(dtoCredential, true)
+		assert.EqualError(t, err, errors.ErrUnauthorized.Error())
+		assert.Nil(t, employee)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("employee not found", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadEmployee", mock.AnythingOfType("*transfert.Employee")).Return(nil, errors_domain_user.ErrEmployeeNotFound)
+
+		employee, err := service.SetAuditor(dtoCredential, true)
+		assert.Equal(t, errors_domain_user.ErrEmployeeNotFound, err)
+		assert.Nil(t, employee)
+		mockRepo.AssertNotCalled(t, "UpdateEmployee", mock.Anything)
+	})
+
+	t.Run("successful grant and revoke", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", []security.Role{security.ROLE_ADMIN}).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadEmployee", mock.MatchedBy(func(dto *transfert.Employee) bool {
+			return *dto.CredentialID == "credential-id"
+		})).Return(&entities.Employee{ID: "employee-id"}, nil)
+		mockRepo.On("UpdateEmployee", mock.AnythingOfType("*entities.Employee")).Return(nil)
+
+		employee, err := service.SetAuditor(dtoCredential, true)
+		require.Nil(t, err)
+		assert.True(t, employee.Auditor)
+
+		employee, err = service.SetAuditor(dtoCredential, false)
+		require.Nil(t, err)
+		assert.False(t, employee.Auditor)
+		mockRepo.AssertNumberOfCalls(t, "UpdateEmployee", 2)
+	})
+}
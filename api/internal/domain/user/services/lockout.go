@@ -0,0 +1,166 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/env"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+)
+
+// LockoutTemplate is the mail warning a client that its account got locked
+const LockoutTemplate = "lockout"
+
+// LockoutLayout formats the end of a lock in the mails
+const LockoutLayout = "02/01/2006 à 15:04"
+
+// Unlock lifts the back-off or the lock of an account, of an address, or of both
+//
+// Parameters:
+// - dtoLockout: *transfert.Lockout The email of the account and/or the IP to unlock
+//
+// Returns:
+// - errors.ErrorInterface: ErrCredentialNotFound for an unknown email, ErrLockoutNotFound when nothing was tracked
+func (s *UserService) Unlock(dtoLockout *transfert.Lockout) errors.ErrorInterface {
+	if dtoLockout == nil || (dtoLockout.Email == nil && dtoLockout.IP == nil) {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, entities.ROLE_EMPLOYEE) {
+		return errors.ErrUnauthorized
+	}
+
+	var targets []*entities.Lockout
+
+	if dtoLockout.Email != nil {
+		credential, err := s.repo.ReadCredential(&transfert.Credential{
+			Email: dtoLockout.Email,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		targets = append(targets, entities.NewLockout(entities.LockoutCredential, credential.ID))
+	}
+
+	if dtoLockout.IP != nil {
+		targets = append(targets, entities.NewLockout(entities.LockoutIP, *dtoLockout.IP))
+	}
+
+	unlocked := false
+	for _, target := range targets {
+		err := s.repo.DeleteLockout(target.Scope, target.Subject)
+		if err == nil {
+			unlocked = true
+		} else if err != errors_domain_user.ErrLockoutNotFound {
+			return err
+		}
+	}
+
+	if !unlocked {
+		return errors_domain_user.ErrLockoutNotFound
+	}
+
+	return nil
+}
+
+// readLockout reads the tracking of a credential or an IP and rejects the attempt during a back-off or a lock
+// A subject without failures gets a new tracking, only recorded on its first failure
+func (s *UserService) readLockout(scope entities.LockoutScope, subject string, at time.Time) (*entities.Lockout, errors.ErrorInterface) {
+	lockout, err := s.repo.ReadLockout(scope, subject)
+	if err == errors_domain_user.ErrLockoutNotFound {
+		return entities.NewLockout(scope, subject), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockout.Check(at); err != nil {
+		return nil, err
+	}
+
+	return lockout, nil
+}
+
+// failLockout records a failed sign-in against a tracking, nil trackings are ignored
+// The back-off or the lock is decided from the count stored by the database, refreshed into lockout
+// A failure to record it is logged and never hides the sign-in error
+//
+// Returns:
+// - bool: true when the failure locks the credential or the IP
+func (s *UserService) failLockout(lockout *entities.Lockout, policy *entities.LockoutPolicy, at time.Time) bool {
+	if lockout == nil {
+		return false
+	}
+
+	stored, err := s.repo.FailLockout(lockout.Scope, lockout.Subject, at, at.Add(-policy.Window))
+	if err != nil {
+		logger.Warn(err)
+		return false
+	}
+
+	*lockout = *stored
+	locked := lockout.Penalize(policy, at)
+
+	if err := s.repo.SaveLockout(lockout); err != nil {
+		logger.Warn(err)
+	}
+
+	return locked
+}
+
+// notifyLockout mails the owner of a locked account until when it is locked
+// Nothing is sent without mail service, a failure is logged
+func (s *UserService) notifyLockout(credential *entities.Credential, lockout *entities.Lockout) {
+	if s.mail == nil || credential.Email == nil || lockout.LockedUntil == nil {
+		return
+	}
+
+	if err := s.sendLockoutMail(credential, lockout); err != nil {
+		logger.Warn(err)
+	}
+}
+
+// sendLockoutMail renders the lockout template and sends it, with up to 3 attempts
+//
+// Returns:
+// - errors.ErrorInterface: ErrMailTemplateNotFound or ErrMailSendFailed
+func (s *UserService) sendLockoutMail(credential *entities.Credential, lockout *entities.Lockout) errors.ErrorInterface {
+	tpl := template.NewTemplate(LockoutTemplate)
+	if tpl == nil {
+		return errors.ErrMailTemplateNotFound
+	}
+
+	text, html, err := tpl.Inject(template.Data{
+		"AppName": env.APP_NAME,
+		"Until":   lockout.LockedUntil.Format(LockoutLayout),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	m := &mail.Mail{
+		To:      []string{*credential.Email},
+		Subject: "Votre compte est temporairement verrouillé",
+		Text:    text,
+		Html:    html,
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := s.mail.Send(m); err == nil {
+			return nil
+		}
+		time.Sleep(1 * time.Second)
+	}
+
+	return errors.ErrMailSendFailed
+}
@@ -0,0 +1,245 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAuthLockout(t *testing.T) {
+	email := aws.String("test@example.com")
+	password := aws.String("password123")
+	ip := aws.String("192.0.2.1")
+	hashedPassword, err := hash.Hash(aws.String(*email+":"+*password), hash.BCRYPT)
+	require.NoError(t, err)
+	credentialID := "42debee6-2063-4566-baf1-37a7bdd139f0"
+
+	credential := &entities.Credential{
+		ID:       credentialID,
+		Email:    email,
+		Password: hashedPassword,
+	}
+
+	t.Run("ip locked", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Une adresse verrouillée est refusée avant même la lecture du compte
+		until := time.Now().Add(time.Minute)
+		mockRepo.On("ReadLockout", entities.LockoutIP, *ip).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutIP, Subject: *ip, LockedUntil: &until}, nil)
+
+		id, role, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, IP: ip})
+
+		assert.Nil(t, id)
+		assert.Empty(t, role)
+		assert.Equal(t, errors_domain_user.ErrCredentialLocked, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Un email inconnu compte comme un échec de l'adresse
+		mockRepo.On("ReadLockout", entities.LockoutIP, *ip).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(nil, errors_domain_user.ErrCredentialNotFound)
+		mockRepo.On("FailLockout", entities.LockoutIP, *ip).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutIP, Subject: *ip, Failures: 1}, nil)
+		mockRepo.On("SaveLockout", mock.MatchedBy(func(lockout *entities.Lockout) bool {
+			return lockout.Scope == entities.LockoutIP && lockout.Subject == *ip && lockout.Failures == 1
+		})).Return(nil)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, IP: ip})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("credential throttled", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Pendant le délai, le mot de passe n'est pas comparé, même correct
+		retry := time.Now().Add(time.Minute)
+		mockRepo.On("ReadLockout", entities.LockoutIP, *ip).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 2, RetryAt: &retry}, nil)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, IP: ip})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialThrottled, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ReadUser", mock.Anything)
+	})
+
+	t.Run("credential gets locked", func(t *testing.T) {
+		service, mockRepo, mockMailer, _, _ := setup()
+
+		// Le dernier échec autorisé verrouille le compte, sans mail par défaut
+		mockRepo.On("ReadLockout", entities.LockoutIP, *ip).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 4}, nil)
+		mockRepo.On("FailLockout", entities.LockoutIP, *ip).
+			Return(&entities.Lockout{ID: "ip-lockout-id", Scope: entities.LockoutIP, Subject: *ip, Failures: 1}, nil)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 5}, nil)
+		mockRepo.On("SaveLockout", mock.MatchedBy(func(lockout *entities.Lockout) bool {
+			return lockout.Scope == entities.LockoutIP
+		})).Return(nil)
+		mockRepo.On("SaveLockout", mock.MatchedBy(func(lockout *entities.Lockout) bool {
+			return lockout.Scope == entities.LockoutCredential && lockout.LockedUntil != nil
+		})).Return(nil)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: aws.String("wrongpassword"), IP: ip})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialLocked, err)
+		mockRepo.AssertExpectations(t)
+		mockMailer.AssertNotCalled(t, "Send", mock.Anything)
+	})
+
+	t.Run("save failure", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// L'échec d'enregistrement ne masque pas l'erreur de connexion
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 2}, nil)
+		mockRepo.On("SaveLockout", mock.AnythingOfType("*entities.Lockout")).Return(errors.ErrInternalServer)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: aws.String("wrongpassword")})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotValid, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Sans compteur enregistré, aucun délai n'est décidé et l'erreur de connexion reste visible
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credentialID).Return(nil, errors.ErrInternalServer)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: aws.String("wrongpassword")})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotValid, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "SaveLockout", mock.Anything)
+	})
+
+	t.Run("parallel failures", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Le compteur lu avant la tentative est dépassé, le verrouillage se décide sur la valeur en base
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 1}, nil)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 5}, nil)
+		mockRepo.On("SaveLockout", mock.MatchedBy(func(lockout *entities.Lockout) bool {
+			return lockout.Failures == 5 && lockout.LockedUntil != nil
+		})).Return(nil)
+
+		_, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: aws.String("wrongpassword")})
+
+		assert.Equal(t, errors_domain_user.ErrCredentialLocked, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("success clears failures", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Une connexion réussie efface les échecs du compte
+		mockRepo.On("ReadLockout", entities.LockoutIP, *ip).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credentialID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credentialID, Failures: 1}, nil)
+		mockRepo.On("DeleteLockout", entities.LockoutCredential, credentialID).Return(nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(&entities.Client{ID: "client-id"}, nil, nil)
+
+		id, role, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, IP: ip})
+
+		require.Nil(t, err)
+		assert.Equal(t, credentialID, *id)
+		assert.Equal(t, entities.ROLE_CLIENT, role)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUnlock(t *testing.T) {
+	email := aws.String("test@example.com")
+	ip := aws.String("192.0.2.1")
+	credential := &entities.Credential{ID: "credential-id", Email: email}
+	roles := []security.Role{security.ROLE_ADMIN, entities.ROLE_EMPLOYEE}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		assert.Equal(t, errors.ErrNoDto, service.Unlock(nil))
+		assert.Equal(t, errors.ErrNoDto, service.Unlock(&transfert.Lockout{}))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.Unlock(&transfert.Lockout{Email: email}))
+		mockRepo.AssertNotCalled(t, "DeleteLockout", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unlock account and address", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		// L'adresse n'était pas suivie, le déverrouillage du compte suffit
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("DeleteLockout", entities.LockoutCredential, credential.ID).Return(nil)
+		mockRepo.On("DeleteLockout", entities.LockoutIP, *ip).Return(errors_domain_user.ErrLockoutNotFound)
+
+		assert.Nil(t, service.Unlock(&transfert.Lockout{Email: email, IP: ip}))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, service.Unlock(&transfert.Lockout{Email: email}))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nothing to unlock", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("DeleteLockout", entities.LockoutIP, *ip).Return(errors_domain_user.ErrLockoutNotFound)
+
+		assert.Equal(t, errors_domain_user.ErrLockoutNotFound, service.Unlock(&transfert.Lockout{IP: ip}))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("delete failure", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("DeleteLockout", entities.LockoutIP, *ip).Return(errors.ErrInternalServer)
+
+		assert.Equal(t, errors.ErrInternalServer, service.Unlock(&transfert.Lockout{IP: ip}))
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/password"
+)
+
+// StartOIDC prepares the sign-in of a client with an external OpenID Connect provider
+// The state, the nonce and the PKCE verifier are kept server side until the callback,
+// with the consents given in case the sign-in registers a new client
+//
+// Returns:
+// - *string: The URL of the provider to send the client to
+// - errors.ErrorInterface: ErrOIDCProviderUnknown for a provider missing from the configuration
+func (s *UserService) StartOIDC(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, errors.ErrorInterface) {
+	if dtoOIDC == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if provider == nil {
+		return nil, errors.ErrOIDCProviderUnknown
+	}
+
+	now := time.Now()
+
+	// Les connexions abandonnées chez le fournisseur sont oubliées
+	if err := s.repo.DeleteExpiredOIDCRequests(now); err != nil {
+		return nil, err
+	}
+
+	request := &entities.OIDCRequest{
+		Provider:   provider.Name(),
+		CGU:        dtoOIDC.CGU,
+		Newsletter: dtoOIDC.Newsletter,
+		ExpiresAt:  now.Add(entities.OIDCRequestLifetime),
+	}
+
+	for _, value := range []*string{&request.State, &request.Nonce, &request.Verifier} {
+		random, err := oidc.Random()
+		if err != nil {
+			return nil, err
+		}
+		*value = random
+	}
+
+	if err := s.repo.CreateOIDCRequest(request); err != nil {
+		return nil, err
+	}
+
+	url := provider.AuthCodeURL(request.State, request.Nonce, oidc.Challenge(request.Verifier))
+
+	return &url, nil
+}
+
+// OIDCAuth completes the sign-in of a client with an external provider
+// The account at the provider is found by its link, else linked to the credential with the same verified email,
+// else a client is registered with the consents given when the sign-in started
+//
+// Returns:
+// - *string: The credential ID
+// - security.Role: The client role
+// - errors.ErrorInterface: The error if the sign-in is refused
+func (s *UserService) OIDCAuth(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, security.Role, errors.ErrorInterface) {
+	if dtoOIDC == nil || dtoOIDC.Code == nil || dtoOIDC.State == nil {
+		return nil, "", errors.ErrNoDto
+	}
+
+	if provider == nil {
+		return nil, "", errors.ErrOIDCProviderUnknown
+	}
+
+	request, err := s.repo.ReadOIDCRequest(*dtoOIDC.State)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// L'état ne sert qu'une fois, même si la connexion échoue ensuite
+	if err := s.repo.DeleteOIDCRequest(request.State); err != nil {
+		return nil, "", err
+	}
+
+	if request.Provider != provider.Name() || request.HasExpired(time.Now()) {
+		return nil, "", errors_domain_user.ErrOIDCStateInvalid
+	}
+
+	idToken, err := provider.Exchange(*dtoOIDC.Code, request.Verifier)
+	if err != nil {
+		return nil, "", err
+	}
+
+	identity, err := provider.Verify(idToken, request.Nonce)
+	if err != nil {
+		return nil, "", err
+	}
+
+	credential, err := s.oidcCredential(provider.Name(), identity, request)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &credential.ID, entities.ROLE_CLIENT, nil
+}
+
+// oidcCredential finds the credential of an account at an external provider, linking or registering it the first time
+func (s *UserService) oidcCredential(provider string, identity *oidc.Identity, request *entities.OIDCRequest) (*entities.Credential, errors.ErrorInterface) {
+	link, err := s.repo.ReadIdentity(provider, identity.Subject)
+	if err == nil {
+		return s.repo.ReadCredential(&transfert.Credential{ID: link.CredentialID})
+	}
+
+	if err != errors_domain_user.ErrIdentityNotFound {
+		return nil, err
+	}
+
+	// Seule une adresse vérifiée par le fournisseur peut être rattachée à un compte
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, errors_domain_user.ErrOIDCEmailNotVerified
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{Email: &identity.Email})
+
+	switch err {
+	case nil:
+		client, _, err := s.repo.ReadUser(&transfert.User{CredentialID: &credential.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		// Les employés se connectent avec leur mot de passe et leur second facteur
+		if client == nil {
+			return nil, errors_domain_user.ErrOIDCNotClient
+		}
+	case errors_domain_user.ErrCredentialNotFound:
+		credential, err = s.registerOIDCClient(identity, request)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	if err := s.repo.CreateIdentity(&entities.Identity{
+		Provider:     provider,
+		Subject:      identity.Subject,
+		CredentialID: &credential.ID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
+
+// registerOIDCClient registers a client signing in with an external provider for the first time
+// Its email is already verified by the provider, its password stays random until it asks for a new one
+func (s *UserService) registerOIDCClient(identity *oidc.Identity, request *entities.OIDCRequest) (*entities.Credential, errors.ErrorInterface) {
+	if request.CGU == nil || !*request.CGU {
+		return nil, errors_domain_user.ErrOIDCConsentRequired
+	}
+
+	secret, perr := password.GeneratePassword(32, password.All)
+	if perr != nil {
+		return nil, errors.ErrInternalServer.Log(perr)
+	}
+
+	credential, err := s.repo.CreateCredential(&transfert.Credential{
+		Email:    &identity.Email,
+		Password: &secret,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	newsletter := request.Newsletter != nil && *request.Newsletter
+
+	client, err := s.repo.CreateClient(&transfert.Client{
+		CredentialID: &credential.ID,
+		CGU:          request.CGU,
+		Newsletter:   &newsletter,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	client.Validations = append(client.Validations, &entities.Validation{
+		ClientID:  &client.ID,
+		Type:      entities.MailValidation,
+		Validated: true,
+	})
+
+	if err := s.repo.UpdateClient(client); err != nil {
+		return nil, err
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,273 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// OIDCProviderMock simule un fournisseur OpenID Connect
+type OIDCProviderMock struct {
+	mock.Mock
+}
+
+func (m *OIDCProviderMock) Name() string {
+	return "google"
+}
+
+func (m *OIDCProviderMock) AuthCodeURL(state, nonce, challenge string) string {
+	args := m.Called(state, nonce, challenge)
+	return args.String(0)
+}
+
+func (m *OIDCProviderMock) Exchange(code, verifier string) (string, errors.ErrorInterface) {
+	args := m.Called(code, verifier)
+	if args.Get(1) == nil {
+		return args.String(0), nil
+	}
+	return "", args.Get(1).(errors.ErrorInterface)
+}
+
+func (m *OIDCProviderMock) Verify(idToken, nonce string) (*oidc.Identity, errors.ErrorInterface) {
+	args := m.Called(idToken, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*oidc.Identity), nil
+}
+
+func TestStartOIDC(t *testing.T) {
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		url, err := service.StartOIDC(new(OIDCProviderMock), nil)
+		assert.Nil(t, url)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		url, err := service.StartOIDC(nil, &transfert.OIDC{})
+		assert.Nil(t, url)
+		assert.Equal(t, errors.ErrOIDCProviderUnknown, err)
+	})
+
+	t.Run("started", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		provider := new(OIDCProviderMock)
+
+		var request *entities.OIDCRequest
+		mockRepo.On("DeleteExpiredOIDCRequests", mock.AnythingOfType("time.Time")).Return(nil)
+		mockRepo.On("CreateOIDCRequest", mock.MatchedBy(func(r *entities.OIDCRequest) bool {
+			request = r
+			return r.Provider == "google" && *r.CGU
+		})).Return(nil)
+		provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything).Return("https://accounts.example.com/authorize")
+
+		url, err := service.StartOIDC(provider, &transfert.OIDC{CGU: aws.Bool(true)})
+		require.Nil(t, err)
+		assert.Equal(t, "https://accounts.example.com/authorize", *url)
+
+		// Le vérificateur reste côté serveur, seul son défi est transmis
+		assert.Len(t, request.State, 43)
+		assert.NotEqual(t, request.State, request.Nonce)
+		assert.NotEqual(t, request.Nonce, request.Verifier)
+		assert.True(t, request.ExpiresAt.After(time.Now()))
+		provider.AssertCalled(t, "AuthCodeURL", request.State, request.Nonce, oidc.Challenge(request.Verifier))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestOIDCAuth(t *testing.T) {
+	state := "state"
+	code := "code"
+	email := "player@example.com"
+	credential := &entities.Credential{ID: "credential-id", Email: &email}
+	identity := &oidc.Identity{Subject: "subject", Email: email, EmailVerified: true}
+
+	request := func(cgu bool) *entities.OIDCRequest {
+		return &entities.OIDCRequest{
+			Provider:  "google",
+			State:     state,
+			Nonce:     "nonce",
+			Verifier:  "verifier",
+			CGU:       aws.Bool(cgu),
+			ExpiresAt: time.Now().Add(entities.OIDCRequestLifetime),
+		}
+	}
+
+	// callback prépare la consommation de l'état et l'échange du code
+	callback := func(r *entities.OIDCRequest, verified *oidc.Identity) (*OIDCProviderMock, *UserRepositoryMock, func() (*string, errors.ErrorInterface)) {
+		service, mockRepo, _, _, _ := setup()
+		provider := new(OIDCProviderMock)
+
+		mockRepo.On("ReadOIDCRequest", state).Return(r, nil)
+		mockRepo.On("DeleteOIDCRequest", state).Return(nil)
+		provider.On("Exchange", code, "verifier").Return("id-token", nil)
+		provider.On("Verify", "id-token", "nonce").Return(verified, nil)
+
+		return provider, mockRepo, func() (*string, errors.ErrorInterface) {
+			id, _, err := service.OIDCAuth(provider, &transfert.OIDC{Code: &code, State: &state})
+			return id, err
+		}
+	}
+
+	t.Run("no code", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		_, _, err := service.OIDCAuth(new(OIDCProviderMock), &transfert.OIDC{State: &state})
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		mockRepo.On("ReadOIDCRequest", state).Return(nil, errors_domain_user.ErrOIDCStateInvalid)
+
+		_, _, err := service.OIDCAuth(new(OIDCProviderMock), &transfert.OIDC{Code: &code, State: &state})
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, err)
+	})
+
+	t.Run("replayed state", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		provider := new(OIDCProviderMock)
+
+		// Deux retours simultanés du même état ne consomment la connexion qu'une fois
+		mockRepo.On("ReadOIDCRequest", state).Return(request(true), nil)
+		mockRepo.On("DeleteOIDCRequest", state).Return(errors_domain_user.ErrOIDCStateInvalid)
+
+		_, _, err := service.OIDCAuth(provider, &transfert.OIDC{Code: &code, State: &state})
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, err)
+		provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expired state", func(t *testing.T) {
+		expired := request(true)
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		provider, _, auth := callback(expired, identity)
+
+		_, err := auth()
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, err)
+		provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("other provider", func(t *testing.T) {
+		other := request(true)
+		other.Provider = "facebook"
+		provider, _, auth := callback(other, identity)
+
+		_, err := auth()
+		assert.Equal(t, errors_domain_user.ErrOIDCStateInvalid, err)
+		provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("exchange failure", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		provider := new(OIDCProviderMock)
+
+		mockRepo.On("ReadOIDCRequest", state).Return(request(true), nil)
+		mockRepo.On("DeleteOIDCRequest", state).Return(nil)
+		provider.On("Exchange", code, "verifier").Return("", errors.ErrOIDCExchangeFailed)
+
+		_, _, err := service.OIDCAuth(provider, &transfert.OIDC{Code: &code, State: &state})
+		assert.Equal(t, errors.ErrOIDCExchangeFailed, err)
+	})
+
+	t.Run("linked account", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(false), identity)
+
+		mockRepo.On("ReadIdentity", "google", "subject").
+			Return(&entities.Identity{Provider: "google", Subject: "subject", CredentialID: &credential.ID}, nil)
+		mockRepo.On("ReadCredential", &transfert.Credential{ID: &credential.ID}).Return(credential, nil)
+
+		id, err := auth()
+		require.Nil(t, err)
+		assert.Equal(t, credential.ID, *id)
+		mockRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything)
+	})
+
+	t.Run("link by verified email", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(false), identity)
+
+		mockRepo.On("ReadIdentity", "google", "subject").Return(nil, errors_domain_user.ErrIdentityNotFound)
+		mockRepo.On("ReadCredential", &transfert.Credential{Email: &email}).Return(credential, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: &credential.ID}).Return(&entities.Client{ID: "client-id"}, nil, nil)
+		mockRepo.On("CreateIdentity", mock.MatchedBy(func(i *entities.Identity) bool {
+			return i.Provider == "google" && i.Subject == "subject" && *i.CredentialID == credential.ID
+		})).Return(nil)
+
+		id, err := auth()
+		require.Nil(t, err)
+		assert.Equal(t, credential.ID, *id)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreateCredential", mock.Anything)
+	})
+
+	t.Run("unverified email", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(true), &oidc.Identity{Subject: "subject", Email: email})
+
+		mockRepo.On("ReadIdentity", "google", "subject").Return(nil, errors_domain_user.ErrIdentityNotFound)
+
+		_, err := auth()
+		assert.Equal(t, errors_domain_user.ErrOIDCEmailNotVerified, err)
+		mockRepo.AssertNotCalled(t, "ReadCredential", mock.Anything)
+	})
+
+	t.Run("employee", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(true), identity)
+
+		mockRepo.On("ReadIdentity", "google", "subject").Return(nil, errors_domain_user.ErrIdentityNotFound)
+		mockRepo.On("ReadCredential", &transfert.Credential{Email: &email}).Return(credential, nil)
+		mockRepo.On("ReadUser", &transfert.User{CredentialID: &credential.ID}).Return(nil, &entities.Employee{ID: "employee-id"}, nil)
+
+		_, err := auth()
+		assert.Equal(t, errors_domain_user.ErrOIDCNotClient, err)
+		mockRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything)
+	})
+
+	t.Run("register", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(true), identity)
+		client := &entities.Client{ID: "client-id"}
+
+		mockRepo.On("ReadIdentity", "google", "subject").Return(nil, errors_domain_user.ErrIdentityNotFound)
+		mockRepo.On("ReadCredential", &transfert.Credential{Email: &email}).Return(nil, errors_domain_user.ErrCredentialNotFound)
+		mockRepo.On("CreateCredential", mock.MatchedBy(func(c *transfert.Credential) bool {
+			return *c.Email == email && len(*c.Password) == 32
+		})).Return(credential, nil)
+		mockRepo.On("CreateClient", mock.MatchedBy(func(c *transfert.Client) bool {
+			return *c.CredentialID == credential.ID && *c.CGU && !*c.Newsletter
+		})).Return(client, nil)
+		mockRepo.On("UpdateClient", client).Return(nil)
+		mockRepo.On("CreateIdentity", mock.AnythingOfType("*entities.Identity")).Return(nil)
+
+		id, err := auth()
+		require.Nil(t, err)
+		assert.Equal(t, credential.ID, *id)
+
+		// L'adresse vérifiée par le fournisseur n'a pas à l'être une seconde fois
+		require.Len(t, client.Validations, 1)
+		assert.Equal(t, entities.MailValidation, client.Validations[0].Type)
+		assert.True(t, client.Validations[0].Validated)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("register without consent", func(t *testing.T) {
+		_, mockRepo, auth := callback(request(false), identity)
+
+		mockRepo.On("ReadIdentity", "google", "subject").Return(nil, errors_domain_user.ErrIdentityNotFound)
+		mockRepo.On("ReadCredential", &transfert.Credential{Email: &email}).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		_, err := auth()
+		assert.Equal(t, errors_domain_user.ErrOIDCConsentRequired, err)
+		mockRepo.AssertNotCalled(t, "CreateCredential", mock.Anything)
+	})
+}
@@ -2,12 +2,15 @@ package services
 
 import (
 	"github.com/kodmain/thetiptop/api/internal/application/security"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
 	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
 	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
 )
 
 type UserService struct {
@@ -23,11 +26,25 @@ func User(security security.PermissionInterface, repo repositories.UserRepositor
 
 type UserServiceInterface interface {
 	// Credential
-	UserAuth(dtoCredential *transfert.Credential) (*string, security.Role, errors.ErrorInterface)
+	UserAuth(dtoCredential *transfert.Credential) (*string, security.Role, bool, errors.ErrorInterface)
 	PasswordUpdate(dtoCredential *transfert.Credential) errors.ErrorInterface
 	ValidationRecover(dtoValidation *transfert.Validation, dtoClient *transfert.Credential) errors.ErrorInterface
 	PasswordValidation(dtoValidation *transfert.Validation, dtoClient *transfert.Credential) (*entities.Validation, errors.ErrorInterface)
 	MailValidation(dtoValidation *transfert.Validation, dtoClient *transfert.Credential) (*entities.Validation, errors.ErrorInterface)
+	Unlock(dtoLockout *transfert.Lockout) errors.ErrorInterface
+	EnrollTOTP() (*entities.TOTPEnrolment, errors.ErrorInterface)
+	ConfirmTOTP(dtoTOTP *transfert.TOTP) ([]string, errors.ErrorInterface)
+	VerifyTOTP(dtoTOTP *transfert.TOTP) (*string, security.Role, errors.ErrorInterface)
+	StartOIDC(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, errors.ErrorInterface)
+	OIDCAuth(provider oidc.ServiceInterface, dtoOIDC *transfert.OIDC) (*string, security.Role, errors.ErrorInterface)
+
+	// Session
+	OpenSession(credentialID string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface)
+	RenewSession(credentialID, jti string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface)
+	Logout() errors.ErrorInterface
+	ListSessions() ([]*entities.RefreshToken, errors.ErrorInterface)
+	RevokeSession(dtoSession *transfert.Session) errors.ErrorInterface
+	RevokeSessions(dtoCredential *transfert.Credential) errors.ErrorInterface
 
 	// Client
 	RegisterClient(dtoCredential *transfert.Credential, dtoClient *transfert.Client) (*entities.Client, errors.ErrorInterface)
@@ -41,4 +58,9 @@ type UserServiceInterface interface {
 	GetEmployee(dtoEmployee *transfert.Employee) (*entities.Employee, errors.ErrorInterface)
 	DeleteEmployee(dtoEmployee *transfert.Employee) errors.ErrorInterface
 	UpdateEmployee(Employee *transfert.Employee) (*entities.Employee, errors.ErrorInterface)
+	SetAuditor(dtoCredential *transfert.Credential, auditor bool) (*entities.Employee, errors.ErrorInterface)
+
+	// Statistics
+	GetRegistrationStatistics(dto *gameTransfert.Statistics) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface)
+	GetNewsletterStatistics(dto *gameTransfert.Statistics) (*entities.NewsletterStatistic, errors.ErrorInterface)
 }
@@ -1,6 +1,8 @@
 package services_test
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
@@ -168,6 +170,158 @@ func (m *UserRepositoryMock) DeleteCredential(credential *transfert.Credential,
 	return args.Get(0).(errors.ErrorInterface)
 }
 
+func (m *UserRepositoryMock) CountClientsByPeriod(period *gameEntity.Period, interval string, options ...database.Option) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface) {
+	args := m.Called(period, interval)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*gameEntity.PeriodStatistic), nil
+}
+
+func (m *UserRepositoryMock) CountNewsletter(period *gameEntity.Period, options ...database.Option) (*entities.NewsletterStatistic, errors.ErrorInterface) {
+	args := m.Called(period)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.NewsletterStatistic), nil
+}
+
+func (m *UserRepositoryMock) ReadLockout(scope entities.LockoutScope, subject string, options ...database.Option) (*entities.Lockout, errors.ErrorInterface) {
+	args := m.Called(scope, subject)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Lockout), nil
+}
+
+func (m *UserRepositoryMock) FailLockout(scope entities.LockoutScope, subject string, at, since time.Time, options ...database.Option) (*entities.Lockout, errors.ErrorInterface) {
+	args := m.Called(scope, subject)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Lockout), nil
+}
+
+func (m *UserRepositoryMock) SaveLockout(lockout *entities.Lockout, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(lockout)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) DeleteLockout(scope entities.LockoutScope, subject string, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(scope, subject)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) CreateOIDCRequest(request *entities.OIDCRequest, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) ReadOIDCRequest(state string, options ...database.Option) (*entities.OIDCRequest, errors.ErrorInterface) {
+	args := m.Called(state)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.OIDCRequest), nil
+}
+
+func (m *UserRepositoryMock) DeleteOIDCRequest(state string, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(state)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) DeleteExpiredOIDCRequests(at time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) CreateIdentity(identity *entities.Identity, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(identity)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) ReadIdentity(provider, subject string, options ...database.Option) (*entities.Identity, errors.ErrorInterface) {
+	args := m.Called(provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.Identity), nil
+}
+
+func (m *UserRepositoryMock) CreateRefreshToken(token *entities.RefreshToken, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) ReadRefreshToken(id string, options ...database.Option) (*entities.RefreshToken, errors.ErrorInterface) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).(*entities.RefreshToken), nil
+}
+
+func (m *UserRepositoryMock) RotateRefreshToken(id string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(id, at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) ReadSessions(credentialID string, at time.Time, options ...database.Option) ([]*entities.RefreshToken, errors.ErrorInterface) {
+	args := m.Called(credentialID, at)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(errors.ErrorInterface)
+	}
+	return args.Get(0).([]*entities.RefreshToken), nil
+}
+
+func (m *UserRepositoryMock) RevokeSession(credentialID, family string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(credentialID, family, at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) RevokeSessions(credentialID string, at time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(credentialID, at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
+func (m *UserRepositoryMock) DeleteExpiredRefreshTokens(at time.Time, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(at)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(errors.ErrorInterface)
+}
+
 type MailServiceMock struct {
 	mock.Mock
 }
@@ -209,6 +363,15 @@ func (m *PermissionMock) GetCredentialID() *string {
 	return args.Get(0).(*string)
 }
 
+func (m *PermissionMock) GetSessionID() *string {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*string)
+}
+
 func (m *PermissionMock) IsGrantedByRules(rules ...security.Rule) bool {
 	args := m.Called(rules)
 	return args.Bool(0)
@@ -313,6 +476,478 @@ func (m *GameRepositoryMock) CountTicket(obj *gameTransfert.Ticket, options ...d
 	return args.Int(0), nil
 }
 
+// UpdateTicketStatus simule le changement de statut d'un ticket.
+func (m *GameRepositoryMock) UpdateTicketStatus(entity *gameEntity.Ticket, history *gameTransfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketHistories simule la lecture de l'historique d'un ticket.
+func (m *GameRepositoryMock) ReadTicketHistories(obj *gameTransfert.TicketHistory, options ...database.Option) ([]*gameEntity.TicketHistory, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.TicketHistory), nil
+}
+
+// CreatePrize simule la création d'un lot.
+func (m *GameRepositoryMock) CreatePrize(obj *gameTransfert.Prize, options ...database.Option) (*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Prize), nil
+}
+
+// ReadPrize simule la lecture d'un lot.
+func (m *GameRepositoryMock) ReadPrize(obj *gameTransfert.Prize, options ...database.Option) (*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Prize), nil
+}
+
+// ReadPrizes simule la lecture du catalogue des lots.
+func (m *GameRepositoryMock) ReadPrizes(obj *gameTransfert.Prize, options ...database.Option) ([]*gameEntity.Prize, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Prize), nil
+}
+
+// UpdatePrize simule la mise à jour d'un lot.
+func (m *GameRepositoryMock) UpdatePrize(entity *gameEntity.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// DeletePrize simule la suppression d'un lot.
+func (m *GameRepositoryMock) DeletePrize(obj *gameTransfert.Prize, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkLegacyPrizes simule le rattachement des anciens tickets à leur lot.
+func (m *GameRepositoryMock) LinkLegacyPrizes() (int, errors.ErrorInterface) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDraw simule l'enregistrement d'un tirage.
+func (m *GameRepositoryMock) CreateDraw(entity *gameEntity.Draw, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDraw simule la lecture d'un tirage.
+func (m *GameRepositoryMock) ReadDraw(obj *gameTransfert.Draw, options ...database.Option) (*gameEntity.Draw, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Draw), nil
+}
+
+// ReadDrawParticipants simule la lecture des participants au tirage.
+func (m *GameRepositoryMock) ReadDrawParticipants(obj *gameTransfert.Ticket, options ...database.Option) ([]string, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]string), nil
+}
+
+// CreateCampaign simule la création d'une campagne.
+func (m *GameRepositoryMock) CreateCampaign(entity *gameEntity.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadCampaign simule la lecture d'une campagne.
+func (m *GameRepositoryMock) ReadCampaign(obj *gameTransfert.Campaign, options ...database.Option) (*gameEntity.Campaign, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Campaign), nil
+}
+
+// ReadCampaigns simule la lecture des campagnes.
+func (m *GameRepositoryMock) ReadCampaigns(options ...database.Option) ([]*gameEntity.Campaign, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Campaign), nil
+}
+
+// UpdateCampaign simule la mise à jour d'une campagne.
+func (m *GameRepositoryMock) UpdateCampaign(entity *gameEntity.Campaign, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// LinkOrphanTickets simule le rattachement des tickets sans campagne.
+func (m *GameRepositoryMock) LinkOrphanTickets(campaign *gameEntity.Campaign) (int, errors.ErrorInterface) {
+	args := m.Called(campaign)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateTicketTransfer simule l'enregistrement d'un transfert de ticket
+func (m *GameRepositoryMock) CreateTicketTransfer(entity *gameEntity.TicketTransfer, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketTransfer simule la lecture d'un transfert de ticket
+func (m *GameRepositoryMock) ReadTicketTransfer(obj *gameTransfert.TicketTransfer, options ...database.Option) (*gameEntity.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.TicketTransfer), nil
+}
+
+// CountTicketTransfers simule le comptage des transferts de tickets
+func (m *GameRepositoryMock) CountTicketTransfers(obj *gameTransfert.TicketTransfer, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// ReadTicketTransfers simule la lecture des transferts de tickets
+func (m *GameRepositoryMock) ReadTicketTransfers(obj *gameTransfert.TicketTransfer, options ...database.Option) ([]*gameEntity.TicketTransfer, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.TicketTransfer), nil
+}
+
+// UpdateTicketTransfer simule la réponse à un transfert de ticket
+func (m *GameRepositoryMock) UpdateTicketTransfer(entity *gameEntity.TicketTransfer, ticket *gameEntity.Ticket, history *gameTransfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ExpireTickets simule l'expiration d'un lot de tickets
+func (m *GameRepositoryMock) ExpireTickets(obj *gameTransfert.Ticket, from []gameEntity.TicketStatus, limit int, history *gameTransfert.TicketHistory, options ...database.Option) (map[string]int, errors.ErrorInterface) {
+	args := m.Called(obj, from, limit, history, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(map[string]int), nil
+}
+
+// CreateTicketExpiry simule l'enregistrement d'une expiration de tickets
+func (m *GameRepositoryMock) CreateTicketExpiry(entity *gameEntity.TicketExpiry, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadTicketExpiries simule la lecture des expirations de tickets
+func (m *GameRepositoryMock) ReadTicketExpiries(options ...database.Option) ([]*gameEntity.TicketExpiry, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.TicketExpiry), nil
+}
+
+// StreamLedger simule le parcours du registre par lots, les entrées fournies en second retour éventuel sont passées à fn
+func (m *GameRepositoryMock) StreamLedger(size int, fn func([]*gameEntity.LedgerEntry) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(size, fn, options)
+	if entries, ok := args.Get(len(args) - 1).([]*gameEntity.LedgerEntry); len(args) > 1 && ok {
+		if err := fn(entries); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// RecordTickets simule l'inscription au registre des tickets qui en sont absents
+func (m *GameRepositoryMock) RecordTickets(limit int) (int, errors.ErrorInterface) {
+	args := m.Called(limit)
+	if args.Get(1) == nil {
+		return args.Int(0), nil
+	}
+
+	return args.Int(0), args.Error(1).(errors.ErrorInterface)
+}
+
+// ReadHouseholdSlots simule la lecture des places prises par un foyer pendant une campagne
+func (m *GameRepositoryMock) ReadHouseholdSlots(campaignID, household string, options ...database.Option) ([]int, errors.ErrorInterface) {
+	args := m.Called(campaignID, household, options)
+	if args.Get(1) != nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]int), nil
+}
+
+// CreateBatch simule la création d'un lot d'export
+func (m *GameRepositoryMock) CreateBatch(entity *gameEntity.Batch, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadBatches simule la lecture des lots d'export
+func (m *GameRepositoryMock) ReadBatches(options ...database.Option) ([]*gameEntity.Batch, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.Batch), nil
+}
+
+// StreamTickets simule le parcours des tickets par lots, les tickets fournis en second retour éventuel sont passés à fn
+func (m *GameRepositoryMock) StreamTickets(obj *gameTransfert.Ticket, size int, fn func([]*gameEntity.Ticket) errors.ErrorInterface, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, size, fn, options)
+	if tickets, ok := args.Get(len(args) - 1).([]*gameEntity.Ticket); len(args) > 1 && ok {
+		if err := fn(tickets); err != nil {
+			return err
+		}
+	}
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// InsertTickets simule l'insertion d'un lot de tickets en ignorant les codes déjà pris
+func (m *GameRepositoryMock) InsertTickets(objs []*gameTransfert.Ticket, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(objs, options)
+	if args.Get(0) == nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CountTicketsByPrize simule le comptage des tickets par lot
+func (m *GameRepositoryMock) CountTicketsByPrize(period *gameEntity.Period, options ...database.Option) ([]*gameEntity.PrizeStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.PrizeStatistic), nil
+}
+
+// CountClaimsByPeriod simule le comptage des réclamations par jour ou par heure
+func (m *GameRepositoryMock) CountClaimsByPeriod(period *gameEntity.Period, interval string, options ...database.Option) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface) {
+	args := m.Called(period, interval, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.PeriodStatistic), nil
+}
+
+// CountStatusesByCaisse simule le comptage des réclamations et remises par caisse
+func (m *GameRepositoryMock) CountStatusesByCaisse(period *gameEntity.Period, options ...database.Option) ([]*gameEntity.StoreStatistic, errors.ErrorInterface) {
+	args := m.Called(period, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.StoreStatistic), nil
+}
+
+// CreateReceipt simule l'enregistrement d'un ticket de caisse.
+func (m *GameRepositoryMock) CreateReceipt(entity *gameEntity.Receipt, ticket *gameEntity.Ticket, history *gameTransfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadReceipt simule la lecture d'un ticket de caisse.
+func (m *GameRepositoryMock) ReadReceipt(obj *gameTransfert.Receipt, options ...database.Option) (*gameEntity.Receipt, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.Receipt), nil
+}
+
+// CreateClaimAttempt simule l'enregistrement d'une tentative de réclamation.
+func (m *GameRepositoryMock) CreateClaimAttempt(obj *gameTransfert.ClaimAttempt, failed bool, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(obj, failed, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimSignals simule le comptage des tentatives de réclamation récentes.
+func (m *GameRepositoryMock) ReadClaimSignals(obj *gameTransfert.ClaimAttempt, since time.Time, options ...database.Option) (*gameEntity.ClaimSignals, errors.ErrorInterface) {
+	args := m.Called(obj, since, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.ClaimSignals), nil
+}
+
+// CreateClaimReview simule la mise en attente d'une réclamation suspecte.
+func (m *GameRepositoryMock) CreateClaimReview(entity *gameEntity.ClaimReview, ticket *gameEntity.Ticket, history *gameTransfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadClaimReview simule la lecture d'une réclamation en attente.
+func (m *GameRepositoryMock) ReadClaimReview(obj *gameTransfert.ClaimReview, options ...database.Option) (*gameEntity.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).(*gameEntity.ClaimReview), nil
+}
+
+// ReadClaimReviews simule la lecture de la file des réclamations en attente.
+func (m *GameRepositoryMock) ReadClaimReviews(obj *gameTransfert.ClaimReview, options ...database.Option) ([]*gameEntity.ClaimReview, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.ClaimReview), nil
+}
+
+// UpdateClaimReview simule la décision sur une réclamation en attente.
+func (m *GameRepositoryMock) UpdateClaimReview(entity *gameEntity.ClaimReview, ticket *gameEntity.Ticket, history *gameTransfert.TicketHistory, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, ticket, history, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// CountTicketPools simule le comptage des tickets par lot d'une campagne.
+func (m *GameRepositoryMock) CountTicketPools(obj *gameTransfert.Ticket, options ...database.Option) ([]*gameEntity.TicketPool, errors.ErrorInterface) {
+	args := m.Called(obj, options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.TicketPool), nil
+}
+
+// CancelTickets simule l'annulation des tickets en réserve.
+func (m *GameRepositoryMock) CancelTickets(obj *gameTransfert.Ticket, limit int, history *gameTransfert.TicketHistory, options ...database.Option) (int, errors.ErrorInterface) {
+	args := m.Called(obj, limit, history, options)
+	if args.Error(1) != nil {
+		return 0, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Int(0), nil
+}
+
+// CreateDistributionChange simule l'enregistrement d'un changement de répartition.
+func (m *GameRepositoryMock) CreateDistributionChange(entity *gameEntity.DistributionChange, options ...database.Option) errors.ErrorInterface {
+	args := m.Called(entity, options)
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Error(0).(errors.ErrorInterface)
+}
+
+// ReadDistributionChanges simule la lecture des changements de répartition.
+func (m *GameRepositoryMock) ReadDistributionChanges(options ...database.Option) ([]*gameEntity.DistributionChange, errors.ErrorInterface) {
+	args := m.Called(options)
+	if args.Get(0) == nil {
+		return nil, args.Error(1).(errors.ErrorInterface)
+	}
+
+	return args.Get(0).([]*gameEntity.DistributionChange), nil
+}
+
 func setup() (*services.UserService, *UserRepositoryMock, *MailServiceMock, *PermissionMock, *GameRepositoryMock) {
 	mockRepository := new(UserRepositoryMock)
 	gameRepository := new(GameRepositoryMock)
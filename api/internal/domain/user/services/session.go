@@ -0,0 +1,214 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+)
+
+// OpenSession records the first refresh token of a sign-in, its ID is the jti and the session of the tokens to sign
+//
+// Parameters:
+// - credentialID: string The credential signed in
+// - dtoSession: *transfert.Session The device, user agent and IP of the sign-in, nil when unknown
+//
+// Returns:
+// - *entities.RefreshToken: The recorded token
+// - errors.ErrorInterface: The error if it could not be recorded
+func (s *UserService) OpenSession(credentialID string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface) {
+	if credentialID == "" {
+		return nil, errors.ErrNoDto
+	}
+
+	now := time.Now()
+
+	// Les jetons dont la signature a expiré n'ont plus à être suivis
+	if err := s.repo.DeleteExpiredRefreshTokens(now); err != nil {
+		return nil, err
+	}
+
+	token := entities.NewRefreshToken(&credentialID, nil, dtoSession, now, jwt.RefreshLifetime())
+
+	if err := s.repo.CreateRefreshToken(token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// RenewSession rotates a refresh token, the token is spent and the next one of its session is returned
+// A spent token presented again means it leaked, the whole session is then revoked
+//
+// Parameters:
+// - credentialID: string The owner of the token, as signed in it
+// - jti: string The jti claim of the token
+// - dtoSession: *transfert.Session The user agent and IP of the renewal, nil when unknown
+//
+// Returns:
+// - *entities.RefreshToken: The next token of the session
+// - errors.ErrorInterface: ErrRefreshTokenNotFound, ErrRefreshTokenRevoked or ErrRefreshTokenReused
+func (s *UserService) RenewSession(credentialID, jti string, dtoSession *transfert.Session) (*entities.RefreshToken, errors.ErrorInterface) {
+	// Les jetons signés avant le suivi des sessions n'ont pas de jti
+	if credentialID == "" || jti == "" {
+		return nil, errors_domain_user.ErrRefreshTokenNotFound
+	}
+
+	token, err := s.repo.ReadRefreshToken(jti)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.CredentialID == nil || *token.CredentialID != credentialID {
+		return nil, errors_domain_user.ErrRefreshTokenNotFound
+	}
+
+	if token.IsRevoked() {
+		return nil, errors_domain_user.ErrRefreshTokenRevoked
+	}
+
+	now := time.Now()
+	if token.HasExpired(now) {
+		return nil, errors.ErrAuthExpiredToken
+	}
+
+	if token.IsRotated() {
+		return nil, s.revokeReused(token, now)
+	}
+
+	if err := s.repo.RotateRefreshToken(token.ID, now); err != nil {
+		if err == errors_domain_user.ErrRefreshTokenReused {
+			return nil, s.revokeReused(token, now)
+		}
+		return nil, err
+	}
+
+	next := entities.NewRefreshToken(token.CredentialID, token, dtoSession, now, jwt.RefreshLifetime())
+
+	if err := s.repo.CreateRefreshToken(next); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// revokeReused closes the session of a token presented after its rotation
+//
+// Returns:
+// - errors.ErrorInterface: ErrRefreshTokenReused, or the error of the revocation
+func (s *UserService) revokeReused(token *entities.RefreshToken, at time.Time) errors.ErrorInterface {
+	logger.Warnf("refresh token %s reused, session %s revoked", token.ID, token.Family)
+
+	// La session a pu être révoquée entre-temps, par un autre renouvellement du même jeton
+	if err := s.repo.RevokeSession(*token.CredentialID, token.Family, at); err != nil && err != errors_domain_user.ErrSessionNotFound {
+		return err
+	}
+
+	return errors_domain_user.ErrRefreshTokenReused
+}
+
+// Logout revokes the session of the signed in user, its refresh tokens are refused from now on
+// Tokens signed before the sessions were tracked have none, there is nothing to revoke
+//
+// Returns:
+// - errors.ErrorInterface: ErrUnauthorized without signed in user
+func (s *UserService) Logout() errors.ErrorInterface {
+	credentialID := s.security.GetCredentialID()
+	if credentialID == nil {
+		return errors.ErrUnauthorized
+	}
+
+	session := s.security.GetSessionID()
+	if session == nil {
+		return nil
+	}
+
+	if err := s.repo.RevokeSession(*credentialID, *session, time.Now()); err != nil && err != errors_domain_user.ErrSessionNotFound {
+		return err
+	}
+
+	return nil
+}
+
+// ListSessions lists the open sessions of the signed in user, the last used first
+// The session of the token of the request is flagged as current
+//
+// Returns:
+// - []*entities.RefreshToken: The current token of each session
+// - errors.ErrorInterface: ErrUnauthorized without signed in user
+func (s *UserService) ListSessions() ([]*entities.RefreshToken, errors.ErrorInterface) {
+	credentialID := s.security.GetCredentialID()
+	if credentialID == nil {
+		return nil, errors.ErrUnauthorized
+	}
+
+	sessions, err := s.repo.ReadSessions(*credentialID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if current := s.security.GetSessionID(); current != nil {
+		for _, session := range sessions {
+			session.Current = session.Family == *current
+		}
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession signs the signed in user out of one of its other sessions, its refresh token is refused from now on
+// The current session is closed by Logout instead
+//
+// Parameters:
+// - dtoSession: *transfert.Session The ID of the session
+//
+// Returns:
+// - errors.ErrorInterface: ErrSessionCurrent for the current session, ErrSessionNotFound for a session of another user or already closed
+func (s *UserService) RevokeSession(dtoSession *transfert.Session) errors.ErrorInterface {
+	if dtoSession == nil || dtoSession.ID == nil {
+		return errors.ErrNoDto
+	}
+
+	credentialID := s.security.GetCredentialID()
+	if credentialID == nil {
+		return errors.ErrUnauthorized
+	}
+
+	if current := s.security.GetSessionID(); current != nil && *current == *dtoSession.ID {
+		return errors_domain_user.ErrSessionCurrent
+	}
+
+	return s.repo.RevokeSession(*credentialID, *dtoSession.ID, time.Now())
+}
+
+// RevokeSessions signs a user out of all its sessions, found by its email
+//
+// Parameters:
+// - dtoCredential: *transfert.Credential The email of the user
+//
+// Returns:
+// - errors.ErrorInterface: ErrUnauthorized for others than admins and employees, ErrCredentialNotFound for an unknown email
+func (s *UserService) RevokeSessions(dtoCredential *transfert.Credential) errors.ErrorInterface {
+	if dtoCredential == nil || dtoCredential.Email == nil {
+		return errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, entities.ROLE_EMPLOYEE) {
+		return errors.ErrUnauthorized
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{
+		Email: dtoCredential.Email,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return s.repo.RevokeSessions(credential.ID, time.Now())
+}
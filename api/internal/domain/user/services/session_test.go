@@ -0,0 +1,324 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOpenSession(t *testing.T) {
+	t.Run("no credential", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		token, err := service.OpenSession("", nil)
+
+		assert.Nil(t, token)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("new session", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("DeleteExpiredRefreshTokens", mock.AnythingOfType("time.Time")).Return(nil)
+		mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(token *entities.RefreshToken) bool {
+			return token.Family == "" && *token.CredentialID == "credential-id" && token.ExpiresAt.After(time.Now()) &&
+				*token.Device == "Pixel 8" && *token.IP == "192.0.2.1"
+		})).Return(nil)
+
+		token, err := service.OpenSession("credential-id", &transfert.Session{Device: aws.String("Pixel 8"), IP: aws.String("192.0.2.1")})
+
+		assert.Nil(t, err)
+		assert.NotNil(t, token)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("create failure", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("DeleteExpiredRefreshTokens", mock.AnythingOfType("time.Time")).Return(nil)
+		mockRepo.On("CreateRefreshToken", mock.Anything).Return(errors.ErrInternalServer)
+
+		token, err := service.OpenSession("credential-id", nil)
+
+		assert.Nil(t, token)
+		assert.Equal(t, errors.ErrInternalServer, err)
+	})
+}
+
+func TestRenewSession(t *testing.T) {
+	credentialID := "credential-id"
+
+	refreshToken := func() *entities.RefreshToken {
+		return &entities.RefreshToken{
+			ID:           "token-id",
+			Family:       "family-id",
+			CredentialID: aws.String(credentialID),
+			ExpiresAt:    time.Now().Add(time.Hour),
+			Device:       aws.String("Pixel 8"),
+			IP:           aws.String("192.0.2.1"),
+			StartedAt:    time.Now().Add(-time.Hour),
+		}
+	}
+
+	t.Run("legacy token", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Les jetons sans jti ne sont plus renouvelés
+		token, err := service.RenewSession(credentialID, "", nil)
+
+		assert.Nil(t, token)
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenNotFound, err)
+		mockRepo.AssertNotCalled(t, "ReadRefreshToken", mock.Anything)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadRefreshToken", "token-id").Return(nil, errors_domain_user.ErrRefreshTokenNotFound)
+
+		_, err := service.RenewSession(credentialID, "token-id", nil)
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenNotFound, err)
+	})
+
+	t.Run("other credential", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadRefreshToken", "token-id").Return(refreshToken(), nil)
+
+		_, err := service.RenewSession("other-id", "token-id", nil)
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenNotFound, err)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		token := refreshToken()
+		token.RevokedAt = aws.Time(time.Now())
+		mockRepo.On("ReadRefreshToken", "token-id").Return(token, nil)
+
+		_, err := service.RenewSession(credentialID, "token-id", nil)
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenRevoked, err)
+		mockRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reused", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Un jeton déjà échangé a fuité, toute sa session est révoquée
+		token := refreshToken()
+		token.RotatedAt = aws.Time(time.Now())
+		mockRepo.On("ReadRefreshToken", "token-id").Return(token, nil)
+		mockRepo.On("RevokeSession", credentialID, "family-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		_, err := service.RenewSession(credentialID, "token-id", nil)
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenReused, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+	})
+
+	t.Run("concurrent reuse", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Un autre renouvellement a échangé le jeton entre sa lecture et sa rotation
+		mockRepo.On("ReadRefreshToken", "token-id").Return(refreshToken(), nil)
+		mockRepo.On("RotateRefreshToken", "token-id", mock.AnythingOfType("time.Time")).Return(errors_domain_user.ErrRefreshTokenReused)
+		mockRepo.On("RevokeSession", credentialID, "family-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		_, err := service.RenewSession(credentialID, "token-id", nil)
+
+		assert.Equal(t, errors_domain_user.ErrRefreshTokenReused, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		mockRepo.On("ReadRefreshToken", "token-id").Return(refreshToken(), nil)
+		mockRepo.On("RotateRefreshToken", "token-id", mock.AnythingOfType("time.Time")).Return(nil)
+		// L'appareil et le début de la session sont conservés, l'adresse suit la dernière utilisation
+		mockRepo.On("CreateRefreshToken", mock.MatchedBy(func(token *entities.RefreshToken) bool {
+			return token.Family == "family-id" && *token.CredentialID == credentialID &&
+				*token.Device == "Pixel 8" && *token.IP == "192.0.2.9" && token.StartedAt.Before(token.LastUsedAt)
+		})).Return(nil)
+
+		next, err := service.RenewSession(credentialID, "token-id", &transfert.Session{Device: aws.String("Other"), IP: aws.String("192.0.2.9")})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "family-id", next.Family)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("not signed in", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(nil)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.Logout())
+	})
+
+	t.Run("legacy token", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(nil)
+
+		assert.Nil(t, service.Logout())
+		mockRepo.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("already revoked", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-id"))
+		mockRepo.On("RevokeSession", "credential-id", "family-id", mock.AnythingOfType("time.Time")).Return(errors_domain_user.ErrSessionNotFound)
+
+		assert.Nil(t, service.Logout())
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-id"))
+		mockRepo.On("RevokeSession", "credential-id", "family-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		assert.Nil(t, service.Logout())
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRevokeSessions(t *testing.T) {
+	email := aws.String("test@example.com")
+	roles := []security.Role{security.ROLE_ADMIN, entities.ROLE_EMPLOYEE}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		assert.Equal(t, errors.ErrNoDto, service.RevokeSessions(nil))
+		assert.Equal(t, errors.ErrNoDto, service.RevokeSessions(&transfert.Credential{}))
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(false)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.RevokeSessions(&transfert.Credential{Email: email}))
+		mockRepo.AssertNotCalled(t, "RevokeSessions", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(nil, errors_domain_user.ErrCredentialNotFound)
+
+		assert.Equal(t, errors_domain_user.ErrCredentialNotFound, service.RevokeSessions(&transfert.Credential{Email: email}))
+	})
+
+	t.Run("revoke all", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", roles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(&entities.Credential{ID: "credential-id", Email: email}, nil)
+		mockRepo.On("RevokeSessions", "credential-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		assert.Nil(t, service.RevokeSessions(&transfert.Credential{Email: email}))
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestListSessions(t *testing.T) {
+	t.Run("not signed in", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(nil)
+
+		sessions, err := service.ListSessions()
+
+		assert.Nil(t, sessions)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("current flagged", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-2"))
+		mockRepo.On("ReadSessions", "credential-id", mock.AnythingOfType("time.Time")).Return([]*entities.RefreshToken{
+			{ID: "token-1", Family: "family-1"},
+			{ID: "token-2", Family: "family-2"},
+		}, nil)
+
+		sessions, err := service.ListSessions()
+
+		assert.Nil(t, err)
+		assert.Len(t, sessions, 2)
+		assert.False(t, sessions[0].Current)
+		assert.True(t, sessions[1].Current)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		assert.Equal(t, errors.ErrNoDto, service.RevokeSession(nil))
+		assert.Equal(t, errors.ErrNoDto, service.RevokeSession(&transfert.Session{}))
+	})
+
+	t.Run("not signed in", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(nil)
+
+		assert.Equal(t, errors.ErrUnauthorized, service.RevokeSession(&transfert.Session{ID: aws.String("family-id")}))
+	})
+
+	t.Run("current session", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		// La session courante se ferme par la déconnexion
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-id"))
+
+		assert.Equal(t, errors_domain_user.ErrSessionCurrent, service.RevokeSession(&transfert.Session{ID: aws.String("family-id")}))
+		mockRepo.AssertNotCalled(t, "RevokeSession", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown session", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-id"))
+		mockRepo.On("RevokeSession", "credential-id", "other-id", mock.AnythingOfType("time.Time")).Return(errors_domain_user.ErrSessionNotFound)
+
+		assert.Equal(t, errors_domain_user.ErrSessionNotFound, service.RevokeSession(&transfert.Session{ID: aws.String("other-id")}))
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(aws.String("credential-id"))
+		mockPerms.On("GetSessionID").Return(aws.String("family-id"))
+		mockRepo.On("RevokeSession", "credential-id", "other-id", mock.AnythingOfType("time.Time")).Return(nil)
+
+		assert.Nil(t, service.RevokeSession(&transfert.Session{ID: aws.String("other-id")}))
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// statisticsPeriod checks that the caller may read statistics and reads the requested period
+func (s *UserService) statisticsPeriod(dto *gameTransfert.Statistics) (*gameEntity.Period, errors.ErrorInterface) {
+	if dto == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	if !s.security.IsGrantedByRoles(security.ROLE_ADMIN, entities.ROLE_EMPLOYEE) {
+		return nil, errors.ErrUnauthorized
+	}
+
+	return gameEntity.NewPeriod(dto.From, dto.To)
+}
+
+func (s *UserService) GetRegistrationStatistics(dto *gameTransfert.Statistics) ([]*gameEntity.PeriodStatistic, errors.ErrorInterface) {
+	period, err := s.statisticsPeriod(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := gameEntity.NewInterval(dto.Interval)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CountClientsByPeriod(period, interval)
+}
+
+func (s *UserService) GetNewsletterStatistics(dto *gameTransfert.Statistics) (*entities.NewsletterStatistic, errors.ErrorInterface) {
+	period, err := s.statisticsPeriod(dto)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.CountNewsletter(period)
+}
@@ -0,0 +1,85 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	errors_domain_game "github.com/kodmain/thetiptop/api/internal/domain/game/errors"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var statisticsRoles = []security.Role{security.ROLE_ADMIN, entities.ROLE_EMPLOYEE}
+
+func TestGetRegistrationStatistics(t *testing.T) {
+	t.Run("nil dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		statistics, err := service.GetRegistrationStatistics(nil)
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("unauthorized role", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(false)
+
+		statistics, err := service.GetRegistrationStatistics(&gameTransfert.Statistics{})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+		mockRepo.AssertNotCalled(t, "CountClientsByPeriod", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+
+		statistics, err := service.GetRegistrationStatistics(&gameTransfert.Statistics{Interval: aws.String("month")})
+		assert.Nil(t, statistics)
+		assert.Equal(t, errors_domain_game.ErrStatisticsInvalidInterval, err)
+	})
+
+	t.Run("count registrations by hour", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("CountClientsByPeriod", mock.Anything, database.Hour).Return([]*gameEntity.PeriodStatistic{{Period: "2024-10-01 09:00", Count: 3}}, nil)
+
+		statistics, err := service.GetRegistrationStatistics(&gameTransfert.Statistics{From: aws.String("2024-10-01"), Interval: aws.String("hour")})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, statistics[0].Count)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetNewsletterStatistics(t *testing.T) {
+	t.Run("invalid period", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+
+		statistic, err := service.GetNewsletterStatistics(&gameTransfert.Statistics{From: aws.String("2024-10-31"), To: aws.String("2024-10-01")})
+		assert.Nil(t, statistic)
+		assert.Equal(t, errors_domain_game.ErrStatisticsInvalidPeriod, err)
+	})
+
+	t.Run("count subscribers", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("IsGrantedByRoles", statisticsRoles).Return(true)
+		mockRepo.On("CountNewsletter", &gameEntity.Period{}).Return(&entities.NewsletterStatistic{Clients: 4, Subscribers: 1, Rate: 0.25}, nil)
+
+		statistic, err := service.GetNewsletterStatistics(&gameTransfert.Statistics{})
+		assert.Nil(t, err)
+		assert.Equal(t, 0.25, statistic.Rate)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/totp"
+)
+
+// EnrollTOTP starts the enrolment of the second factor of the signed in employee with a new secret
+// An enrolment not confirmed yet is replaced, the second factor is only required once confirmed
+//
+// Returns:
+// - *entities.TOTPEnrolment: The secret and its otpauth URI
+// - errors.ErrorInterface: ErrTOTPAlreadyEnabled once confirmed, ErrUnauthorized for other accounts
+func (s *UserService) EnrollTOTP() (*entities.TOTPEnrolment, errors.ErrorInterface) {
+	credential, employee, err := s.signedEmployee()
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.TOTPEnabled {
+		return nil, errors_domain_user.ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	employee.EnrollTOTP(secret)
+
+	if err := s.repo.UpdateEmployee(employee); err != nil {
+		return nil, err
+	}
+
+	account := credential.ID
+	if credential.Email != nil {
+		account = *credential.Email
+	}
+
+	return &entities.TOTPEnrolment{
+		Secret: secret,
+		URI:    totp.URI(entities.NewTOTPPolicy().Issuer, account, secret),
+	}, nil
+}
+
+// ConfirmTOTP enables the second factor of the signed in employee with a first code of its authenticator app
+//
+// Parameters:
+// - dtoTOTP: *transfert.TOTP The code shown by the app
+//
+// Returns:
+// - []string: The recovery codes, only shown once
+// - errors.ErrorInterface: ErrTOTPNotEnrolled, ErrTOTPAlreadyEnabled or ErrTOTPInvalid
+func (s *UserService) ConfirmTOTP(dtoTOTP *transfert.TOTP) ([]string, errors.ErrorInterface) {
+	if dtoTOTP == nil || dtoTOTP.Code == nil {
+		return nil, errors.ErrNoDto
+	}
+
+	_, employee, err := s.signedEmployee()
+	if err != nil {
+		return nil, err
+	}
+
+	if employee.TOTPEnabled {
+		return nil, errors_domain_user.ErrTOTPAlreadyEnabled
+	}
+
+	codes, err := employee.ConfirmTOTP(*dtoTOTP.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateEmployee(employee); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// VerifyTOTP completes a sign-in left pending for the second factor of the signed in employee
+// Wrong codes count as failed sign-ins of the credential and end up locking it
+//
+// Parameters:
+// - dtoTOTP: *transfert.TOTP The TOTP code or a recovery code
+//
+// Returns:
+// - *string: The credential ID
+// - security.Role: The role to issue the tokens with
+// - errors.ErrorInterface: ErrTOTPInvalid, ErrCredentialLocked or ErrTOTPNotEnrolled
+func (s *UserService) VerifyTOTP(dtoTOTP *transfert.TOTP) (*string, security.Role, errors.ErrorInterface) {
+	if dtoTOTP == nil || dtoTOTP.Code == nil {
+		return nil, "", errors.ErrNoDto
+	}
+
+	credential, employee, err := s.signedEmployee()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if !employee.TOTPEnabled {
+		return nil, "", errors_domain_user.ErrTOTPNotEnrolled
+	}
+
+	policy := entities.NewLockoutPolicy()
+	now := time.Now()
+
+	lockout, err := s.readLockout(entities.LockoutCredential, credential.ID, now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.checkTOTP(credential, employee, *dtoTOTP.Code, lockout, policy, now); err != nil {
+		return nil, "", err
+	}
+
+	if err := s.clearLockout(lockout); err != nil {
+		return nil, "", err
+	}
+
+	// La session limitée au second facteur est remplacée par celle des jetons délivrés ensuite
+	if err := s.Logout(); err != nil {
+		return nil, "", err
+	}
+
+	return &credential.ID, employeeRole(employee), nil
+}
+
+// secondFactor checks the second factor given at sign-in by an employee
+//
+// Returns:
+// - bool: true when the sign-in stays pending, no code given or no second factor while the policy requires one
+// - errors.ErrorInterface: ErrTOTPInvalid or ErrCredentialLocked for a wrong code
+func (s *UserService) secondFactor(credential *entities.Credential, employee *entities.Employee, code *string, lockout *entities.Lockout, policy *entities.LockoutPolicy, at time.Time) (bool, errors.ErrorInterface) {
+	if !employee.TOTPEnabled {
+		return entities.NewTOTPPolicy().Required, nil
+	}
+
+	if code == nil || *code == "" {
+		return true, nil
+	}
+
+	if err := s.checkTOTP(credential, employee, *code, lockout, policy, at); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// checkTOTP checks a code of an employee and saves the accepted period or the consumed recovery code
+func (s *UserService) checkTOTP(credential *entities.Credential, employee *entities.Employee, code string, lockout *entities.Lockout, policy *entities.LockoutPolicy, at time.Time) errors.ErrorInterface {
+	if err := employee.CheckTOTP(code, at); err != nil {
+		if err == errors_domain_user.ErrTOTPInvalid && s.failCredential(credential, lockout, policy, at) {
+			return errors_domain_user.ErrCredentialLocked
+		}
+		return err
+	}
+
+	return s.repo.UpdateEmployee(employee)
+}
+
+// signedEmployee reads the credential and the employee of the signed in account
+//
+// Returns:
+// - errors.ErrorInterface: ErrUnauthorized when the account is not an employee
+func (s *UserService) signedEmployee() (*entities.Credential, *entities.Employee, errors.ErrorInterface) {
+	credentialID := s.security.GetCredentialID()
+	if credentialID == nil || !s.security.IsGrantedByRoles(entities.ROLE_EMPLOYEE, entities.ROLE_AUDITOR) {
+		return nil, nil, errors.ErrUnauthorized
+	}
+
+	credential, err := s.repo.ReadCredential(&transfert.Credential{ID: credentialID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, employee, err := s.repo.ReadUser(&transfert.User{CredentialID: credentialID})
+	if err != nil || employee == nil {
+		return nil, nil, errors.ErrUnauthorized
+	}
+
+	return credential, employee, nil
+}
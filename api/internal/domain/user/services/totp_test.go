@@ -0,0 +1,307 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	errors_domain_user "github.com/kodmain/thetiptop/api/internal/domain/user/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/hash"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var employeeRoles = []security.Role{entities.ROLE_EMPLOYEE, entities.ROLE_AUDITOR}
+
+// totpEmployee retourne un employé dont le second facteur est activé, avec le secret de son application
+func totpEmployee(t *testing.T) (*entities.Employee, string) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err)
+
+	employee := &entities.Employee{ID: "employee-id"}
+	employee.EnrollTOTP(secret)
+
+	at := time.Now().Add(-2 * totp.Period)
+	code, _ := totp.Code(secret, totp.Step(at))
+	_, err = employee.ConfirmTOTP(code, at)
+	require.Nil(t, err)
+
+	return employee, secret
+}
+
+func TestUserAuthSecondFactor(t *testing.T) {
+	email := aws.String("employee@example.com")
+	password := aws.String("password123")
+	hashedPassword, err := hash.Hash(aws.String(*email+":"+*password), hash.BCRYPT)
+	require.NoError(t, err)
+	credential := &entities.Credential{ID: "credential-id", Email: email, Password: hashedPassword}
+
+	t.Run("pending without code", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		employee, _ := totpEmployee(t)
+
+		// Sans code, la connexion reste en attente du second facteur et les échecs sont conservés
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, Failures: 1}, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+
+		id, role, pending, err := service.UserAuth(&transfert.Credential{Email: email, Password: password})
+
+		require.Nil(t, err)
+		assert.Equal(t, credential.ID, *id)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, role)
+		assert.True(t, pending)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DeleteLockout", mock.Anything, mock.Anything)
+	})
+
+	t.Run("valid code", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		employee, secret := totpEmployee(t)
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, Failures: 1}, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("UpdateEmployee", employee).Return(nil)
+		mockRepo.On("DeleteLockout", entities.LockoutCredential, credential.ID).Return(nil)
+
+		_, role, pending, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, TOTP: &code})
+
+		require.Nil(t, err)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, role)
+		assert.False(t, pending)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+		employee, _ := totpEmployee(t)
+
+		// Un mauvais code compte comme un échec de connexion du compte
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, Failures: 1}, nil)
+		mockRepo.On("SaveLockout", mock.MatchedBy(func(lockout *entities.Lockout) bool {
+			return lockout.Scope == entities.LockoutCredential && lockout.Failures == 1
+		})).Return(nil)
+
+		id, _, _, err := service.UserAuth(&transfert.Credential{Email: email, Password: password, TOTP: aws.String("000000x")})
+
+		assert.Nil(t, id)
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdateEmployee", mock.Anything)
+	})
+
+	t.Run("without second factor", func(t *testing.T) {
+		service, mockRepo, _, _, _ := setup()
+
+		// Sans second facteur ni obligation, la connexion est complète
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, &entities.Employee{ID: "employee-id", Auditor: true}, nil)
+
+		_, role, pending, err := service.UserAuth(&transfert.Credential{Email: email, Password: password})
+
+		require.Nil(t, err)
+		assert.Equal(t, entities.ROLE_AUDITOR, role)
+		assert.False(t, pending)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	credentialID := aws.String("credential-id")
+	credential := &entities.Credential{ID: *credentialID, Email: aws.String("employee@example.com")}
+
+	t.Run("unauthorized", func(t *testing.T) {
+		service, _, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(false)
+
+		enrolment, err := service.EnrollTOTP()
+		assert.Nil(t, enrolment)
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("not an employee", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(&entities.Client{}, nil, nil)
+
+		_, err := service.EnrollTOTP()
+		assert.Equal(t, errors.ErrUnauthorized, err)
+	})
+
+	t.Run("already enabled", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee, _ := totpEmployee(t)
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+
+		_, err := service.EnrollTOTP()
+		assert.Equal(t, errors_domain_user.ErrTOTPAlreadyEnabled, err)
+		mockRepo.AssertNotCalled(t, "UpdateEmployee", mock.Anything)
+	})
+
+	t.Run("enrolled", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee := &entities.Employee{ID: "employee-id"}
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("UpdateEmployee", employee).Return(nil)
+
+		enrolment, err := service.EnrollTOTP()
+		require.Nil(t, err)
+		assert.Equal(t, *employee.TOTPSecret, enrolment.Secret)
+		assert.Contains(t, enrolment.URI, "otpauth://totp/TheTipTop:employee@example.com")
+		assert.False(t, employee.TOTPEnabled)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	credentialID := aws.String("credential-id")
+	credential := &entities.Credential{ID: *credentialID}
+
+	t.Run("no dto", func(t *testing.T) {
+		service, _, _, _, _ := setup()
+
+		_, err := service.ConfirmTOTP(nil)
+		assert.Equal(t, errors.ErrNoDto, err)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee := &entities.Employee{ID: "employee-id"}
+		secret, _ := totp.GenerateSecret()
+		employee.EnrollTOTP(secret)
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+
+		codes, err := service.ConfirmTOTP(&transfert.TOTP{Code: aws.String("000000x")})
+		assert.Nil(t, codes)
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, err)
+		mockRepo.AssertNotCalled(t, "UpdateEmployee", mock.Anything)
+	})
+
+	t.Run("confirmed", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee := &entities.Employee{ID: "employee-id"}
+		secret, _ := totp.GenerateSecret()
+		employee.EnrollTOTP(secret)
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("UpdateEmployee", employee).Return(nil)
+
+		codes, err := service.ConfirmTOTP(&transfert.TOTP{Code: &code})
+		require.Nil(t, err)
+		assert.Len(t, codes, entities.RecoveryCodes)
+		assert.True(t, employee.TOTPEnabled)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	credentialID := aws.String("credential-id")
+	credential := &entities.Credential{ID: *credentialID}
+
+	t.Run("not enrolled", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, &entities.Employee{}, nil)
+
+		_, _, err := service.VerifyTOTP(&transfert.TOTP{Code: aws.String("123456")})
+		assert.Equal(t, errors_domain_user.ErrTOTPNotEnrolled, err)
+	})
+
+	t.Run("locked", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee, _ := totpEmployee(t)
+		until := time.Now().Add(time.Minute)
+
+		// Le code n'est pas vérifié pendant un verrouillage
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, LockedUntil: &until}, nil)
+
+		_, _, err := service.VerifyTOTP(&transfert.TOTP{Code: aws.String("123456")})
+		assert.Equal(t, errors_domain_user.ErrCredentialLocked, err)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee, _ := totpEmployee(t)
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).Return(nil, errors_domain_user.ErrLockoutNotFound)
+		mockRepo.On("FailLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, Failures: 1}, nil)
+		mockRepo.On("SaveLockout", mock.AnythingOfType("*entities.Lockout")).Return(nil)
+
+		_, _, err := service.VerifyTOTP(&transfert.TOTP{Code: aws.String("000000x")})
+		assert.Equal(t, errors_domain_user.ErrTOTPInvalid, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("verified", func(t *testing.T) {
+		service, mockRepo, _, mockPerms, _ := setup()
+		employee, secret := totpEmployee(t)
+		code, _ := totp.Code(secret, totp.Step(time.Now()))
+
+		mockPerms.On("GetCredentialID").Return(credentialID)
+		mockPerms.On("IsGrantedByRoles", employeeRoles).Return(true)
+		mockRepo.On("ReadCredential", mock.AnythingOfType("*transfert.Credential")).Return(credential, nil)
+		mockRepo.On("ReadUser", mock.AnythingOfType("*transfert.User")).Return(nil, employee, nil)
+		mockRepo.On("ReadLockout", entities.LockoutCredential, credential.ID).
+			Return(&entities.Lockout{ID: "lockout-id", Scope: entities.LockoutCredential, Subject: credential.ID, Failures: 2}, nil)
+		mockRepo.On("UpdateEmployee", employee).Return(nil)
+		mockRepo.On("DeleteLockout", entities.LockoutCredential, credential.ID).Return(nil)
+		// La session en attente du second facteur est fermée
+		mockPerms.On("GetSessionID").Return(aws.String("pending-session"))
+		mockRepo.On("RevokeSession", *credentialID, "pending-session", mock.AnythingOfType("time.Time")).Return(nil)
+
+		id, role, err := service.VerifyTOTP(&transfert.TOTP{Code: &code})
+		require.Nil(t, err)
+		assert.Equal(t, credential.ID, *id)
+		assert.Equal(t, entities.ROLE_EMPLOYEE, role)
+		mockRepo.AssertExpectations(t)
+	})
+}
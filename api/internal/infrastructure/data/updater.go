@@ -29,7 +29,7 @@ func UpdateEntityWithDto(entity interface{}, dto interface{}) {
 				// Si le champ correspondant dans l'entité est aussi un pointeur
 				if entityField.Kind() == reflect.Ptr {
 					// Compare les valeurs pointées, et non les pointeurs eux-mêmes
-					if !reflect.DeepEqual(entityField.Elem().Interface(), dtoField.Elem().Interface()) {
+					if entityField.IsNil() || !reflect.DeepEqual(entityField.Elem().Interface(), dtoField.Elem().Interface()) {
 						entityField.Set(dtoField) // Assigner directement le pointeur si les valeurs sont différentes
 					}
 				} else {
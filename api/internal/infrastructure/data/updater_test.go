@@ -72,6 +72,25 @@ func TestUpdateComplexEntityWithDto(t *testing.T) {
 		assert.Equal(t, false, *entity.IsActive)  // Le pointeur du champ IsActive est mis à jour
 	})
 
+	// Test pour vérifier la mise à jour d'un champ pointeur encore nil dans l'entité
+	t.Run("successful update when entity pointer is nil", func(t *testing.T) {
+		entity := &ComplexEntity{
+			ID: "123",
+		}
+
+		newName := "Jane Doe"
+		dto := &ComplexEntityDTO{
+			ID:   "123",
+			Name: &newName,
+		}
+
+		data.UpdateEntityWithDto(entity, dto)
+
+		assert.NotNil(t, entity.Name)
+		assert.Equal(t, "Jane Doe", *entity.Name)
+		assert.Nil(t, entity.IsActive)
+	})
+
 	// Test pour vérifier la mise à jour lorsque les champs non pointeurs sont différents (reflect.DeepEqual)
 	t.Run("successful update when fields are different", func(t *testing.T) {
 		// Initialisation de l'entité ComplexEntity
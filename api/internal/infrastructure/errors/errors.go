@@ -40,7 +40,9 @@ var (
 	ErrValueIsNotPhone                   = New(http.StatusBadRequest, "validator.is_not_phone")
 	ErrValueIsNotID                      = New(http.StatusBadRequest, "validator.is_not_id")
 	ErrValueIsNotLuhn                    = New(http.StatusBadRequest, "validator.is_not_luhn")
+	ErrValueIsNotSigned                  = New(http.StatusBadRequest, "validator.is_not_signed")
 	ErrValueIsNotURL                     = New(http.StatusBadRequest, "validator.is_not_url")
+	ErrValueIsNotIP                      = New(http.StatusBadRequest, "validator.is_not_ip")
 	ErrValueIsNotDate                    = New(http.StatusBadRequest, "validator.is_not_date")
 	ErrValueIsNotTime                    = New(http.StatusBadRequest, "validator.is_not_time")
 	ErrValueIsNotUUID                    = New(http.StatusBadRequest, "validator.is_not_uuid")
@@ -52,6 +54,12 @@ var (
 	ErrAuthBadFormat    = New(http.StatusBadRequest, "auth.bad_format")
 	ErrAuthForbidden    = New(http.StatusForbidden, "auth.forbidden")
 	ErrAuthExpiredToken = New(http.StatusUnauthorized, "auth.expired_token")
+	ErrAuthSecondFactor = New(http.StatusUnauthorized, "auth.second_factor_required")
+
+	// OIDC errors
+	ErrOIDCProviderUnknown = New(http.StatusNotFound, "oidc.provider_unknown")
+	ErrOIDCExchangeFailed  = New(http.StatusBadGateway, "oidc.exchange_failed")
+	ErrOIDCInvalidToken    = New(http.StatusUnauthorized, "oidc.invalid_token")
 
 	// Mail errors
 	ErrMailSendFailed = New(http.StatusInternalServerError, "mail.send_failed")
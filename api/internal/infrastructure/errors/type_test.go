@@ -17,7 +17,7 @@ func TestNewError(t *testing.T) {
 	assert.Equal(t, "not.found", err.Error())
 
 	errs := errors.ListErrors()
-	assert.Equal(t, 42, len(errs))
+	assert.Equal(t, 48, len(errs))
 
 	err.Log(fmt.Errorf("error"))
 }
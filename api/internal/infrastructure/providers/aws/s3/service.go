@@ -3,6 +3,7 @@ package s3
 import (
 	"bytes"
 	"context"
+	"io"
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/aws"
@@ -13,6 +14,7 @@ var instance *Service
 
 type ServiceInterface interface {
 	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
 }
 
 type Service struct {
@@ -48,3 +50,14 @@ func (s *Service) GetObject(bucket *string, item *string) (*bytes.Buffer, error)
 
 	return buffer.Read(output.Body)
 }
+
+func (s *Service) PutObject(bucket *string, item *string, body io.Reader, contentType *string) error {
+	_, err := s.API.PutObject(aws.CTX, &s3.PutObjectInput{
+		Bucket:      bucket,
+		Key:         item,
+		Body:        body,
+		ContentType: contentType,
+	})
+
+	return err
+}
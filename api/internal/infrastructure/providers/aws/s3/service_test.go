@@ -22,6 +22,11 @@ func (m *MockS3API) GetObject(ctx context.Context, params *s3.GetObjectInput, op
 	return args.Get(0).(*s3.GetObjectOutput), args.Error(1)
 }
 
+func (m *MockS3API) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	args := m.Called(ctx, params, optFns)
+	return args.Get(0).(*s3.PutObjectOutput), args.Error(1)
+}
+
 func TestNew(t *testing.T) {
 	svc, err := service.New()
 	assert.NoError(t, err)
@@ -56,6 +61,26 @@ func TestGetObject(t *testing.T) {
 	mockS3.AssertExpectations(t)
 }
 
+func TestPutObject(t *testing.T) {
+	mockS3 := new(MockS3API)
+	service := &service.Service{
+		API: mockS3,
+	}
+
+	bucket := "test-bucket"
+	item := "test-item"
+	contentType := "image/png"
+
+	mockS3.On("PutObject", aws.CTX, mock.MatchedBy(func(input *s3.PutObjectInput) bool {
+		return *input.Bucket == bucket && *input.Key == item && *input.ContentType == contentType
+	}), mock.Anything).Return(&s3.PutObjectOutput{}, nil)
+
+	err := service.PutObject(&bucket, &item, bytes.NewReader([]byte("content")), &contentType)
+
+	assert.NoError(t, err)
+	mockS3.AssertExpectations(t)
+}
+
 /*
 func TestGetObject(t *testing.T) {
 	bucket := "test-bucket"
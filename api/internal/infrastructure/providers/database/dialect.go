@@ -0,0 +1,90 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Intervalles de regroupement des dates
+const (
+	Day  string = "day"
+	Hour string = "hour"
+)
+
+// Bucket retourne l'expression SQL qui tronque column au jour ou à l'heure, en UTC et sous forme de texte
+// Les jours sont formatés "2006-01-02" et les heures "2006-01-02 15:00" quel que soit le moteur
+//
+// Parameters:
+// - db: *gorm.DB La connexion dont le dialecte est utilisé
+// - column: string La colonne de date à tronquer
+// - interval: string Day ou Hour
+//
+// Returns:
+// - string: L'expression SQL, à utiliser dans un SELECT et un GROUP BY
+func Bucket(db *gorm.DB, column, interval string) string {
+	hour := interval == Hour
+
+	switch db.Dialector.Name() {
+	case PostgreSQL:
+		if hour {
+			return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:00')", column)
+		}
+		return fmt.Sprintf("to_char(%s AT TIME ZONE 'UTC', 'YYYY-MM-DD')", column)
+	case MySQL:
+		if hour {
+			return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d %%H:00')", column)
+		}
+		return fmt.Sprintf("DATE_FORMAT(%s, '%%Y-%%m-%%d')", column)
+	default:
+		if hour {
+			return fmt.Sprintf("strftime('%%Y-%%m-%%d %%H:00', %s)", column)
+		}
+		return fmt.Sprintf("strftime('%%Y-%%m-%%d', %s)", column)
+	}
+}
+
+// Sample retourne une Option qui sélectionne la ligne de rang offset, les lignes étant triées sur column
+// Avec un rang tiré uniformément entre 0 et le nombre de lignes de la requête, chaque ligne a la même
+// chance d'être choisie, quelle que soit la répartition des valeurs de la colonne ; ORDER BY RANDOM()
+// n'existe pas sous MySQL. Le coût croît avec le rang, la base parcourant l'index jusqu'à la ligne.
+// La colonne est citée selon le dialecte de la connexion
+//
+// Parameters:
+// - column: string La colonne indexée de la table courante
+// - offset: int Le rang de la ligne, à partir de 0
+//
+// Returns:
+// - Option: L'Option à appliquer à la requête
+func Sample(column string, offset int) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(clause.OrderByColumn{Column: clause.Column{Table: clause.CurrentTable, Name: column}}).Offset(offset)
+	}
+}
+
+// IsDuplicate indique si err signale la violation d'un index unique, quel que soit le moteur
+// L'erreur est traduite par le dialecte de la connexion, les codes natifs différant d'un moteur à l'autre
+//
+// Parameters:
+// - db: *gorm.DB La connexion qui a renvoyé l'erreur
+// - err: error L'erreur renvoyée par la requête
+//
+// Returns:
+// - bool: true si une ligne existe déjà avec la même clé
+func IsDuplicate(db *gorm.DB, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		return errors.Is(translator.Translate(err), gorm.ErrDuplicatedKey)
+	}
+
+	return false
+}
@@ -0,0 +1,203 @@
+package database
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBucket(t *testing.T) {
+	pg := &gorm.DB{Config: &gorm.Config{Dialector: postgres.New(postgres.Config{})}}
+	my := &gorm.DB{Config: &gorm.Config{Dialector: mysql.New(mysql.Config{})}}
+
+	cases := map[string]string{
+		Bucket(pg, "claimed_at", Day):  "to_char(claimed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')",
+		Bucket(pg, "claimed_at", Hour): "to_char(claimed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:00')",
+		Bucket(my, "claimed_at", Day):  "DATE_FORMAT(claimed_at, '%Y-%m-%d')",
+		Bucket(my, "claimed_at", Hour): "DATE_FORMAT(claimed_at, '%Y-%m-%d %H:00')",
+	}
+
+	for got, expected := range cases {
+		if got != expected {
+			t.Errorf("Expected %s, got %s", expected, got)
+		}
+	}
+}
+
+func TestBucketSQLite(t *testing.T) {
+	type Event struct {
+		ID uint
+		At time.Time
+	}
+
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	db.AutoMigrate(&Event{})
+
+	// Les dates sont regroupées en UTC, quel que soit leur fuseau
+	paris, _ := time.LoadLocation("Europe/Paris")
+	db.Create(&Event{At: time.Date(2024, 10, 1, 0, 30, 0, 0, paris)})
+	db.Create(&Event{At: time.Date(2024, 9, 30, 22, 10, 0, 0, time.UTC)})
+	db.Create(&Event{At: time.Date(2024, 10, 1, 9, 0, 0, 0, time.UTC)})
+
+	type Row struct {
+		Bucket string
+		Count  int
+	}
+
+	var days []Row
+	bucket := Bucket(db, "at", Day)
+	db.Model(&Event{}).Select(bucket + " AS bucket, COUNT(*) AS count").Group(bucket).Order("bucket").Scan(&days)
+
+	if len(days) != 2 || days[0] != (Row{"2024-09-30", 2}) || days[1] != (Row{"2024-10-01", 1}) {
+		t.Errorf("Unexpected days %v", days)
+	}
+
+	var hours []Row
+	bucket = Bucket(db, "at", Hour)
+	db.Model(&Event{}).Select(bucket + " AS bucket, COUNT(*) AS count").Group(bucket).Order("bucket").Scan(&hours)
+
+	if len(hours) != 2 || hours[0] != (Row{"2024-09-30 22:00", 2}) || hours[1] != (Row{"2024-10-01 09:00", 1}) {
+		t.Errorf("Unexpected hours %v", hours)
+	}
+}
+
+func TestSample(t *testing.T) {
+	type Ticket struct {
+		ID     string
+		Status string
+	}
+
+	// Génération du SQL sans connexion, pour chaque dialecte supporté
+	cases := []struct {
+		dialector gorm.Dialector
+		expected  string
+	}{
+		{
+			sqlite.Open(":memory:"),
+			"SELECT * FROM `tickets` WHERE `tickets`.`status` = ? ORDER BY `tickets`.`id` LIMIT 1 OFFSET 7",
+		},
+		{
+			mysql.New(mysql.Config{DSN: "user:password@tcp(localhost:3306)/db", SkipInitializeWithVersion: true}),
+			"SELECT * FROM `tickets` WHERE `tickets`.`status` = ? ORDER BY `tickets`.`id` LIMIT ? OFFSET ?",
+		},
+		{
+			postgres.New(postgres.Config{DSN: "host=localhost port=5432"}),
+			`SELECT * FROM "tickets" WHERE "tickets"."status" = $1 ORDER BY "tickets"."id" LIMIT $2 OFFSET $3`,
+		},
+	}
+
+	for _, c := range cases {
+		db, err := gorm.Open(c.dialector, &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+		if err != nil {
+			t.Fatalf("Failed to open %s: %v", c.dialector.Name(), err)
+		}
+
+		var ticket Ticket
+		query := db.Where(&Ticket{Status: "generated"})
+		Sample("id", 7)(query)
+		statement := query.Take(&ticket).Statement
+
+		if got := statement.SQL.String(); got != c.expected {
+			t.Errorf("Expected %s for %s, got %s", c.expected, c.dialector.Name(), got)
+		}
+
+		// Le rang est lié en paramètre quand le dialecte ne l'inline pas
+		if vars := statement.Vars; len(vars) == 3 && vars[2] != 7 {
+			t.Errorf("Expected offset 7 for %s, got %v", c.dialector.Name(), vars[2])
+		}
+	}
+}
+
+func TestSampleSQLite(t *testing.T) {
+	type Ticket struct {
+		ID string
+	}
+
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	// Des identifiants très inégalement répartis : un tirage sur les valeurs favoriserait le dernier
+	ids := []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000003",
+		"f0000000-0000-4000-8000-000000000000",
+	}
+
+	db.AutoMigrate(&Ticket{})
+	for i := len(ids) - 1; i >= 0; i-- {
+		db.Create(&Ticket{ID: ids[i]})
+	}
+
+	// Chaque rang désigne une ligne distincte, dans l'ordre de la colonne
+	for offset, expected := range ids {
+		var ticket Ticket
+		query := db.Model(&Ticket{})
+		Sample("id", offset)(query)
+
+		if err := query.First(&ticket).Error; err != nil || ticket.ID != expected {
+			t.Errorf("Expected %s at rank %d, got %s (%v)", expected, offset, ticket.ID, err)
+		}
+	}
+
+	// Au-delà du nombre de lignes, rien n'est trouvé : l'appelant reprend au premier rang
+	var ticket Ticket
+	query := db.Model(&Ticket{})
+	Sample("id", len(ids))(query)
+
+	if err := query.First(&ticket).Error; err != gorm.ErrRecordNotFound {
+		t.Errorf("Expected record not found, got %v", err)
+	}
+}
+
+func TestIsDuplicate(t *testing.T) {
+	pg := &gorm.DB{Config: &gorm.Config{Dialector: postgres.New(postgres.Config{})}}
+
+	// Les codes natifs de PostgreSQL sont traduits par le dialecte
+	if !IsDuplicate(pg, &pgconn.PgError{Code: "23505"}) {
+		t.Error("Expected a unique violation to be a duplicate")
+	}
+
+	if IsDuplicate(pg, &pgconn.PgError{Code: "23503"}) || IsDuplicate(pg, fmt.Errorf("db error")) || IsDuplicate(pg, nil) {
+		t.Error("Expected other errors not to be duplicates")
+	}
+}
+
+func TestIsDuplicateSQLite(t *testing.T) {
+	type Ticket struct {
+		ID    string
+		Token string `gorm:"uniqueIndex"`
+	}
+
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	db.AutoMigrate(&Ticket{})
+
+	if err := db.Create(&Ticket{ID: "1", Token: "0042"}).Error; err != nil {
+		t.Fatalf("Failed to create ticket: %v", err)
+	}
+
+	// Une clé déjà prise est signalée, une clé libre non
+	if err := db.Create(&Ticket{ID: "2", Token: "0042"}).Error; !IsDuplicate(db, err) {
+		t.Errorf("Expected a duplicate, got %v", err)
+	}
+
+	if err := db.Create(&Ticket{ID: "3", Token: "0043"}).Error; IsDuplicate(db, err) {
+		t.Errorf("Expected no duplicate, got %v", err)
+	}
+}
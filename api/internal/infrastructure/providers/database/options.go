@@ -1,6 +1,10 @@
 package database
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Option représente une fonction de configuration pour la requête GORM
 type Option func(*gorm.DB) *gorm.DB
@@ -26,9 +30,31 @@ func Limit(limit int) Option {
 	}
 }
 
+// Offset retourne une Option qui ajoute une clause OFFSET
+func Offset(offset int) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Offset(offset)
+	}
+}
+
 // Order retourne une Option qui ajoute une clause ORDER BY
 func Order(order string) Option {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Order(order)
 	}
 }
+
+// Between retourne une Option qui restreint column à [from, to[, une borne nil laisse l'intervalle ouvert
+func Between(column string, from, to *time.Time) Option {
+	return func(db *gorm.DB) *gorm.DB {
+		if from != nil {
+			db = db.Where(column+" >= ?", *from)
+		}
+
+		if to != nil {
+			db = db.Where(column+" < ?", *to)
+		}
+
+		return db
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"testing"
+	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -110,6 +111,51 @@ func TestLimit(t *testing.T) {
 	}
 }
 
+func TestOffset(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	var results []TestModel
+	query := Offset(3)(db.Order("id")).Find(&results)
+
+	if query.Error != nil {
+		t.Fatalf("Failed to execute Offset: %v", query.Error)
+	}
+
+	if len(results) != 1 || results[0].Name != "David" {
+		t.Errorf("Expected only David, got %v", results)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	db, err := setupTestDB()
+	if err != nil {
+		t.Fatalf("Failed to setup test database: %v", err)
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	// Sans borne, aucune condition n'est ajoutée
+	var results []TestModel
+	if query := Between("age", nil, nil)(db).Find(&results); query.Error != nil {
+		t.Fatalf("Failed to execute Between: %v", query.Error)
+	}
+
+	if len(results) != 4 {
+		t.Errorf("Expected 4 results, got %d", len(results))
+	}
+
+	stmt := db.Session(&gorm.Session{DryRun: true})
+	sql := Between("created_at", &from, &to)(stmt).Find(&results).Statement.SQL.String()
+
+	if sql != "SELECT * FROM `test_models` WHERE created_at >= ? AND created_at < ?" {
+		t.Errorf("Unexpected query %s", sql)
+	}
+}
+
 func TestOrder(t *testing.T) {
 	db, err := setupTestDB()
 	if err != nil {
@@ -1 +1,38 @@
 package template_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail/template"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWinnerTemplates(t *testing.T) {
+	for _, name := range []string{"claim", "prize"} {
+		tpl := template.NewTemplate(name)
+		assert.NotNil(t, tpl)
+		assert.NotNil(t, tpl.Text)
+		assert.NotNil(t, tpl.Html)
+
+		text, html, err := tpl.Inject(template.Data{
+			"AppName":  "ThéTipTop",
+			"Prize":    "Infuseur à thé",
+			"Token":    "79927398713",
+			"Deadline": "31/12/2099 à 23:59",
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, string(text), "79927398713")
+		assert.Contains(t, string(text), "31/12/2099")
+		assert.Contains(t, string(html), "Infuseur à thé")
+
+		// Sans échéance, la phrase est omise
+		text, _, err = tpl.Inject(template.Data{
+			"AppName":  "ThéTipTop",
+			"Prize":    "Infuseur à thé",
+			"Token":    "79927398713",
+			"Deadline": "",
+		})
+		assert.NoError(t, err)
+		assert.NotContains(t, string(text), "Vous avez jusqu")
+	}
+}
@@ -0,0 +1,15 @@
+package oidc
+
+// Config Décrit un fournisseur OpenID Connect, ses points d'accès sont lus depuis la configuration
+// afin que les tests puissent viser un émetteur local.
+type Config struct {
+	Issuer                string   `yaml:"issuer"`                 // Émetteur attendu dans les jetons d'identité
+	ClientID              string   `yaml:"client_id"`              // Identifiant de l'application chez le fournisseur, audience des jetons
+	ClientSecret          string   `yaml:"client_secret"`          // Secret de l'application, omis pour un client public
+	AuthorizationEndpoint string   `yaml:"authorization_endpoint"` // Page de connexion du fournisseur
+	TokenEndpoint         string   `yaml:"token_endpoint"`         // Échange du code d'autorisation
+	JWKSURI               string   `yaml:"jwks_uri"`               // Clés publiques signant les jetons d'identité
+	RedirectURL           string   `yaml:"redirect_url"`           // Écran recevant le code et l'état après la connexion
+	Scopes                []string `yaml:"scopes"`                 // Portées demandées, openid et email par défaut
+	TrustEmail            bool     `yaml:"trust_email"`            // Adresses considérées vérifiées sans la revendication email_verified
+}
@@ -0,0 +1,70 @@
+package oidc
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Timeout Durée maximale d'un appel au fournisseur.
+const Timeout = 10 * time.Second
+
+var instances map[string]ServiceInterface = make(map[string]ServiceInterface)
+
+// New Initialise les fournisseurs OpenID Connect avec la configuration donnée.
+// Les fournisseurs sont optionnels, une configuration absente n'en déclare aucun.
+//
+// Parameters:
+// - providers: map[string]*Config Les fournisseurs par nom.
+//
+// Returns:
+// - error: Une erreur si la configuration d'un fournisseur est incomplète.
+func New(providers map[string]*Config) error {
+	instances = make(map[string]ServiceInterface)
+
+	errs := make([]error, 0)
+
+	for name, cfg := range providers {
+		if cfg == nil {
+			errs = append(errs, errors.New("oidc "+name+" config is nil"))
+			continue
+		}
+
+		if cfg.Issuer == "" {
+			errs = append(errs, errors.New("oidc "+name+" issuer is empty"))
+		}
+
+		if cfg.ClientID == "" {
+			errs = append(errs, errors.New("oidc "+name+" client_id is empty"))
+		}
+
+		if cfg.AuthorizationEndpoint == "" || cfg.TokenEndpoint == "" || cfg.JWKSURI == "" {
+			errs = append(errs, errors.New("oidc "+name+" endpoints are incomplete"))
+		}
+
+		if cfg.RedirectURL == "" {
+			errs = append(errs, errors.New("oidc "+name+" redirect_url is empty"))
+		}
+
+		if len(cfg.Scopes) == 0 {
+			cfg.Scopes = []string{"openid", "email"}
+		}
+
+		instances[name] = &Service{
+			name:   name,
+			Config: cfg,
+			client: &http.Client{Timeout: Timeout},
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// Get Retourne le fournisseur portant ce nom, nil s'il n'est pas configuré.
+func Get(name string) ServiceInterface {
+	return instances[name]
+}
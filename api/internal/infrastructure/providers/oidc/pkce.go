@@ -0,0 +1,30 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// Random Génère une valeur aléatoire de 32 octets encodée en base64url,
+// utilisée pour l'état, le nonce et le vérificateur PKCE.
+//
+// Returns:
+// - string: La valeur de 43 caractères.
+// - errors.ErrorInterface: Une erreur si la source aléatoire échoue.
+func Random() (string, errors.ErrorInterface) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", errors.ErrInternalServer.Log(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// Challenge Calcule le défi PKCE S256 d'un vérificateur.
+func Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,175 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// Leeway Tolérance accordée aux horloges du fournisseur lors de la vérification des dates d'un jeton.
+const Leeway = time.Minute
+
+// methods Algorithmes de signature acceptés pour les jetons d'identité.
+var methods = []string{"RS256", "RS384", "RS512", "ES256", "ES384"}
+
+type ServiceInterface interface {
+	Name() string
+	AuthCodeURL(state, nonce, challenge string) string
+	Exchange(code, verifier string) (string, errors.ErrorInterface)
+	Verify(idToken, nonce string) (*Identity, errors.ErrorInterface)
+}
+
+// Identity Représente l'utilisateur authentifié par le fournisseur.
+//
+// Fields:
+// - Subject: string L'identifiant stable de l'utilisateur chez le fournisseur.
+// - Email: string L'adresse e-mail transmise par le fournisseur.
+// - EmailVerified: bool Vrai si le fournisseur a vérifié l'adresse.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type Service struct {
+	Config *Config
+
+	name    string
+	client  *http.Client
+	mu      sync.Mutex
+	keys    map[string]any
+	fetched time.Time
+}
+
+// claims Revendications lues dans un jeton d'identité.
+type claims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"` // Booléen, ou chaîne chez certains fournisseurs
+}
+
+// Name Retourne le nom du fournisseur dans la configuration.
+func (s *Service) Name() string {
+	return s.name
+}
+
+// AuthCodeURL Construit l'adresse de connexion du fournisseur pour le flux code d'autorisation avec PKCE.
+//
+// Parameters:
+// - state: string L'état retourné tel quel avec le code.
+// - nonce: string La valeur attendue dans le jeton d'identité.
+// - challenge: string Le défi PKCE S256 du vérificateur.
+//
+// Returns:
+// - string: L'adresse vers laquelle rediriger l'utilisateur.
+func (s *Service) AuthCodeURL(state, nonce, challenge string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {s.Config.ClientID},
+		"redirect_uri":          {s.Config.RedirectURL},
+		"scope":                 {strings.Join(s.Config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(s.Config.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return s.Config.AuthorizationEndpoint + separator + query.Encode()
+}
+
+// Exchange Échange le code d'autorisation contre le jeton d'identité de l'utilisateur.
+//
+// Parameters:
+// - code: string Le code reçu après la connexion.
+// - verifier: string Le vérificateur PKCE dont le défi a été envoyé.
+//
+// Returns:
+// - string: Le jeton d'identité, encore à vérifier.
+// - errors.ErrorInterface: ErrOIDCExchangeFailed si le fournisseur refuse l'échange.
+func (s *Service) Exchange(code, verifier string) (string, errors.ErrorInterface) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {s.Config.RedirectURL},
+		"client_id":     {s.Config.ClientID},
+		"code_verifier": {verifier},
+	}
+
+	if s.Config.ClientSecret != "" {
+		form.Set("client_secret", s.Config.ClientSecret)
+	}
+
+	response, err := s.client.PostForm(s.Config.TokenEndpoint, form)
+	if err != nil {
+		return "", errors.ErrOIDCExchangeFailed.Log(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.ErrOIDCExchangeFailed
+	}
+
+	tokens := struct {
+		IDToken string `json:"id_token"`
+	}{}
+
+	if err := json.NewDecoder(response.Body).Decode(&tokens); err != nil || tokens.IDToken == "" {
+		return "", errors.ErrOIDCExchangeFailed
+	}
+
+	return tokens.IDToken, nil
+}
+
+// Verify Vérifie la signature d'un jeton d'identité avec les clés du fournisseur,
+// puis son émetteur, son audience, ses dates et son nonce.
+//
+// Parameters:
+// - idToken: string Le jeton d'identité.
+// - nonce: string Le nonce envoyé avec la demande de connexion.
+//
+// Returns:
+// - *Identity: L'utilisateur authentifié.
+// - errors.ErrorInterface: ErrOIDCInvalidToken si le jeton est refusé.
+func (s *Service) Verify(idToken, nonce string) (*Identity, errors.ErrorInterface) {
+	parsed := &claims{}
+
+	_, err := jwt.ParseWithClaims(idToken, parsed, s.key,
+		jwt.WithValidMethods(methods),
+		jwt.WithIssuer(s.Config.Issuer),
+		jwt.WithAudience(s.Config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(Leeway),
+	)
+
+	if err != nil || parsed.Subject == "" || parsed.Nonce != nonce {
+		return nil, errors.ErrOIDCInvalidToken
+	}
+
+	identity := &Identity{
+		Subject:       parsed.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(parsed.Email)),
+		EmailVerified: s.Config.TrustEmail,
+	}
+
+	switch verified := parsed.EmailVerified.(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified = verified == "true"
+	}
+
+	return identity, nil
+}
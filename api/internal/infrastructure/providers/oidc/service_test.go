@@ -0,0 +1,256 @@
+package oidc_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issuer Émetteur OpenID Connect local servant les clés et l'échange des codes
+type issuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	kid       string
+	challenge string
+	idToken   string
+	jwksCalls int
+}
+
+func newIssuer(t *testing.T) *issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	i := &issuer{key: key, kid: "key-1"}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		i.jwksCalls++
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": i.kid,
+				"kty": "RSA",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(i.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(i.key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "good-code" || oidc.Challenge(r.Form.Get("code_verifier")) != i.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"id_token": i.idToken})
+	})
+
+	i.server = httptest.NewServer(mux)
+	t.Cleanup(i.server.Close)
+
+	return i
+}
+
+func (i *issuer) config() *oidc.Config {
+	return &oidc.Config{
+		Issuer:                i.server.URL,
+		ClientID:              "thetiptop",
+		ClientSecret:          "secret",
+		AuthorizationEndpoint: i.server.URL + "/authorize",
+		TokenEndpoint:         i.server.URL + "/token",
+		JWKSURI:               i.server.URL + "/jwks",
+		RedirectURL:           "http://localhost:3000/oidc",
+	}
+}
+
+func (i *issuer) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = i.kid
+
+	signed, err := token.SignedString(i.key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func (i *issuer) claims(nonce string) jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            i.server.URL,
+		"aud":            "thetiptop",
+		"sub":            "subject-1",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          nonce,
+		"email":          "Player@Example.com",
+		"email_verified": true,
+	}
+}
+
+func TestNew(t *testing.T) {
+	assert.NoError(t, oidc.New(nil))
+	assert.Nil(t, oidc.Get("google"))
+
+	assert.Error(t, oidc.New(map[string]*oidc.Config{"google": nil}))
+	assert.Error(t, oidc.New(map[string]*oidc.Config{"google": {Issuer: "https://accounts.google.com"}}))
+
+	i := newIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{"google": i.config()}))
+
+	provider := oidc.Get("google")
+	require.NotNil(t, provider)
+	assert.Equal(t, "google", provider.Name())
+	assert.Nil(t, oidc.Get("facebook"))
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	i := newIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{"google": i.config()}))
+
+	link, err := url.Parse(oidc.Get("google").AuthCodeURL("state", "nonce", oidc.Challenge("verifier")))
+	require.NoError(t, err)
+
+	query := link.Query()
+	assert.Equal(t, "/authorize", link.Path)
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "thetiptop", query.Get("client_id"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, "nonce", query.Get("nonce"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, oidc.Challenge("verifier"), query.Get("code_challenge"))
+}
+
+func TestRandom(t *testing.T) {
+	a, err := oidc.Random()
+	require.Nil(t, err)
+	b, err := oidc.Random()
+	require.Nil(t, err)
+
+	assert.Len(t, a, 43)
+	assert.NotEqual(t, a, b)
+
+	// Vecteur de la RFC 7636, annexe B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", oidc.Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestExchange(t *testing.T) {
+	i := newIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{"google": i.config()}))
+	provider := oidc.Get("google")
+
+	i.challenge = oidc.Challenge("verifier")
+	i.idToken = "id-token"
+
+	t.Run("exchanged", func(t *testing.T) {
+		idToken, err := provider.Exchange("good-code", "verifier")
+		require.Nil(t, err)
+		assert.Equal(t, "id-token", idToken)
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		_, err := provider.Exchange("good-code", "other")
+		assert.Equal(t, errors.ErrOIDCExchangeFailed, err)
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		_, err := provider.Exchange("bad-code", "verifier")
+		assert.Equal(t, errors.ErrOIDCExchangeFailed, err)
+	})
+}
+
+func TestVerify(t *testing.T) {
+	i := newIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{"google": i.config()}))
+	provider := oidc.Get("google")
+
+	t.Run("verified", func(t *testing.T) {
+		identity, err := provider.Verify(i.sign(t, i.claims("nonce")), "nonce")
+		require.Nil(t, err)
+		assert.Equal(t, "subject-1", identity.Subject)
+		assert.Equal(t, "player@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+	})
+
+	t.Run("string email_verified", func(t *testing.T) {
+		claims := i.claims("nonce")
+		claims["email_verified"] = "false"
+
+		identity, err := provider.Verify(i.sign(t, claims), "nonce")
+		require.Nil(t, err)
+		assert.False(t, identity.EmailVerified)
+	})
+
+	refused := map[string]func(jwt.MapClaims){
+		"wrong nonce":    func(c jwt.MapClaims) { c["nonce"] = "other" },
+		"wrong audience": func(c jwt.MapClaims) { c["aud"] = "other" },
+		"wrong issuer":   func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" },
+		"expired":        func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"no expiry":      func(c jwt.MapClaims) { delete(c, "exp") },
+		"no subject":     func(c jwt.MapClaims) { delete(c, "sub") },
+	}
+
+	for name, change := range refused {
+		t.Run(name, func(t *testing.T) {
+			claims := i.claims("nonce")
+			change(claims)
+
+			_, err := provider.Verify(i.sign(t, claims), "nonce")
+			assert.Equal(t, errors.ErrOIDCInvalidToken, err)
+		})
+	}
+
+	t.Run("hmac", func(t *testing.T) {
+		// Un jeton signé avec un secret partagé n'est pas accepté
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, i.claims("nonce")).SignedString([]byte("secret"))
+		require.NoError(t, err)
+
+		_, verr := provider.Verify(token, "nonce")
+		assert.Equal(t, errors.ErrOIDCInvalidToken, verr)
+	})
+
+	t.Run("foreign key", func(t *testing.T) {
+		// Une clé inconnue du fournisseur est refusée sans relire les clés avant le délai
+		other, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, i.claims("nonce"))
+		token.Header["kid"] = "key-2"
+		signed, err := token.SignedString(other)
+		require.NoError(t, err)
+
+		calls := i.jwksCalls
+		_, verr := provider.Verify(signed, "nonce")
+		assert.Equal(t, errors.ErrOIDCInvalidToken, verr)
+		assert.Equal(t, calls, i.jwksCalls)
+	})
+}
+
+func TestVerifyRotation(t *testing.T) {
+	i := newIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{"google": i.config()}))
+	provider := oidc.Get("google")
+
+	_, err := provider.Verify(i.sign(t, i.claims("nonce")), "nonce")
+	require.Nil(t, err)
+	assert.Equal(t, 1, i.jwksCalls)
+
+	// Les clés restent en cache tant que l'identifiant est connu
+	_, err = provider.Verify(i.sign(t, i.claims("nonce")), "nonce")
+	require.Nil(t, err)
+	assert.Equal(t, 1, i.jwksCalls)
+}
@@ -1,11 +1,9 @@
 package token
 
 import (
+	"crypto/rand"
 	"fmt"
-	"math/rand"
 	"strconv"
-	"strings"
-	"time"
 
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
 )
@@ -78,17 +76,33 @@ func (l Luhn) Calculate() (string, string, error) {
 }
 
 // Generate génère un numéro valide Luhn de la longueur fournie.
+// Les chiffres sont tirés de crypto/rand, chaque chiffre de 0 à 9 est équiprobable.
 func Generate(length int) Luhn {
-	rnd := rand.New(rand.NewSource(time.Now().UTC().UnixNano()))
+	l := Luhn(randomDigits(length - 1))
+	_, res, _ := l.Calculate() // ignorer l'erreur car cela sera toujours valide
+	return Luhn(res)
+}
+
+// randomDigits retourne n chiffres tirés de crypto/rand.
+// Les octets supérieurs à 249 sont rejetés pour éviter le biais du modulo.
+// Elle panique si la source d'aléa du système est indisponible.
+func randomDigits(n int) string {
+	digits := make([]byte, 0, n)
+	buf := make([]byte, n+8)
+
+	for len(digits) < n {
+		if _, err := rand.Read(buf); err != nil {
+			panic(fmt.Sprintf("token: crypto/rand unavailable: %v", err))
+		}
 
-	var s strings.Builder
-	for i := 0; i < length-1; i++ {
-		s.WriteString(strconv.Itoa(rnd.Intn(9)))
+		for _, b := range buf {
+			if b < 250 && len(digits) < n {
+				digits = append(digits, asciiZero+b%10)
+			}
+		}
 	}
 
-	l := Luhn(s.String())
-	_, res, _ := l.Calculate() // ignorer l'erreur car cela sera toujours valide
-	return Luhn(res)
+	return string(digits)
 }
 
 // calculateLuhnSum calcule la somme de Luhn pour un nombre donné avec une parité donnée.
@@ -83,5 +83,21 @@ func TestGenerate(t *testing.T) {
 	t.Run("when the number is valid", func(t *testing.T) {
 		number := token.Generate(10)
 		assert.Len(t, number, 10)
+		assert.NoError(t, number.Validate())
+	})
+
+	t.Run("when every digit can be drawn", func(t *testing.T) {
+		seen := map[rune]bool{}
+		for i := 0; i < 100; i++ {
+			for _, d := range token.Generate(12).String()[:11] {
+				seen[d] = true
+			}
+		}
+
+		assert.Len(t, seen, 10)
+	})
+
+	t.Run("when two numbers are generated", func(t *testing.T) {
+		assert.NotEqual(t, token.Generate(12), token.Generate(12))
 	})
 }
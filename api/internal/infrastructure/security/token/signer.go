@@ -0,0 +1,104 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+// Signer génère des codes Luhn portant un segment de contrôle dérivé d'une clé HMAC.
+// Un code signé se compose de chiffres aléatoires, du segment puis du chiffre de Luhn,
+// il peut donc être vérifié hors ligne sans consulter la base de données.
+type Signer struct {
+	secret []byte
+	length int
+	digits int
+}
+
+// NewSigner crée un signataire de codes.
+// Sans secret ou sans chiffre de segment, les codes générés sont de simples codes Luhn.
+//
+// Parameters:
+// - secret: string La clé HMAC
+// - length: int La longueur totale des codes, segment et chiffre de Luhn compris
+// - digits: int Le nombre de chiffres du segment de contrôle
+//
+// Returns:
+// - *Signer: Le signataire
+func NewSigner(secret string, length, digits int) *Signer {
+	if secret == "" || digits < 1 || digits >= length-1 {
+		digits = 0
+	}
+
+	return &Signer{
+		secret: []byte(secret),
+		length: length,
+		digits: digits,
+	}
+}
+
+// IsSigned indique si les codes générés portent un segment de contrôle.
+func (s *Signer) IsSigned() bool {
+	return s.digits > 0
+}
+
+// Generate génère un code de la longueur du signataire.
+//
+// Returns:
+// - Luhn: Le code, valide Luhn, signé si une clé est configurée
+func (s *Signer) Generate() Luhn {
+	if !s.IsSigned() {
+		return Generate(s.length)
+	}
+
+	body := randomDigits(s.length - s.digits - 1)
+	l := Luhn(body + s.segment(body))
+	_, res, _ := l.Calculate() // ignorer l'erreur car cela sera toujours valide
+	return Luhn(res)
+}
+
+// Verify vérifie le chiffre de Luhn puis, pour les codes de la longueur signée, le segment de contrôle.
+// Les codes d'une autre longueur, générés avant la signature, ne portent que le chiffre de Luhn.
+//
+// Parameters:
+// - l: Luhn Le code à vérifier
+//
+// Returns:
+// - errors.ErrorInterface: ErrValueIsNotLuhn, ErrValueIsNotNumber ou ErrValueIsNotSigned
+func (s *Signer) Verify(l Luhn) errors.ErrorInterface {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+
+	if !s.IsSigned() || len(l) != s.length {
+		return nil
+	}
+
+	code := string(l)
+	body := code[:s.length-s.digits-1]
+	segment := code[s.length-s.digits-1 : s.length-1]
+
+	if subtle.ConstantTimeCompare([]byte(segment), []byte(s.segment(body))) != 1 {
+		return errors.ErrValueIsNotSigned
+	}
+
+	return nil
+}
+
+// segment calcule le segment de contrôle d'un corps de code.
+func (s *Signer) segment(body string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(body))
+	sum := binary.BigEndian.Uint64(mac.Sum(nil))
+
+	modulo := uint64(1)
+	for i := 0; i < s.digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", s.digits, sum%modulo)
+}
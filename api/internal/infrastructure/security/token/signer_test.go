@@ -0,0 +1,62 @@
+package token_test
+
+import (
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSigner_Generate(t *testing.T) {
+	t.Run("when a secret is configured", func(t *testing.T) {
+		signer := token.NewSigner("secret", 16, 4)
+		assert.True(t, signer.IsSigned())
+
+		code := signer.Generate()
+		assert.Len(t, code, 16)
+		assert.NoError(t, code.Validate())
+		assert.Nil(t, signer.Verify(code))
+	})
+
+	t.Run("when no secret is configured", func(t *testing.T) {
+		signer := token.NewSigner("", 12, 4)
+		assert.False(t, signer.IsSigned())
+
+		code := signer.Generate()
+		assert.Len(t, code, 12)
+		assert.NoError(t, code.Validate())
+	})
+
+	t.Run("when the segment leaves no random digit", func(t *testing.T) {
+		assert.False(t, token.NewSigner("secret", 5, 4).IsSigned())
+	})
+}
+
+func TestSigner_Verify(t *testing.T) {
+	signer := token.NewSigner("secret", 16, 4)
+	code := signer.Generate().String()
+
+	t.Run("when the code is forged", func(t *testing.T) {
+		// Un corps modifié avec un chiffre de Luhn recalculé passe Luhn mais pas la signature
+		body := []byte(code[:11])
+		body[0] = '0' + (body[0]-'0'+1)%10
+		_, forged, _ := token.NewLuhn(string(body) + code[11:15]).Calculate()
+
+		assert.NoError(t, token.NewLuhn(forged).Validate())
+		assert.Equal(t, errors.ErrValueIsNotSigned, signer.Verify(token.NewLuhn(forged)))
+	})
+
+	t.Run("when the key differs", func(t *testing.T) {
+		other := token.NewSigner("other", 16, 4)
+		assert.Equal(t, errors.ErrValueIsNotSigned, other.Verify(token.NewLuhn(code)))
+	})
+
+	t.Run("when the code is a legacy Luhn code", func(t *testing.T) {
+		assert.Nil(t, signer.Verify(token.NewLuhn("79927398713")))
+	})
+
+	t.Run("when the code is not Luhn", func(t *testing.T) {
+		assert.Equal(t, errors.ErrValueIsNotLuhn, signer.Verify(token.NewLuhn("79927398710")))
+	})
+}
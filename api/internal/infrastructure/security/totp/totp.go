@@ -0,0 +1,139 @@
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+)
+
+const (
+	Digits = 6                // Nombre de chiffres d'un code
+	Period = 30 * time.Second // Durée de validité d'un code
+	Skew   = 1                // Nombre de périodes acceptées avant et après l'instant courant
+	size   = 20               // Taille en octets d'un secret, celle de la sortie de HMAC-SHA1
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret génère un secret aléatoire encodé en base32, tel qu'attendu par les applications d'authentification.
+//
+// Returns:
+// - string: Le secret
+// - errors.ErrorInterface: ErrInternalServer si l'aléa est indisponible
+func GenerateSecret() (string, errors.ErrorInterface) {
+	secret := make([]byte, size)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.ErrInternalServer.Log(err)
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// Step retourne le numéro de la période contenant un instant.
+func Step(at time.Time) int64 {
+	return at.Unix() / int64(Period/time.Second)
+}
+
+// Code calcule le code d'une période selon la RFC 6238.
+//
+// Parameters:
+// - secret: string Le secret encodé en base32
+// - step: int64 Le numéro de la période
+//
+// Returns:
+// - string: Le code, complété par des zéros à gauche
+// - errors.ErrorInterface: ErrNoData si le secret n'est pas du base32
+func Code(secret string, step int64) (string, errors.ErrorInterface) {
+	key, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil || len(key) == 0 {
+		return "", errors.ErrNoData
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", Digits, value%modulo), nil
+}
+
+// Validate vérifie un code autour d'un instant, en tolérant le décalage d'horloge du téléphone.
+// Les périodes inférieures ou égales à after sont refusées pour qu'un code ne serve qu'une fois.
+//
+// Parameters:
+// - secret: string Le secret encodé en base32
+// - code: string Le code saisi
+// - at: time.Time L'instant de la saisie
+// - after: int64 La dernière période acceptée, 0 si aucune
+//
+// Returns:
+// - int64: La période du code, à conserver comme prochaine valeur de after
+// - bool: true si le code est valide
+func Validate(secret, code string, at time.Time, after int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	current := Step(at)
+	for step := current - Skew; step <= current+Skew; step++ {
+		if step <= after {
+			continue
+		}
+
+		expected, err := Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// URI construit l'URI otpauth à présenter sous forme de QR code lors de l'enrôlement.
+//
+// Parameters:
+// - issuer: string Le nom du service affiché par l'application
+// - account: string Le compte, en général l'email
+// - secret: string Le secret encodé en base32
+//
+// Returns:
+// - string: L'URI otpauth://totp
+func URI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(Digits))
+	query.Set("period", fmt.Sprint(int(Period/time.Second)))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}).String()
+}
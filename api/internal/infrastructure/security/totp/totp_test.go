@@ -0,0 +1,88 @@
+package totp_test
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/security/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Secret des vecteurs de test de la RFC 6238 pour SHA1
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// Vecteurs de la RFC 6238, tronqués à 6 chiffres
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := totp.Code(rfcSecret, totp.Step(time.Unix(unix, 0)))
+		require.Nil(t, err)
+		assert.Equal(t, expected, code, "at %d", unix)
+	}
+
+	_, err := totp.Code("not base32 !", 1)
+	assert.NotNil(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1111111109, 0)
+	step := totp.Step(at)
+
+	step, ok := totp.Validate(rfcSecret, "081804", at, 0)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(at), step)
+
+	// Le code de la période précédente reste accepté pour le décalage d'horloge
+	previous, _ := totp.Code(rfcSecret, step-1)
+	_, ok = totp.Validate(rfcSecret, previous, at, 0)
+	assert.True(t, ok)
+
+	// Un code déjà utilisé est refusé
+	_, ok = totp.Validate(rfcSecret, "081804", at, step)
+	assert.False(t, ok)
+
+	// Un code trop ancien ou mal formé est refusé
+	old, _ := totp.Code(rfcSecret, step-2)
+	_, ok = totp.Validate(rfcSecret, old, at, 0)
+	assert.False(t, ok)
+	_, ok = totp.Validate(rfcSecret, "12345", at, 0)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+	require.Nil(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := totp.GenerateSecret()
+	require.Nil(t, err)
+	assert.NotEqual(t, secret, other)
+
+	_, err = totp.Code(secret, 1)
+	assert.Nil(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := totp.URI("TheTipTop", "employee@example.com", rfcSecret)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.True(t, strings.HasPrefix(parsed.Path, "/TheTipTop:employee@example.com"))
+	assert.Equal(t, rfcSecret, parsed.Query().Get("secret"))
+	assert.Equal(t, "TheTipTop", parsed.Query().Get("issuer"))
+	assert.Equal(t, "6", parsed.Query().Get("digits"))
+}
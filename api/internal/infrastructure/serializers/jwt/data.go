@@ -14,6 +14,9 @@ const (
 	REFRESH TYPE = 1 // Jeton de rafraîchissement
 )
 
+// PENDING is the data claim of the tokens issued before the second factor of their owner was checked
+const PENDING = "pending_second_factor"
+
 type Token struct {
 	ID     string         `json:"id"`
 	Exp    int64          `json:"exp"`
@@ -21,12 +24,21 @@ type Token struct {
 	Offset int            `json:"offset"`
 	Type   TYPE           `json:"type"`
 	Data   map[string]any `json:"data"`
+	// Session identifie la connexion dont est issu le jeton, JTI le jeton de rafraîchissement enregistré côté serveur
+	Session string `json:"sid,omitempty"`
+	JTI     string `json:"jti,omitempty"`
 }
 
 func (t *Token) IsNotValid() bool {
 	return t.Type != ACCESS
 }
 
+// IsPending reports whether the token was issued before the second factor, only Pending routes accept it
+func (t *Token) IsPending() bool {
+	pending, _ := t.Data[PENDING].(bool)
+	return pending
+}
+
 func (t *Token) HasExpired() bool {
 	// Charger le fuseau horaire spécifié ou utiliser UTC si une erreur survient
 	location, err := time.LoadLocation(t.TZ)
@@ -54,6 +66,14 @@ func (a Token) Claims() jwt.MapClaims {
 		"data": a.Data,
 	}
 
+	if a.Session != "" {
+		claims["sid"] = a.Session
+	}
+
+	if a.JTI != "" {
+		claims["jti"] = a.JTI
+	}
+
 	return claims
 }
 
@@ -84,6 +104,14 @@ func fromClaims(claims jwt.MapClaims) *Token {
 		token.Data = data
 	}
 
+	if session, ok := claims["sid"].(string); ok {
+		token.Session = session
+	}
+
+	if jti, ok := claims["jti"].(string); ok {
+		token.JTI = jti
+	}
+
 	return token
 }
 
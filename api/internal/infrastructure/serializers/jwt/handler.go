@@ -8,6 +8,15 @@ import (
 )
 
 func Auth(c *fiber.Ctx) error {
+	return check(c, false)
+}
+
+// Pending accepts the access tokens issued before the second factor as well, for the routes completing it
+func Pending(c *fiber.Ctx) error {
+	return check(c, true)
+}
+
+func check(c *fiber.Ctx, pending bool) error {
 	auth := c.Locals("token")
 	if auth == nil {
 		return c.Status(errors.ErrAuthNoToken.Code()).JSON(errors.ErrAuthNoToken)
@@ -22,6 +31,10 @@ func Auth(c *fiber.Ctx) error {
 		return c.Status(errors.ErrAuthInvalidToken.Code()).JSON(errors.ErrAuthInvalidToken)
 	}
 
+	if !pending && token.IsPending() {
+		return c.Status(errors.ErrAuthSecondFactor.Code()).JSON(errors.ErrAuthSecondFactor)
+	}
+
 	return c.Next()
 }
 
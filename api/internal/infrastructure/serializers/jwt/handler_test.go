@@ -36,6 +36,10 @@ func start() error {
 		return c.SendString("Hello, Restricted!")
 	})
 
+	fbr.Get("/pending", jwt.Pending, func(c *fiber.Ctx) error {
+		return c.SendString("Hello, Pending!")
+	})
+
 	c := make(chan error, 1)
 
 	time.AfterFunc(1*time.Second, func() {
@@ -145,6 +149,31 @@ func TestParser(t *testing.T) {
 		assert.Equal(t, "Hello, Restricted!", string(content))
 	})
 
+	t.Run("TestRestrictedPendingToken", func(t *testing.T) {
+		token, _, _ := jwt.FromID("hello", map[string]any{jwt.PENDING: true})
+		content, status, err := request("GET", restricted, bearer+token, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "{\"code\":401,\"message\":\"auth.second_factor_required\"}", string(content))
+	})
+
+	t.Run("TestPendingRoute", func(t *testing.T) {
+		pending, _, _ := jwt.FromID("hello", map[string]any{jwt.PENDING: true})
+		content, status, err := request("GET", "http://localhost:3000/pending", bearer+pending, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Hello, Pending!", string(content))
+
+		token, _, _ := jwt.FromID("hello", nil)
+		_, status, err = request("GET", "http://localhost:3000/pending", bearer+token, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		_, status, err = request("GET", "http://localhost:3000/pending", "", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
 	t.Run("TestRestrictedExpiredToken", func(t *testing.T) {
 		token, _, _ := jwt.FromID("hello", nil)
 		time.Sleep(5 * time.Second)
@@ -69,6 +69,12 @@ func New(t *JWT) error {
 }
 
 func FromID(id string, data map[string]any) (string, string, errors.ErrorInterface) {
+	return FromSession(id, "", "", data)
+}
+
+// FromSession signe les jetons d'une connexion enregistrée côté serveur.
+// Les deux jetons portent l'identifiant de la connexion, seul le jeton de rafraîchissement porte son jti.
+func FromSession(id, session, jti string, data map[string]any) (string, string, errors.ErrorInterface) {
 	location, err := time.LoadLocation(instance.TZ)
 	if err != nil {
 		return "", "", errors.ErrAuthInvalidToken
@@ -78,12 +84,14 @@ func FromID(id string, data map[string]any) (string, string, errors.ErrorInterfa
 	_, offset := now.Zone()
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, Token{
-		ID:     id,
-		Exp:    now.Add(instance.Duration * time.Duration(instance.Refresh)).Unix(),
-		TZ:     location.String(),
-		Type:   REFRESH,
-		Offset: offset,
-		Data:   data,
+		ID:      id,
+		Exp:     now.Add(instance.Duration * time.Duration(instance.Refresh)).Unix(),
+		TZ:      location.String(),
+		Type:    REFRESH,
+		Offset:  offset,
+		Data:    data,
+		Session: session,
+		JTI:     jti,
 	}.Claims())
 
 	refresh, err := token.SignedString([]byte(instance.Secret))
@@ -92,12 +100,13 @@ func FromID(id string, data map[string]any) (string, string, errors.ErrorInterfa
 	}
 
 	token = jwt.NewWithClaims(jwt.SigningMethodHS256, Token{
-		ID:     id,
-		Exp:    now.Add(instance.Duration * time.Duration(instance.Expire)).Unix(),
-		TZ:     location.String(),
-		Offset: offset,
-		Type:   ACCESS,
-		Data:   data,
+		ID:      id,
+		Exp:     now.Add(instance.Duration * time.Duration(instance.Expire)).Unix(),
+		TZ:      location.String(),
+		Offset:  offset,
+		Type:    ACCESS,
+		Data:    data,
+		Session: session,
 	}.Claims())
 
 	access, err := token.SignedString([]byte(instance.Secret))
@@ -108,6 +117,15 @@ func FromID(id string, data map[string]any) (string, string, errors.ErrorInterfa
 	return access, refresh, nil
 }
 
+// RefreshLifetime retourne la durée de validité des jetons de rafraîchissement.
+func RefreshLifetime() time.Duration {
+	if instance == nil {
+		return duration * 30
+	}
+
+	return instance.Duration * time.Duration(instance.Refresh)
+}
+
 func TokenToClaims(tokenString string) (*Token, errors.ErrorInterface) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -2,6 +2,7 @@ package jwt_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
 	"github.com/stretchr/testify/assert"
@@ -46,3 +47,34 @@ func TestFromID(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, claims)
 }
+
+func TestFromSession(t *testing.T) {
+	err := jwt.New(nil)
+	assert.NoError(t, err)
+
+	access, refresh, err := jwt.FromSession("exampleID", "session-id", "token-id", nil)
+	assert.NoError(t, err)
+
+	// Le jeton d'accès porte la connexion sans son jti
+	claims, err := jwt.TokenToClaims(access)
+	assert.NoError(t, err)
+	assert.Equal(t, "session-id", claims.Session)
+	assert.Empty(t, claims.JTI)
+
+	claims, err = jwt.TokenToClaims(refresh)
+	assert.NoError(t, err)
+	assert.Equal(t, jwt.REFRESH, claims.Type)
+	assert.Equal(t, "session-id", claims.Session)
+	assert.Equal(t, "token-id", claims.JTI)
+
+	// Les jetons sans connexion n'ont aucun des deux
+	_, refresh, err = jwt.FromID("exampleID", nil)
+	assert.NoError(t, err)
+
+	claims, err = jwt.TokenToClaims(refresh)
+	assert.NoError(t, err)
+	assert.Empty(t, claims.Session)
+	assert.Empty(t, claims.JTI)
+
+	assert.Equal(t, 30*time.Minute, jwt.RefreshLifetime())
+}
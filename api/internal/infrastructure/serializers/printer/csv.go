@@ -0,0 +1,49 @@
+package printer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+	count  int
+}
+
+func newCSV(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+// Write appends one row per cell, the header is written with the first rows
+func (c *csvWriter) Write(cells []Cell) error {
+	if !c.header {
+		if err := c.w.Write([]string{"number", "code", "caption"}); err != nil {
+			return err
+		}
+
+		c.header = true
+	}
+
+	for _, cell := range cells {
+		c.count++
+		if err := c.w.Write([]string{strconv.Itoa(c.count), cell.Code, cell.Caption}); err != nil {
+			return err
+		}
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	if !c.header {
+		return c.Write(nil)
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
@@ -0,0 +1,115 @@
+package printer
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+)
+
+// Imposition of an A4 sheet, in millimeters
+const (
+	pageWidth  = 210.0
+	pageHeight = 297.0
+	margin     = 10.0
+	columns    = 3
+	rows       = 8
+	qrSize     = 24.0
+)
+
+// PageCells is the number of tickets laid out on one sheet
+const PageCells = columns * rows
+
+type pdfWriter struct {
+	out   io.Writer
+	pdf   *gofpdf.Fpdf
+	count int
+}
+
+func newPDF(w io.Writer) *pdfWriter {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(false, 0)
+
+	return &pdfWriter{out: w, pdf: pdf}
+}
+
+// Write lays the cells out on a grid of columns x rows per page, with cut lines between cells
+func (p *pdfWriter) Write(cells []Cell) error {
+	width := (pageWidth - 2*margin) / columns
+	height := (pageHeight - 2*margin) / rows
+
+	for _, cell := range cells {
+		position := p.count % PageCells
+		if position == 0 {
+			p.pdf.AddPage()
+			p.cutLines(width, height)
+		}
+
+		x := margin + float64(position%columns)*width
+		y := margin + float64(position/columns)*height
+
+		png, err := qr.Encode(cell.Code, qr.PNG, qr.DefaultSize, qr.LevelMedium)
+		if err != nil {
+			return err
+		}
+
+		name := "qr" + strconv.Itoa(p.count)
+		options := gofpdf.ImageOptions{ImageType: "PNG"}
+		p.pdf.RegisterImageOptionsReader(name, options, bytes.NewReader(png))
+		p.pdf.ImageOptions(name, x+2, y+(height-qrSize)/2, qrSize, qrSize, false, options, 0, "")
+
+		p.pdf.SetFont("Courier", "B", 11)
+		p.pdf.Text(x+qrSize+4, y+height/2, group(cell.Code))
+
+		if cell.Caption != "" {
+			p.pdf.SetFont("Helvetica", "", 7)
+			p.pdf.Text(x+qrSize+4, y+height/2+5, cell.Caption)
+		}
+
+		p.count++
+	}
+
+	return p.pdf.Error()
+}
+
+func (p *pdfWriter) Close() error {
+	if p.count == 0 {
+		p.pdf.AddPage()
+	}
+
+	return p.pdf.Output(p.out)
+}
+
+// cutLines draws light dashed lines around the cells of the current page
+func (p *pdfWriter) cutLines(width, height float64) {
+	p.pdf.SetDrawColor(200, 200, 200)
+	p.pdf.SetLineWidth(0.1)
+	p.pdf.SetDashPattern([]float64{1, 1}, 0)
+
+	for i := 0; i <= columns; i++ {
+		x := margin + float64(i)*width
+		p.pdf.Line(x, margin, x, pageHeight-margin)
+	}
+
+	for i := 0; i <= rows; i++ {
+		y := margin + float64(i)*height
+		p.pdf.Line(margin, y, pageWidth-margin, y)
+	}
+
+	p.pdf.SetDashPattern([]float64{}, 0)
+}
+
+// group splits a code into blocks of four digits to ease reading
+func group(code string) string {
+	var blocks []string
+	for len(code) > 4 {
+		blocks = append(blocks, code[:4])
+		code = code[4:]
+	}
+
+	return strings.Join(append(blocks, code), " ")
+}
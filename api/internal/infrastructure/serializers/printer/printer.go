@@ -0,0 +1,56 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	CSV = "csv"
+	PDF = "pdf"
+)
+
+// Cell is one printed ticket: the code and a short caption under it
+type Cell struct {
+	Code    string
+	Caption string
+}
+
+// Writer renders cells to a print-ready output
+// Write may be called several times to stream large batches, Close flushes the output
+type Writer interface {
+	Write(cells []Cell) error
+	Close() error
+}
+
+// New returns the writer of the given format
+//
+// Parameters:
+// - format: string - The output format, CSV or PDF
+// - w: io.Writer - The destination of the output
+//
+// Returns:
+// - Writer: The writer of the format
+// - error: An error if the format is not supported
+func New(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case CSV:
+		return newCSV(w), nil
+	case PDF:
+		return newPDF(w), nil
+	}
+
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// ContentType returns the MIME type of the given format
+func ContentType(format string) string {
+	switch format {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case PDF:
+		return "application/pdf"
+	}
+
+	return "application/octet-stream"
+}
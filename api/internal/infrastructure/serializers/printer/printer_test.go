@@ -0,0 +1,73 @@
+package printer_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/printer"
+	"github.com/stretchr/testify/assert"
+)
+
+func cells(n int) []printer.Cell {
+	list := make([]printer.Cell, n)
+	for i := range list {
+		list[i] = printer.Cell{Code: fmt.Sprintf("%012d", i), Caption: "2024"}
+	}
+
+	return list
+}
+
+func TestNew(t *testing.T) {
+	_, err := printer.New("docx", &bytes.Buffer{})
+	assert.Error(t, err)
+
+	assert.Equal(t, "text/csv; charset=utf-8", printer.ContentType(printer.CSV))
+	assert.Equal(t, "application/pdf", printer.ContentType(printer.PDF))
+	assert.Equal(t, "application/octet-stream", printer.ContentType("docx"))
+}
+
+func TestCSV(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := printer.New(printer.CSV, buf)
+	assert.NoError(t, err)
+
+	// Écriture en deux lots pour simuler le streaming
+	assert.NoError(t, w.Write(cells(2)))
+	assert.NoError(t, w.Write(cells(1)))
+	assert.NoError(t, w.Close())
+
+	records, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"number", "code", "caption"},
+		{"1", "000000000000", "2024"},
+		{"2", "000000000001", "2024"},
+		{"3", "000000000000", "2024"},
+	}, records)
+
+	// Un export vide garde son entête
+	buf.Reset()
+	w, _ = printer.New(printer.CSV, buf)
+	assert.NoError(t, w.Close())
+	assert.Equal(t, "number,code,caption\n", buf.String())
+}
+
+func TestPDF(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w, err := printer.New(printer.PDF, buf)
+	assert.NoError(t, err)
+
+	// 25 cellules, soit deux pages de 24
+	assert.NoError(t, w.Write(cells(25)))
+	assert.NoError(t, w.Close())
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("/Type /Page\n")))
+
+	buf.Reset()
+	w, _ = printer.New(printer.PDF, buf)
+	assert.NoError(t, w.Close())
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+}
@@ -0,0 +1,116 @@
+package qr
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	PNG = "png"
+	SVG = "svg"
+)
+
+// Bounds of the rendered side, in pixels
+const (
+	DefaultSize = 256
+	MinSize     = 64
+	MaxSize     = 2048
+)
+
+// Error-correction levels, the share of the symbol that can be damaged and still be read
+const (
+	LevelLow     = "L" // 7%
+	LevelMedium  = "M" // 15%
+	LevelQuarter = "Q" // 25%
+	LevelHigh    = "H" // 30%
+)
+
+var levels = map[string]qrcode.RecoveryLevel{
+	LevelLow:     qrcode.Low,
+	LevelMedium:  qrcode.Medium,
+	LevelQuarter: qrcode.High,
+	LevelHigh:    qrcode.Highest,
+}
+
+// IsFormat reports whether the format can be rendered
+func IsFormat(format string) bool {
+	return format == PNG || format == SVG
+}
+
+// IsLevel reports whether the error-correction level is known
+func IsLevel(level string) bool {
+	_, ok := levels[level]
+	return ok
+}
+
+// Encode renders content as a square QR code, quiet zone included
+// Everything is computed locally, no external service is called
+//
+// Parameters:
+// - content: string - The text to encode
+// - format: string - The output format, PNG or SVG
+// - size: int - The side of the image, in pixels, between MinSize and MaxSize
+// - level: string - The error-correction level, L, M, Q or H
+//
+// Returns:
+// - []byte: The image
+// - error: An error if a parameter is not supported or the content doesn't fit in a QR code
+func Encode(content, format string, size int, level string) ([]byte, error) {
+	recovery, ok := levels[level]
+	if !ok {
+		return nil, fmt.Errorf("unsupported error-correction level %q", level)
+	}
+
+	if size < MinSize || size > MaxSize {
+		return nil, fmt.Errorf("size %d out of [%d, %d]", size, MinSize, MaxSize)
+	}
+
+	code, err := qrcode.New(content, recovery)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case PNG:
+		return code.PNG(size)
+	case SVG:
+		return svg(code.Bitmap(), size), nil
+	}
+
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// ContentType returns the MIME type of the given format
+func ContentType(format string) string {
+	switch format {
+	case PNG:
+		return "image/png"
+	case SVG:
+		return "image/svg+xml"
+	}
+
+	return "application/octet-stream"
+}
+
+// svg draws one unit square per dark module, the view box scales the modules to the requested size
+func svg(bitmap [][]bool, size int) []byte {
+	modules := len(bitmap)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, modules, modules)
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(buf, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	buf.WriteString(`"/></svg>`)
+
+	return buf.Bytes()
+}
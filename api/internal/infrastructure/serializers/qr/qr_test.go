@@ -0,0 +1,75 @@
+package qr_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsFormat(t *testing.T) {
+	assert.True(t, qr.IsFormat(qr.PNG))
+	assert.True(t, qr.IsFormat(qr.SVG))
+	assert.False(t, qr.IsFormat("gif"))
+
+	assert.Equal(t, "image/png", qr.ContentType(qr.PNG))
+	assert.Equal(t, "image/svg+xml", qr.ContentType(qr.SVG))
+	assert.Equal(t, "application/octet-stream", qr.ContentType("gif"))
+}
+
+func TestIsLevel(t *testing.T) {
+	for _, level := range []string{qr.LevelLow, qr.LevelMedium, qr.LevelQuarter, qr.LevelHigh} {
+		assert.True(t, qr.IsLevel(level))
+	}
+
+	assert.False(t, qr.IsLevel("X"))
+}
+
+func TestEncode_PNG(t *testing.T) {
+	content, err := qr.Encode("79927398713", qr.PNG, 128, qr.LevelMedium)
+	assert.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, 128, img.Bounds().Dx())
+	assert.Equal(t, 128, img.Bounds().Dy())
+}
+
+func TestEncode_SVG(t *testing.T) {
+	content, err := qr.Encode("79927398713", qr.SVG, 300, qr.LevelHigh)
+	assert.NoError(t, err)
+
+	var svg struct {
+		Width  string `xml:"width,attr"`
+		Height string `xml:"height,attr"`
+	}
+	assert.NoError(t, xml.Unmarshal(content, &svg))
+	assert.Equal(t, "300", svg.Width)
+	assert.Equal(t, "300", svg.Height)
+	assert.True(t, strings.Contains(string(content), "h1v1h-1z"))
+
+	// Un niveau de correction plus élevé donne plus de modules
+	low, _ := qr.Encode("79927398713", qr.SVG, 300, qr.LevelLow)
+	assert.Less(t, len(low), len(content))
+}
+
+func TestEncode_Errors(t *testing.T) {
+	_, err := qr.Encode("79927398713", "gif", 256, qr.LevelMedium)
+	assert.Error(t, err)
+
+	_, err = qr.Encode("79927398713", qr.PNG, 256, "X")
+	assert.Error(t, err)
+
+	_, err = qr.Encode("79927398713", qr.PNG, qr.MinSize-1, qr.LevelMedium)
+	assert.Error(t, err)
+
+	_, err = qr.Encode("79927398713", qr.PNG, qr.MaxSize+1, qr.LevelMedium)
+	assert.Error(t, err)
+
+	_, err = qr.Encode(strings.Repeat("9", 8000), qr.PNG, 256, qr.LevelHigh)
+	assert.Error(t, err)
+}
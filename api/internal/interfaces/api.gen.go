@@ -23,34 +23,81 @@ func init() {
 // API represents a collection of HTTP endpoints grouped by namespace and version.
 var (
 	Endpoints map[string]fiber.Handler = map[string]func(*fiber.Ctx) error{
-		"code.ListErrors":        code.ListErrors,
-		"game.GetTicket":         game.GetTicket,
-		"game.GetTicketById":     game.GetTicketById,
-		"game.GetTickets":        game.GetTickets,
-		"game.UpdateTicket":      game.UpdateTicket,
-		"jwt.Auth":               jwt.Auth,
-		"status.HealthCheck":     status.HealthCheck,
-		"status.IP":              status.IP,
-		"store.CreateCaisse":     store.CreateCaisse,
-		"store.DeleteCaisse":     store.DeleteCaisse,
-		"store.GetCaisse":        store.GetCaisse,
-		"store.GetStoreByID":     store.GetStoreByID,
-		"store.List":             store.List,
-		"store.UpdateCaisse":     store.UpdateCaisse,
-		"user.CredentialUpdate":  user.CredentialUpdate,
-		"user.DeleteClient":      user.DeleteClient,
-		"user.DeleteEmployee":    user.DeleteEmployee,
-		"user.ExportClient":      user.ExportClient,
-		"user.GetClient":         user.GetClient,
-		"user.GetEmployee":       user.GetEmployee,
-		"user.MailValidation":    user.MailValidation,
-		"user.RegisterClient":    user.RegisterClient,
-		"user.RegisterEmployee":  user.RegisterEmployee,
-		"user.UpdateClient":      user.UpdateClient,
-		"user.UpdateEmployee":    user.UpdateEmployee,
-		"user.UserAuth":          user.UserAuth,
-		"user.UserAuthRenew":     user.UserAuthRenew,
-		"user.ValidationRecover": user.ValidationRecover,
+		"code.ListErrors":                code.ListErrors,
+		"game.AnswerTicketTransfer":      game.AnswerTicketTransfer,
+		"game.CreateCampaign":            game.CreateCampaign,
+		"game.CreatePrize":               game.CreatePrize,
+		"game.DeletePrize":               game.DeletePrize,
+		"game.ExpireTickets":             game.ExpireTickets,
+		"game.ExportBatch":               game.ExportBatch,
+		"game.ExportLedger":              game.ExportLedger,
+		"game.GetBatches":                game.GetBatches,
+		"game.GetCampaign":               game.GetCampaign,
+		"game.GetCampaigns":              game.GetCampaigns,
+		"game.GetClaimReviews":           game.GetClaimReviews,
+		"game.GetClaimStatistics":        game.GetClaimStatistics,
+		"game.GetDistributionChanges":    game.GetDistributionChanges,
+		"game.GetDraw":                   game.GetDraw,
+		"game.GetPrize":                  game.GetPrize,
+		"game.GetPrizeStatistics":        game.GetPrizeStatistics,
+		"game.GetPrizes":                 game.GetPrizes,
+		"game.GetStoreStatistics":        game.GetStoreStatistics,
+		"game.GetTicket":                 game.GetTicket,
+		"game.GetTicketById":             game.GetTicketById,
+		"game.GetTicketExpiries":         game.GetTicketExpiries,
+		"game.GetTicketHistory":          game.GetTicketHistory,
+		"game.GetTicketTransfers":        game.GetTicketTransfers,
+		"game.GetTickets":                game.GetTickets,
+		"game.GiftTicket":                game.GiftTicket,
+		"game.IssueReceipt":              game.IssueReceipt,
+		"game.PreviewDistribution":       game.PreviewDistribution,
+		"game.RedeemTicket":              game.RedeemTicket,
+		"game.RenderQRCode":              game.RenderQRCode,
+		"game.ReviewClaim":               game.ReviewClaim,
+		"game.RunDraw":                   game.RunDraw,
+		"game.UpdateCampaign":            game.UpdateCampaign,
+		"game.UpdateDistribution":        game.UpdateDistribution,
+		"game.UpdatePrize":               game.UpdatePrize,
+		"game.UpdateTicket":              game.UpdateTicket,
+		"game.UpdateTicketStatus":        game.UpdateTicketStatus,
+		"game.VerifyDraw":                game.VerifyDraw,
+		"game.VerifyLedger":              game.VerifyLedger,
+		"jwt.Auth":                       jwt.Auth,
+		"jwt.Pending":                    jwt.Pending,
+		"status.HealthCheck":             status.HealthCheck,
+		"status.IP":                      status.IP,
+		"store.CreateCaisse":             store.CreateCaisse,
+		"store.DeleteCaisse":             store.DeleteCaisse,
+		"store.GetCaisse":                store.GetCaisse,
+		"store.GetStoreByID":             store.GetStoreByID,
+		"store.List":                     store.List,
+		"store.UpdateCaisse":             store.UpdateCaisse,
+		"user.ConfirmTOTP":               user.ConfirmTOTP,
+		"user.CredentialUpdate":          user.CredentialUpdate,
+		"user.DeleteClient":              user.DeleteClient,
+		"user.DeleteEmployee":            user.DeleteEmployee,
+		"user.EnrollTOTP":                user.EnrollTOTP,
+		"user.ExportClient":              user.ExportClient,
+		"user.GetClient":                 user.GetClient,
+		"user.GetEmployee":               user.GetEmployee,
+		"user.GetNewsletterStatistics":   user.GetNewsletterStatistics,
+		"user.GetRegistrationStatistics": user.GetRegistrationStatistics,
+		"user.ListSessions":              user.ListSessions,
+		"user.Logout":                    user.Logout,
+		"user.MailValidation":            user.MailValidation,
+		"user.OIDCAuth":                  user.OIDCAuth,
+		"user.RegisterClient":            user.RegisterClient,
+		"user.RegisterEmployee":          user.RegisterEmployee,
+		"user.RevokeSession":             user.RevokeSession,
+		"user.RevokeSessions":            user.RevokeSessions,
+		"user.StartOIDC":                 user.StartOIDC,
+		"user.Unlock":                    user.Unlock,
+		"user.UpdateClient":              user.UpdateClient,
+		"user.UpdateEmployee":            user.UpdateEmployee,
+		"user.UserAuth":                  user.UserAuth,
+		"user.UserAuthRenew":             user.UserAuthRenew,
+		"user.ValidationRecover":         user.ValidationRecover,
+		"user.VerifyTOTP":                user.VerifyTOTP,
 	}
 	Mapping = &docs.Swagger{}
 	doc, _  = swag.ReadDoc()
@@ -15,6 +15,8 @@ import (
 	"github.com/kodmain/thetiptop/api/internal/application/hook"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
 	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/events"
 	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	userRepository "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
@@ -28,6 +30,12 @@ const (
 	password = "Aa1@azetyuiop"
 )
 
+// Compte de l'huissier, auditeur du registre des tickets
+const auditor = "huissier@yopmail.com"
+
+// Comptes clients, pour les échanges de tickets entre joueurs
+var clients = []string{"client@yopmail.com", "ami@yopmail.com"}
+
 var srv *server.Server
 var callBack hook.HandlerSync = func(tags ...string) {
 	if len(tags) > 0 && tags[0] == "default" {
@@ -47,10 +55,59 @@ var callBack hook.HandlerSync = func(tags ...string) {
 			})
 		}
 
+		if crd, _ := user.ReadCredential(&userTransfert.Credential{
+			Email: aws.String(auditor),
+		}); crd == nil {
+			cred, _ := user.CreateCredential(&userTransfert.Credential{
+				Email:    aws.String(auditor),
+				Password: aws.String(password),
+			})
+
+			employee, _ := user.CreateEmployee(&userTransfert.Employee{
+				CredentialID: &cred.ID,
+			})
+
+			employee.Auditor = true
+			user.UpdateEmployee(employee)
+		}
+
+		for _, client := range clients {
+			if crd, _ := user.ReadCredential(&userTransfert.Credential{
+				Email: aws.String(client),
+			}); crd == nil {
+				cred, _ := user.CreateCredential(&userTransfert.Credential{
+					Email:    aws.String(client),
+					Password: aws.String(password),
+				})
+
+				user.CreateClient(&userTransfert.Client{
+					CredentialID: &cred.ID,
+					CGU:          aws.Bool(true),
+				})
+			}
+		}
+
+		prize, _ := game.ReadPrize(&transfert.Prize{
+			Label: aws.String("Infuseur à thé"),
+		})
+
+		if prize == nil {
+			prize, _ = game.CreatePrize(&transfert.Prize{
+				Label:        aws.String("Infuseur à thé"),
+				Value:        aws.Float64(8.9),
+				Position:     aws.Int(1),
+				Distribution: aws.Int(60),
+			})
+		}
+
+		campaign := events.CreateCampaign(game)
+
+		signer := entities.NewTicketSigner()
 		for i := 0; i < 100; i++ {
 			game.CreateTicket(&transfert.Ticket{
-				Prize: aws.String("prize"),
-				Token: aws.String("token:" + fmt.Sprintf("%d", i)),
+				PrizeID:    &prize.ID,
+				CampaignID: &campaign.ID,
+				Token:      aws.String(signer.Generate().String()),
 			})
 		}
 	}
@@ -0,0 +1,91 @@
+package game
+
+import (
+	"bufio"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/printer"
+)
+
+// @Tags		Batch
+// @Accept		multipart/form-data
+// @Summary		Export a batch of tickets for the printer.
+// @Description	Streams the tickets ordered by ID as CSV or as an A4 PDF of 24 cells, each with the code and its QR code. The batch is recorded once fully written.
+// @Description	A PDF is built in memory before being sent, so it holds at most 100 sheets (2400 tickets): limit defaults to 2400 and a larger limit is rejected. Export a larger range part by part, moving offset by the limit of the previous part. A CSV streams every ticket.
+// @Produce		text/csv
+// @Produce		application/pdf
+// @Router		/game/batch [post]
+// @Id			jwt.Auth => game.ExportBatch
+// @Security 	Bearer
+// @Param		format		formData	string	true	"Output format" Enums(csv, pdf)
+// @Param		campaign	formData	string	false	"Campaign label, current campaign when empty"
+// @Param		prize_id	formData	string	false	"Prize ID" format(uuid)
+// @Param		offset		formData	integer	false	"Number of tickets to skip"
+// @Param		limit		formData	integer	false	"Number of tickets to export, all when empty for a CSV, 2400 at most and by default for a PDF"
+// @Success		200	{file} 		nil "Tickets file"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Campaign or prize not found"
+func ExportBatch(ctx *fiber.Ctx) error {
+	dtoBatch := &transfert.Batch{}
+	if err := ctx.BodyParser(dtoBatch); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	service := services.Game(
+		security.NewUserAccess(ctx.Locals("token")),
+		repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+		mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+	)
+
+	status, response := game.PrepareBatch(service, dtoBatch)
+
+	batch, ok := response.(*entities.Batch)
+	if !ok {
+		return ctx.Status(status).JSON(response)
+	}
+
+	ctx.Status(status)
+	ctx.Attachment("tickets." + batch.Format)
+	ctx.Set(fiber.HeaderContentType, printer.ContentType(batch.Format))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := game.WriteBatch(service, batch, w); err != nil {
+			logger.Warn(err)
+		}
+	})
+
+	return nil
+}
+
+// @Tags		Batch
+// @Summary		List the ticket exports.
+// @Produce		application/json
+// @Router		/game/batches [get]
+// @Id			jwt.Auth => game.GetBatches
+// @Security 	Bearer
+// @Success		200	{object} 	nil "Batches details"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetBatches(ctx *fiber.Ctx) error {
+	status, response := game.GetBatches(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,103 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	_, status, err = request("POST", "http://localhost:8888/game/batch", "", JSONEncoded, map[string][]any{
+		"format": {"csv"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+		}
+
+		t.Run("ExportBatch/"+encodingName, func(t *testing.T) {
+			_, status, err := request("POST", "http://localhost:8888/game/batch", authorization, encoding, map[string][]any{})
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/batch", authorization, encoding, map[string][]any{
+				"format": {"docx"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/batch", authorization, encoding, map[string][]any{
+				"format":   {"csv"},
+				"campaign": {"batch-" + encodingName},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 404, status)
+
+			content, status, err := request("POST", "http://localhost:8888/game/batch", authorization, encoding, map[string][]any{
+				"format": {"csv"},
+				"offset": {1},
+				"limit":  {3},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			assert.Equal(t, "number,code,caption", lines[0])
+			assert.Len(t, lines, 4)
+
+			content, status, err = request("POST", "http://localhost:8888/game/batch", authorization, encoding, map[string][]any{
+				"format": {"pdf"},
+				"limit":  {30},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+			assert.True(t, bytes.HasPrefix(content, []byte("%PDF-")))
+		})
+	}
+
+	_, status, err = request("GET", "http://localhost:8888/game/batches", "", JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	content, status, err := request("GET", "http://localhost:8888/game/batches", authorization, JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var batches []*entities.Batch
+	assert.Nil(t, json.Unmarshal(content, &batches))
+	assert.Len(t, batches, 4)
+
+	for _, batch := range batches {
+		assert.NotNil(t, batch.CredentialID)
+		assert.NotNil(t, batch.CampaignID)
+		assert.Len(t, batch.Checksum, 64)
+	}
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,137 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Campaign
+// @Summary		List the campaigns, the most recent first.
+// @Produce		application/json
+// @Router		/game/campaigns [get]
+// @Id			game.GetCampaigns
+// @Success		200	{object} 	nil "Campaigns details"
+// @Failure		500	{object} 	nil "Internal server error"
+func GetCampaigns(ctx *fiber.Ctx) error {
+	status, response := game.GetCampaigns(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Summary		Get a campaign by id.
+// @Produce		application/json
+// @Router		/game/campaign/{id} [get]
+// @Id			game.GetCampaign
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Campaign details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		404	{object} 	nil "Not found"
+func GetCampaign(ctx *fiber.Ctx) error {
+	CampaignID := ctx.Params("id")
+
+	status, response := game.GetCampaign(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Campaign{
+			ID: &CampaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Open a new campaign, the tickets of the previous ones are kept.
+// @Description	Dates without offset are read in the campaign timezone. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the active prize catalogue. The eligibility rules (minimum_age, countries, household_limit, household_prizes) can only be sent as JSON too.
+// @Produce		application/json
+// @Router		/game/campaign [post]
+// @Id			jwt.Auth => game.CreateCampaign
+// @Security 	Bearer
+// @Param		label			formData	string	true	"Label"
+// @Param		start_at		formData	string	true	"Tickets can be played from" default(2024-09-01 00:00)
+// @Param		end_at			formData	string	true	"Tickets can be played until" default(2024-09-30 23:59)
+// @Param		claim_deadline	formData	string	true	"Prizes can be handed over until" default(2024-10-30 23:59)
+// @Param		timezone		formData	string	false	"IANA timezone" default(Europe/Paris)
+// @Param		tickets			formData	int		false	"Number of tickets to generate"
+// @Success		201	{object} 	nil "Campaign created"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Campaign already exists"
+func CreateCampaign(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.CreateCampaign(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Update a campaign.
+// @Description	The distribution and the eligibility rules can only be sent as JSON, the rules left out are kept.
+// @Produce		application/json
+// @Router		/game/campaign/{id} [put]
+// @Id			jwt.Auth => game.UpdateCampaign
+// @Security 	Bearer
+// @Param		id				path		string	true	"Campaign ID" format(uuid)
+// @Param		label			formData	string	false	"Label"
+// @Param		start_at		formData	string	false	"Tickets can be played from"
+// @Param		end_at			formData	string	false	"Tickets can be played until"
+// @Param		claim_deadline	formData	string	false	"Prizes can be handed over until"
+// @Param		timezone		formData	string	false	"IANA timezone"
+// @Param		tickets			formData	int		false	"Number of tickets to generate"
+// @Success		200	{object} 	nil "Campaign updated"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Campaign already exists"
+func UpdateCampaign(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	CampaignID := ctx.Params("id")
+	dtoCampaign.ID = &CampaignID
+
+	status, response := game.UpdateCampaign(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,119 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCampaign(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+		}
+
+		label := "campaign-" + encodingName
+
+		t.Run("CreateCampaign/"+encodingName, func(t *testing.T) {
+			_, status, err := request("POST", "http://localhost:8888/game/campaign", "", encoding, map[string][]any{
+				"label":          {label},
+				"start_at":       {"2099-10-01"},
+				"end_at":         {"2099-10-31"},
+				"claim_deadline": {"2099-11-30"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 401, status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/campaign", authorization, encoding, map[string][]any{
+				"label":          {label},
+				"start_at":       {"2099-10-31"},
+				"end_at":         {"2099-10-01"},
+				"claim_deadline": {"2099-11-30"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+
+			content, status, err := request("POST", "http://localhost:8888/game/campaign", authorization, encoding, map[string][]any{
+				"label":          {label},
+				"start_at":       {"2099-10-01"},
+				"end_at":         {"2099-10-31 23:59"},
+				"claim_deadline": {"2099-11-30 23:59"},
+				"timezone":       {"Europe/Paris"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 201, status)
+
+			campaign := entities.Campaign{}
+			json.Unmarshal(content, &campaign)
+			assert.NotEmpty(t, campaign.ID)
+			assert.NotEmpty(t, campaign.Distribution)
+
+			_, status, err = request("POST", "http://localhost:8888/game/campaign", authorization, encoding, map[string][]any{
+				"label":          {label},
+				"start_at":       {"2099-10-01"},
+				"end_at":         {"2099-10-31"},
+				"claim_deadline": {"2099-11-30"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 409, status)
+
+			t.Run("GetCampaigns/"+encodingName, func(t *testing.T) {
+				content, status, err := request("GET", "http://localhost:8888/game/campaigns", "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				campaigns := []*entities.Campaign{}
+				json.Unmarshal(content, &campaigns)
+				assert.NotEmpty(t, campaigns)
+			})
+
+			t.Run("GetCampaign/"+encodingName, func(t *testing.T) {
+				_, status, err := request("GET", "http://localhost:8888/game/campaign/"+campaign.ID, "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+			})
+
+			t.Run("UpdateCampaign/"+encodingName, func(t *testing.T) {
+				_, status, err := request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID, authorization, encoding, map[string][]any{
+					"timezone": {"Mars/Olympus"},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
+
+				content, status, err := request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID, authorization, encoding, map[string][]any{
+					"claim_deadline": {"2099-12-31"},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				updated := entities.Campaign{}
+				json.Unmarshal(content, &updated)
+				assert.Equal(t, 2099, updated.ClaimDeadline.Year())
+				assert.Equal(t, 12, int(updated.ClaimDeadline.Month()))
+			})
+		})
+	}
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,77 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Game
+// @Summary		List the suspicious claims held for review, oldest first.
+// @Produce		application/json
+// @Router		/game/reviews [get]
+// @Id			jwt.Auth => game.GetClaimReviews
+// @Security 	Bearer
+// @Param		status	query	string	false	"Review status" Enums(pending, approved, rejected) default(pending)
+// @Success		200	{object} 	nil "Reviews with their ticket"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetClaimReviews(ctx *fiber.Ctx) error {
+	dtoReview := &transfert.ClaimReview{}
+	if err := ctx.QueryParser(dtoReview); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.GetClaimReviews(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoReview,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Approve or reject a claim held for review.
+// @Produce		application/json
+// @Router		/game/review/{id} [put]
+// @Id			jwt.Auth => game.ReviewClaim
+// @Security 	Bearer
+// @Param		id		path		string	true	"Review ID" format(uuid)
+// @Param		status	formData	string	true	"Decision" Enums(approved, rejected)
+// @Success		200	{object} 	nil "Review with its ticket"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Review already decided"
+func ReviewClaim(ctx *fiber.Ctx) error {
+	dtoReview := &transfert.ClaimReview{}
+	if err := ctx.BodyParser(dtoReview); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	ReviewID := ctx.Params("id")
+	dtoReview.ID = &ReviewID
+
+	status, response := game.ReviewClaim(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoReview,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,71 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimReview(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+		}
+
+		t.Run("GetClaimReviews/"+encodingName, func(t *testing.T) {
+			_, status, err := request("GET", "http://localhost:8888/game/reviews", "", encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 401, status)
+
+			content, status, err := request("GET", "http://localhost:8888/game/reviews?status=pending", authorization, encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+
+			var reviews []*entities.ClaimReview
+			assert.Nil(t, json.Unmarshal(content, &reviews))
+
+			_, status, err = request("GET", "http://localhost:8888/game/reviews?status=lost", authorization, encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+		})
+
+		t.Run("ReviewClaim/"+encodingName, func(t *testing.T) {
+			url := "http://localhost:8888/game/review/5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"
+
+			_, status, err := request("PUT", url, authorization, encoding, map[string][]any{
+				"status": {"approved"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 404, status)
+
+			_, status, err = request("PUT", url, authorization, encoding, map[string][]any{
+				"status": {"maybe"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+		})
+	}
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,116 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Preview the tickets to generate or cancel for a campaign to match a new distribution.
+// @Description	Nothing is written. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the current one.
+// @Produce		application/json
+// @Router		/game/campaign/{id}/distribution/preview [post]
+// @Id			jwt.Auth => game.PreviewDistribution
+// @Security 	Bearer
+// @Param		id		path		string	true	"Campaign ID" format(uuid)
+// @Param		tickets	formData	int		false	"Number of tickets the campaign should own"
+// @Success		200	{object} 	nil "Tickets to generate and cancel per prize"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Campaign ended"
+// @Failure		404	{object} 	nil "Not found"
+func PreviewDistribution(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	CampaignID := ctx.Params("id")
+	dtoCampaign.ID = &CampaignID
+
+	status, response := game.PreviewDistribution(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Accept		multipart/form-data
+// @Summary		Change the distribution of a running campaign and rebalance its tickets.
+// @Description	Missing tickets are generated and spare ones cancelled, tickets already handed over or claimed are kept. Every change is recorded. The distribution, a map of prize ID to percent, can only be sent as JSON and defaults to the current one.
+// @Description	At most 10000 tickets are generated or cancelled per call. When the recorded change has tickets remaining, call again, with or without body, to resume from the tickets in database.
+// @Produce		application/json
+// @Router		/game/campaign/{id}/distribution [put]
+// @Id			jwt.Auth => game.UpdateDistribution
+// @Security 	Bearer
+// @Param		id		path		string	true	"Campaign ID" format(uuid)
+// @Param		tickets	formData	int		false	"Number of tickets the campaign should own"
+// @Success		200	{object} 	nil "Recorded change, with the tickets remaining for the next call"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Campaign ended"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Tickets changed during the rebalance"
+func UpdateDistribution(ctx *fiber.Ctx) error {
+	dtoCampaign := &transfert.Campaign{}
+	if err := ctx.BodyParser(dtoCampaign); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	CampaignID := ctx.Params("id")
+	dtoCampaign.ID = &CampaignID
+
+	status, response := game.UpdateDistribution(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoCampaign,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Summary		List the distribution changes of a campaign, the most recent first.
+// @Produce		application/json
+// @Router		/game/campaign/{id}/distributions [get]
+// @Id			jwt.Auth => game.GetDistributionChanges
+// @Security 	Bearer
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Distribution changes"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetDistributionChanges(ctx *fiber.Ctx) error {
+	CampaignID := ctx.Params("id")
+
+	status, response := game.GetDistributionChanges(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Campaign{
+			ID: &CampaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,141 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribution(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	content, status, err := request("GET", "http://localhost:8888/game/prizes", "", JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	prizes := []*entities.Prize{}
+	json.Unmarshal(content, &prizes)
+
+	var prizeID string
+	for _, prize := range prizes {
+		if prize.Label != nil && *prize.Label == "Infuseur à thé" {
+			prizeID = prize.ID
+		}
+	}
+	assert.NotEmpty(t, prizeID)
+
+	// Une campagne future, ses tickets ne peuvent pas être tirés par les autres tests
+	content, status, err = request("POST", "http://localhost:8888/game/campaign", authorization, JSONEncoded, map[string][]any{
+		"label":          {"campaign-distribution"},
+		"start_at":       {"2099-10-01"},
+		"end_at":         {"2099-10-31"},
+		"claim_deadline": {"2099-11-30"},
+		"tickets":        {10},
+		"distribution":   {map[string]int{prizeID: 60}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	campaign := entities.Campaign{}
+	json.Unmarshal(content, &campaign)
+	assert.NotEmpty(t, campaign.ID)
+
+	t.Run("PreviewDistribution", func(t *testing.T) {
+		_, status, err := request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution/preview", "", JSONEncoded, map[string][]any{
+			"tickets": {20},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/campaign/campaign/distribution/preview", authorization, JSONEncoded, map[string][]any{
+			"tickets": {20},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution/preview", authorization, JSONEncoded, map[string][]any{
+			"distribution": {map[string]int{prizeID: 150}},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		content, status, err := request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution/preview", authorization, JSONEncoded, map[string][]any{
+			"tickets": {20},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		change := entities.DistributionChange{}
+		json.Unmarshal(content, &change)
+		assert.Empty(t, change.ID)
+		assert.Equal(t, 20, change.Tickets)
+		if assert.Len(t, change.Lines, 1) {
+			assert.Equal(t, 12, change.Lines[0].Generate)
+		}
+	})
+
+	t.Run("UpdateDistribution", func(t *testing.T) {
+		content, status, err := request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution", authorization, JSONEncoded, map[string][]any{
+			"tickets": {20},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		change := entities.DistributionChange{}
+		json.Unmarshal(content, &change)
+		assert.NotEmpty(t, change.ID)
+		assert.Equal(t, 12, change.Generated)
+		assert.Equal(t, 0, change.Cancelled)
+
+		content, status, err = request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution", authorization, FormURLEncoded, map[string][]any{
+			"tickets": {10},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		change = entities.DistributionChange{}
+		json.Unmarshal(content, &change)
+		assert.Equal(t, 0, change.Generated)
+		assert.Equal(t, 6, change.Cancelled)
+	})
+
+	t.Run("GetDistributionChanges", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/campaign/"+campaign.ID+"/distributions", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/campaign/00000000-0000-4000-8000-000000000000/distributions", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 404, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/campaign/"+campaign.ID+"/distributions", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		changes := []*entities.DistributionChange{}
+		json.Unmarshal(content, &changes)
+		if assert.Len(t, changes, 2) {
+			assert.Equal(t, 6, changes[0].Cancelled)
+			assert.Equal(t, 12, changes[1].Generated)
+		}
+	})
+
+	assert.Nil(t, stop())
+}
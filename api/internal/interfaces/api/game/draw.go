@@ -0,0 +1,100 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Draw
+// @Accept		multipart/form-data
+// @Summary		Run the grand prize draw of a campaign.
+// @Produce		application/json
+// @Router		/game/draw [post]
+// @Id			jwt.Auth => game.RunDraw
+// @Security 	Bearer
+// @Param		campaign	formData	string	true	"Campaign label"
+// @Success		201	{object} 	nil "Draw recorded"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Campaign not found"
+// @Failure		409	{object} 	nil "Draw already done, campaign running or no participant"
+func RunDraw(ctx *fiber.Ctx) error {
+	dtoDraw := &transfert.Draw{}
+	if err := ctx.BodyParser(dtoDraw); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.RunDraw(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoDraw,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Draw
+// @Summary		Get the grand prize draw of a campaign.
+// @Produce		application/json
+// @Router		/game/draw/{campaign} [get]
+// @Id			jwt.Auth => game.GetDraw
+// @Security 	Bearer
+// @Param		campaign	path	string	true	"Campaign label"
+// @Success		200	{object} 	nil "Draw details"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetDraw(ctx *fiber.Ctx) error {
+	campaign := ctx.Params("campaign")
+
+	status, response := game.GetDraw(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Draw{
+			Campaign: &campaign,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Draw
+// @Summary		Replay the grand prize draw of a campaign against the participants recorded with it.
+// @Produce		application/json
+// @Router		/game/draw/{campaign}/verify [get]
+// @Id			jwt.Auth => game.VerifyDraw
+// @Security 	Bearer
+// @Param		campaign	path	string	true	"Campaign label"
+// @Success		200	{object} 	nil "Draw verified"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Draw does not match"
+func VerifyDraw(ctx *fiber.Ctx) error {
+	campaign := ctx.Params("campaign")
+
+	status, response := game.VerifyDraw(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Draw{
+			Campaign: &campaign,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
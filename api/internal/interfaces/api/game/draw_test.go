@@ -0,0 +1,117 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraw(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	// Au moins un ticket réclamé pour avoir un participant
+	randomTicket, status, err := request("GET", "http://localhost:8888/game/random", authorization, JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	ticket := entities.Ticket{}
+	json.Unmarshal(randomTicket, &ticket)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/ticket", authorization, JSONEncoded, map[string][]any{
+		"token": {ticket.Token.String()},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	// La campagne par défaut n'a pas de fin, son tirage peut avoir lieu
+	_, status, err = request("POST", "http://localhost:8888/game/draw", "", JSONEncoded, map[string][]any{
+		"campaign": {"default"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 401, status)
+
+	content, status, err := request("POST", "http://localhost:8888/game/draw", authorization, JSONEncoded, map[string][]any{
+		"campaign": {"default"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	draw := entities.Draw{}
+	json.Unmarshal(content, &draw)
+	assert.NotEmpty(t, draw.Seed)
+	assert.NotNil(t, draw.WinnerID)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+		}
+
+		t.Run("RunDraw/"+encodingName, func(t *testing.T) {
+			_, status, err := request("POST", "http://localhost:8888/game/draw", authorization, encoding, map[string][]any{
+				"campaign": {"default"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 409, status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/draw", authorization, encoding, map[string][]any{
+				"campaign": {"draw-" + encodingName},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 404, status)
+		})
+
+		t.Run("RunDraw/Running/"+encodingName, func(t *testing.T) {
+			_, status, err := request("POST", "http://localhost:8888/game/campaign", authorization, encoding, map[string][]any{
+				"label":          {"draw-" + encodingName},
+				"start_at":       {"2099-01-01"},
+				"end_at":         {"2099-01-31"},
+				"claim_deadline": {"2099-02-28"},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 201, status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/draw", authorization, encoding, map[string][]any{
+				"campaign": {"draw-" + encodingName},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 409, status)
+		})
+
+		t.Run("GetDraw/"+encodingName, func(t *testing.T) {
+			content, status, err := request("GET", "http://localhost:8888/game/draw/default", authorization, encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+
+			recorded := entities.Draw{}
+			json.Unmarshal(content, &recorded)
+			assert.Equal(t, draw.ID, recorded.ID)
+		})
+
+		t.Run("VerifyDraw/"+encodingName, func(t *testing.T) {
+			_, status, err := request("GET", "http://localhost:8888/game/draw/default/verify", authorization, encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+		})
+	}
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,73 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Campaign
+// @Summary		Expire the tickets of a campaign whose deadline has passed.
+// @Description	Tickets never claimed expire after the end of the campaign, prizes never collected after the claim deadline, each deadline delayed by project.expiry.grace minutes. Claims held for review are kept. Expired tickets stay stored but are left out of the ticket listings, and the forfeited prizes are recorded with their value.
+// @Description	The server runs the expiry by itself once each deadline and its grace period have passed, and at start for the deadlines passed while it was down. This endpoint runs it again on demand, e.g. after a failure logged by the server.
+// @Produce		application/json
+// @Router		/game/campaign/{id}/expire [post]
+// @Id			jwt.Auth => game.ExpireTickets
+// @Security 	Bearer
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Expired tickets and forfeited prizes"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "No deadline of the campaign has passed, or tickets changed during the expiry"
+func ExpireTickets(ctx *fiber.Ctx) error {
+	CampaignID := ctx.Params("id")
+
+	status, response := game.ExpireTickets(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Campaign{
+			ID: &CampaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Campaign
+// @Summary		List the ticket expiries of a campaign, the most recent first.
+// @Produce		application/json
+// @Router		/game/campaign/{id}/expiries [get]
+// @Id			jwt.Auth => game.GetTicketExpiries
+// @Security 	Bearer
+// @Param		id	path	string	true	"Campaign ID" format(uuid)
+// @Success		200	{object} 	nil "Ticket expiries"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetTicketExpiries(ctx *fiber.Ctx) error {
+	CampaignID := ctx.Params("id")
+
+	status, response := game.GetTicketExpiries(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Campaign{
+			ID: &CampaignID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,152 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiry(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	content, status, err := request("GET", "http://localhost:8888/game/prizes", "", JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	prizes := []*entities.Prize{}
+	json.Unmarshal(content, &prizes)
+	assert.NotEmpty(t, prizes)
+
+	// Une campagne future, ses tickets ne peuvent pas être tirés par les autres tests
+	content, status, err = request("POST", "http://localhost:8888/game/campaign", authorization, JSONEncoded, map[string][]any{
+		"label":          {"campaign-expiry"},
+		"start_at":       {"2099-10-01"},
+		"end_at":         {"2099-10-31"},
+		"claim_deadline": {"2099-11-30"},
+		"tickets":        {10},
+		"distribution":   {map[string]int{prizes[0].ID: 100}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 201, status)
+
+	campaign := entities.Campaign{}
+	json.Unmarshal(content, &campaign)
+	assert.NotEmpty(t, campaign.ID)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID+"/distribution", authorization, JSONEncoded, map[string][]any{
+		"tickets": {10},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	// dates déplace les échéances de la campagne
+	dates := func(start, end, deadline string) {
+		_, status, err := request("PUT", "http://localhost:8888/game/campaign/"+campaign.ID, authorization, JSONEncoded, map[string][]any{
+			"start_at":       {start},
+			"end_at":         {end},
+			"claim_deadline": {deadline},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+	}
+
+	t.Run("ExpireTickets", func(t *testing.T) {
+		_, status, err := request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/expire", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/campaign/campaign/expire", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/campaign/00000000-0000-4000-8000-000000000000/expire", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 404, status)
+
+		// Aucune échéance n'est encore passée
+		_, status, err = request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/expire", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 409, status)
+
+		// Toutes les échéances sont passées, puis la campagne redevient future pour les autres tests
+		dates("2001-10-01", "2001-10-31", "2001-11-30")
+		defer dates("2099-10-01", "2099-10-31", "2099-11-30")
+
+		content, status, err := request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/expire", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		expiry := entities.TicketExpiry{}
+		json.Unmarshal(content, &expiry)
+		assert.NotEmpty(t, expiry.ID)
+		assert.Equal(t, 10, expiry.Unclaimed)
+		assert.Equal(t, 0, expiry.Uncollected)
+
+		// Plus rien à expirer, rien n'est enregistré
+		content, status, err = request("POST", "http://localhost:8888/game/campaign/"+campaign.ID+"/expire", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		expiry = entities.TicketExpiry{}
+		json.Unmarshal(content, &expiry)
+		assert.Empty(t, expiry.ID)
+		assert.Equal(t, 0, expiry.Unclaimed)
+	})
+
+	t.Run("GetTickets", func(t *testing.T) {
+		content, status, err := request("GET", "http://localhost:8888/game/tickets?campaign_id="+campaign.ID, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		page := entities.TicketPage{}
+		json.Unmarshal(content, &page)
+		assert.Equal(t, 0, page.Total)
+
+		content, status, err = request("GET", "http://localhost:8888/game/tickets?status=expired&campaign_id="+campaign.ID, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		page = entities.TicketPage{}
+		json.Unmarshal(content, &page)
+		assert.Equal(t, 10, page.Total)
+	})
+
+	t.Run("GetTicketExpiries", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/campaign/"+campaign.ID+"/expiries", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/campaign/00000000-0000-4000-8000-000000000000/expiries", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 404, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/campaign/"+campaign.ID+"/expiries", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		expiries := []*entities.TicketExpiry{}
+		json.Unmarshal(content, &expiries)
+		if assert.Len(t, expiries, 1) {
+			assert.Equal(t, 10, expiries[0].Unclaimed)
+		}
+	})
+
+	assert.Nil(t, stop())
+}
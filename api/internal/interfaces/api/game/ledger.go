@@ -0,0 +1,72 @@
+package game
+
+import (
+	"bufio"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/observability/logger"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Ledger
+// @Summary		Export the ticket ledger for the bailiff.
+// @Description	Streams as CSV every entry of the append-only ledger of ticket creations, claims, redemptions and cancellations, in chain order. Each entry carries the SHA-256 of the ticket code rather than the code, and the hash chaining it to the previous entry, so the chain can be recomputed offline. Reserved to administrators and auditors.
+// @Produce		text/csv
+// @Router		/game/ledger [get]
+// @Id			jwt.Auth => game.ExportLedger
+// @Security 	Bearer
+// @Success		200	{file} 		nil "Ledger file"
+// @Failure		401	{object} 	nil "Unauthorized"
+func ExportLedger(ctx *fiber.Ctx) error {
+	service := services.Game(
+		security.NewUserAccess(ctx.Locals("token")),
+		repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+		userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+		mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+	)
+
+	status, response := game.PrepareLedger(service)
+	if status != fiber.StatusOK {
+		return ctx.Status(status).JSON(response)
+	}
+
+	ctx.Status(status)
+	ctx.Attachment("ledger.csv")
+	ctx.Set(fiber.HeaderContentType, "text/csv")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := game.WriteLedger(service, w); err != nil {
+			logger.Warn(err)
+		}
+	})
+
+	return nil
+}
+
+// @Tags		Ledger
+// @Summary		Verify the ticket ledger.
+// @Description	Recomputes the hash chain and compares the state it vouches for with the stored tickets. The report gives the sequence of the first broken entry, the number of tickets altered, added or removed behind the ledger with the first of them, and the hash of the last entry. Reserved to administrators and auditors.
+// @Produce		application/json
+// @Router		/game/ledger/verify [get]
+// @Id			jwt.Auth => game.VerifyLedger
+// @Security 	Bearer
+// @Success		200	{object} 	nil "Verification report"
+// @Failure		401	{object} 	nil "Unauthorized"
+func VerifyLedger(ctx *fiber.Ctx) error {
+	status, response := game.VerifyLedger(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,72 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	// login retourne l'en-tête d'autorisation du compte
+	login := func(account string) string {
+		JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+			"email":    {account},
+			"password": {password},
+		})
+		require.Nil(t, err)
+		require.Equal(t, 200, status)
+
+		var tokenData fiber.Map
+		require.Nil(t, json.Unmarshal(JWT, &tokenData))
+
+		return "Bearer " + tokenData["access_token"].(string)
+	}
+
+	employee, bailiff := login(email), login(auditor)
+
+	t.Run("ExportLedger", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/ledger", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		// Le registre est réservé aux administrateurs et aux auditeurs
+		_, status, err = request("GET", "http://localhost:8888/game/ledger", employee, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/ledger", bailiff, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		require.Nil(t, err)
+		require.Greater(t, len(records), 1)
+		assert.Equal(t, entities.LedgerHeader, records[0])
+		assert.Equal(t, entities.LedgerGenesis, records[1][11])
+	})
+
+	t.Run("VerifyLedger", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/ledger/verify", employee, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/ledger/verify", bailiff, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		report := entities.LedgerReport{}
+		require.Nil(t, json.Unmarshal(content, &report))
+		assert.True(t, report.Valid, "%+v", report)
+		assert.Nil(t, report.BrokenAt)
+		assert.NotEmpty(t, report.Head)
+		assert.GreaterOrEqual(t, report.Entries, report.Tickets)
+	})
+}
@@ -0,0 +1,166 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Prize
+// @Summary		List the prizes of the game, in display order.
+// @Produce		application/json
+// @Router		/game/prizes [get]
+// @Id			game.GetPrizes
+// @Success		200	{object} 	nil "Prizes details"
+// @Failure		500	{object} 	nil "Internal server error"
+func GetPrizes(ctx *fiber.Ctx) error {
+	status, response := game.GetPrizes(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Summary		Get a prize by id.
+// @Produce		application/json
+// @Router		/game/prize/{id} [get]
+// @Id			game.GetPrize
+// @Param		id	path	string	true	"Prize ID" format(uuid)
+// @Success		200	{object} 	nil "Prize details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		404	{object} 	nil "Not found"
+func GetPrize(ctx *fiber.Ctx) error {
+	PrizeID := ctx.Params("id")
+
+	status, response := game.GetPrize(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Prize{
+			ID: &PrizeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Add a prize to the catalogue.
+// @Produce		application/json
+// @Router		/game/prize [post]
+// @Id			jwt.Auth => game.CreatePrize
+// @Security 	Bearer
+// @Param		label			formData	string	true	"Label"
+// @Param		description		formData	string	false	"Description"
+// @Param		value			formData	number	true	"Retail value in euros"
+// @Param		image			formData	string	false	"Image URL"
+// @Param		position		formData	int		false	"Display order"
+// @Param		active			formData	bool	false	"Can be won" default(true)
+// @Param		distribution	formData	int		true	"Share of the tickets, in percent"
+// @Success		201	{object} 	nil "Prize created"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		409	{object} 	nil "Prize already exists"
+func CreatePrize(ctx *fiber.Ctx) error {
+	dtoPrize := &transfert.Prize{}
+	if err := ctx.BodyParser(dtoPrize); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.CreatePrize(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoPrize,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Accept		multipart/form-data
+// @Summary		Update a prize of the catalogue.
+// @Produce		application/json
+// @Router		/game/prize/{id} [put]
+// @Id			jwt.Auth => game.UpdatePrize
+// @Security 	Bearer
+// @Param		id				path		string	true	"Prize ID" format(uuid)
+// @Param		label			formData	string	false	"Label"
+// @Param		description		formData	string	false	"Description"
+// @Param		value			formData	number	false	"Retail value in euros"
+// @Param		image			formData	string	false	"Image URL"
+// @Param		position		formData	int		false	"Display order"
+// @Param		active			formData	bool	false	"Can be won"
+// @Param		distribution	formData	int		false	"Share of the tickets, in percent"
+// @Success		200	{object} 	nil "Prize updated"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Prize already exists"
+func UpdatePrize(ctx *fiber.Ctx) error {
+	dtoPrize := &transfert.Prize{}
+	if err := ctx.BodyParser(dtoPrize); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	PrizeID := ctx.Params("id")
+	dtoPrize.ID = &PrizeID
+
+	status, response := game.UpdatePrize(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoPrize,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Prize
+// @Summary		Remove a prize that was never attributed to a ticket.
+// @Produce		application/json
+// @Router		/game/prize/{id} [delete]
+// @Id			jwt.Auth => game.DeletePrize
+// @Security 	Bearer
+// @Param		id	path	string	true	"Prize ID" format(uuid)
+// @Success		204	{object} 	nil "Prize deleted"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Prize in use"
+func DeletePrize(ctx *fiber.Ctx) error {
+	PrizeID := ctx.Params("id")
+
+	status, response := game.DeletePrize(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), &transfert.Prize{
+			ID: &PrizeID,
+		},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,104 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrize(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+		}
+
+		t.Run("CreatePrize/"+encodingName, func(t *testing.T) {
+			_, status, err := request("POST", "http://localhost:8888/game/prize", "", encoding, map[string][]any{
+				"label":        {"Coffret découverte " + encodingName},
+				"value":        {39},
+				"distribution": {6},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 401, status)
+
+			content, status, err := request("POST", "http://localhost:8888/game/prize", authorization, encoding, map[string][]any{
+				"label":        {"Coffret découverte " + encodingName},
+				"value":        {39},
+				"distribution": {6},
+			})
+			assert.Nil(t, err)
+			assert.Equal(t, 201, status)
+
+			prize := entities.Prize{}
+			json.Unmarshal(content, &prize)
+			assert.NotEmpty(t, prize.ID)
+
+			t.Run("GetPrizes/"+encodingName, func(t *testing.T) {
+				content, status, err := request("GET", "http://localhost:8888/game/prizes", "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				prizes := []*entities.Prize{}
+				json.Unmarshal(content, &prizes)
+				assert.NotEmpty(t, prizes)
+			})
+
+			t.Run("GetPrize/"+encodingName, func(t *testing.T) {
+				_, status, err := request("GET", "http://localhost:8888/game/prize/"+prize.ID, "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+			})
+
+			t.Run("UpdatePrize/"+encodingName, func(t *testing.T) {
+				content, status, err := request("PUT", "http://localhost:8888/game/prize/"+prize.ID, authorization, encoding, map[string][]any{
+					"distribution": {500},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
+
+				content, status, err = request("PUT", "http://localhost:8888/game/prize/"+prize.ID, authorization, encoding, map[string][]any{
+					"active": {false},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				updated := entities.Prize{}
+				json.Unmarshal(content, &updated)
+				assert.False(t, updated.IsActive())
+
+				_, status, err = request("GET", "http://localhost:8888/game/prize/"+prize.ID, "", encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 404, status)
+			})
+
+			t.Run("DeletePrize/"+encodingName, func(t *testing.T) {
+				_, status, err := request("DELETE", "http://localhost:8888/game/prize/"+prize.ID, authorization, encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 204, status)
+			})
+		})
+	}
+
+	assert.Nil(t, stop())
+}
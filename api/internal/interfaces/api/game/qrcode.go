@@ -0,0 +1,62 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/qr"
+)
+
+// @Tags		Ticket
+// @Summary		Render a ticket token as a QR code.
+// @Description	Renders the code of a ticket for printed tickets and prize vouchers, locally without any external service. Employees may render any ticket, clients only the tickets they hold.
+// @Produce		image/png
+// @Produce		image/svg+xml
+// @Router		/game/qrcode/{token} [get]
+// @Id			jwt.Auth => game.RenderQRCode
+// @Security 	Bearer
+// @Param		token	path	string	true	"Ticket code"
+// @Param		format	query	string	false	"Image format" Enums(png, svg) default(png)
+// @Param		size	query	integer	false	"Side of the image in pixels" minimum(64) maximum(2048) default(256)
+// @Param		level	query	string	false	"Error-correction level" Enums(L, M, Q, H) default(M)
+// @Param		link	query	boolean	false	"Encode the link to the claim screen instead of the bare code"
+// @Success		200	{file} 		nil "QR code image"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Ticket not found"
+// @Failure		500	{object} 	nil "No claim screen configured"
+func RenderQRCode(ctx *fiber.Ctx) error {
+	dtoQRCode := &transfert.QRCode{}
+	if err := ctx.QueryParser(dtoQRCode); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	token := ctx.Params("token")
+	dtoQRCode.Token = &token
+
+	status, response := game.RenderQRCode(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoQRCode,
+	)
+
+	qrcode, ok := response.(*entities.QRCode)
+	if !ok {
+		return ctx.Status(status).JSON(response)
+	}
+
+	ctx.Set(fiber.HeaderContentType, qr.ContentType(qrcode.Format))
+
+	return ctx.Status(status).Send(qrcode.Image)
+}
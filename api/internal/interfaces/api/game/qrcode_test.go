@@ -0,0 +1,68 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQRCode(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	content, status, err := request("GET", "http://localhost:8888/game/random", authorization, JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	ticket := entities.Ticket{}
+	json.Unmarshal(content, &ticket)
+	code := ticket.Token.String()
+	assert.NotEmpty(t, code)
+
+	t.Run("RenderQRCode", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/qrcode/"+code, "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/qrcode/79927398710", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/qrcode/"+code+"?size=10", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/qrcode/"+code+"?size=128", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		img, err := png.Decode(bytes.NewReader(content))
+		assert.Nil(t, err)
+		assert.Equal(t, 128, img.Bounds().Dx())
+
+		content, status, err = request("GET", "http://localhost:8888/game/qrcode/"+code+"?format=svg&level=H&link=true", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+		assert.Contains(t, string(content), "<svg")
+	})
+
+	assert.Nil(t, stop())
+}
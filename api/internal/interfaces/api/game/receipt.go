@@ -0,0 +1,50 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Record a purchase over 49€ at a caisse and hand a ticket over for it.
+// @Produce		application/json
+// @Router		/game/receipt [post]
+// @Id			jwt.Auth => game.IssueReceipt
+// @Security 	Bearer
+// @Param		store_id		formData	string	true	"Store ID" format(uuid)
+// @Param		caisse_id		formData	string	true	"Caisse ID" format(uuid)
+// @Param		number			formData	string	true	"Receipt number, unique within the store"
+// @Param		amount			formData	number	true	"Purchase amount in euros"
+// @Param		purchased_at	formData	string	false	"Purchase date, now by default"
+// @Param		token			formData	string	false	"Printed ticket code to hand over, a ticket is issued otherwise"
+// @Success		201	{object} 	nil "Receipt with its ticket"
+// @Failure		400	{object} 	nil "Bad request or purchase not qualifying"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Ticket not found"
+// @Failure		409	{object} 	nil "Receipt already recorded or no ticket to hand over"
+func IssueReceipt(ctx *fiber.Ctx) error {
+	dtoReceipt := &transfert.Receipt{}
+	if err := ctx.BodyParser(dtoReceipt); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.IssueReceipt(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoReceipt,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,73 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceipt(t *testing.T) {
+	encodingTypes := []EncodingType{FormURLEncoded, JSONEncoded}
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+
+	for _, encoding := range encodingTypes {
+		var encodingName string = "FormURLEncoded"
+		number := "form-0042"
+		if encoding == JSONEncoded {
+			encodingName = "JSONEncoded"
+			number = "json-0042"
+		}
+
+		t.Run("IssueReceipt/"+encodingName, func(t *testing.T) {
+			values := map[string][]any{
+				"store_id":  {"5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"},
+				"caisse_id": {"9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"},
+				"number":    {number},
+				"amount":    {52.3},
+			}
+
+			_, status, err := request("POST", "http://localhost:8888/game/receipt", "", encoding, values)
+			assert.Nil(t, err)
+			assert.Equal(t, 401, status)
+
+			content, status, err := request("POST", "http://localhost:8888/game/receipt", authorization, encoding, values)
+			assert.Nil(t, err)
+			assert.Equal(t, 201, status)
+
+			receipt := entities.Receipt{}
+			assert.Nil(t, json.Unmarshal(content, &receipt))
+			assert.Equal(t, number, receipt.Number)
+			assert.NotNil(t, receipt.Ticket)
+			assert.Equal(t, entities.TicketDistributed, receipt.Ticket.Status)
+
+			_, status, err = request("POST", "http://localhost:8888/game/receipt", authorization, encoding, values)
+			assert.Nil(t, err)
+			assert.Equal(t, 409, status)
+
+			values["number"] = []any{number + "-small"}
+			values["amount"] = []any{12.5}
+			_, status, err = request("POST", "http://localhost:8888/game/receipt", authorization, encoding, values)
+			assert.Nil(t, err)
+			assert.Equal(t, 400, status)
+		})
+	}
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,102 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Statistics
+// @Summary		Count the tickets claimed during a period and those still unclaimed at its end, per prize.
+// @Produce		application/json
+// @Router		/game/statistics/prizes [get]
+// @Id			jwt.Auth => game.GetPrizeStatistics
+// @Security 	Bearer
+// @Param		from	query	string	false	"First day or instant included, UTC without offset" example(2024-10-01)
+// @Param		to		query	string	false	"Last day or instant included, UTC without offset" example(2024-10-31)
+// @Success		200	{object} 	nil "Statistics per prize"
+// @Failure		400	{object} 	nil "Invalid period"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetPrizeStatistics(ctx *fiber.Ctx) error {
+	dtoStatistics := &transfert.Statistics{}
+	if err := ctx.QueryParser(dtoStatistics); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.GetPrizeStatistics(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoStatistics,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Statistics
+// @Summary		Count the tickets claimed during a period, per day or hour.
+// @Produce		application/json
+// @Router		/game/statistics/claims [get]
+// @Id			jwt.Auth => game.GetClaimStatistics
+// @Security 	Bearer
+// @Param		from		query	string	false	"First day or instant included, UTC without offset" example(2024-10-01)
+// @Param		to			query	string	false	"Last day or instant included, UTC without offset" example(2024-10-31)
+// @Param		interval	query	string	false	"Grouping interval" Enums(day, hour) default(day)
+// @Success		200	{object} 	nil "Claims per period"
+// @Failure		400	{object} 	nil "Invalid period or interval"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetClaimStatistics(ctx *fiber.Ctx) error {
+	dtoStatistics := &transfert.Statistics{}
+	if err := ctx.QueryParser(dtoStatistics); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.GetClaimStatistics(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoStatistics,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Statistics
+// @Summary		Count the tickets claimed and redeemed during a period, per store and caisse.
+// @Produce		application/json
+// @Router		/game/statistics/stores [get]
+// @Id			jwt.Auth => game.GetStoreStatistics
+// @Security 	Bearer
+// @Param		from	query	string	false	"First day or instant included, UTC without offset" example(2024-10-01)
+// @Param		to		query	string	false	"Last day or instant included, UTC without offset" example(2024-10-31)
+// @Success		200	{object} 	nil "Statistics per store and caisse"
+// @Failure		400	{object} 	nil "Invalid period"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetStoreStatistics(ctx *fiber.Ctx) error {
+	dtoStatistics := &transfert.Statistics{}
+	if err := ctx.QueryParser(dtoStatistics); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.GetStoreStatistics(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoStatistics,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,75 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistics(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+		"email":    {email},
+		"password": {password},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	var tokenData fiber.Map
+	err = json.Unmarshal(JWT, &tokenData)
+	assert.Nil(t, err)
+
+	authorization := "Bearer " + tokenData["access_token"].(string)
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	for _, route := range []string{"prizes", "claims", "stores"} {
+		_, status, err = request("GET", "http://localhost:8888/game/statistics/"+route, "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/statistics/"+route+"?from=2024-10-31&to=2024-10-01", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/statistics/"+route+"?to="+today, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+	}
+
+	t.Run("Prizes", func(t *testing.T) {
+		content, status, err := request("GET", "http://localhost:8888/game/statistics/prizes", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		var statistics []*entities.PrizeStatistic
+		assert.Nil(t, json.Unmarshal(content, &statistics))
+		assert.NotEmpty(t, statistics)
+
+		total := 0
+		for _, statistic := range statistics {
+			total += statistic.Claimed + statistic.Unclaimed
+		}
+		assert.GreaterOrEqual(t, total, 100)
+	})
+
+	t.Run("Claims", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/statistics/claims?interval=week", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/statistics/claims?interval=hour&from="+today, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		var statistics []*entities.PeriodStatistic
+		assert.Nil(t, json.Unmarshal(content, &statistics))
+	})
+
+	assert.Nil(t, stop())
+}
@@ -1,16 +1,26 @@
 package game
 
 import (
+	"mime/multipart"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/kodmain/thetiptop/api/config"
 	"github.com/kodmain/thetiptop/api/internal/application/security"
 	"github.com/kodmain/thetiptop/api/internal/application/services/game"
 	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	userTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
 	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/errors"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/aws/s3"
 	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
 )
 
+// DeviceHeader carries the device identifier sent by the client apps, used to detect claims made in bulk
+const DeviceHeader = "X-Device-ID"
+
 // @Tags		Game
 // @Accept		multipart/form-data
 // @Summary		Get a random ticket.
@@ -26,6 +36,8 @@ func GetTicket(ctx *fiber.Ctx) error {
 		services.Game(
 			security.NewUserAccess(ctx.Locals("token")),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
 		),
 	)
 
@@ -33,21 +45,35 @@ func GetTicket(ctx *fiber.Ctx) error {
 }
 
 // @Tags		Game
-// @Accept		multipart/form-data
-// @Summary		List all tickets likend to the authenticated user.
+// @Summary		List a page of tickets, those of the authenticated client or all of them for employees.
 // @Produce		application/json
 // @Router		/game/tickets [get]
 // @Id			jwt.Auth => game.GetTickets
 // @Security 	Bearer
-// @Success		200	{object} 	nil "Tickets details"
+// @Param		prize_id		query	string	false	"Prize ID" format(uuid)
+// @Param		campaign_id		query	string	false	"Campaign ID" format(uuid)
+// @Param		status			query	string	false	"Ticket status" Enums(generated, distributed, review, claimed, redeemed, cancelled, expired)
+// @Param		credential_id	query	string	false	"Owner credential ID, employees only" format(uuid)
+// @Param		token			query	string	false	"Ticket code, employees only"
+// @Param		sort			query	string	false	"Sort by claim date" Enums(claimed_at, -claimed_at) default(-claimed_at)
+// @Param		page			query	int		false	"Page number" minimum(1) default(1)
+// @Param		limit			query	int		false	"Tickets per page" minimum(1) maximum(100) default(20)
+// @Success		200	{object} 	nil "Page of tickets with the total count"
 // @Failure		400	{object} 	nil "Bad request"
-// @Failure		404	{object} 	nil "Not found"
+// @Failure		401	{object} 	nil "Unauthorized"
 func GetTickets(ctx *fiber.Ctx) error {
+	dtoSearch := &transfert.TicketSearch{}
+	if err := ctx.QueryParser(dtoSearch); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
 	status, response := game.GetTickets(
 		services.Game(
 			security.NewUserAccess(ctx.Locals("token")),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
-		),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoSearch,
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -60,27 +86,78 @@ func GetTickets(ctx *fiber.Ctx) error {
 // @Router		/game/ticket [put]
 // @Id			jwt.Auth => game.UpdateTicket
 // @Security 	Bearer
-// @Param		id	formData	string	true	"Ticket ID" format(uuid)
+// @Param		token			formData	string	true	"Printed code of the ticket"
+// @Param		receipt_photo	formData	file	false	"Photo of the purchase receipt, JPEG, PNG or PDF up to 3 MB, kept for disputes"
+// @Param		X-Device-ID		header		string	false	"Identifier of the client device"
 // @Success		200	{object} 	nil "Ticket details"
+// @Success		202	{object} 	nil "Claim held for review"
 // @Failure		400	{object} 	nil "Bad request"
 // @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Client excluded by the eligibility rules of the campaign"
 // @Failure		404	{object} 	nil "Not found"
+// @Failure		413	{object} 	nil "Receipt photo too large"
 func UpdateTicket(ctx *fiber.Ctx) error {
 	dtoTicket := &transfert.Ticket{}
 	if err := ctx.BodyParser(dtoTicket); err != nil {
 		return ctx.Status(fiber.StatusBadRequest).JSON(err)
 	}
 
+	if photo, err := ctx.FormFile("receipt_photo"); err == nil {
+		key, err := uploadReceiptPhoto(photo)
+		if err != nil {
+			return ctx.Status(err.Code()).JSON(err)
+		}
+
+		dtoTicket.ReceiptPhoto = key
+	}
+
+	access := security.NewUserAccess(ctx.Locals("token"))
+
 	status, response := game.UpdateTicket(
 		services.Game(
-			security.NewUserAccess(ctx.Locals("token")),
+			access,
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
-		), dtoTicket,
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTicket, claimAttempt(ctx, access),
 	)
 
 	return ctx.Status(status).JSON(response)
 }
 
+// uploadReceiptPhoto stores the receipt photo attached to a claim in the receipts bucket
+func uploadReceiptPhoto(photo *multipart.FileHeader) (*string, errors.ErrorInterface) {
+	storage, err := s3.New()
+	if err != nil {
+		return nil, errors.ErrInternalServer.Log(err)
+	}
+
+	return game.UploadReceiptPhoto(storage, config.GetString("project.receipts.bucket", ""), photo)
+}
+
+// claimAttempt reads the origin of a claim from the request, with the registration date of the client account
+func claimAttempt(ctx *fiber.Ctx, access *security.UserAccess) *transfert.ClaimAttempt {
+	ip, device := ctx.IP(), ctx.Get(DeviceHeader)
+	claim := &transfert.ClaimAttempt{
+		IP:     &ip,
+		Device: &device,
+	}
+
+	if !access.IsAuthenticated() {
+		return claim
+	}
+
+	credential, err := userRepositories.NewUserRepository(
+		database.Get(config.GetString("services.client.database", config.DEFAULT)),
+	).ReadCredential(&userTransfert.Credential{ID: access.GetCredentialID()})
+
+	if err == nil {
+		claim.RegisteredAt = &credential.CreatedAt
+	}
+
+	return claim
+}
+
 // @Tags		Game
 // @Accept		multipart/form-data
 // @Summary		Get ticket by id.
@@ -106,6 +183,111 @@ func GetTicketById(ctx *fiber.Ctx) error {
 		services.Game(
 			security.NewUserAccess(ctx.Locals("token")),
 			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTicket,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Distribute or cancel a ticket.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/status [put]
+// @Id			jwt.Auth => game.UpdateTicketStatus
+// @Security 	Bearer
+// @Param		id		path		string	true	"Ticket ID" format(uuid)
+// @Param		status	formData	string	true	"New status, claims, reviews and redemptions have their own endpoints" Enums(distributed, cancelled)
+// @Param		store_id	formData	string	false	"Store where the change happens" format(uuid)
+// @Param		caisse_id	formData	string	false	"Caisse where the change happens" format(uuid)
+// @Success		200	{object} 	nil "Ticket details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Transition not allowed"
+func UpdateTicketStatus(ctx *fiber.Ctx) error {
+	dtoTicket := &transfert.Ticket{}
+	if err := ctx.BodyParser(dtoTicket); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	TicketID := ctx.Params("id")
+	dtoTicket.ID = &TicketID
+
+	status, response := game.UpdateTicketStatus(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTicket,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Hand over the prize of a claimed ticket at a caisse, found by its printed code.
+// @Produce		application/json
+// @Router		/game/ticket/redeem [put]
+// @Id			jwt.Auth => game.RedeemTicket
+// @Security 	Bearer
+// @Param		token			formData	string	true	"Printed ticket code"
+// @Param		credential_id	formData	string	true	"Credential ID of the presenting client" format(uuid)
+// @Param		store_id		formData	string	true	"Store ID" format(uuid)
+// @Param		caisse_id		formData	string	true	"Caisse ID" format(uuid)
+// @Success		200	{object} 	nil "Ticket details"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Ticket claimed by another client"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Ticket not claimed or already redeemed"
+func RedeemTicket(ctx *fiber.Ctx) error {
+	dtoTicket := &transfert.Ticket{}
+	if err := ctx.BodyParser(dtoTicket); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := game.RedeemTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTicket,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		List the status changes of a ticket.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/history [get]
+// @Id			jwt.Auth => game.GetTicketHistory
+// @Security 	Bearer
+// @Param		id	path	string	true	"Ticket ID" format(uuid)
+// @Success		200	{object} 	nil "Ticket history"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		404	{object} 	nil "Not found"
+func GetTicketHistory(ctx *fiber.Ctx) error {
+	TicketID := ctx.Params("id")
+
+	dtoTicket := &transfert.Ticket{
+		ID: &TicketID,
+	}
+
+	status, response := game.GetTicketHistory(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
 		), dtoTicket,
 	)
 
@@ -48,11 +48,36 @@ func TestGame(t *testing.T) {
 			json.Unmarshal(randomTicket, &ticket)
 
 			assert.NotNil(t, ticket)
+
+			// Un ticket jamais remis en caisse, de la même campagne, n'a pas encore d'historique
+			search := "http://localhost:8888/game/tickets?status=generated&limit=1"
+			if ticket.CampaignID != nil {
+				search += "&campaign_id=" + *ticket.CampaignID
+			}
+
+			generated, status, err := request("GET", search, authorization, encoding)
+			assert.Nil(t, err)
+			assert.Equal(t, 200, status)
+
+			page := entities.TicketPage{}
+			json.Unmarshal(generated, &page)
+
+			if assert.Len(t, page.Tickets, 1) {
+				ticket = *page.Tickets[0]
+			}
+
 			t.Run("UpdateTicket/"+encodingName, func(t *testing.T) {
+				// Un ticket ne peut être réclamé que par son code imprimé
 				updatedTicket, status, err := request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
 					"id": {ticket.ID},
 				})
 				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
+
+				updatedTicket, status, err = request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
+					"token": {ticket.Token.String()},
+				})
+				assert.Nil(t, err)
 				assert.Equal(t, 200, status)
 
 				ticket = entities.Ticket{}
@@ -61,15 +86,101 @@ func TestGame(t *testing.T) {
 				assert.NotNil(t, ticket)
 
 				t.Run("GetTickets/"+encodingName, func(t *testing.T) {
-					tickets, status, err := request("GET", "http://localhost:8888/game/tickets", authorization, encoding)
+					tickets, status, err := request("GET", "http://localhost:8888/game/tickets?status=claimed&limit=1", authorization, encoding)
+					assert.Nil(t, err)
+					assert.Equal(t, 200, status)
+
+					page := entities.TicketPage{}
+					json.Unmarshal(tickets, &page)
+
+					assert.Len(t, page.Tickets, 1)
+					assert.GreaterOrEqual(t, page.Total, 1)
+					assert.Equal(t, 1, page.Page)
+					assert.Equal(t, 1, page.Limit)
+
+					_, status, err = request("GET", "http://localhost:8888/game/tickets?sort=token", authorization, encoding)
+					assert.Nil(t, err)
+					assert.Equal(t, 400, status)
+				})
+
+				t.Run("UpdateTicketStatus/"+encodingName, func(t *testing.T) {
+					_, status, err := request("PUT", "http://localhost:8888/game/ticket/"+ticket.ID+"/status", authorization, encoding, map[string][]any{
+						"status": {"redeemed"},
+					})
+					assert.Nil(t, err)
+					assert.Equal(t, 409, status)
+
+					cancelledTicket, status, err := request("PUT", "http://localhost:8888/game/ticket/"+ticket.ID+"/status", authorization, encoding, map[string][]any{
+						"status": {"cancelled"},
+					})
+					assert.Nil(t, err)
+					assert.Equal(t, 200, status)
+
+					cancelled := entities.Ticket{}
+					json.Unmarshal(cancelledTicket, &cancelled)
+
+					assert.Equal(t, entities.TicketCancelled, cancelled.Status)
+
+					_, status, err = request("PUT", "http://localhost:8888/game/ticket/"+ticket.ID+"/status", authorization, encoding, map[string][]any{
+						"status": {"claimed"},
+					})
+					assert.Nil(t, err)
+					assert.Equal(t, 409, status)
+				})
+
+				t.Run("GetTicketHistory/"+encodingName, func(t *testing.T) {
+					history, status, err := request("GET", "http://localhost:8888/game/ticket/"+ticket.ID+"/history", authorization, encoding)
 					assert.Nil(t, err)
 					assert.Equal(t, 200, status)
 
-					ticketsArray := []*entities.Ticket{}
-					json.Unmarshal(tickets, &ticketsArray)
+					histories := []*entities.TicketHistory{}
+					json.Unmarshal(history, &histories)
+
+					assert.Len(t, histories, 2)
+					assert.Equal(t, entities.TicketClaimed, histories[0].Status)
+					assert.Equal(t, entities.TicketCancelled, histories[1].Status)
+				})
+			})
+
+			t.Run("RedeemTicket/"+encodingName, func(t *testing.T) {
+				randomTicket, status, err := request("GET", "http://localhost:8888/game/random", authorization, encoding)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				claimed := entities.Ticket{}
+				json.Unmarshal(randomTicket, &claimed)
+
+				_, status, err = request("PUT", "http://localhost:8888/game/ticket", authorization, encoding, map[string][]any{
+					"token": {claimed.Token.String()},
+				})
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				values := map[string][]any{
+					"token":         {claimed.Token.String()},
+					"credential_id": {claims.ID},
+					"store_id":      {"5c1d7a2e-8b3f-4e6a-9d0c-1f2e3a4b5c6d"},
+					"caisse_id":     {"9e8d7c6b-5a4f-4e3d-8c2b-1a0f9e8d7c6b"},
+				}
+
+				redeemedTicket, status, err := request("PUT", "http://localhost:8888/game/ticket/redeem", authorization, encoding, values)
+				assert.Nil(t, err)
+				assert.Equal(t, 200, status)
+
+				redeemed := entities.Ticket{}
+				json.Unmarshal(redeemedTicket, &redeemed)
+
+				assert.Equal(t, entities.TicketRedeemed, redeemed.Status)
 
-					assert.NotNil(t, ticketsArray)
+				_, status, err = request("PUT", "http://localhost:8888/game/ticket/redeem", authorization, encoding, values)
+				assert.Nil(t, err)
+				assert.Equal(t, 409, status)
+
+				_, status, err = request("PUT", "http://localhost:8888/game/ticket/redeem", authorization, encoding, map[string][]any{
+					"token": {claimed.Token.String()},
 				})
+				assert.Nil(t, err)
+				assert.Equal(t, 400, status)
 			})
 
 			t.Run("GetTicketById/"+encodingName, func(t *testing.T) {
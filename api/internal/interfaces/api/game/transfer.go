@@ -0,0 +1,116 @@
+package game
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	"github.com/kodmain/thetiptop/api/internal/application/services/game"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/services"
+	userRepositories "github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Offer a claimed ticket to another client, who must accept it.
+// @Produce		application/json
+// @Router		/game/ticket/{id}/transfer [post]
+// @Id			jwt.Auth => game.GiftTicket
+// @Security 	Bearer
+// @Param		id		path		string	true	"Ticket ID" format(uuid)
+// @Param		email	formData	string	true	"Email of the recipient" format(email)
+// @Success		201	{object} 	nil "Pending transfer"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Ticket held by another client or claim deadline passed"
+// @Failure		404	{object} 	nil "Ticket or recipient not found"
+// @Failure		409	{object} 	nil "Ticket not claimed, already redeemed or already offered"
+func GiftTicket(ctx *fiber.Ctx) error {
+	dtoTransfer := &transfert.TicketTransfer{}
+	if err := ctx.BodyParser(dtoTransfer); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	TicketID := ctx.Params("id")
+	dtoTransfer.TicketID = &TicketID
+
+	status, response := game.GiftTicket(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTransfer,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Summary		List a page of the ticket transfers sent or received by the client, most recent first.
+// @Produce		application/json
+// @Router		/game/transfers [get]
+// @Id			jwt.Auth => game.GetTicketTransfers
+// @Security 	Bearer
+// @Param		status	query	string	false	"Transfer status" Enums(pending, accepted, declined, cancelled)
+// @Param		page	query	int		false	"Page number" minimum(1) default(1)
+// @Param		limit	query	int		false	"Transfers per page" minimum(1) maximum(100) default(20)
+// @Success		200	{object} 	nil "Page of transfers with their ticket and the total count"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+func GetTicketTransfers(ctx *fiber.Ctx) error {
+	dtoTransfer := &transfert.TicketTransfer{}
+	if err := ctx.QueryParser(dtoTransfer); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := game.GetTicketTransfers(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTransfer,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Game
+// @Accept		multipart/form-data
+// @Summary		Accept or decline a ticket offered to the client, or cancel an offer it made.
+// @Produce		application/json
+// @Router		/game/transfer/{id} [put]
+// @Id			jwt.Auth => game.AnswerTicketTransfer
+// @Security 	Bearer
+// @Param		id		path		string	true	"Transfer ID" format(uuid)
+// @Param		status	formData	string	true	"Answer" Enums(accepted, declined, cancelled)
+// @Success		200	{object} 	nil "Transfer with its ticket"
+// @Failure		400	{object} 	nil "Bad request"
+// @Failure		401	{object} 	nil "Unauthorized"
+// @Failure		403	{object} 	nil "Ticket no longer held by the sender, claim deadline passed or recipient not eligible"
+// @Failure		404	{object} 	nil "Not found"
+// @Failure		409	{object} 	nil "Transfer already answered or ticket no longer transferable"
+func AnswerTicketTransfer(ctx *fiber.Ctx) error {
+	dtoTransfer := &transfert.TicketTransfer{}
+	if err := ctx.BodyParser(dtoTransfer); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	TransferID := ctx.Params("id")
+	dtoTransfer.ID = &TransferID
+
+	status, response := game.AnswerTicketTransfer(
+		services.Game(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			userRepositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.game.mail", config.DEFAULT)),
+		), dtoTransfer,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
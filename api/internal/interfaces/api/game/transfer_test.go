@@ -0,0 +1,161 @@
+package game_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransfer(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	login := func(login string) string {
+		JWT, status, err := request("POST", "http://localhost:8888/user/auth", "", JSONEncoded, map[string][]any{
+			"email":    {login},
+			"password": {password},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		var tokenData fiber.Map
+		assert.Nil(t, json.Unmarshal(JWT, &tokenData))
+
+		return "Bearer " + tokenData["access_token"].(string)
+	}
+
+	employee := login(email)
+	sender := login(clients[0])
+	recipient := login(clients[1])
+
+	content, status, err := request("GET", "http://localhost:8888/game/random", employee, JSONEncoded)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	ticket := entities.Ticket{}
+	json.Unmarshal(content, &ticket)
+
+	_, status, err = request("PUT", "http://localhost:8888/game/ticket", sender, JSONEncoded, map[string][]any{
+		"token": {ticket.Token.String()},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, status)
+
+	transfer := entities.TicketTransfer{}
+
+	t.Run("GiftTicket", func(t *testing.T) {
+		_, status, err := request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", "", FormURLEncoded, map[string][]any{
+			"email": {clients[1]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", recipient, FormURLEncoded, map[string][]any{
+			"email": {clients[0]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 403, status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", sender, FormURLEncoded, map[string][]any{
+			"email": {clients[0]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		// Les employés ne sont pas des clients
+		_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", sender, FormURLEncoded, map[string][]any{
+			"email": {email},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 404, status)
+
+		content, status, err := request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", sender, JSONEncoded, map[string][]any{
+			"email": {clients[1]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 201, status)
+
+		json.Unmarshal(content, &transfer)
+		assert.NotEmpty(t, transfer.ID)
+		assert.Equal(t, entities.TransferPending, transfer.Status)
+
+		_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", sender, FormURLEncoded, map[string][]any{
+			"email": {clients[1]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 409, status)
+	})
+
+	t.Run("GetTicketTransfers", func(t *testing.T) {
+		_, status, err := request("GET", "http://localhost:8888/game/transfers?status=lost", recipient, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		_, status, err = request("GET", "http://localhost:8888/game/transfers?limit=101", recipient, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 400, status)
+
+		content, status, err := request("GET", "http://localhost:8888/game/transfers?status=pending", recipient, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		page := &entities.TransferPage{}
+		json.Unmarshal(content, page)
+		assert.Equal(t, 1, page.Total)
+		if assert.NotEmpty(t, page.Transfers) {
+			assert.Equal(t, transfer.ID, page.Transfers[0].ID)
+		}
+	})
+
+	t.Run("AnswerTicketTransfer", func(t *testing.T) {
+		// Le donateur ne peut pas accepter à la place du destinataire
+		_, status, err := request("PUT", "http://localhost:8888/game/transfer/"+transfer.ID, sender, FormURLEncoded, map[string][]any{
+			"status": {"accepted"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 401, status)
+
+		content, status, err := request("PUT", "http://localhost:8888/game/transfer/"+transfer.ID, recipient, FormURLEncoded, map[string][]any{
+			"status": {"accepted"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		answered := entities.TicketTransfer{}
+		json.Unmarshal(content, &answered)
+		assert.Equal(t, entities.TransferAccepted, answered.Status)
+
+		_, status, err = request("PUT", "http://localhost:8888/game/transfer/"+transfer.ID, recipient, FormURLEncoded, map[string][]any{
+			"status": {"declined"},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 409, status)
+
+		// L'historique garde la trace des deux propriétaires
+		content, status, err = request("GET", "http://localhost:8888/game/ticket/"+ticket.ID+"/history", recipient, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, 200, status)
+
+		histories := []*entities.TicketHistory{}
+		json.Unmarshal(content, &histories)
+
+		owners := []string{}
+		for _, history := range histories {
+			if history.OwnerID != nil {
+				owners = append(owners, *history.OwnerID)
+			}
+		}
+		assert.Equal(t, []string{transfer.SenderID, transfer.RecipientID}, owners)
+
+		// Le ticket n'appartient plus au donateur
+		_, status, err = request("POST", "http://localhost:8888/game/ticket/"+ticket.ID+"/transfer", sender, FormURLEncoded, map[string][]any{
+			"email": {clients[1]},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 403, status)
+	})
+
+	assert.Nil(t, stop())
+}
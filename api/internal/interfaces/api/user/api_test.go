@@ -367,6 +367,9 @@ const (
 	CLIENT_REGISTER = CLIENT + "/register"
 	CLIENT_WITH_ID  = CLIENT + "/%s"
 
+	// Statistics
+	CLIENT_STATISTICS = CLIENT + "/statistics"
+
 	// Employee
 	EMPLOYEE          = DOMAIN + "/employee"
 	EMPLOYEE_REGISTER = EMPLOYEE + "/register"
@@ -379,4 +382,7 @@ const (
 	USER_PASSWORD            = USER + "/password"
 	USER_REGISTER_VALIDATION = USER + "/register/validation"
 	USER_VALIDATION_RENEW    = USER + "/validation/renew"
+	USER_OIDC                = USER + "/oidc"
+	USER_LOGOUT              = USER + "/logout"
+	USER_SESSIONS            = USER + "/sessions"
 )
@@ -23,8 +23,13 @@ import (
 // @Param		password	formData	string	true	"Password" default(Aa1@azetyuiop)
 // @Param 		cgu			formData	bool	true	"CGU" default(true)
 // @Param 		newsletter	formData	bool	true	"Newsletter" default(false)
+// @Param		birth_date	formData	string	false	"Birth date" default(1990-05-17)
+// @Param		address		formData	string	false	"Postal address"
+// @Param		postal_code	formData	string	false	"Postal code"
+// @Param		city		formData	string	false	"City"
+// @Param		country		formData	string	false	"ISO 3166-1 alpha-2 country of residence" default(FR)
 // @Success		201	{object}	nil "Client created"
-// @Failure		400	{object}	nil "Invalid email or password"
+// @Failure		400	{object}	nil "Invalid email, password, birth date or country"
 // @Failure		409	{object}	nil "Client already exists"
 // @Failure		500	{object}	nil "Internal server error"
 // @Router		/client/register [post]
@@ -58,8 +63,13 @@ func RegisterClient(ctx *fiber.Ctx) error {
 // @Produce		application/json
 // @Param		id			formData	string	true	"Client ID" format(uuid)
 // @Param		newsletter	formData	bool	true	"Newsletter" default(false)
+// @Param		birth_date	formData	string	false	"Birth date" default(1990-05-17)
+// @Param		address		formData	string	false	"Postal address"
+// @Param		postal_code	formData	string	false	"Postal code"
+// @Param		city		formData	string	false	"City"
+// @Param		country		formData	string	false	"ISO 3166-1 alpha-2 country of residence" default(FR)
 // @Success		204	{object}	nil "Password updated"
-// @Failure		400	{object}	nil "Invalid email, password or token"
+// @Failure		400	{object}	nil "Invalid email, password, token, birth date or country"
 // @Failure		404	{object}	nil "Client not found"
 // @Failure		409	{object}	nil "Client already validated"
 // @Failure		410	{object}	nil "Token expired"
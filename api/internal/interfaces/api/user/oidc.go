@@ -0,0 +1,82 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+)
+
+// @Tags		User
+// @Summary		Start the sign-in of a client with an external OpenID Connect provider.
+// @Produce		application/json
+// @Param		provider	path	string	true	"Provider name in the configuration" default(google)
+// @Param		cgu			query	bool	false	"Consent to the terms, required when the sign-in registers a new client"
+// @Param		newsletter	query	bool	false	"Subscription of a new client to the newsletter"
+// @Success		200	{object}	nil "URL of the provider to send the client to"
+// @Failure		400	{object}	nil "Invalid consents"
+// @Failure		404	{object}	nil "Unknown provider"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/oidc/{provider} [get]
+// @Id			user.StartOIDC
+func StartOIDC(ctx *fiber.Ctx) error {
+	dto := &transfert.OIDC{}
+	if err := ctx.QueryParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.StartOIDC(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), oidc.Get(ctx.Params("provider")), dto,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Complete the sign-in of a client with an external OpenID Connect provider.
+// @Accept		multipart/form-data
+// @Produce		application/json
+// @Param		provider	path		string	true	"Provider name in the configuration" default(google)
+// @Param		code		formData	string	true	"Authorization code sent back by the provider"
+// @Param		state		formData	string	true	"State sent back by the provider"
+// @Param		X-Device-Name	header	string	false	"Name of the device, shown in the list of the sessions"
+// @Success		200	{object}	nil "Signed in, the account is linked or registered on the first sign-in"
+// @Failure		400	{object}	nil "Missing code, invalid or expired state, or terms not accepted to register"
+// @Failure		401	{object}	nil "Identity token refused"
+// @Failure		403	{object}	nil "Email not verified by the provider or account of an employee"
+// @Failure		404	{object}	nil "Unknown provider"
+// @Failure		500	{object}	nil "Internal server error"
+// @Failure		502	{object}	nil "Code refused by the provider"
+// @Router		/user/oidc/{provider} [post]
+// @Id			user.OIDCAuth
+func OIDCAuth(ctx *fiber.Ctx) error {
+	dto := &transfert.OIDC{}
+	if err := ctx.BodyParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := services.OIDCAuth(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), oidc.Get(ctx.Params("provider")), dto, clientSession(ctx),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
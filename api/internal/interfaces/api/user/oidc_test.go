@@ -0,0 +1,182 @@
+package user_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/oidc"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIssuer Émetteur OpenID Connect local, il accepte le code "good-code" avec le vérificateur du dernier défi reçu
+type stubIssuer struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	claims    gojwt.MapClaims
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &stubIssuer{key: key}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kid": "stub",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "good-code" || oidc.Challenge(r.Form.Get("code_verifier")) != issuer.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		token := gojwt.NewWithClaims(gojwt.SigningMethodRS256, issuer.claims)
+		token.Header["kid"] = "stub"
+		signed, _ := token.SignedString(key)
+
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	})
+
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+// authorize Simule la connexion chez le fournisseur et retourne l'état renvoyé à l'application
+func (issuer *stubIssuer) authorize(t *testing.T, link, subject, email string) string {
+	target, err := url.Parse(link)
+	require.NoError(t, err)
+
+	query := target.Query()
+	issuer.challenge = query.Get("code_challenge")
+	issuer.claims = gojwt.MapClaims{
+		"iss":            issuer.server.URL,
+		"aud":            "thetiptop",
+		"sub":            subject,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"nonce":          query.Get("nonce"),
+		"email":          email,
+		"email_verified": true,
+	}
+
+	return query.Get("state")
+}
+
+func TestOIDC(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	issuer := newStubIssuer(t)
+	require.NoError(t, oidc.New(map[string]*oidc.Config{
+		"stub": {
+			Issuer:                issuer.server.URL,
+			ClientID:              "thetiptop",
+			AuthorizationEndpoint: issuer.server.URL + "/authorize",
+			TokenEndpoint:         issuer.server.URL + "/token",
+			JWKSURI:               issuer.server.URL + "/jwks",
+			RedirectURL:           "http://localhost:3000/oidc/stub",
+		},
+	}))
+
+	// begin démarre une connexion et retourne l'adresse du fournisseur
+	begin := func(query string) string {
+		content, status, err := request("GET", USER_OIDC+"/stub"+query, "", JSONEncoded)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, status)
+
+		var response fiber.Map
+		require.Nil(t, json.Unmarshal(content, &response))
+
+		return response["url"].(string)
+	}
+
+	// finish termine une connexion et retourne l'identifiant du compte connecté
+	finish := func(state string, expected int) string {
+		content, status, err := request("POST", USER_OIDC+"/stub", "", JSONEncoded, map[string][]any{
+			"code":  {"good-code"},
+			"state": {state},
+		})
+		require.Nil(t, err)
+		require.Equal(t, expected, status, string(content))
+
+		if expected != http.StatusOK {
+			return ""
+		}
+
+		var tokens fiber.Map
+		require.Nil(t, json.Unmarshal(content, &tokens))
+
+		token, terr := jwt.TokenToClaims(tokens["access_token"].(string))
+		require.Nil(t, terr)
+
+		return token.ID
+	}
+
+	t.Run("unknown provider", func(t *testing.T) {
+		_, status, err := request("GET", USER_OIDC+"/unknown", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	var registered string
+
+	t.Run("register", func(t *testing.T) {
+		// Sans consentement aux CGU, aucun compte n'est créé
+		finish(issuer.authorize(t, begin(""), "subject-1", "oidc-player@example.com"), http.StatusBadRequest)
+
+		state := issuer.authorize(t, begin("?cgu=true&newsletter=false"), "subject-1", "oidc-player@example.com")
+		registered = finish(state, http.StatusOK)
+		assert.NotEmpty(t, registered)
+
+		// L'état ne sert qu'une fois
+		finish(state, http.StatusBadRequest)
+	})
+
+	t.Run("linked account", func(t *testing.T) {
+		// Le compte lié est retrouvé même si l'adresse change chez le fournisseur
+		state := issuer.authorize(t, begin(""), "subject-1", "renamed@example.com")
+		assert.Equal(t, registered, finish(state, http.StatusOK))
+	})
+
+	t.Run("existing client", func(t *testing.T) {
+		state := issuer.authorize(t, begin(""), "subject-2", emailClient)
+		assert.NotEqual(t, registered, finish(state, http.StatusOK))
+	})
+
+	t.Run("employee", func(t *testing.T) {
+		state := issuer.authorize(t, begin(""), "subject-3", emailEmployee)
+		finish(state, http.StatusForbidden)
+	})
+
+	t.Run("wrong verifier", func(t *testing.T) {
+		state := issuer.authorize(t, begin(""), "subject-1", "oidc-player@example.com")
+		issuer.challenge = oidc.Challenge("other")
+		finish(state, http.StatusBadGateway)
+	})
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// DeviceHeader carries the device name sent by the client apps, shown in the list of the sessions
+const DeviceHeader = "X-Device-Name"
+
+// clientSession describes the device, the user agent and the IP of a sign-in or a renewal
+func clientSession(ctx *fiber.Ctx) *transfert.Session {
+	device, agent, ip := ctx.Get(DeviceHeader), ctx.Get(fiber.HeaderUserAgent), ctx.IP()
+
+	return &transfert.Session{
+		Device:    &device,
+		UserAgent: &agent,
+		IP:        &ip,
+	}
+}
+
+// @Tags		User
+// @Summary		List the sessions of the signed in user.
+// @Produce		application/json
+// @Success		200	{object}	nil "Sessions with their device, the current one flagged"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/sessions [get]
+// @Id			jwt.Auth => user.ListSessions
+// @Security 	Bearer
+func ListSessions(ctx *fiber.Ctx) error {
+	status, response := services.ListSessions(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Sign out of another session of the signed in user.
+// @Produce		application/json
+// @Param		id	path	string	true	"Session ID"
+// @Success		204	{object}	nil "Session revoked, its refresh token is refused"
+// @Failure		400	{object}	nil "Invalid ID"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Session not found"
+// @Failure		409	{object}	nil "Current session, to close by logout"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/sessions/{id} [delete]
+// @Id			jwt.Auth => user.RevokeSession
+// @Security 	Bearer
+func RevokeSession(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	status, response := services.RevokeSession(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), &transfert.Session{ID: &id},
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Sign out, the refresh tokens of the session are revoked.
+// @Produce		application/json
+// @Success		204	{object}	nil "Signed out"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/logout [post]
+// @Id			jwt.Pending => user.Logout
+// @Security 	Bearer
+func Logout(ctx *fiber.Ctx) error {
+	status, response := services.Logout(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Sign a user out of all its sessions.
+// @Produce		application/json
+// @Param		email	query	string	true	"Email address of the user" format(email)
+// @Success		204	{object}	nil "Sessions revoked"
+// @Failure		400	{object}	nil "Invalid email"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "User not found"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/sessions [delete]
+// @Id			jwt.Auth => user.RevokeSessions
+// @Security 	Bearer
+func RevokeSessions(ctx *fiber.Ctx) error {
+	dto := &transfert.Credential{}
+	if err := ctx.QueryParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.RevokeSessions(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), dto,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -0,0 +1,159 @@
+package user_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/serializers/jwt"
+	"github.com/kodmain/thetiptop/api/internal/interfaces/api/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSession(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	// signInFrom connecte un utilisateur depuis un appareil nommé et retourne ses jetons
+	signInFrom := func(email, device string) fiber.Map {
+		req, err := createRequest("POST", USER_AUTH, "", map[string][]any{
+			"email":    {email},
+			"password": {password},
+		}, JSONEncoded)
+		require.Nil(t, err)
+		req.Header.Set(user.DeviceHeader, device)
+		req.Header.Set("User-Agent", "thetiptop-test")
+
+		resp, err := http.DefaultClient.Do(req)
+		require.Nil(t, err)
+		defer resp.Body.Close()
+
+		content, err := io.ReadAll(resp.Body)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode, string(content))
+
+		var tokens fiber.Map
+		require.Nil(t, json.Unmarshal(content, &tokens))
+
+		return tokens
+	}
+
+	// signIn connecte un utilisateur et retourne ses jetons
+	signIn := func(email string) fiber.Map {
+		return signInFrom(email, "")
+	}
+
+	// renew échange un jeton de rafraîchissement et retourne les nouveaux jetons
+	renew := func(tokens fiber.Map, expected int) fiber.Map {
+		content, status, err := request("GET", USER_AUTH_RENEW, "Bearer "+tokens["refresh_token"].(string), JSONEncoded)
+		require.Nil(t, err)
+		require.Equal(t, expected, status, string(content))
+
+		var renewed fiber.Map
+		json.Unmarshal(content, &renewed)
+
+		return renewed
+	}
+
+	t.Run("not signed in", func(t *testing.T) {
+		_, status, err := request("POST", USER_LOGOUT, "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+	})
+
+	t.Run("rotation", func(t *testing.T) {
+		first := signIn(emailClient)
+		second := renew(first, http.StatusOK)
+		assert.NotEqual(t, first["refresh_token"], second["refresh_token"])
+
+		// Le jeton échangé ne sert plus, sa réutilisation révoque toute la session
+		renew(first, http.StatusUnauthorized)
+		renew(second, http.StatusUnauthorized)
+
+		// Les autres sessions ne sont pas concernées
+		renew(signIn(emailClient), http.StatusOK)
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		tokens := signIn(emailClient)
+
+		_, status, err := request("POST", USER_LOGOUT, "Bearer "+tokens["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		renew(tokens, http.StatusUnauthorized)
+	})
+
+	t.Run("devices", func(t *testing.T) {
+		phone := signInFrom(emailEmployee, "Pixel 8")
+		laptop := signInFrom(emailEmployee, "Laptop")
+
+		// La liste décrit les sessions et signale la courante
+		content, status, err := request("GET", USER_SESSIONS, "Bearer "+laptop["access_token"].(string), JSONEncoded)
+		require.Nil(t, err)
+		require.Equal(t, http.StatusOK, status, string(content))
+
+		var sessions []map[string]any
+		require.Nil(t, json.Unmarshal(content, &sessions))
+
+		var phoneSession string
+		found := map[string]bool{}
+		for _, session := range sessions {
+			device, _ := session["device"].(string)
+			found[device] = true
+			assert.Equal(t, device == "Laptop", session["current"])
+			if device == "Pixel 8" {
+				phoneSession = session["id"].(string)
+				assert.Equal(t, "thetiptop-test", session["user_agent"])
+				assert.NotEmpty(t, session["ip"])
+			}
+		}
+		assert.True(t, found["Pixel 8"])
+		assert.True(t, found["Laptop"])
+		require.NotEmpty(t, phoneSession)
+
+		// La session courante se ferme par la déconnexion
+		laptopClaims, terr := jwt.TokenToClaims(laptop["access_token"].(string))
+		require.Nil(t, terr)
+		_, status, err = request("DELETE", USER_SESSIONS+"/"+laptopClaims.Session, "Bearer "+laptop["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusConflict, status)
+
+		// Un client ne peut révoquer la session d'un autre
+		_, status, err = request("DELETE", USER_SESSIONS+"/"+phoneSession, "Bearer "+signIn(emailClient)["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		_, status, err = request("DELETE", USER_SESSIONS+"/"+phoneSession, "Bearer "+laptop["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		// La session révoquée est refusée au renouvellement suivant
+		renew(phone, http.StatusUnauthorized)
+		renew(laptop, http.StatusOK)
+	})
+
+	t.Run("revoke all", func(t *testing.T) {
+		first := signIn(emailClient)
+		second := signIn(emailClient)
+
+		// Un client ne peut déconnecter les autres
+		_, status, err := request("DELETE", USER_SESSIONS+"?email="+emailEmployee, "Bearer "+first["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+
+		employee := signIn(emailEmployee)
+
+		_, status, err = request("DELETE", USER_SESSIONS+"?email="+emailClient, "Bearer "+employee["access_token"].(string), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusNoContent, status)
+
+		renew(first, http.StatusUnauthorized)
+		renew(second, http.StatusUnauthorized)
+		renew(employee, http.StatusOK)
+	})
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	gameTransfert "github.com/kodmain/thetiptop/api/internal/application/transfert/game"
+
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		Statistics
+// @Summary		Count the clients registered during a period, per day or hour.
+// @Produce		application/json
+// @Param		from		query	string	false	"First day or instant included, UTC without offset" example(2024-10-01)
+// @Param		to			query	string	false	"Last day or instant included, UTC without offset" example(2024-10-31)
+// @Param		interval	query	string	false	"Grouping interval" Enums(day, hour) default(day)
+// @Success		200	{object}	nil "Registrations per period"
+// @Failure		400	{object}	nil "Invalid period or interval"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Router		/client/statistics/registrations [get]
+// @Id			jwt.Auth => user.GetRegistrationStatistics
+// @Security 	Bearer
+func GetRegistrationStatistics(ctx *fiber.Ctx) error {
+	dtoStatistics := &gameTransfert.Statistics{}
+	if err := ctx.QueryParser(dtoStatistics); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.GetRegistrationStatistics(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), dtoStatistics,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		Statistics
+// @Summary		Newsletter opt-in rate of the clients registered during a period.
+// @Produce		application/json
+// @Param		from	query	string	false	"First day or instant included, UTC without offset" example(2024-10-01)
+// @Param		to		query	string	false	"Last day or instant included, UTC without offset" example(2024-10-31)
+// @Success		200	{object}	nil "Clients, subscribers and rate"
+// @Failure		400	{object}	nil "Invalid period"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Router		/client/statistics/newsletter [get]
+// @Id			jwt.Auth => user.GetNewsletterStatistics
+// @Security 	Bearer
+func GetNewsletterStatistics(ctx *fiber.Ctx) error {
+	dtoStatistics := &gameTransfert.Statistics{}
+	if err := ctx.QueryParser(dtoStatistics); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.GetNewsletterStatistics(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), dtoStatistics,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
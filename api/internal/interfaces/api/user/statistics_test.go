@@ -0,0 +1,78 @@
+package user_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	gameEntity "github.com/kodmain/thetiptop/api/internal/domain/game/entities"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatistics(t *testing.T) {
+	assert.Nil(t, start(8888, 8444))
+
+	login := func(email string) string {
+		JWT, status, err := request("POST", USER_AUTH, "", JSONEncoded, map[string][]any{
+			"email":    {email},
+			"password": {password},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		var tokenData fiber.Map
+		assert.Nil(t, json.Unmarshal(JWT, &tokenData))
+
+		return "Bearer " + tokenData["access_token"].(string)
+	}
+
+	today := time.Now().UTC().Format(time.DateOnly)
+
+	t.Run("Registrations", func(t *testing.T) {
+		_, status, err := request("GET", CLIENT_STATISTICS+"/registrations", "", JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+
+		_, status, err = request("GET", CLIENT_STATISTICS+"/registrations", login(emailClient), JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusUnauthorized, status)
+
+		authorization := login(emailEmployee)
+
+		_, status, err = request("GET", CLIENT_STATISTICS+"/registrations?interval=week", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		content, status, err := request("GET", CLIENT_STATISTICS+"/registrations?from="+today+"&to="+today, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		var statistics []*gameEntity.PeriodStatistic
+		assert.Nil(t, json.Unmarshal(content, &statistics))
+		assert.Len(t, statistics, 1)
+		assert.Equal(t, today, statistics[0].Period)
+		assert.GreaterOrEqual(t, statistics[0].Count, 1)
+	})
+
+	t.Run("Newsletter", func(t *testing.T) {
+		authorization := login(emailEmployee)
+
+		_, status, err := request("GET", CLIENT_STATISTICS+"/newsletter?from=tomorrow", authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		content, status, err := request("GET", CLIENT_STATISTICS+"/newsletter?to="+today, authorization, JSONEncoded)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		var statistic entities.NewsletterStatistic
+		assert.Nil(t, json.Unmarshal(content, &statistic))
+		assert.GreaterOrEqual(t, statistic.Clients, 1)
+		assert.LessOrEqual(t, statistic.Subscribers, statistic.Clients)
+	})
+
+	assert.Nil(t, stop())
+}
@@ -0,0 +1,104 @@
+package user
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/kodmain/thetiptop/api/config"
+	"github.com/kodmain/thetiptop/api/internal/application/security"
+	services "github.com/kodmain/thetiptop/api/internal/application/services/user"
+	transfert "github.com/kodmain/thetiptop/api/internal/application/transfert/user"
+
+	gameRepository "github.com/kodmain/thetiptop/api/internal/domain/game/repositories"
+	"github.com/kodmain/thetiptop/api/internal/domain/user/repositories"
+	domain "github.com/kodmain/thetiptop/api/internal/domain/user/services"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/database"
+	"github.com/kodmain/thetiptop/api/internal/infrastructure/providers/mail"
+)
+
+// @Tags		User
+// @Summary		Start the enrolment of the TOTP second factor of the signed in employee.
+// @Produce		application/json
+// @Success		201	{object}	nil "Secret and otpauth URI to scan"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		409	{object}	nil "Second factor already enabled"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/totp [post]
+// @Id			jwt.Pending => user.EnrollTOTP
+// @Security 	Bearer
+func EnrollTOTP(ctx *fiber.Ctx) error {
+	status, response := services.EnrollTOTP(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.employee.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.employee.mail", config.DEFAULT)),
+		),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Enable the TOTP second factor of the signed in employee with a first code.
+// @Accept		multipart/form-data
+// @Produce		application/json
+// @Param		code	formData	string	true	"Code shown by the authenticator app"
+// @Success		200	{object}	nil "Recovery codes, only shown once"
+// @Failure		400	{object}	nil "Missing code"
+// @Failure		401	{object}	nil "Unauthorized or invalid code"
+// @Failure		409	{object}	nil "Not enrolled or already enabled"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/totp [put]
+// @Id			jwt.Pending => user.ConfirmTOTP
+// @Security 	Bearer
+func ConfirmTOTP(ctx *fiber.Ctx) error {
+	dto := &transfert.TOTP{}
+	if err := ctx.BodyParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := services.ConfirmTOTP(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.employee.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.employee.mail", config.DEFAULT)),
+		), dto,
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Complete a sign-in pending for the second factor.
+// @Accept		multipart/form-data
+// @Produce		application/json
+// @Param		code	formData	string	true	"TOTP code or recovery code"
+// @Param		X-Device-Name	header	string	false	"Name of the device, shown in the list of the sessions"
+// @Success		200	{object}	nil "Employee signed in"
+// @Failure		400	{object}	nil "Missing code"
+// @Failure		401	{object}	nil "Unauthorized or invalid code"
+// @Failure		409	{object}	nil "Second factor not enabled"
+// @Failure		423	{object}	nil "Account locked after too many failures"
+// @Failure		429	{object}	nil "Retry later after a failure"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/auth/totp [post]
+// @Id			jwt.Pending => user.VerifyTOTP
+// @Security 	Bearer
+func VerifyTOTP(ctx *fiber.Ctx) error {
+	dto := &transfert.TOTP{}
+	if err := ctx.BodyParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err)
+	}
+
+	status, response := services.VerifyTOTP(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.employee.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.employee.mail", config.DEFAULT)),
+		), dto, clientSession(ctx),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
@@ -21,8 +21,13 @@ import (
 // @Produce		application/json
 // @Param		email		formData	string	true	"Email address" format(email) default(user-thetiptop@yopmail.com)
 // @Param		password	formData	string	true	"Password" default(Aa1@azetyuiop)
-// @Success		200	{object}	nil "Client signed in"
+// @Param		totp		formData	string	false	"TOTP or recovery code of employees with a second factor"
+// @Param		X-Device-Name	header	string	false	"Name of the device, shown in the list of the sessions"
+// @Success		200	{object}	nil "Signed in, tokens limited to the second factor routes when second_factor is set"
 // @Failure		400	{object}	nil "Invalid email or password"
+// @Failure		401	{object}	nil "Invalid second factor"
+// @Failure		423	{object}	nil "Account or address locked after too many failures"
+// @Failure		429	{object}	nil "Retry later after a failure"
 // @Failure		500	{object}	nil "Internal server error"
 // @Router		/user/auth [post]
 // @Id			user.UserAuth
@@ -32,13 +37,16 @@ func UserAuth(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(err)
 	}
 
+	ip := ctx.IP()
+	dto.IP = &ip
+
 	status, response := services.UserAuth(
 		domain.User(
 			security.NewUserAccess(ctx.Locals("token")),
 			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
 			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
 			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
-		), dto,
+		), dto, clientSession(ctx),
 	)
 
 	return ctx.Status(status).JSON(response)
@@ -49,9 +57,9 @@ func UserAuth(ctx *fiber.Ctx) error {
 // @Accept		*/*
 // @Accept		multipart/form-data
 // @Produce		application/json
-// @Success		200	{object}	nil "JWT token renewed"
+// @Success		200	{object}	nil "JWT token renewed, the refresh token is spent"
 // @Failure		400	{object}	nil "Invalid token"
-// @Failure		401	{object}	nil "Token expired"
+// @Failure		401	{object}	nil "Token expired, revoked or reused"
 // @Failure		500	{object}	nil "Internal server error"
 // @Param 		Authorization header string true "With the bearer started"
 // @Router		/user/auth/renew [get]
@@ -63,7 +71,43 @@ func UserAuthRenew(ctx *fiber.Ctx) error {
 	}
 
 	status, response := services.UserAuthRenew(
-		token.(*jwt.Token),
+		domain.User(
+			security.NewUserAccess(token),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), token.(*jwt.Token), clientSession(ctx),
+	)
+
+	return ctx.Status(status).JSON(response)
+}
+
+// @Tags		User
+// @Summary		Unlock an account and/or an address after failed sign-ins.
+// @Produce		application/json
+// @Param		email	query	string	false	"Email address of the account" format(email)
+// @Param		ip		query	string	false	"IP address"
+// @Success		204	{object}	nil "Unlocked"
+// @Failure		400	{object}	nil "Invalid email or IP"
+// @Failure		401	{object}	nil "Unauthorized"
+// @Failure		404	{object}	nil "Account not found or not locked"
+// @Failure		500	{object}	nil "Internal server error"
+// @Router		/user/lockout [delete]
+// @Id			jwt.Auth => user.Unlock
+// @Security 	Bearer
+func Unlock(ctx *fiber.Ctx) error {
+	dto := &transfert.Lockout{}
+	if err := ctx.QueryParser(dto); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(err.Error())
+	}
+
+	status, response := services.Unlock(
+		domain.User(
+			security.NewUserAccess(ctx.Locals("token")),
+			repositories.NewUserRepository(database.Get(config.GetString("services.client.database", config.DEFAULT))),
+			gameRepository.NewGameRepository(database.Get(config.GetString("services.game.database", config.DEFAULT))),
+			mail.Get(config.GetString("services.client.mail", config.DEFAULT)),
+		), dto,
 	)
 
 	return ctx.Status(status).JSON(response)